@@ -404,6 +404,8 @@ describe('getCompletionItems()', () => {
               "revdeps(\${1}) ",
               "dependents(\${1}) ",
               "has.description(\${1}) ",
+              "has.topic(\${1}) ",
+              "has.meta(\${1:key}:\${2:value}) ",
               "^repo/with\\\\ a\\\\ space$ "
             ]
         `)
@@ -429,7 +431,9 @@ describe('getCompletionItems()', () => {
               "dependencies(\${1}) ",
               "revdeps(\${1}) ",
               "dependents(\${1}) ",
-              "has.description(\${1}) "
+              "has.description(\${1}) ",
+              "has.topic(\${1}) ",
+              "has.meta(\${1:key}:\${2:value}) "
             ]
         `)
     })
@@ -258,8 +258,8 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
         placeholder: '"content"',
     },
     [FilterType.patterntype]: {
-        discreteValues: () => ['regexp', 'structural', 'literal'].map(value => ({ label: value })),
-        description: 'The pattern type (regexp, literal, structural) in use',
+        discreteValues: () => ['regexp', 'structural', 'literal', 'syntax'].map(value => ({ label: value })),
+        description: 'The pattern type (regexp, literal, structural, syntax) in use',
         singular: true,
     },
     [FilterType.repo]: {
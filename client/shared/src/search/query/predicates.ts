@@ -28,7 +28,7 @@ export const PREDICATES: Access[] = [
             },
             {
                 name: 'has',
-                fields: [{ name: 'description' }],
+                fields: [{ name: 'description' }, { name: 'topic' }, { name: 'meta' }],
             },
             {
                 name: 'dependencies',
@@ -51,6 +51,16 @@ export const PREDICATES: Access[] = [
                 name: 'contains',
                 fields: [{ name: 'content' }],
             },
+            {
+                name: 'has',
+                fields: [
+                    {
+                        name: 'commit',
+                        fields: [{ name: 'after' }],
+                    },
+                    { name: 'author' },
+                ],
+            },
         ],
     },
 ]
@@ -205,6 +215,16 @@ export const predicateCompletion = (field: string): Completion[] => {
                 insertText: 'has.description(${1})',
                 asSnippet: true,
             },
+            {
+                label: 'has.topic(...)',
+                insertText: 'has.topic(${1})',
+                asSnippet: true,
+            },
+            {
+                label: 'has.meta(...)',
+                insertText: 'has.meta(${1:key}:${2:value})',
+                asSnippet: true,
+            },
         ]
     }
     return []
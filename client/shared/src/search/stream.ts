@@ -95,6 +95,10 @@ export interface MatchedSymbol {
     name: string
     containerName: string
     kind: SymbolKind
+    /** The commit that introduced the symbol, only set by `symbol.history:yes` searches. */
+    introducedCommit?: string
+    /** The commit that deleted the symbol, only set by `symbol.history:yes` searches for deleted symbols. */
+    deletedCommit?: string
 }
 
 type MarkdownText = string
@@ -117,6 +117,11 @@ func AllowAnonymousRequest(req *http.Request) bool {
 		return true
 	}
 
+	// Permission is checked by the signature of the download URL
+	if strings.HasPrefix(req.URL.Path, "/.api/search/exports/") && strings.HasSuffix(req.URL.Path, "/download") {
+		return true
+	}
+
 	// This is just a redirect to a public download
 	if strings.HasPrefix(req.URL.Path, "/.api/src-cli") {
 		return true
@@ -41,6 +41,8 @@ func TestAllowAnonymousRequest(t *testing.T) {
 		{req: req("GET", "/doesntexist"), want: false},
 		{req: req("POST", "/doesntexist"), want: false},
 		{req: req("GET", "/doesnt/exist"), want: false},
+		{req: req("GET", "/.api/search/exports/1/download"), want: true},
+		{req: req("GET", "/.api/search/exports/1"), want: false},
 		{req: req("POST", "/doesnt/exist"), want: false},
 	}
 	for _, test := range tests {
@@ -3,6 +3,9 @@ package backend
 import (
 	"context"
 
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	symbolsclient "github.com/sourcegraph/sourcegraph/internal/symbols"
@@ -11,6 +14,12 @@ import (
 // Symbols backend.
 var Symbols = &symbols{}
 
+// PreciseSymbols searches the symbols of the precise code intelligence uploads of the given
+// repository at the searched commit. It returns false if the repository has no upload for that
+// commit. Like those of ListTags, the lines of the returned symbols are 1-indexed. It is set by
+// the enterprise frontend.
+var PreciseSymbols func(ctx context.Context, repoID api.RepoID, args search.SymbolsParameters) (result.Symbols, bool, error)
+
 type symbols struct{}
 
 // ListTags returns symbols in a repository from ctags.
@@ -24,3 +33,47 @@ func (symbols) ListTags(ctx context.Context, args search.SymbolsParameters) (res
 	}
 	return symbols, nil
 }
+
+// Search returns symbols in a repository from ctags, merged with the symbols of its precise code
+// intelligence uploads for the searched commit. An upload only covers the documents under its root
+// in the languages of its indexer, so precise symbols replace the ctags symbols of the files they
+// are in, and the ctags symbols of all other files are kept. Symbol history searches always use ctags.
+func (s symbols) Search(ctx context.Context, repoID api.RepoID, args search.SymbolsParameters) (result.Symbols, error) {
+	symbols, err := s.ListTags(ctx, args)
+	if err != nil || PreciseSymbols == nil || args.History {
+		return symbols, err
+	}
+
+	preciseSymbols, ok, err := PreciseSymbols(ctx, repoID, args)
+	if err != nil {
+		log15.Warn("Failed to search precise symbols, falling back to ctags.", "repo", args.Repo, "commitID", args.CommitID, "err", err)
+		return symbols, nil
+	}
+	if !ok {
+		return symbols, nil
+	}
+
+	return mergeSymbols(preciseSymbols, symbols, args.First), nil
+}
+
+// mergeSymbols returns the given precise symbols followed by the ctags symbols of the files
+// without precise symbols. If limit is positive, at most limit symbols are returned.
+func mergeSymbols(preciseSymbols, tags result.Symbols, limit int) result.Symbols {
+	precisePaths := make(map[string]struct{}, len(preciseSymbols))
+	for _, symbol := range preciseSymbols {
+		precisePaths[symbol.Path] = struct{}{}
+	}
+
+	merged := make(result.Symbols, 0, len(preciseSymbols)+len(tags))
+	merged = append(merged, preciseSymbols...)
+	for _, symbol := range tags {
+		if _, ok := precisePaths[symbol.Path]; !ok {
+			merged = append(merged, symbol)
+		}
+	}
+
+	if limit > 0 && len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged
+}
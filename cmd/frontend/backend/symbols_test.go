@@ -0,0 +1,34 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestMergeSymbols(t *testing.T) {
+	preciseSymbols := result.Symbols{
+		{Name: "Server", Path: "lib/server.go", Line: 3},
+	}
+	tags := result.Symbols{
+		{Name: "Server", Path: "lib/server.go", Line: 3},
+		{Name: "server", Path: "lib/server.go", Line: 5},
+		{Name: "main", Path: "cmd/main.go", Line: 1},
+		{Name: "Server", Path: "web/server.ts", Line: 8},
+	}
+
+	want := result.Symbols{
+		{Name: "Server", Path: "lib/server.go", Line: 3},
+		{Name: "main", Path: "cmd/main.go", Line: 1},
+		{Name: "Server", Path: "web/server.ts", Line: 8},
+	}
+	if diff := cmp.Diff(want, mergeSymbols(preciseSymbols, tags, 0)); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(want[:2], mergeSymbols(preciseSymbols, tags, 2)); diff != "" {
+		t.Errorf("unexpected limited symbols (-want +got):\n%s", diff)
+	}
+}
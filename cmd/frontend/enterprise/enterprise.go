@@ -24,6 +24,8 @@ type Services struct {
 	NewExecutorProxyHandler       NewExecutorProxyHandler
 	NewGitHubAppCloudSetupHandler NewGitHubAppCloudSetupHandler
 	NewComputeStreamHandler       NewComputeStreamHandler
+	NewSearchExportsHandler       NewSearchExportsHandler
+	NewInsightsExportHandler      NewInsightsExportHandler
 	AuthzResolver                 graphqlbackend.AuthzResolver
 	BatchChangesResolver          graphqlbackend.BatchChangesResolver
 	CodeIntelResolver             graphqlbackend.CodeIntelResolver
@@ -54,6 +56,12 @@ type NewGitHubAppCloudSetupHandler func() http.Handler
 // NewComputeStreamHandler creates a new handler for the Sourcegraph Compute streaming endpoint.
 type NewComputeStreamHandler func() http.Handler
 
+// NewSearchExportsHandler creates a new handler for the search exports API.
+type NewSearchExportsHandler func() http.Handler
+
+// NewInsightsExportHandler creates a new handler for the code insights export endpoint.
+type NewInsightsExportHandler func() http.Handler
+
 // DefaultServices creates a new Services value that has default implementations for all services.
 func DefaultServices() Services {
 	return Services{
@@ -65,6 +73,8 @@ func DefaultServices() Services {
 		NewExecutorProxyHandler:       func() http.Handler { return makeNotFoundHandler("executor proxy") },
 		NewGitHubAppCloudSetupHandler: func() http.Handler { return makeNotFoundHandler("Sourcegraph Cloud GitHub App setup") },
 		NewComputeStreamHandler:       func() http.Handler { return makeNotFoundHandler("compute streaming endpoint") },
+		NewSearchExportsHandler:       func() http.Handler { return makeNotFoundHandler("search exports") },
+		NewInsightsExportHandler:      func() http.Handler { return makeNotFoundHandler("code insights export") },
 	}
 }
 
@@ -22,6 +22,7 @@ type CodeMonitorsResolver interface {
 	TriggerTestEmailAction(ctx context.Context, args *TriggerTestEmailActionArgs) (*EmptyResponse, error)
 	TriggerTestWebhookAction(ctx context.Context, args *TriggerTestWebhookActionArgs) (*EmptyResponse, error)
 	TriggerTestSlackWebhookAction(ctx context.Context, args *TriggerTestSlackWebhookActionArgs) (*EmptyResponse, error)
+	TriggerTestTemplatedWebhookAction(ctx context.Context, args *TriggerTestTemplatedWebhookActionArgs) (*EmptyResponse, error)
 
 	NodeResolvers() map[string]NodeByIDFunc
 }
@@ -80,6 +81,7 @@ type MonitorAction interface {
 	ToMonitorEmail() (MonitorEmailResolver, bool)
 	ToMonitorWebhook() (MonitorWebhookResolver, bool)
 	ToMonitorSlackWebhook() (MonitorSlackWebhookResolver, bool)
+	ToMonitorTemplatedWebhook() (MonitorTemplatedWebhookResolver, bool)
 }
 
 type MonitorEmailResolver interface {
@@ -108,6 +110,24 @@ type MonitorSlackWebhookResolver interface {
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
 
+type MonitorTemplatedWebhookResolver interface {
+	ID() graphql.ID
+	Preset() string
+	Enabled() bool
+	IncludeResults() bool
+	URL() string
+	RoutingKey() string
+	BodyTemplate() string
+	ContentType() string
+	Headers() []MonitorWebhookHeaderResolver
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
+type MonitorWebhookHeaderResolver interface {
+	Name() string
+	Value() string
+}
+
 type MonitorEmailRecipient interface {
 	ToUser() (*UserResolver, bool)
 }
@@ -162,9 +182,10 @@ type CreateTriggerArgs struct {
 }
 
 type CreateActionArgs struct {
-	Email        *CreateActionEmailArgs
-	Webhook      *CreateActionWebhookArgs
-	SlackWebhook *CreateActionSlackWebhookArgs
+	Email            *CreateActionEmailArgs
+	Webhook          *CreateActionWebhookArgs
+	SlackWebhook     *CreateActionSlackWebhookArgs
+	TemplatedWebhook *CreateActionTemplatedWebhookArgs
 }
 
 type CreateActionEmailArgs struct {
@@ -187,6 +208,22 @@ type CreateActionSlackWebhookArgs struct {
 	URL            string
 }
 
+type CreateActionTemplatedWebhookArgs struct {
+	Preset         string
+	Enabled        bool
+	IncludeResults bool
+	URL            *string
+	RoutingKey     *string
+	BodyTemplate   *string
+	ContentType    *string
+	Headers        *[]*WebhookHeaderArgs
+}
+
+type WebhookHeaderArgs struct {
+	Name  string
+	Value string
+}
+
 type ToggleCodeMonitorArgs struct {
 	Id      graphql.ID
 	Enabled bool
@@ -218,6 +255,12 @@ type TriggerTestSlackWebhookActionArgs struct {
 	SlackWebhook *CreateActionSlackWebhookArgs
 }
 
+type TriggerTestTemplatedWebhookActionArgs struct {
+	Namespace        graphql.ID
+	Description      string
+	TemplatedWebhook *CreateActionTemplatedWebhookArgs
+}
+
 type CreateMonitorArgs struct {
 	Namespace   graphql.ID
 	Description string
@@ -239,10 +282,16 @@ type EditActionSlackWebhookArgs struct {
 	Update *CreateActionSlackWebhookArgs
 }
 
+type EditActionTemplatedWebhookArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionTemplatedWebhookArgs
+}
+
 type EditActionArgs struct {
-	Email        *EditActionEmailArgs
-	Webhook      *EditActionWebhookArgs
-	SlackWebhook *EditActionSlackWebhookArgs
+	Email            *EditActionEmailArgs
+	Webhook          *EditActionWebhookArgs
+	SlackWebhook     *EditActionSlackWebhookArgs
+	TemplatedWebhook *EditActionTemplatedWebhookArgs
 }
 
 type EditTriggerArgs struct {
@@ -221,7 +221,11 @@ type GitBlobLSIFDataResolver interface {
 	Definitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
 	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Implementations(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	TypeDefinitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
+	IncomingCalls(ctx context.Context, args *LSIFPagedQueryPositionArgs) (CallHierarchyCallConnectionResolver, error)
+	OutgoingCalls(ctx context.Context, args *LSIFPagedQueryPositionArgs) (CallHierarchyCallConnectionResolver, error)
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
+	DocumentSymbols(ctx context.Context) ([]DocumentSymbolResolver, error)
 }
 
 type GitBlobLSIFDataArgs struct {
@@ -271,6 +275,26 @@ type LocationConnectionResolver interface {
 	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
 }
 
+type CallHierarchyCallConnectionResolver interface {
+	Nodes(ctx context.Context) ([]CallHierarchyCallResolver, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
+type CallHierarchyCallResolver interface {
+	Symbol(ctx context.Context) (LocationResolver, error)
+	Ranges(ctx context.Context) ([]LocationResolver, error)
+}
+
+type DocumentSymbolResolver interface {
+	Name() string
+	Kind() string
+	Detail() *string
+	Tags() []string
+	Range() RangeResolver
+	FullRange() RangeResolver
+	Children() []DocumentSymbolResolver
+}
+
 type HoverResolver interface {
 	Markdown() Markdown
 	Range() RangeResolver
@@ -36,6 +36,14 @@ type InsightsResolver interface {
 
 	DeleteInsightView(ctx context.Context, args *DeleteInsightViewArgs) (*EmptyResponse, error)
 
+	// Alerts
+	InsightSeriesAlerts(ctx context.Context, args *InsightSeriesAlertsArgs) ([]InsightSeriesAlertResolver, error)
+	CreateInsightSeriesAlert(ctx context.Context, args *CreateInsightSeriesAlertArgs) (InsightSeriesAlertResolver, error)
+	DeleteInsightSeriesAlert(ctx context.Context, args *DeleteInsightSeriesAlertArgs) (*EmptyResponse, error)
+
+	// Drill-down
+	InsightSeriesRepositoryBreakdown(ctx context.Context, args *InsightSeriesRepositoryBreakdownArgs) (InsightSeriesRepositoryBreakdownResolver, error)
+
 	// Admin Management
 	UpdateInsightSeries(ctx context.Context, args *UpdateInsightSeriesArgs) (InsightSeriesMetadataPayloadResolver, error)
 	InsightSeriesQueryStatus(ctx context.Context) ([]InsightSeriesQueryStatusResolver, error)
@@ -449,3 +457,63 @@ type SearchInsightLivePreviewSeriesResolver interface {
 	Points(ctx context.Context) ([]InsightsDataPointResolver, error)
 	Label(ctx context.Context) (string, error)
 }
+
+type InsightSeriesAlertsArgs struct {
+	InsightViewId graphql.ID
+}
+
+type CreateInsightSeriesAlertArgs struct {
+	Input CreateInsightSeriesAlertInput
+}
+
+type CreateInsightSeriesAlertInput struct {
+	InsightViewId graphql.ID
+	SeriesId      string
+	Kind          string // enum
+	Threshold     *float64
+	Channel       string // enum
+	WebhookURL    *string
+}
+
+type DeleteInsightSeriesAlertArgs struct {
+	Id graphql.ID
+}
+
+type InsightSeriesAlertResolver interface {
+	ID() graphql.ID
+	SeriesId() string
+	Kind() string
+	Threshold() *float64
+	Channel() string
+	WebhookURL() *string
+	Firing() bool
+	LastNotifiedAt() *DateTime
+	CreatedAt() DateTime
+}
+
+type InsightSeriesRepositoryBreakdownArgs struct {
+	InsightViewId    graphql.ID
+	SeriesId         string
+	From             DateTime
+	To               DateTime
+	First            int32
+	IncludeUnchanged bool
+}
+
+type InsightSeriesRepositoryBreakdownResolver interface {
+	From() *DateTime
+	To() *DateTime
+	FromValue() float64
+	ToValue() float64
+	Nodes() []InsightSeriesRepositoryContributionResolver
+	TotalCount() int32
+}
+
+type InsightSeriesRepositoryContributionResolver interface {
+	RepositoryId() graphql.ID
+	RepositoryName() string
+	Capture() *string
+	FromValue() float64
+	ToValue() float64
+	Delta() float64
+}
@@ -26,6 +26,9 @@ type NotebooksResolver interface {
 	CreateNotebookStar(ctx context.Context, args CreateNotebookStarInputArgs) (NotebookStarResolver, error)
 	DeleteNotebookStar(ctx context.Context, args DeleteNotebookStarInputArgs) (*EmptyResponse, error)
 
+	SetNotebookSnapshotSchedule(ctx context.Context, args SetNotebookSnapshotScheduleArgs) (NotebookSnapshotScheduleResolver, error)
+	DeleteNotebookSnapshotSchedule(ctx context.Context, args DeleteNotebookSnapshotScheduleArgs) (*EmptyResponse, error)
+
 	NodeResolvers() map[string]NodeByIDFunc
 }
 
@@ -59,6 +62,38 @@ type NotebookResolver interface {
 	ViewerCanManage(ctx context.Context) (bool, error)
 	ViewerHasStarred(ctx context.Context) (bool, error)
 	Stars(ctx context.Context, args ListNotebookStarsArgs) (NotebookStarConnectionResolver, error)
+	Export(ctx context.Context, args NotebookExportArgs) (string, error)
+	SnapshotSchedule(ctx context.Context) (NotebookSnapshotScheduleResolver, error)
+	Snapshots(ctx context.Context, args ListNotebookSnapshotsArgs) (NotebookSnapshotConnectionResolver, error)
+}
+
+type NotebookSnapshotScheduleResolver interface {
+	Interval() string
+	User(ctx context.Context) (*UserResolver, error)
+	NextRunAt() DateTime
+	CreatedAt() DateTime
+	UpdatedAt() DateTime
+}
+
+type NotebookSnapshotConnectionResolver interface {
+	Nodes() []NotebookSnapshotResolver
+	TotalCount() int32
+	PageInfo() *graphqlutil.PageInfo
+}
+
+type NotebookSnapshotResolver interface {
+	CreatedAt() DateTime
+	Blocks() []NotebookBlockSnapshotResolver
+	ChangedBlockIDs() []string
+}
+
+type NotebookBlockSnapshotResolver interface {
+	BlockID() string
+	Status() string
+	ResultCount() int32
+	LimitHit() bool
+	Error() *string
+	ResultLabels() []string
 }
 
 type NotebookBlockResolver interface {
@@ -194,6 +229,24 @@ type ListNotebookStarsArgs struct {
 	After *string `json:"after"`
 }
 
+type NotebookExportArgs struct {
+	Format string `json:"format"`
+}
+
+type ListNotebookSnapshotsArgs struct {
+	First int32   `json:"first"`
+	After *string `json:"after"`
+}
+
+type SetNotebookSnapshotScheduleArgs struct {
+	NotebookID graphql.ID
+	Interval   string
+}
+
+type DeleteNotebookSnapshotScheduleArgs struct {
+	NotebookID graphql.ID
+}
+
 type CreateNotebookStarInputArgs struct {
 	NotebookID graphql.ID
 }
@@ -16,6 +16,8 @@ func (r *schemaResolver) ParseSearchQuery(ctx context.Context, args *struct {
 		searchType = query.SearchTypeLiteral
 	case "structural":
 		searchType = query.SearchTypeStructural
+	case "syntax":
+		searchType = query.SearchTypeSyntax
 	case "regexp", "regex":
 		searchType = query.SearchTypeRegex
 	default:
@@ -0,0 +1,82 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (r *schemaResolver) SetRepositoryMetadata(ctx context.Context, args *struct {
+	Repository graphql.ID
+	Key        string
+	Value      *string
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may set repository metadata.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	repo, err := r.repositoryByID(ctx, args.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Value == nil {
+		err = r.db.Repos().DeleteMetaTag(ctx, repo.IDInt32(), args.Key)
+	} else {
+		err = r.db.Repos().SetMetaTag(ctx, repo.IDInt32(), args.Key, *args.Value)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *RepositoryResolver) Topics(ctx context.Context) ([]string, error) {
+	tags, err := r.tags(ctx, types.RepoTagKindTopic)
+	if err != nil {
+		return nil, err
+	}
+	topics := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		topics = append(topics, tag.Key)
+	}
+	return topics, nil
+}
+
+func (r *RepositoryResolver) Metadata(ctx context.Context) ([]*repositoryMetadataResolver, error) {
+	tags, err := r.tags(ctx, types.RepoTagKindMeta)
+	if err != nil {
+		return nil, err
+	}
+	metadata := make([]*repositoryMetadataResolver, 0, len(tags))
+	for _, tag := range tags {
+		metadata = append(metadata, &repositoryMetadataResolver{tag: tag})
+	}
+	return metadata, nil
+}
+
+// tags returns the tags of the given kind of the repository.
+func (r *RepositoryResolver) tags(ctx context.Context, kind types.RepoTagKind) ([]*types.RepoTag, error) {
+	tags, err := r.db.Repos().ListTags(ctx, r.IDInt32())
+	if err != nil {
+		return nil, err
+	}
+	filtered := tags[:0]
+	for _, tag := range tags {
+		if tag.Kind == kind {
+			filtered = append(filtered, tag)
+		}
+	}
+	return filtered, nil
+}
+
+type repositoryMetadataResolver struct {
+	tag *types.RepoTag
+}
+
+func (r *repositoryMetadataResolver) Key() string   { return r.tag.Key }
+func (r *repositoryMetadataResolver) Value() string { return r.tag.Value }
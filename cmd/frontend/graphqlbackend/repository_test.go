@@ -240,3 +240,49 @@ func TestRepository_DefaultBranch(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_TopicsAndMetadata(t *testing.T) {
+	repos := database.NewMockRepoStore()
+	repos.GetFunc.SetDefaultReturn(&types.Repo{ID: 2, Name: "github.com/gorilla/mux"}, nil)
+	repos.GetByNameFunc.SetDefaultReturn(&types.Repo{ID: 2, Name: "github.com/gorilla/mux"}, nil)
+	repos.ListTagsFunc.SetDefaultHook(func(ctx context.Context, id api.RepoID) ([]*types.RepoTag, error) {
+		assert.Equal(t, api.RepoID(2), id)
+		return []*types.RepoTag{
+			{Kind: types.RepoTagKindMeta, Key: "deprecated"},
+			{Kind: types.RepoTagKindMeta, Key: "team", Value: "search"},
+			{Kind: types.RepoTagKindTopic, Key: "go"},
+			{Kind: types.RepoTagKindTopic, Key: "http"},
+		}, nil
+	})
+
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	RunTests(t, []*Test{
+		{
+			Schema: mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					repository(name: "github.com/gorilla/mux") {
+						topics
+						metadata {
+							key
+							value
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"repository": {
+						"topics": ["go", "http"],
+						"metadata": [
+							{"key": "deprecated", "value": ""},
+							{"key": "team", "value": "search"}
+						]
+					}
+				}
+			`,
+		},
+	})
+}
@@ -378,6 +378,8 @@ func LogSearchLatency(ctx context.Context, db database.DB, si *run.SearchInputs,
 				types = append(types, "standard")
 			case si.PatternType == query.SearchTypeStructural:
 				types = append(types, "structural")
+			case si.PatternType == query.SearchTypeSyntax:
+				types = append(types, "syntax")
 			case si.PatternType == query.SearchTypeLiteral:
 				types = append(types, "literal")
 			case si.PatternType == query.SearchTypeRegex:
@@ -414,6 +416,8 @@ func LogSearchLatency(ctx context.Context, db database.DB, si *run.SearchInputs,
 			types = append(types, "regexp")
 		} else if q.IsStructural() {
 			types = append(types, "structural")
+		} else if q.IsSyntax() {
+			types = append(types, "syntax")
 		} else if si.Query.Exists(query.FieldFile) {
 			// No search pattern specified and file: is specified.
 			types = append(types, "file")
@@ -344,6 +344,8 @@ func makeExternalAPI(db database.DB, schema *graphql.Schema, enterprise enterpri
 			BitbucketCloudWebhook:     enterprise.BitbucketCloudWebhook,
 			NewCodeIntelUploadHandler: enterprise.NewCodeIntelUploadHandler,
 			NewComputeStreamHandler:   enterprise.NewComputeStreamHandler,
+			NewSearchExportsHandler:   enterprise.NewSearchExportsHandler,
+			NewInsightsExportHandler:  enterprise.NewInsightsExportHandler,
 		},
 		enterprise.NewExecutorProxyHandler,
 		enterprise.NewGitHubAppCloudSetupHandler,
@@ -37,6 +37,8 @@ func newTest(t *testing.T) *httptestutil.Client {
 			BitbucketCloudWebhook:     enterpriseServices.BitbucketCloudWebhook,
 			NewCodeIntelUploadHandler: enterpriseServices.NewCodeIntelUploadHandler,
 			NewComputeStreamHandler:   enterpriseServices.NewComputeStreamHandler,
+			NewSearchExportsHandler:   enterpriseServices.NewSearchExportsHandler,
+			NewInsightsExportHandler:  enterpriseServices.NewInsightsExportHandler,
 		},
 	))
 }
@@ -46,6 +46,8 @@ type Handlers struct {
 	BitbucketCloudWebhook     http.Handler
 	NewCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler
 	NewComputeStreamHandler   enterprise.NewComputeStreamHandler
+	NewSearchExportsHandler   enterprise.NewSearchExportsHandler
+	NewInsightsExportHandler  enterprise.NewInsightsExportHandler
 }
 
 // NewHandler returns a new API handler that uses the provided API
@@ -103,6 +105,8 @@ func NewHandler(
 	m.Get(apirouter.GraphQL).Handler(trace.Route(handler(serveGraphQL(schema, rateLimiter, false))))
 
 	m.Get(apirouter.SearchStream).Handler(trace.Route(frontendsearch.StreamHandler(db)))
+	m.Get(apirouter.SearchExports).Handler(trace.Route(handlers.NewSearchExportsHandler()))
+	m.Get(apirouter.InsightsExport).Handler(trace.Route(handlers.NewInsightsExportHandler()))
 
 	// Return the minimum src-cli version that's compatible with this instance
 	m.Get(apirouter.SrcCliVersion).Handler(trace.Route(handler(srcCliVersionServe)))
@@ -2,6 +2,7 @@ package search
 
 import (
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
 	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
@@ -66,3 +67,13 @@ func (e *eventWriter) Alert(alert *search.Alert) error {
 		ProposedQueries: pqs,
 	})
 }
+
+// Profile writes the timings of the jobs profiled by p, which is the root of
+// the profile of a search.
+func (e *eventWriter) Profile(p *job.Profile) error {
+	profiles := fromProfiles(p.Children)
+	if profiles == nil {
+		profiles = []streamhttp.EventProfile{}
+	}
+	return e.inner.Event("profile", profiles)
+}
@@ -0,0 +1,57 @@
+package search
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/printer"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+)
+
+var profileRepoStatuses = []struct {
+	status search.RepoStatus
+	name   string
+}{
+	{search.RepoStatusCloning, "cloning"},
+	{search.RepoStatusMissing, "missing"},
+	{search.RepoStatusLimitHit, "limithit"},
+	{search.RepoStatusTimedout, "timedout"},
+}
+
+func fromProfiles(profiles []*job.Profile) []streamhttp.EventProfile {
+	if len(profiles) == 0 {
+		return nil
+	}
+	events := make([]streamhttp.EventProfile, 0, len(profiles))
+	for _, p := range profiles {
+		event := streamhttp.EventProfile{
+			Name:          p.Name,
+			DurationMs:    p.Duration.Milliseconds(),
+			ResultCount:   p.ResultCount,
+			ReposSearched: len(p.Stats.Repos),
+			LimitHit:      p.Stats.IsLimitHit,
+			Children:      fromProfiles(p.Children),
+		}
+		if len(p.Fields) > 0 {
+			event.Fields = printer.FieldsMap(p.Fields)
+		}
+		for _, s := range profileRepoStatuses {
+			count := 0
+			p.Stats.Status.Filter(s.status, func(api.RepoID) { count++ })
+			if count > 0 {
+				if event.RepoStatuses == nil {
+					event.RepoStatuses = map[string]int{}
+				}
+				event.RepoStatuses[s.name] = count
+			}
+		}
+		if p.Alert != nil {
+			event.Alert = p.Alert.Title
+		}
+		if p.Err != nil {
+			event.Error = p.Err.Error()
+		}
+		events = append(events, event)
+	}
+	return events
+}
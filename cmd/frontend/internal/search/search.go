@@ -29,6 +29,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/run"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
@@ -157,14 +158,26 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr *trace.Trace, eventWriter
 		args.EnableChunkMatches,
 		logLatency,
 	)
+	// The jobs of a profiled search record their timings, which are sent
+	// once the search finished.
+	var profile *job.Profile
+	execCtx := ctx
+	if args.Profile {
+		profile = job.NewProfile()
+		execCtx = job.ContextWithProfile(ctx, profile)
+	}
+
 	batchedStream := streaming.NewBatchingStream(50*time.Millisecond, eventHandler)
-	alert, err := h.searchClient.Execute(ctx, batchedStream, inputs)
+	alert, err := h.searchClient.Execute(execCtx, batchedStream, inputs)
 	// Clean up streams before writing to eventWriter again.
 	batchedStream.Done()
 	eventHandler.Done()
 	if alert != nil {
 		eventWriter.Alert(alert)
 	}
+	if profile != nil {
+		eventWriter.Profile(profile)
+	}
 	logSearch(ctx, h.logger, alert, err, start, inputs.OriginalQuery, progress)
 	return err
 }
@@ -207,6 +220,10 @@ type args struct {
 	Display            int
 	EnableChunkMatches bool
 
+	// Profile is true if the timings of the jobs of the search are sent,
+	// which is requested with debug=profile.
+	Profile bool
+
 	// Optional decoration parameters for server-side rendering a result set
 	// or subset. Decorations may specify, e.g., highlighting results with
 	// HTML markup up-front, and/or including context lines around file results.
@@ -246,6 +263,14 @@ func parseURLQuery(q url.Values) (*args, error) {
 		return nil, errors.Errorf("chunk matches must be parseable as a boolean, got %q: %w", chunkMatches, err)
 	}
 
+	switch debug := get("debug", ""); debug {
+	case "":
+	case "profile":
+		a.Profile = true
+	default:
+		return nil, errors.Errorf("debug must be profile, got %q", debug)
+	}
+
 	decorationLimit := get("dl", "0")
 	if a.DecorationLimit, err = strconv.Atoi(decorationLimit); err != nil {
 		return nil, errors.Errorf("decorationLimit must be an integer, got %q: %w", decorationLimit, err)
@@ -295,6 +320,8 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo,
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.OwnerMatch:
+		return fromOwner(v)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
@@ -417,12 +444,17 @@ func fromSymbolMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searc
 			kindString = strings.ToUpper(kind.String())
 		}
 
-		symbols = append(symbols, streamhttp.Symbol{
+		symbol := streamhttp.Symbol{
 			URL:           sym.URL().String(),
 			Name:          sym.Symbol.Name,
 			ContainerName: sym.Symbol.Parent,
 			Kind:          kindString,
-		})
+		}
+		if history := sym.Symbol.History; history != nil {
+			symbol.IntroducedCommit = string(history.Introduced)
+			symbol.DeletedCommit = string(history.Deleted)
+		}
+		symbols = append(symbols, symbol)
 	}
 
 	symbolMatch := &streamhttp.EventSymbolMatch{
@@ -471,6 +503,16 @@ func fromRepository(rm *result.RepoMatch, repoCache map[api.RepoID]*types.Search
 	return repoEvent
 }
 
+func fromOwner(om *result.OwnerMatch) *streamhttp.EventOwnerMatch {
+	return &streamhttp.EventOwnerMatch{
+		Type:         streamhttp.OwnerMatchType,
+		Handle:       om.Handle,
+		RepositoryID: int32(om.Repo.ID),
+		Repository:   string(om.Repo.Name),
+		Commit:       string(om.CommitID),
+	}
+}
+
 func fromCommit(commit *result.CommitMatch, repoCache map[api.RepoID]*types.SearchedRepo) *streamhttp.EventCommitMatch {
 	hls := commit.Body().ToHighlightedString()
 	ranges := make([][3]int32, len(hls.Highlights))
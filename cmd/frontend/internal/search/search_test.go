@@ -19,6 +19,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/run"
@@ -285,3 +288,67 @@ func mkRepoMatch(id int) *result.RepoMatch {
 		Name: api2.RepoName(fmt.Sprintf("repo%d", id)),
 	}
 }
+
+func TestServeStream_profile(t *testing.T) {
+	graphqlbackend.MockDecodedViewerFinalSettings = &schema.Settings{}
+	t.Cleanup(func() { graphqlbackend.MockDecodedViewerFinalSettings = nil })
+
+	child := mockjob.NewMockJob()
+	child.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: result.Matches{&result.RepoMatch{Name: "foo"}}})
+		return nil, nil
+	})
+	limitJob := jobutil.NewLimitJob(10, child)
+
+	mock := client.NewMockSearchClient()
+	mock.PlanFunc.SetDefaultReturn(&run.SearchInputs{}, nil)
+	mock.ExecuteFunc.SetDefaultHook(func(ctx context.Context, s streaming.Sender, _ *run.SearchInputs) (*search.Alert, error) {
+		return limitJob.Run(ctx, job.RuntimeClients{}, s)
+	})
+
+	mockRepos := database.NewMockRepoStore()
+	mockRepos.MetadataFunc.SetDefaultReturn(nil, nil)
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(mockRepos)
+
+	ts := httptest.NewServer(&streamHandler{
+		logger:              logtest.Scoped(t),
+		db:                  db,
+		flushTickerInternal: 1 * time.Millisecond,
+		pingTickerInterval:  1 * time.Millisecond,
+		searchClient:        mock,
+	})
+	defer ts.Close()
+
+	search := func(t *testing.T, path string) (profiles []streamhttp.EventProfile, sent bool) {
+		res, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+
+		err = streamhttp.FrontendStreamDecoder{
+			OnProfile: func(ev []streamhttp.EventProfile) {
+				profiles, sent = ev, true
+			},
+		}.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return profiles, sent
+	}
+
+	t.Run("not profiled", func(t *testing.T) {
+		_, sent := search(t, "?q=test")
+		require.False(t, sent)
+	})
+
+	t.Run("profiled", func(t *testing.T) {
+		profiles, sent := search(t, "?q=test&debug=profile")
+		require.True(t, sent)
+		require.Len(t, profiles, 1)
+		require.Equal(t, "LimitJob", profiles[0].Name)
+		require.Equal(t, map[string]any{"limit": float64(10)}, profiles[0].Fields)
+		require.Equal(t, 1, profiles[0].ResultCount)
+	})
+}
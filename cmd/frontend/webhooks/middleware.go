@@ -133,6 +133,7 @@ func loggingEnabledByDefault(keys *schema.EncryptionKeys) bool {
 	// If any encryption key is provided, then this is off by default.
 	if keys != nil {
 		return keys.BatchChangesCredentialKey == nil &&
+			keys.CodeMonitorActionKey == nil &&
 			keys.ExternalServiceKey == nil &&
 			keys.UserExternalAccountKey == nil &&
 			keys.WebhookLogKey == nil
@@ -1,26 +1,15 @@
 #!/usr/bin/env bash
 
-# We want to build multiple go binaries, so we use a custom build step on CI.
-cd "$(dirname "${BASH_SOURCE[0]}")"/../..
-set -ex
-
-OUTPUT=$(mktemp -d -t sgdockerbuild_XXXXXXX)
-cleanup() {
-  rm -rf "$OUTPUT"
-}
-trap cleanup EXIT
+# This script builds the searcher docker image. searcher is built with cgo
+# inside the image, since syntax search uses tree-sitter.
 
-# Environment for building linux binaries
-export GO111MODULE=on
-export GOARCH=amd64
-export GOOS=linux
-export CGO_ENABLED=0
-
-pkg="github.com/sourcegraph/sourcegraph/cmd/searcher"
-go build -trimpath -ldflags "-X github.com/sourcegraph/sourcegraph/internal/version.version=$VERSION  -X github.com/sourcegraph/sourcegraph/internal/version.timestamp=$(date +%s)" -buildmode exe -tags dist -o "$OUTPUT/$(basename $pkg)" "$pkg"
+cd "$(dirname "${BASH_SOURCE[0]}")"/../..
+set -eu
 
-docker build -f cmd/searcher/Dockerfile -t "$IMAGE" "$OUTPUT" \
+echo "--- docker build searcher"
+docker build -f cmd/searcher/Dockerfile -t "$IMAGE" "$(pwd)" \
   --progress=plain \
   --build-arg COMMIT_SHA \
   --build-arg DATE \
-  --build-arg VERSION
+  --build-arg VERSION \
+  --build-arg PKG="${PKG:-github.com/sourcegraph/sourcegraph/cmd/searcher}"
@@ -30,9 +30,11 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/internal/syntax"
 	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/pathmatch"
 	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
 	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
@@ -148,6 +150,7 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 	span.SetTag("pattern", p.Pattern)
 	span.SetTag("isRegExp", strconv.FormatBool(p.IsRegExp))
 	span.SetTag("isStructuralPat", strconv.FormatBool(p.IsStructuralPat))
+	span.SetTag("isSyntaxPat", strconv.FormatBool(p.IsSyntaxPat))
 	span.SetTag("languages", p.Languages)
 	span.SetTag("isWordMatch", strconv.FormatBool(p.IsWordMatch))
 	span.SetTag("isCaseSensitive", strconv.FormatBool(p.IsCaseSensitive))
@@ -190,6 +193,7 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 			log.String("pattern", p.Pattern),
 			log.Bool("isRegExp", p.IsRegExp),
 			log.Bool("isStructuralPat", p.IsStructuralPat),
+			log.Bool("isSyntaxPat", p.IsSyntaxPat),
 			log.Strings("languages", p.Languages),
 			log.Bool("isWordMatch", p.IsWordMatch),
 			log.Bool("isCaseSensitive", p.IsCaseSensitive),
@@ -209,8 +213,19 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 	}
 
 	// Compile pattern before fetching from store incase it is bad.
-	var rg *readerGrep
-	if !p.IsStructuralPat {
+	var (
+		rg          *readerGrep
+		syntaxQuery *syntax.Query
+		matchPath   pathmatch.PathMatcher
+	)
+	switch {
+	case p.IsSyntaxPat:
+		syntaxQuery, matchPath, err = compileSyntax(&p.PatternInfo)
+		if err != nil {
+			return badRequestError{err.Error()}
+		}
+		defer syntaxQuery.Close()
+	case !p.IsStructuralPat:
 		rg, err = compile(&p.PatternInfo)
 		if err != nil {
 			return badRequestError{err.Error()}
@@ -236,7 +251,7 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 		return path, zf, err
 	}
 
-	hybrid := !p.IsStructuralPat && p.FeatHybrid
+	hybrid := !p.IsStructuralPat && !p.IsSyntaxPat && p.FeatHybrid
 	if hybrid {
 		unsearched, ok, err := s.hybrid(ctx, p, sender)
 		if err != nil {
@@ -283,9 +298,12 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 	metricArchiveFiles.Observe(float64(nFiles))
 	metricArchiveSize.Observe(float64(bytes))
 
-	if p.IsStructuralPat {
+	switch {
+	case p.IsStructuralPat:
 		return filteredStructuralSearch(ctx, zipPath, zf, &p.PatternInfo, p.Repo, sender)
-	} else {
+	case p.IsSyntaxPat:
+		return syntaxSearch(ctx, syntaxQuery, matchPath, zf, sender)
+	default:
 		return regexSearch(ctx, rg, zf, p.PatternMatchesContent, p.PatternMatchesPath, p.IsNegated, sender)
 	}
 }
@@ -304,6 +322,12 @@ func validateParams(p *protocol.Request) error {
 	if p.IsNegated && p.IsStructuralPat {
 		return errors.New("Negated patterns are not supported for structural searches")
 	}
+	if p.IsNegated && p.IsSyntaxPat {
+		return errors.New("Negated patterns are not supported for syntax searches")
+	}
+	if p.IsStructuralPat && p.IsSyntaxPat {
+		return errors.New("A pattern cannot be both a structural and a syntax pattern")
+	}
 	return nil
 }
 
@@ -0,0 +1,102 @@
+package search
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"go.uber.org/atomic"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/internal/syntax"
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/pathmatch"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+)
+
+// compileSyntax compiles the tree-sitter query of p, and the include/exclude
+// path patterns that select the files it runs on.
+func compileSyntax(p *protocol.PatternInfo) (*syntax.Query, pathmatch.PathMatcher, error) {
+	pathOnly := *p
+	pathOnly.Pattern = ""
+	rg, err := compile(&pathOnly)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	q, err := syntax.Compile(p.Pattern, p.Languages)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q, rg.matchPath, nil
+}
+
+// syntaxSearch concurrently runs the tree-sitter query q on the files in zf
+// that match matchPath. Each node the query captures is a match.
+func syntaxSearch(ctx context.Context, q *syntax.Query, matchPath pathmatch.PathMatcher, zf *zipFile, sender matchSender) (err error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "SyntaxSearch")
+	ext.Component.Set(span, "syntax_search")
+	span.SetTag("path", matchPath.String())
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.SetTag("err", err.Error())
+		}
+		span.Finish()
+	}()
+
+	// The sender cancels ctx once the limit is hit, which stops the workers.
+	var (
+		files         = zf.Files
+		lastFileIdx   = atomic.NewInt32(-1)
+		filesSkipped  atomic.Uint32
+		filesSearched atomic.Uint32
+	)
+
+	g, gctx := errgroup.WithContext(ctx)
+	for i := 0; i < numWorkers; i++ {
+		g.Go(func() error {
+			for gctx.Err() == nil {
+				idx := int(lastFileIdx.Inc())
+				if idx >= len(files) {
+					return nil
+				}
+
+				f := &files[idx]
+				if !matchPath.MatchPath(f.Name) {
+					filesSkipped.Inc()
+					continue
+				}
+				filesSearched.Inc()
+
+				fileBuf := zf.DataFor(f)
+				ranges, err := q.Match(gctx, f.Name, fileBuf)
+				if err != nil {
+					if gctx.Err() != nil {
+						return nil
+					}
+					return err
+				}
+				if len(ranges) == 0 {
+					continue
+				}
+				sender.Send(protocol.FileMatch{
+					Path:         f.Name,
+					ChunkMatches: chunksToMatches(fileBuf, chunkRanges(ranges, 0)),
+				})
+			}
+			return nil
+		})
+	}
+
+	err = g.Wait()
+	if err == nil && ctx.Err() == context.DeadlineExceeded {
+		err = ctx.Err()
+	}
+
+	span.LogFields(
+		otlog.Int("filesSkipped", int(filesSkipped.Load())),
+		otlog.Int("filesSearched", int(filesSearched.Load())),
+	)
+	return err
+}
@@ -248,6 +248,14 @@ file++.plus:1:1:
 filename contains regex metachars
 `},
 
+		{protocol.PatternInfo{
+			Pattern:     `(call_expression function: (selector_expression field: (field_identifier) @f (#eq? @f "Println")))`,
+			IsSyntaxPat: true,
+		}, `
+main.go:6:6:
+	fmt.Println("Hello world")
+`},
+
 		{protocol.PatternInfo{Pattern: "World", IsNegated: true}, `
 abc.txt
 file++.plus
@@ -441,6 +449,17 @@ func TestSearch_badrequest(t *testing.T) {
 				IsStructuralPat:        true,
 			},
 		},
+
+		// syntax search with an invalid tree-sitter query
+		{
+			Repo:   "foo",
+			URL:    "u",
+			Commit: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+			PatternInfo: protocol.PatternInfo{
+				Pattern:     "(call_expression",
+				IsSyntaxPat: true,
+			},
+		},
 	}
 
 	store := newStore(t, nil)
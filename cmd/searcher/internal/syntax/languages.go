@@ -0,0 +1,132 @@
+//go:build cgo
+
+package syntax
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/cpp"
+	"github.com/smacker/go-tree-sitter/csharp"
+	"github.com/smacker/go-tree-sitter/golang"
+	"github.com/smacker/go-tree-sitter/java"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/python"
+	"github.com/smacker/go-tree-sitter/ruby"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+)
+
+type language struct {
+	name   string
+	sitter *sitter.Language
+
+	// aliases are other values of lang: filters that select the language.
+	aliases []string
+
+	extensions []string
+	fileNames  []string
+}
+
+// supportedLanguages are the languages with a tree-sitter grammar. They are
+// the languages that cmd/symbols/squirrel supports, with the same file
+// extensions.
+var supportedLanguages = []*language{
+	{
+		name:       "go",
+		sitter:     golang.GetLanguage(),
+		aliases:    []string{"golang"},
+		extensions: []string{"go"},
+	},
+	{
+		name:       "java",
+		sitter:     java.GetLanguage(),
+		extensions: []string{"java"},
+	},
+	{
+		name:       "python",
+		sitter:     python.GetLanguage(),
+		aliases:    []string{"py"},
+		extensions: []string{"py", "pyw"},
+	},
+	{
+		name:       "csharp",
+		sitter:     csharp.GetLanguage(),
+		aliases:    []string{"c#", "cs"},
+		extensions: []string{"cs", "csx"},
+	},
+	{
+		name:       "cpp",
+		sitter:     cpp.GetLanguage(),
+		aliases:    []string{"c++", "c"},
+		extensions: []string{"c", "cc", "cpp", "cxx", "c++", "h++", "hh", "h", "hpp"},
+	},
+	{
+		name:       "javascript",
+		sitter:     javascript.GetLanguage(),
+		aliases:    []string{"js"},
+		extensions: []string{"js", "jsx", "es", "es6", "mjs", "cjs"},
+	},
+	{
+		// The TSX grammar is a superset of the TypeScript grammar.
+		name:       "typescript",
+		sitter:     tsx.GetLanguage(),
+		aliases:    []string{"ts", "tsx"},
+		extensions: []string{"ts", "tsx"},
+	},
+	{
+		name:       "ruby",
+		sitter:     ruby.GetLanguage(),
+		aliases:    []string{"rb"},
+		extensions: []string{"rb", "gemspec", "rake", "ru"},
+		fileNames:  []string{"Gemfile", "Rakefile"},
+	},
+	{
+		// Starlark is syntactically a subset of Python.
+		name:       "starlark",
+		sitter:     python.GetLanguage(),
+		aliases:    []string{"bazel"},
+		extensions: []string{"bzl", "bazel", "star"},
+		fileNames:  []string{"BUILD", "WORKSPACE"},
+	},
+}
+
+var (
+	languageByExtension = map[string]*language{}
+	languageByFileName  = map[string]*language{}
+)
+
+func init() {
+	for _, lang := range supportedLanguages {
+		for _, ext := range lang.extensions {
+			languageByExtension[ext] = lang
+		}
+		for _, name := range lang.fileNames {
+			languageByFileName[name] = lang
+		}
+	}
+}
+
+// languageByName returns the language selected by the value of a lang:
+// filter.
+func languageByName(name string) (*language, bool) {
+	name = strings.ToLower(name)
+	for _, lang := range supportedLanguages {
+		if lang.name == name {
+			return lang, true
+		}
+		for _, alias := range lang.aliases {
+			if alias == name {
+				return lang, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func supportedLanguageNames() []string {
+	names := make([]string, 0, len(supportedLanguages))
+	for _, lang := range supportedLanguages {
+		names = append(names, lang.name)
+	}
+	return names
+}
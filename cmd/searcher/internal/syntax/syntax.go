@@ -0,0 +1,302 @@
+//go:build cgo
+
+// Package syntax matches tree-sitter queries against the syntax trees of
+// files, for searches with patterntype:syntax.
+package syntax
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/grafana/regexp"
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Query is a tree-sitter query, compiled for each language it is valid in. A
+// query is valid in a language if the node types and fields it refers to
+// exist in the grammar of the language.
+type Query struct {
+	queries map[*language]*compiledQuery
+}
+
+type compiledQuery struct {
+	query *sitter.Query
+
+	// predicates are the predicates of each pattern of the query, by the
+	// index of the pattern.
+	predicates [][]predicate
+}
+
+// Compile compiles the tree-sitter query pattern for the languages in
+// languages, which are values of lang: filters. If languages is empty, the
+// query is compiled for all supported languages.
+func Compile(pattern string, languages []string) (*Query, error) {
+	candidates := supportedLanguages
+	if len(languages) > 0 {
+		candidates = nil
+		for _, name := range languages {
+			lang, ok := languageByName(name)
+			if !ok {
+				return nil, errors.Errorf("syntax search does not support lang:%s, supported languages are %s", name, strings.Join(supportedLanguageNames(), ", "))
+			}
+			candidates = append(candidates, lang)
+		}
+	}
+
+	q := &Query{queries: map[*language]*compiledQuery{}}
+	var errs []string
+	for _, lang := range candidates {
+		cq, err := compileFor(pattern, lang)
+		if err != nil {
+			errs = append(errs, lang.name+": "+err.Error())
+			continue
+		}
+		q.queries[lang] = cq
+	}
+	if len(q.queries) == 0 {
+		return nil, errors.Errorf("the pattern is not a valid tree-sitter query in any searched language (%s)", strings.Join(errs, "; "))
+	}
+	return q, nil
+}
+
+func compileFor(pattern string, lang *language) (*compiledQuery, error) {
+	query, err := sitter.NewQuery([]byte(pattern), lang.sitter)
+	if err != nil {
+		return nil, err
+	}
+
+	hasCapture := false
+	for i := uint32(0); i < query.CaptureCount(); i++ {
+		if isPublicCapture(query.CaptureNameForId(i)) {
+			hasCapture = true
+			break
+		}
+	}
+	if !hasCapture {
+		query.Close()
+		return nil, errors.New("the query must capture at least one node, e.g. (function_declaration) @match")
+	}
+
+	cq := &compiledQuery{query: query}
+	for i := uint32(0); i < query.PatternCount(); i++ {
+		predicates, err := parsePredicates(query, i)
+		if err != nil {
+			query.Close()
+			return nil, err
+		}
+		cq.predicates = append(cq.predicates, predicates)
+	}
+	return cq, nil
+}
+
+// isPublicCapture returns false for captures that start with an underscore,
+// which by convention are only used in predicates and not highlighted.
+func isPublicCapture(name string) bool {
+	return !strings.HasPrefix(name, "_")
+}
+
+// Close frees the compiled queries.
+func (q *Query) Close() {
+	for _, cq := range q.queries {
+		cq.query.Close()
+	}
+}
+
+// Match returns the ranges of the nodes captured by q in the file at path
+// with the given content. It returns no ranges if the language of the file is
+// not supported, or q is not valid in it.
+func (q *Query) Match(ctx context.Context, filePath string, content []byte) ([]protocol.Range, error) {
+	lang, ok := languageForPath(filePath)
+	if !ok {
+		return nil, nil
+	}
+	cq, ok := q.queries[lang]
+	if !ok {
+		return nil, nil
+	}
+
+	parser := sitter.NewParser()
+	defer parser.Close()
+	parser.SetLanguage(lang.sitter)
+	tree, err := parser.ParseCtx(ctx, nil, content)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := sitter.NewQueryCursor()
+	defer cursor.Close()
+	cursor.Exec(cq.query, tree.RootNode())
+
+	// A node can be captured by many matches, e.g. by overlapping patterns.
+	seen := map[[2]uint32]struct{}{}
+	var ranges []protocol.Range
+	for {
+		match, ok := cursor.NextMatch()
+		if !ok {
+			break
+		}
+		if !cq.matches(match, content) {
+			continue
+		}
+		for _, capture := range match.Captures {
+			if !isPublicCapture(cq.query.CaptureNameForId(capture.Index)) {
+				continue
+			}
+			key := [2]uint32{capture.Node.StartByte(), capture.Node.EndByte()}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			ranges = append(ranges, protocol.Range{
+				Start: location(content, capture.Node.StartByte(), capture.Node.StartPoint()),
+				End:   location(content, capture.Node.EndByte(), capture.Node.EndPoint()),
+			})
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start.Offset < ranges[j].Start.Offset
+	})
+	return ranges, nil
+}
+
+// location converts a tree-sitter position to a protocol location. Like the
+// other searches, columns count runes rather than bytes.
+func location(content []byte, offset uint32, point sitter.Point) protocol.Location {
+	lineStart := offset - point.Column
+	return protocol.Location{
+		Offset: int32(offset),
+		Line:   int32(point.Row),
+		Column: int32(utf8.RuneCount(content[lineStart:offset])),
+	}
+}
+
+// predicate is a general predicate of a tree-sitter query, e.g.
+// (#eq? @name "Unlock"). Tree-sitter parses predicates, but leaves evaluating
+// them to the caller.
+type predicate struct {
+	name    string
+	capture uint32
+
+	// Exactly one of the following is set, depending on the predicate and
+	// whether its second argument is a capture or a string.
+	otherCapture *uint32
+	value        string
+	re           *regexp.Regexp
+}
+
+func parsePredicates(query *sitter.Query, patternIndex uint32) ([]predicate, error) {
+	var (
+		predicates []predicate
+		steps      []sitter.QueryPredicateStep
+	)
+	for _, step := range query.PredicatesForPattern(patternIndex) {
+		if step.Type != sitter.QueryPredicateStepTypeDone {
+			steps = append(steps, step)
+			continue
+		}
+		p, err := parsePredicate(query, steps)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, p)
+		steps = steps[:0]
+	}
+	return predicates, nil
+}
+
+func parsePredicate(query *sitter.Query, steps []sitter.QueryPredicateStep) (predicate, error) {
+	if len(steps) == 0 || steps[0].Type != sitter.QueryPredicateStepTypeString {
+		return predicate{}, errors.New("invalid predicate")
+	}
+	name := query.StringValueForId(steps[0].ValueId)
+
+	switch name {
+	case "eq?", "not-eq?", "match?", "not-match?":
+	default:
+		return predicate{}, errors.Errorf("unsupported predicate #%s, supported predicates are #eq?, #not-eq?, #match? and #not-match?", name)
+	}
+	if len(steps) != 3 || steps[1].Type != sitter.QueryPredicateStepTypeCapture {
+		return predicate{}, errors.Errorf("#%s takes a capture and a capture or string, e.g. (#%s @name \"value\")", name, name)
+	}
+
+	p := predicate{name: name, capture: steps[1].ValueId}
+	arg := steps[2]
+	switch {
+	case arg.Type == sitter.QueryPredicateStepTypeCapture && (name == "eq?" || name == "not-eq?"):
+		id := arg.ValueId
+		p.otherCapture = &id
+	case arg.Type == sitter.QueryPredicateStepTypeString && (name == "eq?" || name == "not-eq?"):
+		p.value = query.StringValueForId(arg.ValueId)
+	case arg.Type == sitter.QueryPredicateStepTypeString:
+		re, err := regexp.Compile(query.StringValueForId(arg.ValueId))
+		if err != nil {
+			return predicate{}, errors.Wrapf(err, "invalid regular expression in #%s", name)
+		}
+		p.re = re
+	default:
+		return predicate{}, errors.Errorf("#%s takes a capture and a string, e.g. (#%s @name \"^value$\")", name, name)
+	}
+	return p, nil
+}
+
+// matches returns true if match satisfies the predicates of its pattern.
+func (cq *compiledQuery) matches(match *sitter.QueryMatch, content []byte) bool {
+	predicates := cq.predicates[match.PatternIndex]
+	if len(predicates) == 0 {
+		return true
+	}
+
+	captured := func(id uint32) (string, bool) {
+		for _, c := range match.Captures {
+			if c.Index == id {
+				return c.Node.Content(content), true
+			}
+		}
+		return "", false
+	}
+
+	for _, p := range predicates {
+		text, ok := captured(p.capture)
+		if !ok {
+			// Optional nodes that were not captured do not constrain
+			// the match.
+			continue
+		}
+
+		var holds bool
+		switch {
+		case p.re != nil:
+			holds = p.re.MatchString(text)
+		case p.otherCapture != nil:
+			other, ok := captured(*p.otherCapture)
+			holds = ok && text == other
+		default:
+			holds = text == p.value
+		}
+		if strings.HasPrefix(p.name, "not-") {
+			holds = !holds
+		}
+		if !holds {
+			return false
+		}
+	}
+	return true
+}
+
+// languageForPath returns the language of the file at filePath, based on its
+// extension or name.
+func languageForPath(filePath string) (*language, bool) {
+	base := path.Base(filePath)
+	if lang, ok := languageByFileName[base]; ok {
+		return lang, true
+	}
+	ext := strings.TrimPrefix(path.Ext(base), ".")
+	lang, ok := languageByExtension[strings.ToLower(ext)]
+	return lang, ok
+}
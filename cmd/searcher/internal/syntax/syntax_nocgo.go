@@ -0,0 +1,26 @@
+//go:build !cgo
+
+package syntax
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Query is a tree-sitter query. Tree-sitter requires cgo, so without it
+// queries cannot be compiled.
+type Query struct{}
+
+// Compile returns an error, since syntax search requires searcher to be
+// built with cgo.
+func Compile(pattern string, languages []string) (*Query, error) {
+	return nil, errors.New("syntax search is not supported: searcher was built without cgo")
+}
+
+func (q *Query) Close() {}
+
+func (q *Query) Match(ctx context.Context, filePath string, content []byte) ([]protocol.Range, error) {
+	return nil, errors.New("syntax search is not supported: searcher was built without cgo")
+}
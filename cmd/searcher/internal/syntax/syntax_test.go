@@ -0,0 +1,183 @@
+//go:build cgo
+
+package syntax
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+)
+
+const goSource = `package main
+
+func f(mu *sync.Mutex) {
+	mu.Lock()
+	defer mu.Unlock()
+	mu.TryLock()
+}
+`
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		name    string
+		pattern string
+		path    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "eq predicate",
+			pattern: `(call_expression function: (selector_expression field: (field_identifier) @f (#eq? @f "Unlock")))`,
+			path:    "main.go",
+			content: goSource,
+			want:    []string{"Unlock"},
+		},
+		{
+			name:    "not-eq predicate",
+			pattern: `(call_expression function: (selector_expression field: (field_identifier) @f (#not-eq? @f "Unlock")))`,
+			path:    "main.go",
+			content: goSource,
+			want:    []string{"Lock", "TryLock"},
+		},
+		{
+			name:    "match predicate",
+			pattern: `(call_expression function: (selector_expression field: (field_identifier) @f (#match? @f "^Try")))`,
+			path:    "main.go",
+			content: goSource,
+			want:    []string{"TryLock"},
+		},
+		{
+			name:    "predicate on private capture",
+			pattern: `(call_expression function: (selector_expression field: (field_identifier) @_f (#eq? @_f "Lock"))) @call`,
+			path:    "main.go",
+			content: goSource,
+			want:    []string{"mu.Lock()"},
+		},
+		{
+			name:    "capture equality",
+			pattern: `(assignment (identifier) @a (identifier) @b (#eq? @a @b))`,
+			path:    "main.py",
+			content: "x = x\nx = y\n",
+			want:    []string{"x", "x"},
+		},
+		{
+			name:    "unsupported file",
+			pattern: `(identifier) @id`,
+			path:    "README.md",
+			content: "# hello",
+		},
+		{
+			name:    "file name",
+			pattern: `(call function: (identifier) @f)`,
+			path:    "foo/BUILD",
+			content: "go_library(name = \"foo\")\n",
+			want:    []string{"go_library"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := Compile(tc.pattern, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer q.Close()
+
+			ranges, err := q.Match(context.Background(), tc.path, []byte(tc.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, r := range ranges {
+				got = append(got, tc.content[r.Start.Offset:r.End.Offset])
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Fatalf("unexpected matches (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestMatch_location(t *testing.T) {
+	q, err := Compile(`((identifier) @id (#eq? @id "y"))`, []string{"python"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+
+	content := "s = 'é'; y = 1\n"
+	got, err := q.Match(context.Background(), "a.py", []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Offsets are in bytes, columns in runes.
+	want := []protocol.Range{{
+		Start: protocol.Location{Offset: 10, Line: 0, Column: 9},
+		End:   protocol.Location{Offset: 11, Line: 0, Column: 10},
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Fatalf("unexpected ranges (-want +got):\n%s", d)
+	}
+}
+
+func TestCompile(t *testing.T) {
+	cases := []struct {
+		name      string
+		pattern   string
+		languages []string
+		wantErr   string
+	}{
+		{
+			name:      "valid in lang",
+			pattern:   `(function_declaration name: (identifier) @name)`,
+			languages: []string{"Go"},
+		},
+		{
+			name:      "invalid in lang",
+			pattern:   `(method_invocation) @call`,
+			languages: []string{"go"},
+			wantErr:   "not a valid tree-sitter query",
+		},
+		{
+			name:      "unsupported lang",
+			pattern:   `(identifier) @id`,
+			languages: []string{"cobol"},
+			wantErr:   "does not support lang:cobol",
+		},
+		{
+			name:    "no captures",
+			pattern: `(identifier)`,
+			wantErr: "must capture at least one node",
+		},
+		{
+			name:    "unsupported predicate",
+			pattern: `((identifier) @id (#any-of? @id "a" "b"))`,
+			wantErr: "unsupported predicate #any-of?",
+		},
+		{
+			name:    "invalid regexp",
+			pattern: `((identifier) @id (#match? @id "("))`,
+			wantErr: "invalid regular expression",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := Compile(tc.pattern, tc.languages)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				q.Close()
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -76,6 +76,10 @@ type PatternInfo struct {
 	// IsStructuralPat if true will treat the pattern as a Comby structural search pattern.
 	IsStructuralPat bool
 
+	// IsSyntaxPat if true will treat the pattern as a tree-sitter query. The
+	// nodes captured by the query are the matches.
+	IsSyntaxPat bool
+
 	// IsWordMatch if true will only match the pattern at word boundaries.
 	IsWordMatch bool
 
@@ -145,6 +149,9 @@ func (p *PatternInfo) String() string {
 			args = append(args, "comby")
 		}
 	}
+	if p.IsSyntaxPat {
+		args = append(args, "syntax")
+	}
 	if p.IsWordMatch {
 		args = append(args, "word")
 	}
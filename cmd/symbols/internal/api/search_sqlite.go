@@ -37,6 +37,7 @@ func MakeSqliteSearchFunc(operations *sharedobservability.Operations, cachedData
 			log.String("excludePattern", args.ExcludePattern),
 			log.Int("first", args.First),
 			log.Int("timeout", args.Timeout),
+			log.Bool("history", args.History),
 		}})
 		defer func() {
 			endObservation(1, observation.Args{
@@ -46,6 +47,10 @@ func MakeSqliteSearchFunc(operations *sharedobservability.Operations, cachedData
 		}()
 		ctx = observability.SeedParseAmount(ctx)
 
+		if args.History {
+			return nil, errors.New("Symbol history is only supported by [Rockskip](https://docs.sourcegraph.com/code_intelligence/explanations/rockskip), which is not enabled for this repository.")
+		}
+
 		timeout := searchTimeout
 		if args.Timeout > 0 && time.Duration(args.Timeout)*time.Second < timeout {
 			timeout = time.Duration(args.Timeout) * time.Second
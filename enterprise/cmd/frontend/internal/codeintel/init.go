@@ -4,14 +4,18 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	codeintelresolvers "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
 	codeintelgqlresolvers "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers/graphql"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	policies "github.com/sourcegraph/sourcegraph/internal/codeintel/policies/enterprise"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/symbols"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -28,17 +32,23 @@ func Init(ctx context.Context, db database.DB, config *Config, enterpriseService
 		},
 	}
 
-	resolver, err := newResolver(db, config, resolverObservationContext, services)
+	resolver, innerResolver, err := newResolver(db, config, resolverObservationContext, services)
 	if err != nil {
 		return err
 	}
 
 	enterpriseServices.CodeIntelResolver = resolver
 	enterpriseServices.NewCodeIntelUploadHandler = newUploadHandler(services)
+
+	// Merge the document symbols of precise uploads into symbol searches when possible
+	backend.PreciseSymbols = func(ctx context.Context, repoID api.RepoID, args search.SymbolsParameters) (result.Symbols, bool, error) {
+		return innerResolver.SearchSymbols(ctx, int(repoID), args)
+	}
+
 	return nil
 }
 
-func newResolver(db database.DB, config *Config, observationContext *observation.Context, services *Services) (gql.CodeIntelResolver, error) {
+func newResolver(db database.DB, config *Config, observationContext *observation.Context, services *Services) (gql.CodeIntelResolver, codeintelresolvers.Resolver, error) {
 	policyMatcher := policies.NewMatcher(
 		services.gitserverClient,
 		policies.NoopExtractor,
@@ -48,7 +58,7 @@ func newResolver(db database.DB, config *Config, observationContext *observation
 
 	hunkCache, err := codeintelresolvers.NewHunkCache(config.HunkCacheSize)
 	if err != nil {
-		return nil, errors.Errorf("failed to initialize hunk cache: %s", err)
+		return nil, nil, errors.Errorf("failed to initialize hunk cache: %s", err)
 	}
 
 	innerResolver := codeintelresolvers.NewResolver(
@@ -71,7 +81,7 @@ func newResolver(db database.DB, config *Config, observationContext *observation
 		Tracer:       &trace.Tracer{},
 		Registerer:   nil,
 		HoneyDataset: &honey.Dataset{},
-	}), nil
+	}), innerResolver, nil
 }
 
 func newUploadHandler(services *Services) func(internal bool) http.Handler {
@@ -0,0 +1,63 @@
+package graphql
+
+import (
+	"context"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
+)
+
+type CallHierarchyCallConnectionResolver struct {
+	calls            []resolvers.AdjustedCall
+	cursor           *string
+	locationResolver *CachedLocationResolver
+}
+
+func NewCallHierarchyCallConnectionResolver(calls []resolvers.AdjustedCall, cursor *string, locationResolver *CachedLocationResolver) gql.CallHierarchyCallConnectionResolver {
+	return &CallHierarchyCallConnectionResolver{
+		calls:            calls,
+		cursor:           cursor,
+		locationResolver: locationResolver,
+	}
+}
+
+func (r *CallHierarchyCallConnectionResolver) Nodes(ctx context.Context) ([]gql.CallHierarchyCallResolver, error) {
+	resolvedCalls := make([]gql.CallHierarchyCallResolver, 0, len(r.calls))
+	for _, call := range r.calls {
+		symbol, err := resolveLocation(ctx, r.locationResolver, call.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		if symbol == nil {
+			// The commit of the symbol is not known by gitserver
+			continue
+		}
+
+		ranges, err := resolveLocations(ctx, r.locationResolver, call.Ranges)
+		if err != nil {
+			return nil, err
+		}
+
+		resolvedCalls = append(resolvedCalls, &CallHierarchyCallResolver{symbol: symbol, ranges: ranges})
+	}
+
+	return resolvedCalls, nil
+}
+
+func (r *CallHierarchyCallConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	return graphqlutil.EncodeCursor(r.cursor), nil
+}
+
+type CallHierarchyCallResolver struct {
+	symbol gql.LocationResolver
+	ranges []gql.LocationResolver
+}
+
+func (r *CallHierarchyCallResolver) Symbol(ctx context.Context) (gql.LocationResolver, error) {
+	return r.symbol, nil
+}
+
+func (r *CallHierarchyCallResolver) Ranges(ctx context.Context) ([]gql.LocationResolver, error) {
+	return r.ranges, nil
+}
@@ -0,0 +1,63 @@
+package graphql
+
+import (
+	"strings"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+)
+
+type DocumentSymbolResolver struct {
+	symbol resolvers.AdjustedSymbol
+}
+
+func NewDocumentSymbolResolver(symbol resolvers.AdjustedSymbol) gql.DocumentSymbolResolver {
+	return &DocumentSymbolResolver{
+		symbol: symbol,
+	}
+}
+
+func (r *DocumentSymbolResolver) Name() string { return r.symbol.Name }
+
+func (r *DocumentSymbolResolver) Kind() string /* enum SymbolKind */ {
+	if r.symbol.Kind < protocol.File || r.symbol.Kind > protocol.TypeParameter {
+		return "UNKNOWN"
+	}
+	return strings.ToUpper(r.symbol.Kind.String())
+}
+
+func (r *DocumentSymbolResolver) Detail() *string { return strPtr(r.symbol.Detail) }
+
+func (r *DocumentSymbolResolver) Tags() []string /* enum DocumentSymbolTag */ {
+	tags := make([]string, 0, len(r.symbol.Tags))
+	for _, tag := range r.symbol.Tags {
+		switch tag {
+		case protocol.Deprecated, protocol.Exported, protocol.Unexported:
+			tags = append(tags, strings.ToUpper(tag.String()))
+		}
+	}
+
+	return tags
+}
+
+func (r *DocumentSymbolResolver) Range() gql.RangeResolver {
+	return gql.NewRangeResolver(convertRange(r.symbol.Range))
+}
+
+func (r *DocumentSymbolResolver) FullRange() gql.RangeResolver {
+	return gql.NewRangeResolver(convertRange(r.symbol.FullRange))
+}
+
+func (r *DocumentSymbolResolver) Children() []gql.DocumentSymbolResolver {
+	return newDocumentSymbolResolvers(r.symbol.Children)
+}
+
+func newDocumentSymbolResolvers(symbols []resolvers.AdjustedSymbol) []gql.DocumentSymbolResolver {
+	resolvers := make([]gql.DocumentSymbolResolver, 0, len(symbols))
+	for _, symbol := range symbols {
+		resolvers = append(resolvers, NewDocumentSymbolResolver(symbol))
+	}
+
+	return resolvers
+}
@@ -19,6 +19,9 @@ const DefaultReferencesPageSize = 100
 // DefaultReferencesPageSize is the implementation result page size when no limit is supplied.
 const DefaultImplementationsPageSize = 100
 
+// DefaultCallHierarchyPageSize is the incoming and outgoing calls page size when no limit is supplied.
+const DefaultCallHierarchyPageSize = 100
+
 // DefaultDiagnosticsPageSize is the diagnostic result page size when no limit is supplied.
 const DefaultDiagnosticsPageSize = 100
 
@@ -111,6 +114,27 @@ func (r *QueryResolver) Definitions(ctx context.Context, args *gql.LSIFQueryPosi
 	return NewLocationConnectionResolver(locations, nil, r.locationResolver), nil
 }
 
+func (r *QueryResolver) TypeDefinitions(ctx context.Context, args *gql.LSIFQueryPositionArgs) (_ gql.LocationConnectionResolver, err error) {
+	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "typeDefinitions"))
+
+	locations, err := r.queryResolver.TypeDefinitions(ctx, int(args.Line), int(args.Character))
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Filter != nil && *args.Filter != "" {
+		filtered := locations[:0]
+		for _, loc := range locations {
+			if strings.Contains(loc.Path, *args.Filter) {
+				filtered = append(filtered, loc)
+			}
+		}
+		locations = filtered
+	}
+
+	return NewLocationConnectionResolver(locations, nil, r.locationResolver), nil
+}
+
 func (r *QueryResolver) References(ctx context.Context, args *gql.LSIFPagedQueryPositionArgs) (_ gql.LocationConnectionResolver, err error) {
 	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "references"))
 
@@ -173,6 +197,48 @@ func (r *QueryResolver) Implementations(ctx context.Context, args *gql.LSIFPaged
 	return NewLocationConnectionResolver(locations, strPtr(cursor), r.locationResolver), nil
 }
 
+func (r *QueryResolver) IncomingCalls(ctx context.Context, args *gql.LSIFPagedQueryPositionArgs) (_ gql.CallHierarchyCallConnectionResolver, err error) {
+	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "incomingCalls"))
+
+	limit := derefInt32(args.First, DefaultCallHierarchyPageSize)
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	cursor, err := graphqlutil.DecodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	calls, cursor, err := r.queryResolver.IncomingCalls(ctx, int(args.Line), int(args.Character), limit, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCallHierarchyCallConnectionResolver(calls, strPtr(cursor), r.locationResolver), nil
+}
+
+func (r *QueryResolver) OutgoingCalls(ctx context.Context, args *gql.LSIFPagedQueryPositionArgs) (_ gql.CallHierarchyCallConnectionResolver, err error) {
+	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "outgoingCalls"))
+
+	limit := derefInt32(args.First, DefaultCallHierarchyPageSize)
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	cursor, err := graphqlutil.DecodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	calls, cursor, err := r.queryResolver.OutgoingCalls(ctx, int(args.Line), int(args.Character), limit, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCallHierarchyCallConnectionResolver(calls, strPtr(cursor), r.locationResolver), nil
+}
+
 func (r *QueryResolver) Hover(ctx context.Context, args *gql.LSIFQueryPositionArgs) (_ gql.HoverResolver, err error) {
 	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "hover"))
 
@@ -184,6 +250,17 @@ func (r *QueryResolver) Hover(ctx context.Context, args *gql.LSIFQueryPositionAr
 	return NewHoverResolver(text, convertRange(rx)), nil
 }
 
+func (r *QueryResolver) DocumentSymbols(ctx context.Context) (_ []gql.DocumentSymbolResolver, err error) {
+	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "documentSymbols"))
+
+	symbols, err := r.queryResolver.DocumentSymbols(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDocumentSymbolResolvers(symbols), nil
+}
+
 func (r *QueryResolver) LSIFUploads(ctx context.Context) (_ []gql.LSIFUploadResolver, err error) {
 	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "lsifUploads"))
 
@@ -9,10 +9,12 @@ import (
 
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
 	resolvermocks "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers/mocks"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
 )
 
 func TestRanges(t *testing.T) {
@@ -63,6 +65,30 @@ func TestDefinitions(t *testing.T) {
 	}
 }
 
+func TestTypeDefinitions(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, nil)
+
+	mockQueryResolver := resolvermocks.NewMockQueryResolver()
+	mockResolver := resolvermocks.NewMockResolver()
+	resolver := NewQueryResolver(nil, mockQueryResolver, mockResolver, NewCachedLocationResolver(db), nil)
+
+	args := &gql.LSIFQueryPositionArgs{Line: 10, Character: 15}
+	if _, err := resolver.TypeDefinitions(context.Background(), args); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockQueryResolver.TypeDefinitionsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockQueryResolver.TypeDefinitionsFunc.History()))
+	}
+	if val := mockQueryResolver.TypeDefinitionsFunc.History()[0].Arg1; val != 10 {
+		t.Fatalf("unexpected line. want=%d have=%d", 10, val)
+	}
+	if val := mockQueryResolver.TypeDefinitionsFunc.History()[0].Arg2; val != 15 {
+		t.Fatalf("unexpected character. want=%d have=%d", 15, val)
+	}
+}
+
 func TestReferences(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, nil)
@@ -154,6 +180,75 @@ func TestReferencesDefaultIllegalLimit(t *testing.T) {
 	}
 }
 
+func TestIncomingCalls(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, nil)
+
+	mockQueryResolver := resolvermocks.NewMockQueryResolver()
+	mockResolver := resolvermocks.NewMockResolver()
+	resolver := NewQueryResolver(nil, mockQueryResolver, mockResolver, NewCachedLocationResolver(db), nil)
+
+	offset := int32(25)
+	cursor := base64.StdEncoding.EncodeToString([]byte("test-cursor"))
+
+	args := &gql.LSIFPagedQueryPositionArgs{
+		LSIFQueryPositionArgs: gql.LSIFQueryPositionArgs{
+			Line:      10,
+			Character: 15,
+		},
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &offset},
+		After:          &cursor,
+	}
+
+	if _, err := resolver.IncomingCalls(context.Background(), args); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockQueryResolver.IncomingCallsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockQueryResolver.IncomingCallsFunc.History()))
+	}
+	if val := mockQueryResolver.IncomingCallsFunc.History()[0].Arg1; val != 10 {
+		t.Fatalf("unexpected line. want=%d have=%d", 10, val)
+	}
+	if val := mockQueryResolver.IncomingCallsFunc.History()[0].Arg2; val != 15 {
+		t.Fatalf("unexpected character. want=%d have=%d", 15, val)
+	}
+	if val := mockQueryResolver.IncomingCallsFunc.History()[0].Arg3; val != 25 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 25, val)
+	}
+	if val := mockQueryResolver.IncomingCallsFunc.History()[0].Arg4; val != "test-cursor" {
+		t.Fatalf("unexpected cursor. want=%s have=%s", "test-cursor", val)
+	}
+}
+
+func TestOutgoingCallsDefaultLimit(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, nil)
+
+	mockQueryResolver := resolvermocks.NewMockQueryResolver()
+	mockResolver := resolvermocks.NewMockResolver()
+	resolver := NewQueryResolver(nil, mockQueryResolver, mockResolver, NewCachedLocationResolver(db), nil)
+
+	args := &gql.LSIFPagedQueryPositionArgs{
+		LSIFQueryPositionArgs: gql.LSIFQueryPositionArgs{
+			Line:      10,
+			Character: 15,
+		},
+		ConnectionArgs: graphqlutil.ConnectionArgs{},
+	}
+
+	if _, err := resolver.OutgoingCalls(context.Background(), args); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockQueryResolver.OutgoingCallsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockQueryResolver.OutgoingCallsFunc.History()))
+	}
+	if val := mockQueryResolver.OutgoingCallsFunc.History()[0].Arg3; val != DefaultCallHierarchyPageSize {
+		t.Fatalf("unexpected limit. want=%d have=%d", DefaultCallHierarchyPageSize, val)
+	}
+}
+
 func TestHover(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, nil)
@@ -179,6 +274,65 @@ func TestHover(t *testing.T) {
 	}
 }
 
+func TestDocumentSymbols(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, nil)
+
+	mockQueryResolver := resolvermocks.NewMockQueryResolver()
+	mockQueryResolver.DocumentSymbolsFunc.SetDefaultReturn([]resolvers.AdjustedSymbol{
+		{
+			Name:      "Vertex",
+			Kind:      protocol.Struct,
+			Range:     lsifstore.Range{Start: lsifstore.Position{Line: 10, Character: 5}, End: lsifstore.Position{Line: 10, Character: 11}},
+			FullRange: lsifstore.Range{Start: lsifstore.Position{Line: 10, Character: 0}, End: lsifstore.Position{Line: 13, Character: 1}},
+			Children: []resolvers.AdjustedSymbol{
+				{
+					Name:   "Label",
+					Kind:   protocol.Field,
+					Detail: "VertexLabel",
+					Tags:   []protocol.SymbolTag{protocol.Deprecated, protocol.SymbolTag(42)},
+				},
+			},
+		},
+	}, nil)
+	mockResolver := resolvermocks.NewMockResolver()
+	resolver := NewQueryResolver(nil, mockQueryResolver, mockResolver, NewCachedLocationResolver(db), nil)
+
+	symbols, err := resolver.DocumentSymbols(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(symbols) != 1 {
+		t.Fatalf("unexpected number of symbols. want=%d have=%d", 1, len(symbols))
+	}
+	if kind := symbols[0].Kind(); kind != "STRUCT" {
+		t.Errorf("unexpected kind. want=%s have=%s", "STRUCT", kind)
+	}
+	if detail := symbols[0].Detail(); detail != nil {
+		t.Errorf("unexpected detail. want=nil have=%s", *detail)
+	}
+	if line := symbols[0].FullRange().End().Line(); line != 13 {
+		t.Errorf("unexpected full range end line. want=%d have=%d", 13, line)
+	}
+
+	children := symbols[0].Children()
+	if len(children) != 1 {
+		t.Fatalf("unexpected number of children. want=%d have=%d", 1, len(children))
+	}
+	if name := children[0].Name(); name != "Label" {
+		t.Errorf("unexpected name. want=%s have=%s", "Label", name)
+	}
+	if kind := children[0].Kind(); kind != "FIELD" {
+		t.Errorf("unexpected kind. want=%s have=%s", "FIELD", kind)
+	}
+	if detail := children[0].Detail(); detail == nil || *detail != "VertexLabel" {
+		t.Errorf("unexpected detail. want=%s have=%v", "VertexLabel", detail)
+	}
+	if tags := children[0].Tags(); len(tags) != 1 || tags[0] != "DEPRECATED" {
+		t.Errorf("unexpected tags. want=%v have=%v", []string{"DEPRECATED"}, tags)
+	}
+}
+
 func TestDiagnostics(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, nil)
@@ -16,6 +16,8 @@ import (
 	api "github.com/sourcegraph/sourcegraph/internal/api"
 	dbstore "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
 	lsifstore "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+	search "github.com/sourcegraph/sourcegraph/internal/search"
+	result "github.com/sourcegraph/sourcegraph/internal/search/result"
 	graphql "github.com/sourcegraph/sourcegraph/internal/services/executors/transport/graphql"
 	config "github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 )
@@ -31,15 +33,24 @@ type MockQueryResolver struct {
 	// DiagnosticsFunc is an instance of a mock function object controlling
 	// the behavior of the method Diagnostics.
 	DiagnosticsFunc *QueryResolverDiagnosticsFunc
+	// DocumentSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method DocumentSymbols.
+	DocumentSymbolsFunc *QueryResolverDocumentSymbolsFunc
 	// HoverFunc is an instance of a mock function object controlling the
 	// behavior of the method Hover.
 	HoverFunc *QueryResolverHoverFunc
 	// ImplementationsFunc is an instance of a mock function object
 	// controlling the behavior of the method Implementations.
 	ImplementationsFunc *QueryResolverImplementationsFunc
+	// IncomingCallsFunc is an instance of a mock function object
+	// controlling the behavior of the method IncomingCalls.
+	IncomingCallsFunc *QueryResolverIncomingCallsFunc
 	// LSIFUploadsFunc is an instance of a mock function object controlling
 	// the behavior of the method LSIFUploads.
 	LSIFUploadsFunc *QueryResolverLSIFUploadsFunc
+	// OutgoingCallsFunc is an instance of a mock function object
+	// controlling the behavior of the method OutgoingCalls.
+	OutgoingCallsFunc *QueryResolverOutgoingCallsFunc
 	// RangesFunc is an instance of a mock function object controlling the
 	// behavior of the method Ranges.
 	RangesFunc *QueryResolverRangesFunc
@@ -49,6 +60,9 @@ type MockQueryResolver struct {
 	// StencilFunc is an instance of a mock function object controlling the
 	// behavior of the method Stencil.
 	StencilFunc *QueryResolverStencilFunc
+	// TypeDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method TypeDefinitions.
+	TypeDefinitionsFunc *QueryResolverTypeDefinitionsFunc
 }
 
 // NewMockQueryResolver creates a new mock of the QueryResolver interface.
@@ -65,6 +79,11 @@ func NewMockQueryResolver() *MockQueryResolver {
 				return
 			},
 		},
+		DocumentSymbolsFunc: &QueryResolverDocumentSymbolsFunc{
+			defaultHook: func(context.Context) (r0 []resolvers.AdjustedSymbol, r1 error) {
+				return
+			},
+		},
 		HoverFunc: &QueryResolverHoverFunc{
 			defaultHook: func(context.Context, int, int) (r0 string, r1 lsifstore.Range, r2 bool, r3 error) {
 				return
@@ -75,11 +94,21 @@ func NewMockQueryResolver() *MockQueryResolver {
 				return
 			},
 		},
+		IncomingCallsFunc: &QueryResolverIncomingCallsFunc{
+			defaultHook: func(context.Context, int, int, int, string) (r0 []resolvers.AdjustedCall, r1 string, r2 error) {
+				return
+			},
+		},
 		LSIFUploadsFunc: &QueryResolverLSIFUploadsFunc{
 			defaultHook: func(context.Context) (r0 []dbstore.Upload, r1 error) {
 				return
 			},
 		},
+		OutgoingCallsFunc: &QueryResolverOutgoingCallsFunc{
+			defaultHook: func(context.Context, int, int, int, string) (r0 []resolvers.AdjustedCall, r1 string, r2 error) {
+				return
+			},
+		},
 		RangesFunc: &QueryResolverRangesFunc{
 			defaultHook: func(context.Context, int, int) (r0 []resolvers.AdjustedCodeIntelligenceRange, r1 error) {
 				return
@@ -95,6 +124,11 @@ func NewMockQueryResolver() *MockQueryResolver {
 				return
 			},
 		},
+		TypeDefinitionsFunc: &QueryResolverTypeDefinitionsFunc{
+			defaultHook: func(context.Context, int, int) (r0 []resolvers.AdjustedLocation, r1 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -112,6 +146,11 @@ func NewStrictMockQueryResolver() *MockQueryResolver {
 				panic("unexpected invocation of MockQueryResolver.Diagnostics")
 			},
 		},
+		DocumentSymbolsFunc: &QueryResolverDocumentSymbolsFunc{
+			defaultHook: func(context.Context) ([]resolvers.AdjustedSymbol, error) {
+				panic("unexpected invocation of MockQueryResolver.DocumentSymbols")
+			},
+		},
 		HoverFunc: &QueryResolverHoverFunc{
 			defaultHook: func(context.Context, int, int) (string, lsifstore.Range, bool, error) {
 				panic("unexpected invocation of MockQueryResolver.Hover")
@@ -122,11 +161,21 @@ func NewStrictMockQueryResolver() *MockQueryResolver {
 				panic("unexpected invocation of MockQueryResolver.Implementations")
 			},
 		},
+		IncomingCallsFunc: &QueryResolverIncomingCallsFunc{
+			defaultHook: func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error) {
+				panic("unexpected invocation of MockQueryResolver.IncomingCalls")
+			},
+		},
 		LSIFUploadsFunc: &QueryResolverLSIFUploadsFunc{
 			defaultHook: func(context.Context) ([]dbstore.Upload, error) {
 				panic("unexpected invocation of MockQueryResolver.LSIFUploads")
 			},
 		},
+		OutgoingCallsFunc: &QueryResolverOutgoingCallsFunc{
+			defaultHook: func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error) {
+				panic("unexpected invocation of MockQueryResolver.OutgoingCalls")
+			},
+		},
 		RangesFunc: &QueryResolverRangesFunc{
 			defaultHook: func(context.Context, int, int) ([]resolvers.AdjustedCodeIntelligenceRange, error) {
 				panic("unexpected invocation of MockQueryResolver.Ranges")
@@ -142,6 +191,11 @@ func NewStrictMockQueryResolver() *MockQueryResolver {
 				panic("unexpected invocation of MockQueryResolver.Stencil")
 			},
 		},
+		TypeDefinitionsFunc: &QueryResolverTypeDefinitionsFunc{
+			defaultHook: func(context.Context, int, int) ([]resolvers.AdjustedLocation, error) {
+				panic("unexpected invocation of MockQueryResolver.TypeDefinitions")
+			},
+		},
 	}
 }
 
@@ -156,15 +210,24 @@ func NewMockQueryResolverFrom(i resolvers.QueryResolver) *MockQueryResolver {
 		DiagnosticsFunc: &QueryResolverDiagnosticsFunc{
 			defaultHook: i.Diagnostics,
 		},
+		DocumentSymbolsFunc: &QueryResolverDocumentSymbolsFunc{
+			defaultHook: i.DocumentSymbols,
+		},
 		HoverFunc: &QueryResolverHoverFunc{
 			defaultHook: i.Hover,
 		},
 		ImplementationsFunc: &QueryResolverImplementationsFunc{
 			defaultHook: i.Implementations,
 		},
+		IncomingCallsFunc: &QueryResolverIncomingCallsFunc{
+			defaultHook: i.IncomingCalls,
+		},
 		LSIFUploadsFunc: &QueryResolverLSIFUploadsFunc{
 			defaultHook: i.LSIFUploads,
 		},
+		OutgoingCallsFunc: &QueryResolverOutgoingCallsFunc{
+			defaultHook: i.OutgoingCalls,
+		},
 		RangesFunc: &QueryResolverRangesFunc{
 			defaultHook: i.Ranges,
 		},
@@ -174,6 +237,9 @@ func NewMockQueryResolverFrom(i resolvers.QueryResolver) *MockQueryResolver {
 		StencilFunc: &QueryResolverStencilFunc{
 			defaultHook: i.Stencil,
 		},
+		TypeDefinitionsFunc: &QueryResolverTypeDefinitionsFunc{
+			defaultHook: i.TypeDefinitions,
+		},
 	}
 }
 
@@ -399,6 +465,112 @@ func (c QueryResolverDiagnosticsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// QueryResolverDocumentSymbolsFunc describes the behavior when the
+// DocumentSymbols method of the parent MockQueryResolver instance is
+// invoked.
+type QueryResolverDocumentSymbolsFunc struct {
+	defaultHook func(context.Context) ([]resolvers.AdjustedSymbol, error)
+	hooks       []func(context.Context) ([]resolvers.AdjustedSymbol, error)
+	history     []QueryResolverDocumentSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// DocumentSymbols delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockQueryResolver) DocumentSymbols(v0 context.Context) ([]resolvers.AdjustedSymbol, error) {
+	r0, r1 := m.DocumentSymbolsFunc.nextHook()(v0)
+	m.DocumentSymbolsFunc.appendCall(QueryResolverDocumentSymbolsFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DocumentSymbols
+// method of the parent MockQueryResolver instance is invoked and the hook
+// queue is empty.
+func (f *QueryResolverDocumentSymbolsFunc) SetDefaultHook(hook func(context.Context) ([]resolvers.AdjustedSymbol, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DocumentSymbols method of the parent MockQueryResolver instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *QueryResolverDocumentSymbolsFunc) PushHook(hook func(context.Context) ([]resolvers.AdjustedSymbol, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *QueryResolverDocumentSymbolsFunc) SetDefaultReturn(r0 []resolvers.AdjustedSymbol, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]resolvers.AdjustedSymbol, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *QueryResolverDocumentSymbolsFunc) PushReturn(r0 []resolvers.AdjustedSymbol, r1 error) {
+	f.PushHook(func(context.Context) ([]resolvers.AdjustedSymbol, error) {
+		return r0, r1
+	})
+}
+
+func (f *QueryResolverDocumentSymbolsFunc) nextHook() func(context.Context) ([]resolvers.AdjustedSymbol, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *QueryResolverDocumentSymbolsFunc) appendCall(r0 QueryResolverDocumentSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of QueryResolverDocumentSymbolsFuncCall
+// objects describing the invocations of this function.
+func (f *QueryResolverDocumentSymbolsFunc) History() []QueryResolverDocumentSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]QueryResolverDocumentSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// QueryResolverDocumentSymbolsFuncCall is an object that describes an
+// invocation of method DocumentSymbols on an instance of MockQueryResolver.
+type QueryResolverDocumentSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []resolvers.AdjustedSymbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c QueryResolverDocumentSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c QueryResolverDocumentSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // QueryResolverHoverFunc describes the behavior when the Hover method of
 // the parent MockQueryResolver instance is invoked.
 type QueryResolverHoverFunc struct {
@@ -636,6 +808,126 @@ func (c QueryResolverImplementationsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// QueryResolverIncomingCallsFunc describes the behavior when the
+// IncomingCalls method of the parent MockQueryResolver instance is invoked.
+type QueryResolverIncomingCallsFunc struct {
+	defaultHook func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error)
+	hooks       []func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error)
+	history     []QueryResolverIncomingCallsFuncCall
+	mutex       sync.Mutex
+}
+
+// IncomingCalls delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockQueryResolver) IncomingCalls(v0 context.Context, v1 int, v2 int, v3 int, v4 string) ([]resolvers.AdjustedCall, string, error) {
+	r0, r1, r2 := m.IncomingCallsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.IncomingCallsFunc.appendCall(QueryResolverIncomingCallsFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the IncomingCalls method
+// of the parent MockQueryResolver instance is invoked and the hook queue is
+// empty.
+func (f *QueryResolverIncomingCallsFunc) SetDefaultHook(hook func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IncomingCalls method of the parent MockQueryResolver instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *QueryResolverIncomingCallsFunc) PushHook(hook func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *QueryResolverIncomingCallsFunc) SetDefaultReturn(r0 []resolvers.AdjustedCall, r1 string, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *QueryResolverIncomingCallsFunc) PushReturn(r0 []resolvers.AdjustedCall, r1 string, r2 error) {
+	f.PushHook(func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *QueryResolverIncomingCallsFunc) nextHook() func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *QueryResolverIncomingCallsFunc) appendCall(r0 QueryResolverIncomingCallsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of QueryResolverIncomingCallsFuncCall objects
+// describing the invocations of this function.
+func (f *QueryResolverIncomingCallsFunc) History() []QueryResolverIncomingCallsFuncCall {
+	f.mutex.Lock()
+	history := make([]QueryResolverIncomingCallsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// QueryResolverIncomingCallsFuncCall is an object that describes an
+// invocation of method IncomingCalls on an instance of MockQueryResolver.
+type QueryResolverIncomingCallsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []resolvers.AdjustedCall
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c QueryResolverIncomingCallsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c QueryResolverIncomingCallsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // QueryResolverLSIFUploadsFunc describes the behavior when the LSIFUploads
 // method of the parent MockQueryResolver instance is invoked.
 type QueryResolverLSIFUploadsFunc struct {
@@ -741,6 +1033,126 @@ func (c QueryResolverLSIFUploadsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// QueryResolverOutgoingCallsFunc describes the behavior when the
+// OutgoingCalls method of the parent MockQueryResolver instance is invoked.
+type QueryResolverOutgoingCallsFunc struct {
+	defaultHook func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error)
+	hooks       []func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error)
+	history     []QueryResolverOutgoingCallsFuncCall
+	mutex       sync.Mutex
+}
+
+// OutgoingCalls delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockQueryResolver) OutgoingCalls(v0 context.Context, v1 int, v2 int, v3 int, v4 string) ([]resolvers.AdjustedCall, string, error) {
+	r0, r1, r2 := m.OutgoingCallsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.OutgoingCallsFunc.appendCall(QueryResolverOutgoingCallsFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the OutgoingCalls method
+// of the parent MockQueryResolver instance is invoked and the hook queue is
+// empty.
+func (f *QueryResolverOutgoingCallsFunc) SetDefaultHook(hook func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// OutgoingCalls method of the parent MockQueryResolver instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *QueryResolverOutgoingCallsFunc) PushHook(hook func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *QueryResolverOutgoingCallsFunc) SetDefaultReturn(r0 []resolvers.AdjustedCall, r1 string, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *QueryResolverOutgoingCallsFunc) PushReturn(r0 []resolvers.AdjustedCall, r1 string, r2 error) {
+	f.PushHook(func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *QueryResolverOutgoingCallsFunc) nextHook() func(context.Context, int, int, int, string) ([]resolvers.AdjustedCall, string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *QueryResolverOutgoingCallsFunc) appendCall(r0 QueryResolverOutgoingCallsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of QueryResolverOutgoingCallsFuncCall objects
+// describing the invocations of this function.
+func (f *QueryResolverOutgoingCallsFunc) History() []QueryResolverOutgoingCallsFuncCall {
+	f.mutex.Lock()
+	history := make([]QueryResolverOutgoingCallsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// QueryResolverOutgoingCallsFuncCall is an object that describes an
+// invocation of method OutgoingCalls on an instance of MockQueryResolver.
+type QueryResolverOutgoingCallsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []resolvers.AdjustedCall
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c QueryResolverOutgoingCallsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c QueryResolverOutgoingCallsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // QueryResolverRangesFunc describes the behavior when the Ranges method of
 // the parent MockQueryResolver instance is invoked.
 type QueryResolverRangesFunc struct {
@@ -1076,6 +1488,118 @@ func (c QueryResolverStencilFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// QueryResolverTypeDefinitionsFunc describes the behavior when the
+// TypeDefinitions method of the parent MockQueryResolver instance is
+// invoked.
+type QueryResolverTypeDefinitionsFunc struct {
+	defaultHook func(context.Context, int, int) ([]resolvers.AdjustedLocation, error)
+	hooks       []func(context.Context, int, int) ([]resolvers.AdjustedLocation, error)
+	history     []QueryResolverTypeDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// TypeDefinitions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockQueryResolver) TypeDefinitions(v0 context.Context, v1 int, v2 int) ([]resolvers.AdjustedLocation, error) {
+	r0, r1 := m.TypeDefinitionsFunc.nextHook()(v0, v1, v2)
+	m.TypeDefinitionsFunc.appendCall(QueryResolverTypeDefinitionsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the TypeDefinitions
+// method of the parent MockQueryResolver instance is invoked and the hook
+// queue is empty.
+func (f *QueryResolverTypeDefinitionsFunc) SetDefaultHook(hook func(context.Context, int, int) ([]resolvers.AdjustedLocation, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// TypeDefinitions method of the parent MockQueryResolver instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *QueryResolverTypeDefinitionsFunc) PushHook(hook func(context.Context, int, int) ([]resolvers.AdjustedLocation, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *QueryResolverTypeDefinitionsFunc) SetDefaultReturn(r0 []resolvers.AdjustedLocation, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, int) ([]resolvers.AdjustedLocation, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *QueryResolverTypeDefinitionsFunc) PushReturn(r0 []resolvers.AdjustedLocation, r1 error) {
+	f.PushHook(func(context.Context, int, int) ([]resolvers.AdjustedLocation, error) {
+		return r0, r1
+	})
+}
+
+func (f *QueryResolverTypeDefinitionsFunc) nextHook() func(context.Context, int, int) ([]resolvers.AdjustedLocation, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *QueryResolverTypeDefinitionsFunc) appendCall(r0 QueryResolverTypeDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of QueryResolverTypeDefinitionsFuncCall
+// objects describing the invocations of this function.
+func (f *QueryResolverTypeDefinitionsFunc) History() []QueryResolverTypeDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]QueryResolverTypeDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// QueryResolverTypeDefinitionsFuncCall is an object that describes an
+// invocation of method TypeDefinitions on an instance of MockQueryResolver.
+type QueryResolverTypeDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []resolvers.AdjustedLocation
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c QueryResolverTypeDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c QueryResolverTypeDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // MockResolver is a mock implementation of the Resolver interface (from the
 // package
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
@@ -1166,6 +1690,9 @@ type MockResolver struct {
 	// RetentionPolicyOverviewFunc is an instance of a mock function object
 	// controlling the behavior of the method RetentionPolicyOverview.
 	RetentionPolicyOverviewFunc *ResolverRetentionPolicyOverviewFunc
+	// SearchSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchSymbols.
+	SearchSymbolsFunc *ResolverSearchSymbolsFunc
 	// SupportedByCtagsFunc is an instance of a mock function object
 	// controlling the behavior of the method SupportedByCtags.
 	SupportedByCtagsFunc *ResolverSupportedByCtagsFunc
@@ -1316,6 +1843,11 @@ func NewMockResolver() *MockResolver {
 				return
 			},
 		},
+		SearchSymbolsFunc: &ResolverSearchSymbolsFunc{
+			defaultHook: func(context.Context, int, search.SymbolsParameters) (r0 []result.Symbol, r1 bool, r2 error) {
+				return
+			},
+		},
 		SupportedByCtagsFunc: &ResolverSupportedByCtagsFunc{
 			defaultHook: func(context.Context, string, api.RepoName) (r0 bool, r1 string, r2 error) {
 				return
@@ -1473,6 +2005,11 @@ func NewStrictMockResolver() *MockResolver {
 				panic("unexpected invocation of MockResolver.RetentionPolicyOverview")
 			},
 		},
+		SearchSymbolsFunc: &ResolverSearchSymbolsFunc{
+			defaultHook: func(context.Context, int, search.SymbolsParameters) ([]result.Symbol, bool, error) {
+				panic("unexpected invocation of MockResolver.SearchSymbols")
+			},
+		},
 		SupportedByCtagsFunc: &ResolverSupportedByCtagsFunc{
 			defaultHook: func(context.Context, string, api.RepoName) (bool, string, error) {
 				panic("unexpected invocation of MockResolver.SupportedByCtags")
@@ -1578,6 +2115,9 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		RetentionPolicyOverviewFunc: &ResolverRetentionPolicyOverviewFunc{
 			defaultHook: i.RetentionPolicyOverview,
 		},
+		SearchSymbolsFunc: &ResolverSearchSymbolsFunc{
+			defaultHook: i.SearchSymbols,
+		},
 		SupportedByCtagsFunc: &ResolverSupportedByCtagsFunc{
 			defaultHook: i.SupportedByCtags,
 		},
@@ -4505,6 +5045,120 @@ func (c ResolverRetentionPolicyOverviewFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// ResolverSearchSymbolsFunc describes the behavior when the SearchSymbols
+// method of the parent MockResolver instance is invoked.
+type ResolverSearchSymbolsFunc struct {
+	defaultHook func(context.Context, int, search.SymbolsParameters) ([]result.Symbol, bool, error)
+	hooks       []func(context.Context, int, search.SymbolsParameters) ([]result.Symbol, bool, error)
+	history     []ResolverSearchSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchSymbols delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockResolver) SearchSymbols(v0 context.Context, v1 int, v2 search.SymbolsParameters) ([]result.Symbol, bool, error) {
+	r0, r1, r2 := m.SearchSymbolsFunc.nextHook()(v0, v1, v2)
+	m.SearchSymbolsFunc.appendCall(ResolverSearchSymbolsFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the SearchSymbols method
+// of the parent MockResolver instance is invoked and the hook queue is
+// empty.
+func (f *ResolverSearchSymbolsFunc) SetDefaultHook(hook func(context.Context, int, search.SymbolsParameters) ([]result.Symbol, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchSymbols method of the parent MockResolver instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ResolverSearchSymbolsFunc) PushHook(hook func(context.Context, int, search.SymbolsParameters) ([]result.Symbol, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ResolverSearchSymbolsFunc) SetDefaultReturn(r0 []result.Symbol, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, search.SymbolsParameters) ([]result.Symbol, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ResolverSearchSymbolsFunc) PushReturn(r0 []result.Symbol, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int, search.SymbolsParameters) ([]result.Symbol, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ResolverSearchSymbolsFunc) nextHook() func(context.Context, int, search.SymbolsParameters) ([]result.Symbol, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverSearchSymbolsFunc) appendCall(r0 ResolverSearchSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverSearchSymbolsFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverSearchSymbolsFunc) History() []ResolverSearchSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverSearchSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverSearchSymbolsFuncCall is an object that describes an invocation
+// of method SearchSymbols on an instance of MockResolver.
+type ResolverSearchSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 search.SymbolsParameters
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []result.Symbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverSearchSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverSearchSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // ResolverSupportedByCtagsFunc describes the behavior when the
 // SupportedByCtags method of the parent MockResolver instance is invoked.
 type ResolverSupportedByCtagsFunc struct {
@@ -7895,6 +7895,9 @@ type MockLSIFStore struct {
 	// BulkMonikerResultsFunc is an instance of a mock function object
 	// controlling the behavior of the method BulkMonikerResults.
 	BulkMonikerResultsFunc *LSIFStoreBulkMonikerResultsFunc
+	// DefinitionRangesFunc is an instance of a mock function object
+	// controlling the behavior of the method DefinitionRanges.
+	DefinitionRangesFunc *LSIFStoreDefinitionRangesFunc
 	// DefinitionsFunc is an instance of a mock function object controlling
 	// the behavior of the method Definitions.
 	DefinitionsFunc *LSIFStoreDefinitionsFunc
@@ -7904,6 +7907,9 @@ type MockLSIFStore struct {
 	// DocumentPathsFunc is an instance of a mock function object
 	// controlling the behavior of the method DocumentPaths.
 	DocumentPathsFunc *LSIFStoreDocumentPathsFunc
+	// DocumentSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method DocumentSymbols.
+	DocumentSymbolsFunc *LSIFStoreDocumentSymbolsFunc
 	// ExistsFunc is an instance of a mock function object controlling the
 	// behavior of the method Exists.
 	ExistsFunc *LSIFStoreExistsFunc
@@ -7925,9 +7931,15 @@ type MockLSIFStore struct {
 	// ReferencesFunc is an instance of a mock function object controlling
 	// the behavior of the method References.
 	ReferencesFunc *LSIFStoreReferencesFunc
+	// SearchSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchSymbols.
+	SearchSymbolsFunc *LSIFStoreSearchSymbolsFunc
 	// StencilFunc is an instance of a mock function object controlling the
 	// behavior of the method Stencil.
 	StencilFunc *LSIFStoreStencilFunc
+	// TypeDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method TypeDefinitions.
+	TypeDefinitionsFunc *LSIFStoreTypeDefinitionsFunc
 }
 
 // NewMockLSIFStore creates a new mock of the LSIFStore interface. All
@@ -7939,6 +7951,11 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return
 			},
 		},
+		DefinitionRangesFunc: &LSIFStoreDefinitionRangesFunc{
+			defaultHook: func(context.Context, int, string) (r0 []lsifstore.Range, r1 error) {
+				return
+			},
+		},
 		DefinitionsFunc: &LSIFStoreDefinitionsFunc{
 			defaultHook: func(context.Context, int, string, int, int, int, int) (r0 []lsifstore.Location, r1 int, r2 error) {
 				return
@@ -7954,6 +7971,11 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return
 			},
 		},
+		DocumentSymbolsFunc: &LSIFStoreDocumentSymbolsFunc{
+			defaultHook: func(context.Context, int, string) (r0 []precise.SymbolData, r1 error) {
+				return
+			},
+		},
 		ExistsFunc: &LSIFStoreExistsFunc{
 			defaultHook: func(context.Context, int, string) (r0 bool, r1 error) {
 				return
@@ -7989,11 +8011,21 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return
 			},
 		},
+		SearchSymbolsFunc: &LSIFStoreSearchSymbolsFunc{
+			defaultHook: func(context.Context, int, string, lsifstore.SymbolSearchOptions, int) (r0 []lsifstore.SymbolMatch, r1 error) {
+				return
+			},
+		},
 		StencilFunc: &LSIFStoreStencilFunc{
 			defaultHook: func(context.Context, int, string) (r0 []lsifstore.Range, r1 error) {
 				return
 			},
 		},
+		TypeDefinitionsFunc: &LSIFStoreTypeDefinitionsFunc{
+			defaultHook: func(context.Context, int, string, int, int, int, int) (r0 []lsifstore.Location, r1 int, r2 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -8006,6 +8038,11 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 				panic("unexpected invocation of MockLSIFStore.BulkMonikerResults")
 			},
 		},
+		DefinitionRangesFunc: &LSIFStoreDefinitionRangesFunc{
+			defaultHook: func(context.Context, int, string) ([]lsifstore.Range, error) {
+				panic("unexpected invocation of MockLSIFStore.DefinitionRanges")
+			},
+		},
 		DefinitionsFunc: &LSIFStoreDefinitionsFunc{
 			defaultHook: func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
 				panic("unexpected invocation of MockLSIFStore.Definitions")
@@ -8021,6 +8058,11 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 				panic("unexpected invocation of MockLSIFStore.DocumentPaths")
 			},
 		},
+		DocumentSymbolsFunc: &LSIFStoreDocumentSymbolsFunc{
+			defaultHook: func(context.Context, int, string) ([]precise.SymbolData, error) {
+				panic("unexpected invocation of MockLSIFStore.DocumentSymbols")
+			},
+		},
 		ExistsFunc: &LSIFStoreExistsFunc{
 			defaultHook: func(context.Context, int, string) (bool, error) {
 				panic("unexpected invocation of MockLSIFStore.Exists")
@@ -8056,11 +8098,21 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 				panic("unexpected invocation of MockLSIFStore.References")
 			},
 		},
+		SearchSymbolsFunc: &LSIFStoreSearchSymbolsFunc{
+			defaultHook: func(context.Context, int, string, lsifstore.SymbolSearchOptions, int) ([]lsifstore.SymbolMatch, error) {
+				panic("unexpected invocation of MockLSIFStore.SearchSymbols")
+			},
+		},
 		StencilFunc: &LSIFStoreStencilFunc{
 			defaultHook: func(context.Context, int, string) ([]lsifstore.Range, error) {
 				panic("unexpected invocation of MockLSIFStore.Stencil")
 			},
 		},
+		TypeDefinitionsFunc: &LSIFStoreTypeDefinitionsFunc{
+			defaultHook: func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+				panic("unexpected invocation of MockLSIFStore.TypeDefinitions")
+			},
+		},
 	}
 }
 
@@ -8071,6 +8123,9 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 		BulkMonikerResultsFunc: &LSIFStoreBulkMonikerResultsFunc{
 			defaultHook: i.BulkMonikerResults,
 		},
+		DefinitionRangesFunc: &LSIFStoreDefinitionRangesFunc{
+			defaultHook: i.DefinitionRanges,
+		},
 		DefinitionsFunc: &LSIFStoreDefinitionsFunc{
 			defaultHook: i.Definitions,
 		},
@@ -8080,6 +8135,9 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 		DocumentPathsFunc: &LSIFStoreDocumentPathsFunc{
 			defaultHook: i.DocumentPaths,
 		},
+		DocumentSymbolsFunc: &LSIFStoreDocumentSymbolsFunc{
+			defaultHook: i.DocumentSymbols,
+		},
 		ExistsFunc: &LSIFStoreExistsFunc{
 			defaultHook: i.Exists,
 		},
@@ -8101,9 +8159,15 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 		ReferencesFunc: &LSIFStoreReferencesFunc{
 			defaultHook: i.References,
 		},
+		SearchSymbolsFunc: &LSIFStoreSearchSymbolsFunc{
+			defaultHook: i.SearchSymbols,
+		},
 		StencilFunc: &LSIFStoreStencilFunc{
 			defaultHook: i.Stencil,
 		},
+		TypeDefinitionsFunc: &LSIFStoreTypeDefinitionsFunc{
+			defaultHook: i.TypeDefinitions,
+		},
 	}
 }
 
@@ -8231,6 +8295,117 @@ func (c LSIFStoreBulkMonikerResultsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LSIFStoreDefinitionRangesFunc describes the behavior when the
+// DefinitionRanges method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreDefinitionRangesFunc struct {
+	defaultHook func(context.Context, int, string) ([]lsifstore.Range, error)
+	hooks       []func(context.Context, int, string) ([]lsifstore.Range, error)
+	history     []LSIFStoreDefinitionRangesFuncCall
+	mutex       sync.Mutex
+}
+
+// DefinitionRanges delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) DefinitionRanges(v0 context.Context, v1 int, v2 string) ([]lsifstore.Range, error) {
+	r0, r1 := m.DefinitionRangesFunc.nextHook()(v0, v1, v2)
+	m.DefinitionRangesFunc.appendCall(LSIFStoreDefinitionRangesFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DefinitionRanges
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreDefinitionRangesFunc) SetDefaultHook(hook func(context.Context, int, string) ([]lsifstore.Range, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DefinitionRanges method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreDefinitionRangesFunc) PushHook(hook func(context.Context, int, string) ([]lsifstore.Range, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreDefinitionRangesFunc) SetDefaultReturn(r0 []lsifstore.Range, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string) ([]lsifstore.Range, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreDefinitionRangesFunc) PushReturn(r0 []lsifstore.Range, r1 error) {
+	f.PushHook(func(context.Context, int, string) ([]lsifstore.Range, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreDefinitionRangesFunc) nextHook() func(context.Context, int, string) ([]lsifstore.Range, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreDefinitionRangesFunc) appendCall(r0 LSIFStoreDefinitionRangesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreDefinitionRangesFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreDefinitionRangesFunc) History() []LSIFStoreDefinitionRangesFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreDefinitionRangesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreDefinitionRangesFuncCall is an object that describes an
+// invocation of method DefinitionRanges on an instance of MockLSIFStore.
+type LSIFStoreDefinitionRangesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []lsifstore.Range
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreDefinitionRangesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreDefinitionRangesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LSIFStoreDefinitionsFunc describes the behavior when the Definitions
 // method of the parent MockLSIFStore instance is invoked.
 type LSIFStoreDefinitionsFunc struct {
@@ -8591,6 +8766,117 @@ func (c LSIFStoreDocumentPathsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LSIFStoreDocumentSymbolsFunc describes the behavior when the
+// DocumentSymbols method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreDocumentSymbolsFunc struct {
+	defaultHook func(context.Context, int, string) ([]precise.SymbolData, error)
+	hooks       []func(context.Context, int, string) ([]precise.SymbolData, error)
+	history     []LSIFStoreDocumentSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// DocumentSymbols delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) DocumentSymbols(v0 context.Context, v1 int, v2 string) ([]precise.SymbolData, error) {
+	r0, r1 := m.DocumentSymbolsFunc.nextHook()(v0, v1, v2)
+	m.DocumentSymbolsFunc.appendCall(LSIFStoreDocumentSymbolsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DocumentSymbols
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreDocumentSymbolsFunc) SetDefaultHook(hook func(context.Context, int, string) ([]precise.SymbolData, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DocumentSymbols method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreDocumentSymbolsFunc) PushHook(hook func(context.Context, int, string) ([]precise.SymbolData, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreDocumentSymbolsFunc) SetDefaultReturn(r0 []precise.SymbolData, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string) ([]precise.SymbolData, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreDocumentSymbolsFunc) PushReturn(r0 []precise.SymbolData, r1 error) {
+	f.PushHook(func(context.Context, int, string) ([]precise.SymbolData, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreDocumentSymbolsFunc) nextHook() func(context.Context, int, string) ([]precise.SymbolData, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreDocumentSymbolsFunc) appendCall(r0 LSIFStoreDocumentSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreDocumentSymbolsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreDocumentSymbolsFunc) History() []LSIFStoreDocumentSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreDocumentSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreDocumentSymbolsFuncCall is an object that describes an
+// invocation of method DocumentSymbols on an instance of MockLSIFStore.
+type LSIFStoreDocumentSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []precise.SymbolData
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreDocumentSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreDocumentSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LSIFStoreExistsFunc describes the behavior when the Exists method of the
 // parent MockLSIFStore instance is invoked.
 type LSIFStoreExistsFunc struct {
@@ -9426,6 +9712,123 @@ func (c LSIFStoreReferencesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LSIFStoreSearchSymbolsFunc describes the behavior when the SearchSymbols
+// method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreSearchSymbolsFunc struct {
+	defaultHook func(context.Context, int, string, lsifstore.SymbolSearchOptions, int) ([]lsifstore.SymbolMatch, error)
+	hooks       []func(context.Context, int, string, lsifstore.SymbolSearchOptions, int) ([]lsifstore.SymbolMatch, error)
+	history     []LSIFStoreSearchSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchSymbols delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockLSIFStore) SearchSymbols(v0 context.Context, v1 int, v2 string, v3 lsifstore.SymbolSearchOptions, v4 int) ([]lsifstore.SymbolMatch, error) {
+	r0, r1 := m.SearchSymbolsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.SearchSymbolsFunc.appendCall(LSIFStoreSearchSymbolsFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the SearchSymbols method
+// of the parent MockLSIFStore instance is invoked and the hook queue is
+// empty.
+func (f *LSIFStoreSearchSymbolsFunc) SetDefaultHook(hook func(context.Context, int, string, lsifstore.SymbolSearchOptions, int) ([]lsifstore.SymbolMatch, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchSymbols method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreSearchSymbolsFunc) PushHook(hook func(context.Context, int, string, lsifstore.SymbolSearchOptions, int) ([]lsifstore.SymbolMatch, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreSearchSymbolsFunc) SetDefaultReturn(r0 []lsifstore.SymbolMatch, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, lsifstore.SymbolSearchOptions, int) ([]lsifstore.SymbolMatch, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreSearchSymbolsFunc) PushReturn(r0 []lsifstore.SymbolMatch, r1 error) {
+	f.PushHook(func(context.Context, int, string, lsifstore.SymbolSearchOptions, int) ([]lsifstore.SymbolMatch, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreSearchSymbolsFunc) nextHook() func(context.Context, int, string, lsifstore.SymbolSearchOptions, int) ([]lsifstore.SymbolMatch, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreSearchSymbolsFunc) appendCall(r0 LSIFStoreSearchSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreSearchSymbolsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreSearchSymbolsFunc) History() []LSIFStoreSearchSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreSearchSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreSearchSymbolsFuncCall is an object that describes an invocation
+// of method SearchSymbols on an instance of MockLSIFStore.
+type LSIFStoreSearchSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 lsifstore.SymbolSearchOptions
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []lsifstore.SymbolMatch
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreSearchSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreSearchSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LSIFStoreStencilFunc describes the behavior when the Stencil method of
 // the parent MockLSIFStore instance is invoked.
 type LSIFStoreStencilFunc struct {
@@ -9536,6 +9939,132 @@ func (c LSIFStoreStencilFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// LSIFStoreTypeDefinitionsFunc describes the behavior when the
+// TypeDefinitions method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreTypeDefinitionsFunc struct {
+	defaultHook func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)
+	hooks       []func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)
+	history     []LSIFStoreTypeDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// TypeDefinitions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) TypeDefinitions(v0 context.Context, v1 int, v2 string, v3 int, v4 int, v5 int, v6 int) ([]lsifstore.Location, int, error) {
+	r0, r1, r2 := m.TypeDefinitionsFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6)
+	m.TypeDefinitionsFunc.appendCall(LSIFStoreTypeDefinitionsFuncCall{v0, v1, v2, v3, v4, v5, v6, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the TypeDefinitions
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreTypeDefinitionsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// TypeDefinitions method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreTypeDefinitionsFunc) PushHook(hook func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreTypeDefinitionsFunc) SetDefaultReturn(r0 []lsifstore.Location, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreTypeDefinitionsFunc) PushReturn(r0 []lsifstore.Location, r1 int, r2 error) {
+	f.PushHook(func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LSIFStoreTypeDefinitionsFunc) nextHook() func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreTypeDefinitionsFunc) appendCall(r0 LSIFStoreTypeDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreTypeDefinitionsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreTypeDefinitionsFunc) History() []LSIFStoreTypeDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreTypeDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreTypeDefinitionsFuncCall is an object that describes an
+// invocation of method TypeDefinitions on an instance of MockLSIFStore.
+type LSIFStoreTypeDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 int
+	// Arg6 is the value of the 7th argument passed to this method
+	// invocation.
+	Arg6 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []lsifstore.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreTypeDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5, c.Arg6}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreTypeDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // MockPositionAdjuster is a mock implementation of the PositionAdjuster
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
@@ -22,6 +22,11 @@ type operations struct {
 	references      *observation.Operation
 	implementations *observation.Operation
 	stencil         *observation.Operation
+	typeDefinitions *observation.Operation
+	incomingCalls   *observation.Operation
+	outgoingCalls   *observation.Operation
+	documentSymbols *observation.Operation
+	searchSymbols   *observation.Operation
 
 	findClosestDumps *observation.Operation
 }
@@ -62,6 +67,11 @@ func newOperations(observationContext *observation.Context) *operations {
 		ranges:          op("Ranges"),
 		references:      op("References"),
 		stencil:         op("Stencil"),
+		typeDefinitions: op("TypeDefinitions"),
+		incomingCalls:   op("IncomingCalls"),
+		outgoingCalls:   op("OutgoingCalls"),
+		documentSymbols: op("DocumentSymbols"),
+		searchSymbols:   op("SearchSymbols"),
 		queryResolver:   op("QueryResolver"),
 
 		findClosestDumps: subOp("findClosestDumps"),
@@ -8,6 +8,7 @@ import (
 	store "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
 )
 
 // AdjustedLocation is a path and range pair from within a particular upload. The adjusted commit
@@ -39,6 +40,28 @@ type AdjustedCodeIntelligenceRange struct {
 	HoverText       string
 }
 
+// AdjustedCall is a call between two symbols. For incoming calls, the symbol is the definition of the
+// caller. For outgoing calls, the symbol is the definition of the callee. In both cases the ranges are
+// the references to the callee within the caller. The locations have been adjusted to fit the target
+// (originally requested) commit.
+type AdjustedCall struct {
+	Symbol AdjustedLocation
+	Ranges []AdjustedLocation
+}
+
+// AdjustedSymbol is a document symbol whose ranges have been adjusted to fit the target (originally
+// requested) commit. The range spans the name of the symbol and the full range spans its entire
+// declaration.
+type AdjustedSymbol struct {
+	Name      string
+	Kind      protocol.SymbolKind
+	Detail    string
+	Tags      []protocol.SymbolTag
+	Range     lsifstore.Range
+	FullRange lsifstore.Range
+	Children  []AdjustedSymbol
+}
+
 // QueryResolver is the main interface to bundle-related operations exposed to the GraphQL API. This
 // resolver consolidates the logic for bundle operations and is not itself concerned with GraphQL/API
 // specifics (auth, validation, marshaling, etc.). This resolver is wrapped by a symmetrics resolver
@@ -50,8 +73,12 @@ type QueryResolver interface {
 	Definitions(ctx context.Context, line, character int) ([]AdjustedLocation, error)
 	References(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
 	Implementations(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
+	TypeDefinitions(ctx context.Context, line, character int) ([]AdjustedLocation, error)
+	IncomingCalls(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedCall, string, error)
+	OutgoingCalls(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedCall, string, error)
 	Hover(ctx context.Context, line, character int) (string, lsifstore.Range, bool, error)
 	Diagnostics(ctx context.Context, limit int) ([]AdjustedDiagnostic, int, error)
+	DocumentSymbols(ctx context.Context) ([]AdjustedSymbol, error)
 }
 
 type queryResolver struct {
@@ -0,0 +1,430 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/opentracing/opentracing-go/log"
+
+	store "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const slowCallHierarchyRequestThreshold = time.Second
+
+// IncomingCalls returns the calls to the symbol at the given position, grouped by caller.
+//
+// Calls are the references to the symbol, which are gathered and paged exactly as References does,
+// including the references found in other repositories via a moniker search. As indexes do not record
+// the extent of declarations, the caller of a reference is the closest preceding definition of a
+// non-local symbol in the same document. References that are not preceded by such a definition are
+// skipped. A caller may appear on several pages when its calls span a page boundary.
+func (r *queryResolver) IncomingCalls(ctx context.Context, line, character, limit int, rawCursor string) (_ []AdjustedCall, _ string, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, r.operations.incomingCalls, slowCallHierarchyRequestThreshold, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", r.repositoryID),
+			log.String("commit", r.commit),
+			log.String("path", r.path),
+			log.Int("numUploads", len(r.uploads)),
+			log.String("uploads", uploadIDsToString(r.uploads)),
+			log.Int("line", line),
+			log.Int("character", character),
+		},
+	})
+	defer endObservation()
+
+	// Incoming calls are pages of references, so they share the cursor of References.
+	cursor, err := decodeReferencesCursor(rawCursor)
+	if err != nil {
+		return nil, "", errors.Wrap(err, fmt.Sprintf("invalid cursor: %q", rawCursor))
+	}
+
+	locations, err := r.pageReferences(ctx, line, character, limit, &cursor, trace)
+	if err != nil {
+		return nil, "", err
+	}
+	trace.Log(log.Int("numLocations", len(locations)))
+
+	definitionRanges := map[callHierarchyDocument][]lsifstore.Range{}
+
+	var calls callsBuilder
+	for _, location := range locations {
+		ranges, err := r.cachedDefinitionRanges(ctx, definitionRanges, location.DumpID, location.Path)
+		if err != nil {
+			return nil, "", err
+		}
+
+		caller, ok := enclosingDefinitionRange(ranges, location.Range)
+		if !ok {
+			continue
+		}
+
+		calls.add(lsifstore.Location{DumpID: location.DumpID, Path: location.Path, Range: caller}, location)
+	}
+	trace.Log(log.Int("numCalls", len(calls.calls)))
+
+	adjustedCalls, err := r.adjustCalls(ctx, calls.calls)
+	if err != nil {
+		return nil, "", err
+	}
+	trace.Log(log.Int("numAdjustedCalls", len(adjustedCalls)))
+
+	nextCursor := ""
+	if cursor.Phase != "done" {
+		nextCursor = encodeReferencesCursor(cursor)
+	}
+
+	return adjustedCalls, nextCursor, nil
+}
+
+// OutgoingCalls returns the calls made by the symbol at the given position, grouped by callee.
+//
+// Calls are the references within the body of the first definition of the symbol, which may be in another
+// repository. As indexes do not record the extent of declarations, the body of a definition spans until the
+// next definition of a non-local symbol in the same document. The callee of a reference is its definition,
+// which is resolved as Definitions does. References to local symbols of the document, such as variables
+// and parameters, are skipped. The given limit bounds the number of references resolved per page, so a
+// page may hold fewer calls than the limit. The definitions of the references of a page are resolved in
+// bulk by callees.
+func (r *queryResolver) OutgoingCalls(ctx context.Context, line, character, limit int, rawCursor string) (_ []AdjustedCall, _ string, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, r.operations.outgoingCalls, slowCallHierarchyRequestThreshold, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", r.repositoryID),
+			log.String("commit", r.commit),
+			log.String("path", r.path),
+			log.Int("numUploads", len(r.uploads)),
+			log.String("uploads", uploadIDsToString(r.uploads)),
+			log.Int("line", line),
+			log.Int("character", character),
+		},
+	})
+	defer endObservation()
+
+	cursor, err := decodeOutgoingCallsCursor(rawCursor)
+	if err != nil {
+		return nil, "", errors.Wrap(err, fmt.Sprintf("invalid cursor: %q", rawCursor))
+	}
+
+	// The definition is resolved again for every page rather than carried in the cursor, as the
+	// cursor is supplied by the client and must not be able to name an arbitrary index.
+	adjustedUploads, err := r.adjustUploads(ctx, line, character)
+	if err != nil {
+		return nil, "", err
+	}
+
+	definitions, err := r.definitionLocations(ctx, adjustedUploads, trace)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(definitions) == 0 {
+		return nil, "", nil
+	}
+	definition := definitions[0]
+
+	uploads, err := r.uploadsByIDs(ctx, []int{definition.DumpID})
+	if err != nil {
+		return nil, "", err
+	}
+	if len(uploads) == 0 {
+		// The index of the definition is no longer visible
+		return nil, "", nil
+	}
+	upload := uploads[0]
+
+	documentDefinitionRanges, err := r.lsifStore.DefinitionRanges(ctx, definition.DumpID, definition.Path)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "lsifStore.DefinitionRanges")
+	}
+
+	callSites, err := r.definitionBody(ctx, documentDefinitionRanges, definition)
+	if err != nil {
+		return nil, "", err
+	}
+	trace.Log(log.Int("numCallSites", len(callSites)))
+
+	start, end := cursor.Offset, cursor.Offset+limit
+	if start > len(callSites) {
+		start = len(callSites)
+	}
+	if end > len(callSites) {
+		end = len(callSites)
+	}
+	if end < start {
+		end = start
+	}
+	page := callSites[start:end]
+	cursor.Offset = end
+
+	callees, err := r.callees(ctx, upload, definition.Path, page, documentDefinitionRanges, trace)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var calls callsBuilder
+	for i, callSite := range page {
+		callee, ok := callees[i]
+		if !ok {
+			continue
+		}
+
+		calls.add(callee, lsifstore.Location{DumpID: definition.DumpID, Path: definition.Path, Range: callSite})
+	}
+	trace.Log(log.Int("numCalls", len(calls.calls)))
+
+	adjustedCalls, err := r.adjustCalls(ctx, calls.calls)
+	if err != nil {
+		return nil, "", err
+	}
+	trace.Log(log.Int("numAdjustedCalls", len(adjustedCalls)))
+
+	nextCursor := ""
+	if cursor.Offset < len(callSites) {
+		nextCursor = encodeOutgoingCallsCursor(cursor)
+	}
+
+	return adjustedCalls, nextCursor, nil
+}
+
+// callHierarchyDocument identifies a document within an index.
+type callHierarchyDocument struct {
+	dumpID int
+	path   string
+}
+
+// cachedDefinitionRanges returns the ranges that define a non-local symbol within the given document,
+// ordered by position. The ranges are stored in the given cache so that each document is read once.
+func (r *queryResolver) cachedDefinitionRanges(ctx context.Context, cache map[callHierarchyDocument][]lsifstore.Range, dumpID int, path string) ([]lsifstore.Range, error) {
+	key := callHierarchyDocument{dumpID: dumpID, path: path}
+	if ranges, ok := cache[key]; ok {
+		return ranges, nil
+	}
+
+	ranges, err := r.lsifStore.DefinitionRanges(ctx, dumpID, path)
+	if err != nil {
+		return nil, errors.Wrap(err, "lsifStore.DefinitionRanges")
+	}
+
+	cache[key] = ranges
+	return ranges, nil
+}
+
+// definitionBody returns the ranges of the document of the given definition that follow the definition
+// and precede the next definition of a non-local symbol, ordered by position.
+func (r *queryResolver) definitionBody(ctx context.Context, definitionRanges []lsifstore.Range, definition lsifstore.Location) ([]lsifstore.Range, error) {
+	ranges, err := r.lsifStore.Stencil(ctx, definition.DumpID, definition.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "lsifStore.Stencil")
+	}
+
+	var end *lsifstore.Position
+	for i := range definitionRanges {
+		if comparePositions(definitionRanges[i].Start, definition.Range.End) >= 0 {
+			end = &definitionRanges[i].Start
+			break
+		}
+	}
+
+	body := make([]lsifstore.Range, 0, len(ranges))
+	for _, rn := range ranges {
+		if comparePositions(rn.Start, definition.Range.End) < 0 {
+			continue
+		}
+		if end != nil && comparePositions(rn.Start, *end) >= 0 {
+			continue
+		}
+
+		body = append(body, rn)
+	}
+	sort.Slice(body, func(i, j int) bool {
+		return comparePositions(body[i].Start, body[j].Start) < 0
+	})
+
+	return body, nil
+}
+
+// callees returns the definitions of the symbols referenced by the given ranges of the given document,
+// keyed by the index of the range. Ranges whose definition cannot be found, or is a local symbol of the
+// document, are omitted.
+//
+// Definitions are resolved as Definitions does, but in bulk: the definitions within the index are resolved
+// first, then the indexes defining the import monikers of the remaining ranges are looked up once, and the
+// ranges with the same monikers share a single moniker search.
+func (r *queryResolver) callees(
+	ctx context.Context,
+	upload store.Dump,
+	path string,
+	callSites []lsifstore.Range,
+	documentDefinitionRanges []lsifstore.Range,
+	trace observation.TraceLogger,
+) (map[int]lsifstore.Location, error) {
+	callees := make(map[int]lsifstore.Location, len(callSites))
+	callSiteMonikers := map[int][]precise.QualifiedMonikerData{}
+	monikerSet := newQualifiedMonikerSet()
+
+	for i, callSite := range callSites {
+		locations, _, err := r.lsifStore.Definitions(ctx, upload.ID, path, callSite.Start.Line, callSite.Start.Character, DefinitionsLimit, 0)
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.Definitions")
+		}
+		if len(locations) > 0 {
+			callees[i] = locations[0]
+			continue
+		}
+
+		adjustedUploads := []adjustedUpload{{
+			Upload:               upload,
+			AdjustedPath:         upload.Root + path,
+			AdjustedPosition:     callSite.Start,
+			AdjustedPathInBundle: path,
+		}}
+		orderedMonikers, err := r.orderedMonikers(ctx, adjustedUploads, "import")
+		if err != nil {
+			return nil, err
+		}
+		if len(orderedMonikers) == 0 {
+			continue
+		}
+
+		callSiteMonikers[i] = orderedMonikers
+		for _, moniker := range orderedMonikers {
+			monikerSet.add(moniker)
+		}
+	}
+	trace.Log(
+		log.Int("numLocalCallees", len(callees)),
+		log.Int("numMonikerCallSites", len(callSiteMonikers)),
+	)
+
+	if len(callSiteMonikers) > 0 {
+		uploads, err := r.definitionUploads(ctx, monikerSet.monikers)
+		if err != nil {
+			return nil, err
+		}
+		trace.Log(
+			log.Int("numXrepoDefinitionUploads", len(uploads)),
+			log.String("xrepoDefinitionUploads", uploadIDsToString(uploads)),
+		)
+
+		locationsByMonikers := map[string][]lsifstore.Location{}
+		for i, orderedMonikers := range callSiteMonikers {
+			key := monikersToString(orderedMonikers)
+			locations, ok := locationsByMonikers[key]
+			if !ok {
+				locations, _, err = r.monikerLocations(ctx, uploads, orderedMonikers, "definitions", DefinitionsLimit, 0)
+				if err != nil {
+					return nil, err
+				}
+				locationsByMonikers[key] = locations
+			}
+			if len(locations) > 0 {
+				callees[i] = locations[0]
+			}
+		}
+	}
+
+	for i, callee := range callees {
+		if callee.DumpID == upload.ID && callee.Path == path && !containsRange(documentDefinitionRanges, callee.Range) {
+			// Local symbols of the document, such as variables and parameters, are not callees
+			delete(callees, i)
+		}
+	}
+
+	return callees, nil
+}
+
+// unadjustedCall is a call whose locations are relative to their indexed commits.
+type unadjustedCall struct {
+	symbol lsifstore.Location
+	ranges []lsifstore.Location
+}
+
+// callsBuilder groups the ranges of calls by symbol, in the order in which the symbols are first seen.
+type callsBuilder struct {
+	calls   []unadjustedCall
+	indexes map[lsifstore.Location]int
+}
+
+func (b *callsBuilder) add(symbol, rn lsifstore.Location) {
+	if b.indexes == nil {
+		b.indexes = map[lsifstore.Location]int{}
+	}
+
+	i, ok := b.indexes[symbol]
+	if !ok {
+		i = len(b.calls)
+		b.indexes[symbol] = i
+		b.calls = append(b.calls, unadjustedCall{symbol: symbol})
+	}
+
+	b.calls[i].ranges = append(b.calls[i].ranges, rn)
+}
+
+// adjustCalls translates the locations of the given calls into equivalent locations in the requested
+// commit. Calls whose symbol or ranges are all filtered out (e.g., by sub-repo permissions) are dropped.
+func (r *queryResolver) adjustCalls(ctx context.Context, calls []unadjustedCall) ([]AdjustedCall, error) {
+	adjustedCalls := make([]AdjustedCall, 0, len(calls))
+	for _, call := range calls {
+		adjustedSymbols, err := r.adjustLocations(ctx, []lsifstore.Location{call.symbol})
+		if err != nil {
+			return nil, err
+		}
+		if len(adjustedSymbols) == 0 {
+			continue
+		}
+
+		adjustedRanges, err := r.adjustLocations(ctx, call.ranges)
+		if err != nil {
+			return nil, err
+		}
+		if len(adjustedRanges) == 0 {
+			continue
+		}
+
+		adjustedCalls = append(adjustedCalls, AdjustedCall{
+			Symbol: adjustedSymbols[0],
+			Ranges: adjustedRanges,
+		})
+	}
+
+	return adjustedCalls, nil
+}
+
+// enclosingDefinitionRange returns the last of the given definition ranges (ordered by position) that starts
+// at or before the given range. A false-valued flag is returned if there is no such range, or if the given
+// range is itself a definition.
+func enclosingDefinitionRange(definitionRanges []lsifstore.Range, rn lsifstore.Range) (lsifstore.Range, bool) {
+	i := sort.Search(len(definitionRanges), func(i int) bool {
+		return comparePositions(definitionRanges[i].Start, rn.Start) > 0
+	})
+	if i == 0 || definitionRanges[i-1] == rn {
+		return lsifstore.Range{}, false
+	}
+
+	return definitionRanges[i-1], true
+}
+
+// containsRange returns true if the given slice contains the given range.
+func containsRange(ranges []lsifstore.Range, rn lsifstore.Range) bool {
+	for _, r := range ranges {
+		if r == rn {
+			return true
+		}
+	}
+
+	return false
+}
+
+// comparePositions returns a negative value if a precedes b, zero if they are equal, and a positive value
+// otherwise.
+func comparePositions(a, b lsifstore.Position) int {
+	if a.Line != b.Line {
+		return a.Line - b.Line
+	}
+
+	return a.Character - b.Character
+}
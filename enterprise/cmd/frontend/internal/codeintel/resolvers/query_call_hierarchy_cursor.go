@@ -0,0 +1,46 @@
+package resolvers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// outgoingCallsCursor stores (enough of) the state of a previous OutgoingCalls request used to
+// calculate the offset into the result set to be returned by the current request.
+type outgoingCallsCursor struct {
+	// Offset is the number of ranges of the body of the definition that were resolved by previous
+	// requests.
+	Offset int `json:"offset"`
+}
+
+// decodeOutgoingCallsCursor is the inverse of encodeOutgoingCallsCursor. If the given encoded string
+// is empty, then a fresh cursor is returned. As the cursor is supplied by the client, an error is
+// returned if its offset is negative.
+func decodeOutgoingCallsCursor(rawEncoded string) (outgoingCallsCursor, error) {
+	if rawEncoded == "" {
+		return outgoingCallsCursor{}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(rawEncoded)
+	if err != nil {
+		return outgoingCallsCursor{}, err
+	}
+
+	var cursor outgoingCallsCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return outgoingCallsCursor{}, err
+	}
+	if cursor.Offset < 0 {
+		return outgoingCallsCursor{}, errors.Newf("negative offset %d", cursor.Offset)
+	}
+
+	return cursor, nil
+}
+
+// encodeOutgoingCallsCursor returns an encoding of the given cursor suitable for a URL or a GraphQL token.
+func encodeOutgoingCallsCursor(cursor outgoingCallsCursor) string {
+	rawEncoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(rawEncoded)
+}
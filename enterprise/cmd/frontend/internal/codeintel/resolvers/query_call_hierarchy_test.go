@@ -0,0 +1,344 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestIncomingCalls(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	// Empty result set (prevents nil pointer as scanner is always non-nil)
+	mockDBStore.ReferenceIDsFunc.PushReturn(dbstore.PackageReferenceScannerFromSlice(), 0, nil)
+
+	locations := []lsifstore.Location{
+		{DumpID: 51, Path: "a.go", Range: testRange1},
+		{DumpID: 51, Path: "b.go", Range: testRange2},
+		{DumpID: 51, Path: "a.go", Range: testRange3},
+	}
+	mockLSIFStore.ReferencesFunc.PushReturn(locations, len(locations), nil)
+
+	callerRange := lsifstore.Range{Start: lsifstore.Position{Line: 5, Character: 5}, End: lsifstore.Position{Line: 5, Character: 8}}
+	mockLSIFStore.DefinitionRangesFunc.SetDefaultHook(func(ctx context.Context, bundleID int, path string) ([]lsifstore.Range, error) {
+		if path == "a.go" {
+			return []lsifstore.Range{callerRange}, nil
+		}
+
+		// References in b.go are not within a definition
+		return nil, nil
+	})
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+	}
+	resolver := newQueryResolver(
+		database.NewMockDB(),
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+		authz.NewMockSubRepoPermissionChecker(),
+		50,
+	)
+	adjustedCalls, _, err := resolver.IncomingCalls(context.Background(), 10, 20, 50, "")
+	if err != nil {
+		t.Fatalf("unexpected error querying incoming calls: %s", err)
+	}
+
+	expectedCalls := []AdjustedCall{
+		{
+			Symbol: AdjustedLocation{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: callerRange},
+			Ranges: []AdjustedLocation{
+				{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
+				{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange3},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, adjustedCalls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+
+	if callCount := len(mockLSIFStore.DefinitionRangesFunc.History()); callCount != 2 {
+		t.Errorf("unexpected definition ranges call count. want=%d have=%d", 2, callCount)
+	}
+}
+
+func TestOutgoingCalls(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	newRange := func(line, startCharacter, endCharacter int) lsifstore.Range {
+		return lsifstore.Range{
+			Start: lsifstore.Position{Line: line, Character: startCharacter},
+			End:   lsifstore.Position{Line: line, Character: endCharacter},
+		}
+	}
+
+	definitionRange := newRange(1, 5, 8)
+	nextDefinitionRange := newRange(20, 5, 8)
+	localDefinitionRange := newRange(2, 10, 11)
+	callRange1 := newRange(2, 1, 4)
+	localRange := newRange(3, 1, 2)
+	callRange2 := newRange(4, 1, 4)
+	callRange3 := newRange(5, 1, 4)
+	afterRange := newRange(21, 1, 4)
+
+	mockLSIFStore.DefinitionRangesFunc.SetDefaultReturn([]lsifstore.Range{definitionRange, nextDefinitionRange}, nil)
+	mockLSIFStore.StencilFunc.SetDefaultReturn([]lsifstore.Range{
+		afterRange,
+		callRange3,
+		definitionRange,
+		callRange1,
+		localRange,
+		callRange2,
+		nextDefinitionRange,
+	}, nil)
+	mockLSIFStore.DefinitionsFunc.SetDefaultHook(func(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error) {
+		var locations []lsifstore.Location
+		switch line {
+		case 10:
+			locations = []lsifstore.Location{{DumpID: 51, Path: "a.go", Range: definitionRange}}
+		case callRange1.Start.Line, callRange3.Start.Line:
+			locations = []lsifstore.Location{{DumpID: 51, Path: "b.go", Range: testRange1}}
+		case localRange.Start.Line:
+			locations = []lsifstore.Location{{DumpID: 51, Path: "a.go", Range: localDefinitionRange}}
+		case callRange2.Start.Line:
+			locations = []lsifstore.Location{{DumpID: 51, Path: "a.go", Range: nextDefinitionRange}}
+		}
+
+		return locations, len(locations), nil
+	})
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+	}
+	resolver := newQueryResolver(
+		database.NewMockDB(),
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+		authz.NewMockSubRepoPermissionChecker(),
+		50,
+	)
+
+	adjustedCalls, cursor, err := resolver.OutgoingCalls(context.Background(), 10, 20, 3, "")
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+
+	expectedCalls := []AdjustedCall{
+		{
+			Symbol: AdjustedLocation{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
+			Ranges: []AdjustedLocation{
+				{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: callRange1},
+			},
+		},
+		{
+			Symbol: AdjustedLocation{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: nextDefinitionRange},
+			Ranges: []AdjustedLocation{
+				{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: callRange2},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, adjustedCalls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+	if cursor == "" {
+		t.Fatalf("expected a cursor for the next page")
+	}
+
+	adjustedCalls, cursor, err = resolver.OutgoingCalls(context.Background(), 10, 20, 3, cursor)
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+
+	expectedCalls = []AdjustedCall{
+		{
+			Symbol: AdjustedLocation{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
+			Ranges: []AdjustedLocation{
+				{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: callRange3},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, adjustedCalls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+	if cursor != "" {
+		t.Errorf("unexpected cursor. want=%q have=%q", "", cursor)
+	}
+}
+
+func TestOutgoingCallsRemote(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	newRange := func(line, startCharacter, endCharacter int) lsifstore.Range {
+		return lsifstore.Range{
+			Start: lsifstore.Position{Line: line, Character: startCharacter},
+			End:   lsifstore.Position{Line: line, Character: endCharacter},
+		}
+	}
+
+	definitionRange := newRange(1, 5, 8)
+	callRange1 := newRange(2, 1, 4)
+	callRange2 := newRange(4, 1, 4)
+
+	mockLSIFStore.DefinitionRangesFunc.SetDefaultReturn([]lsifstore.Range{definitionRange}, nil)
+	mockLSIFStore.StencilFunc.SetDefaultReturn([]lsifstore.Range{definitionRange, callRange1, callRange2}, nil)
+	mockLSIFStore.DefinitionsFunc.SetDefaultHook(func(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error) {
+		if line == 10 {
+			return []lsifstore.Location{{DumpID: 51, Path: "a.go", Range: definitionRange}}, 1, nil
+		}
+
+		// The call sites have no definition within the index
+		return nil, 0, nil
+	})
+
+	moniker := precise.MonikerData{Kind: "import", Scheme: "tsc", Identifier: "padLeft", PackageInformationID: "51"}
+	packageInformation := precise.PackageInformationData{Name: "leftpad", Version: "0.1.0"}
+	mockLSIFStore.MonikersByPositionFunc.SetDefaultReturn([][]precise.MonikerData{{moniker}}, nil)
+	mockLSIFStore.PackageInformationFunc.SetDefaultReturn(packageInformation, true, nil)
+
+	remoteUploads := []dbstore.Dump{{ID: 151, Commit: "deadbeef2", Root: "sub2/"}}
+	mockDBStore.DefinitionDumpsFunc.SetDefaultReturn(remoteUploads, nil)
+	mockGitserverClient.CommitsExistFunc.SetDefaultHook(func(ctx context.Context, rcs []gitserver.RepositoryCommit) (exists []bool, _ error) {
+		for range rcs {
+			exists = append(exists, true)
+		}
+		return
+	})
+	mockLSIFStore.BulkMonikerResultsFunc.SetDefaultReturn([]lsifstore.Location{{DumpID: 151, Path: "b.go", Range: testRange1}}, 1, nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+	}
+	resolver := newQueryResolver(
+		database.NewMockDB(),
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+		authz.NewMockSubRepoPermissionChecker(),
+		50,
+	)
+
+	adjustedCalls, cursor, err := resolver.OutgoingCalls(context.Background(), 10, 20, 10, "")
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+
+	expectedCalls := []AdjustedCall{
+		{
+			Symbol: AdjustedLocation{Dump: remoteUploads[0], Path: "sub2/b.go", AdjustedCommit: "deadbeef2", AdjustedRange: testRange1},
+			Ranges: []AdjustedLocation{
+				{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: callRange1},
+				{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: callRange2},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, adjustedCalls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+	if cursor != "" {
+		t.Errorf("unexpected cursor. want=%q have=%q", "", cursor)
+	}
+
+	// Both call sites share the lookup of the definition uploads and the moniker search
+	if history := mockDBStore.DefinitionDumpsFunc.History(); len(history) != 1 {
+		t.Errorf("unexpected call count for dbstore.DefinitionDumps. want=%d have=%d", 1, len(history))
+	}
+	if history := mockLSIFStore.BulkMonikerResultsFunc.History(); len(history) != 1 {
+		t.Errorf("unexpected call count for lsifstore.BulkMonikerResults. want=%d have=%d", 1, len(history))
+	}
+}
+
+func TestOutgoingCallsInvalidCursor(t *testing.T) {
+	resolver := newQueryResolver(
+		database.NewMockDB(),
+		NewMockDBStore(),
+		NewMockLSIFStore(),
+		newCachedCommitChecker(NewMockGitserverClient()),
+		noopPositionAdjuster(),
+		42,
+		"deadbeef",
+		"s1/main.go",
+		nil,
+		newOperations(&observation.TestContext),
+		authz.NewMockSubRepoPermissionChecker(),
+		50,
+	)
+
+	cursor := encodeOutgoingCallsCursor(outgoingCallsCursor{Offset: -1})
+	if _, _, err := resolver.OutgoingCalls(context.Background(), 10, 20, 3, cursor); err == nil {
+		t.Fatalf("expected an error for a negative offset")
+	}
+}
+
+func TestEnclosingDefinitionRange(t *testing.T) {
+	newRange := func(line, character int) lsifstore.Range {
+		return lsifstore.Range{
+			Start: lsifstore.Position{Line: line, Character: character},
+			End:   lsifstore.Position{Line: line, Character: character + 3},
+		}
+	}
+
+	definitionRanges := []lsifstore.Range{newRange(5, 0), newRange(10, 4), newRange(20, 0)}
+
+	testCases := []struct {
+		rn       lsifstore.Range
+		expected lsifstore.Range
+		ok       bool
+	}{
+		{rn: newRange(2, 0), ok: false},
+		{rn: newRange(5, 0), ok: false},
+		{rn: newRange(5, 6), expected: newRange(5, 0), ok: true},
+		{rn: newRange(10, 2), expected: newRange(5, 0), ok: true},
+		{rn: newRange(12, 0), expected: newRange(10, 4), ok: true},
+		{rn: newRange(30, 0), expected: newRange(20, 0), ok: true},
+	}
+
+	for _, testCase := range testCases {
+		rn, ok := enclosingDefinitionRange(definitionRanges, testCase.rn)
+		if ok != testCase.ok {
+			t.Errorf("unexpected flag for %v. want=%v have=%v", testCase.rn, testCase.ok, ok)
+		} else if rn != testCase.expected {
+			t.Errorf("unexpected range for %v. want=%v have=%v", testCase.rn, testCase.expected, rn)
+		}
+	}
+}
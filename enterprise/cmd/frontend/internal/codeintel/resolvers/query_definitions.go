@@ -6,6 +6,7 @@ import (
 
 	"github.com/opentracing/opentracing-go/log"
 
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -38,6 +39,28 @@ func (r *queryResolver) Definitions(ctx context.Context, line, character int) (_
 		return nil, err
 	}
 
+	locations, err := r.definitionLocations(ctx, adjustedUploads, trace)
+	if err != nil {
+		return nil, err
+	}
+
+	// Adjust the locations back to the appropriate range in the target commits. This adjusts
+	// locations within the repository the user is browsing so that it appears all definitions
+	// are occurring at the same commit they are looking at.
+
+	adjustedLocations, err := r.adjustLocations(ctx, locations)
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numAdjustedLocations", len(adjustedLocations)))
+
+	return adjustedLocations, nil
+}
+
+// definitionLocations returns the locations (relative to their indexed commits) that define the symbol at
+// the adjusted position of the given uploads. Definitions within the given uploads are preferred to those
+// found by a moniker search in other indexes.
+func (r *queryResolver) definitionLocations(ctx context.Context, adjustedUploads []adjustedUpload, trace observation.TraceLogger) ([]lsifstore.Location, error) {
 	// Gather the "local" reference locations that are reachable via a referenceResult vertex.
 	// If the definition exists within the index, it should be reachable via an LSIF graph
 	// traversal and should not require an additional moniker search in the same index.
@@ -59,7 +82,7 @@ func (r *queryResolver) Definitions(ctx context.Context, line, character int) (_
 		}
 		if len(locations) > 0 {
 			// If we have a local definition, we won't find a better one and can exit early
-			return r.adjustLocations(ctx, locations)
+			return locations, nil
 		}
 	}
 
@@ -92,15 +115,5 @@ func (r *queryResolver) Definitions(ctx context.Context, line, character int) (_
 	}
 	trace.Log(log.Int("numXrepoLocations", len(locations)))
 
-	// Adjust the locations back to the appropriate range in the target commits. This adjusts
-	// locations within the repository the user is browsing so that it appears all definitions
-	// are occurring at the same commit they are looking at.
-
-	adjustedLocations, err := r.adjustLocations(ctx, locations)
-	if err != nil {
-		return nil, err
-	}
-	trace.Log(log.Int("numAdjustedXrepoLocations", len(adjustedLocations)))
-
-	return adjustedLocations, nil
+	return locations, nil
 }
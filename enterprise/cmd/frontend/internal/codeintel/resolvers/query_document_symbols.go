@@ -0,0 +1,122 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const slowDocumentSymbolsRequestThreshold = time.Second
+
+// DocumentSymbols returns the symbol trees of the document. Distinct uploads covering the same document
+// would produce redundant outlines, so only the symbols of the first upload that has any are returned.
+func (r *queryResolver) DocumentSymbols(ctx context.Context) (_ []AdjustedSymbol, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, r.operations.documentSymbols, slowDocumentSymbolsRequestThreshold, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", r.repositoryID),
+			log.String("commit", r.commit),
+			log.String("path", r.path),
+			log.Int("numUploads", len(r.uploads)),
+			log.String("uploads", uploadIDsToString(r.uploads)),
+		},
+	})
+	defer endObservation()
+
+	adjustedUploads, err := r.adjustUploadPaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range adjustedUploads {
+		trace.Log(log.Int("uploadID", adjustedUploads[i].Upload.ID))
+
+		symbols, err := r.lsifStore.DocumentSymbols(
+			ctx,
+			adjustedUploads[i].Upload.ID,
+			adjustedUploads[i].AdjustedPathInBundle,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.DocumentSymbols")
+		}
+		if len(symbols) == 0 {
+			continue
+		}
+
+		adjustedSymbols, err := r.adjustSymbols(ctx, adjustedUploads[i], symbols)
+		if err != nil {
+			return nil, err
+		}
+		trace.Log(log.Int("numSymbols", len(adjustedSymbols)))
+
+		return adjustedSymbols, nil
+	}
+
+	return nil, nil
+}
+
+// adjustSymbols translates the given symbol trees (relative to the indexed commit) into equivalent
+// symbol trees in the requested commit. A symbol whose name was edited since the indexed commit is
+// dropped and its children take its place. A symbol whose declaration was edited, but not its name,
+// has its full range reduced to the range of its name.
+func (r *queryResolver) adjustSymbols(ctx context.Context, adjustedUpload adjustedUpload, symbols []precise.SymbolData) ([]AdjustedSymbol, error) {
+	adjustedSymbols := make([]AdjustedSymbol, 0, len(symbols))
+	for _, symbol := range symbols {
+		children, err := r.adjustSymbols(ctx, adjustedUpload, symbol.Children)
+		if err != nil {
+			return nil, err
+		}
+
+		_, adjustedRange, ok, err := r.adjustRange(
+			ctx,
+			adjustedUpload.Upload.RepositoryID,
+			adjustedUpload.Upload.Commit,
+			r.path,
+			lsifstore.Range{
+				Start: lsifstore.Position{Line: symbol.StartLine, Character: symbol.StartCharacter},
+				End:   lsifstore.Position{Line: symbol.EndLine, Character: symbol.EndCharacter},
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			adjustedSymbols = append(adjustedSymbols, children...)
+			continue
+		}
+
+		_, adjustedFullRange, ok, err := r.adjustRange(
+			ctx,
+			adjustedUpload.Upload.RepositoryID,
+			adjustedUpload.Upload.Commit,
+			r.path,
+			lsifstore.Range{
+				Start: lsifstore.Position{Line: symbol.FullStartLine, Character: symbol.FullStartCharacter},
+				End:   lsifstore.Position{Line: symbol.FullEndLine, Character: symbol.FullEndCharacter},
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			adjustedFullRange = adjustedRange
+		}
+
+		adjustedSymbols = append(adjustedSymbols, AdjustedSymbol{
+			Name:      symbol.Name,
+			Kind:      symbol.Kind,
+			Detail:    symbol.Detail,
+			Tags:      symbol.Tags,
+			Range:     adjustedRange,
+			FullRange: adjustedFullRange,
+			Children:  children,
+		})
+	}
+
+	return adjustedSymbols, nil
+}
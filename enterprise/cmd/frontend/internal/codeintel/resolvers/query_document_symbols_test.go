@@ -0,0 +1,154 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestDocumentSymbols(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+
+	// Ranges starting on line 20 were edited since the indexed commit, and all other ranges
+	// were moved one line down.
+	mockPositionAdjuster := noopPositionAdjuster().(*MockPositionAdjuster)
+	mockPositionAdjuster.AdjustRangeFunc.SetDefaultHook(func(ctx context.Context, commit, path string, rx lsifstore.Range, _ bool) (string, lsifstore.Range, bool, error) {
+		if rx.Start.Line == 20 || rx.End.Line == 20 {
+			return "", lsifstore.Range{}, false, nil
+		}
+
+		rx.Start.Line++
+		rx.End.Line++
+		return path, rx, true, nil
+	})
+
+	mockLSIFStore.DocumentSymbolsFunc.PushReturn(nil, nil)
+	mockLSIFStore.DocumentSymbolsFunc.PushReturn([]precise.SymbolData{
+		{
+			Name:               "Vertex",
+			Kind:               protocol.Struct,
+			StartLine:          10,
+			StartCharacter:     5,
+			EndLine:            10,
+			EndCharacter:       11,
+			FullStartLine:      10,
+			FullStartCharacter: 0,
+			FullEndLine:        20,
+			FullEndCharacter:   1,
+			Children: []precise.SymbolData{
+				{
+					Name:               "Label",
+					Kind:               protocol.Field,
+					Tags:               []protocol.SymbolTag{protocol.Deprecated},
+					StartLine:          12,
+					StartCharacter:     1,
+					EndLine:            12,
+					EndCharacter:       6,
+					FullStartLine:      12,
+					FullStartCharacter: 1,
+					FullEndLine:        12,
+					FullEndCharacter:   18,
+				},
+			},
+		},
+		{
+			Name:               "Edited",
+			Kind:               protocol.Class,
+			StartLine:          20,
+			StartCharacter:     6,
+			EndLine:            20,
+			EndCharacter:       12,
+			FullStartLine:      20,
+			FullStartCharacter: 0,
+			FullEndLine:        30,
+			FullEndCharacter:   1,
+			Children: []precise.SymbolData{
+				{
+					Name:               "method",
+					Kind:               protocol.Method,
+					StartLine:          22,
+					StartCharacter:     2,
+					EndLine:            22,
+					EndCharacter:       8,
+					FullStartLine:      22,
+					FullStartCharacter: 2,
+					FullEndLine:        24,
+					FullEndCharacter:   3,
+				},
+			},
+		},
+	}, nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 50, RepositoryID: 42, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, RepositoryID: 42, Commit: "deadbeef", Root: "sub2/"},
+		{ID: 52, RepositoryID: 42, Commit: "deadbeef", Root: "sub3/"},
+	}
+	resolver := newQueryResolver(
+		database.NewMockDB(),
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+		authz.NewMockSubRepoPermissionChecker(),
+		50,
+	)
+	symbols, err := resolver.DocumentSymbols(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error querying document symbols: %s", err)
+	}
+
+	expectedSymbols := []AdjustedSymbol{
+		{
+			Name: "Vertex",
+			Kind: protocol.Struct,
+			// The declaration was edited, so the full range is reduced to the name
+			Range:     lsifstore.Range{Start: lsifstore.Position{Line: 11, Character: 5}, End: lsifstore.Position{Line: 11, Character: 11}},
+			FullRange: lsifstore.Range{Start: lsifstore.Position{Line: 11, Character: 5}, End: lsifstore.Position{Line: 11, Character: 11}},
+			Children: []AdjustedSymbol{
+				{
+					Name:      "Label",
+					Kind:      protocol.Field,
+					Tags:      []protocol.SymbolTag{protocol.Deprecated},
+					Range:     lsifstore.Range{Start: lsifstore.Position{Line: 13, Character: 1}, End: lsifstore.Position{Line: 13, Character: 6}},
+					FullRange: lsifstore.Range{Start: lsifstore.Position{Line: 13, Character: 1}, End: lsifstore.Position{Line: 13, Character: 18}},
+					Children:  []AdjustedSymbol{},
+				},
+			},
+		},
+		// The name of the enclosing symbol was edited, so its children are promoted
+		{
+			Name:      "method",
+			Kind:      protocol.Method,
+			Range:     lsifstore.Range{Start: lsifstore.Position{Line: 23, Character: 2}, End: lsifstore.Position{Line: 23, Character: 8}},
+			FullRange: lsifstore.Range{Start: lsifstore.Position{Line: 23, Character: 2}, End: lsifstore.Position{Line: 25, Character: 3}},
+			Children:  []AdjustedSymbol{},
+		},
+	}
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	// Only the first upload with document symbols is used
+	if history := mockLSIFStore.DocumentSymbolsFunc.History(); len(history) != 2 {
+		t.Errorf("unexpected number of calls to DocumentSymbols. want=%d have=%d", 2, len(history))
+	} else if history[1].Arg1 != 51 {
+		t.Errorf("unexpected upload. want=%d have=%d", 51, history[1].Arg1)
+	}
+}
@@ -15,6 +15,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	executor "github.com/sourcegraph/sourcegraph/internal/services/executors/transport/graphql"
 	"github.com/sourcegraph/sourcegraph/internal/symbols"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -59,6 +61,7 @@ type Resolver interface {
 	UploadConnectionResolver(opts dbstore.GetUploadsOptions) *UploadsResolver
 	IndexConnectionResolver(opts dbstore.GetIndexesOptions) *IndexesResolver
 	QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (QueryResolver, error)
+	SearchSymbols(ctx context.Context, repositoryID int, args search.SymbolsParameters) (result.Symbols, bool, error)
 	RepositorySummary(ctx context.Context, repositoryID int) (RepositorySummary, error)
 
 	RequestLanguageSupport(ctx context.Context, userID int, language string) error
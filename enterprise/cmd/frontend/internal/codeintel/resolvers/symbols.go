@@ -0,0 +1,128 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/grafana/regexp"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const slowSearchSymbolsRequestThreshold = time.Second
+
+// defaultSymbolSearchLimit is the number of symbols returned by a search that does not specify
+// a limit. It matches the default of the symbols service.
+const defaultSymbolSearchLimit = 100
+
+// SearchSymbols returns the document symbols of the uploads of the given repository that match the
+// given search args. Only uploads of the searched commit are used, as symbols of other commits may
+// have moved or no longer exist. If the searched commit has no uploads, a false-valued flag is returned
+// so that the caller can fall back to another symbol search backend.
+func (r *resolver) SearchSymbols(ctx context.Context, repositoryID int, args search.SymbolsParameters) (_ result.Symbols, _ bool, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, r.operations.searchSymbols, slowSearchSymbolsRequestThreshold, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", repositoryID),
+			log.String("commit", string(args.CommitID)),
+			log.String("query", args.Query),
+			log.Int("first", args.First),
+		},
+	})
+	defer endObservation()
+
+	dumps, err := r.dbStore.FindClosestDumps(ctx, repositoryID, string(args.CommitID), "", false, "")
+	if err != nil {
+		return nil, false, errors.Wrap(err, "dbStore.FindClosestDumps")
+	}
+
+	freshDumps := make([]dbstore.Dump, 0, len(dumps))
+	for _, dump := range dumps {
+		if dump.Commit == string(args.CommitID) {
+			freshDumps = append(freshDumps, dump)
+		}
+	}
+	trace.Log(
+		log.Int("numDumps", len(dumps)),
+		log.Int("numFreshDumps", len(freshDumps)),
+		log.String("freshDumps", uploadIDsToString(freshDumps)),
+	)
+	if len(freshDumps) == 0 {
+		return nil, false, nil
+	}
+
+	query := args.Query
+	if !args.IsRegExp {
+		query = regexp.QuoteMeta(query)
+	}
+	opts := lsifstore.SymbolSearchOptions{
+		Query:           query,
+		IsCaseSensitive: args.IsCaseSensitive,
+		IncludePatterns: args.IncludePatterns,
+		ExcludePattern:  args.ExcludePattern,
+	}
+
+	limit := args.First
+	if limit <= 0 {
+		limit = defaultSymbolSearchLimit
+	}
+
+	type symbolKey struct {
+		path            string
+		name            string
+		line, character int
+	}
+	seen := map[symbolKey]struct{}{}
+
+	symbols := make(result.Symbols, 0, limit)
+	for _, dump := range freshDumps {
+		matches, err := r.lsifStore.SearchSymbols(ctx, dump.ID, dump.Root, opts, limit-len(symbols))
+		if err != nil {
+			return nil, false, errors.Wrap(err, "lsifStore.SearchSymbols")
+		}
+
+		for _, match := range matches {
+			// Distinct indexers may have uploaded the same document
+			key := symbolKey{dump.Root + match.Path, match.Name, match.Range.Start.Line, match.Range.Start.Character}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+
+			symbols = append(symbols, result.Symbol{
+				Name:      match.Name,
+				Path:      dump.Root + match.Path,
+				Line:      match.Range.Start.Line + 1, // callers expect 1-indexed lines
+				Character: match.Range.Start.Character,
+				Kind:      symbolKindName(match.Kind),
+				Parent:    match.Parent,
+			})
+		}
+
+		if len(symbols) >= limit {
+			break
+		}
+	}
+	trace.Log(log.Int("numSymbols", len(symbols)))
+
+	return symbols, true, nil
+}
+
+// symbolKindName returns the name of the given symbol kind as understood by result.Symbol.LSPKind.
+func symbolKindName(kind protocol.SymbolKind) string {
+	switch kind {
+	case protocol.EnumMember:
+		return "enum member"
+	case protocol.TypeParameter:
+		return "type parameter"
+	}
+
+	return strings.ToLower(kind.String())
+}
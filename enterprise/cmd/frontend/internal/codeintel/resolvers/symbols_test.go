@@ -0,0 +1,114 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	store "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+)
+
+func TestSearchSymbols(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+
+	mockDBStore.FindClosestDumpsFunc.SetDefaultReturn([]store.Dump{
+		{ID: 50, RepositoryID: 42, Commit: "deadbeef", Root: ""},
+		{ID: 51, RepositoryID: 42, Commit: "cafebabe", Root: "sub/"}, // not fresh
+		{ID: 52, RepositoryID: 42, Commit: "deadbeef", Root: "sub/"},
+		{ID: 53, RepositoryID: 42, Commit: "deadbeef", Root: "sub/"}, // another indexer
+	}, nil)
+
+	symbolsByDumpID := map[int][]lsifstore.SymbolMatch{
+		50: {
+			{DumpID: 50, Path: "main.go", Name: "NewServer", Kind: protocol.Function, Range: testRange1},
+		},
+		52: {
+			{DumpID: 52, Path: "server.go", Name: "Server", Kind: protocol.Struct, Range: testRange2},
+			{DumpID: 52, Path: "server.go", Name: "Handler", Kind: protocol.EnumMember, Parent: "Server", Range: testRange3},
+		},
+		53: {
+			{DumpID: 53, Path: "server.go", Name: "Server", Kind: protocol.Struct, Range: testRange2},
+		},
+	}
+	mockLSIFStore.SearchSymbolsFunc.SetDefaultHook(func(ctx context.Context, bundleID int, root string, opts lsifstore.SymbolSearchOptions, limit int) ([]lsifstore.SymbolMatch, error) {
+		symbols := symbolsByDumpID[bundleID]
+		if len(symbols) > limit {
+			symbols = symbols[:limit]
+		}
+		return symbols, nil
+	})
+
+	resolver := newResolver(mockDBStore, mockLSIFStore, mockGitserverClient, nil, nil, nil, nil, 50, &observation.TestContext, database.NewMockDB())
+	symbols, ok, err := resolver.SearchSymbols(context.Background(), 42, search.SymbolsParameters{
+		CommitID:        "deadbeef",
+		Query:           "Server.go",
+		IncludePatterns: []string{`\.go$`},
+		First:           10,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error searching symbols: %s", err)
+	}
+	if !ok {
+		t.Fatalf("expected symbols to be searched")
+	}
+
+	expectedSymbols := result.Symbols{
+		{Name: "NewServer", Path: "main.go", Line: 12, Character: 21, Kind: "function"},
+		{Name: "Server", Path: "sub/server.go", Line: 13, Character: 22, Kind: "struct"},
+		{Name: "Handler", Path: "sub/server.go", Line: 14, Character: 23, Kind: "enum member", Parent: "Server"},
+	}
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	var searchedDumpIDs []int
+	for _, call := range mockLSIFStore.SearchSymbolsFunc.History() {
+		searchedDumpIDs = append(searchedDumpIDs, call.Arg1)
+	}
+	if diff := cmp.Diff([]int{50, 52, 53}, searchedDumpIDs); diff != "" {
+		t.Errorf("unexpected searched dumps (-want +got):\n%s", diff)
+	}
+
+	call := mockLSIFStore.SearchSymbolsFunc.History()[1]
+	if call.Arg2 != "sub/" {
+		t.Errorf("unexpected root. want=%q have=%q", "sub/", call.Arg2)
+	}
+	expectedOpts := lsifstore.SymbolSearchOptions{Query: `Server\.go`, IncludePatterns: []string{`\.go$`}}
+	if diff := cmp.Diff(expectedOpts, call.Arg3); diff != "" {
+		t.Errorf("unexpected search options (-want +got):\n%s", diff)
+	}
+	if call.Arg4 != 9 {
+		t.Errorf("unexpected limit. want=%d have=%d", 9, call.Arg4)
+	}
+}
+
+func TestSearchSymbolsNoFreshUploads(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+
+	mockDBStore.FindClosestDumpsFunc.SetDefaultReturn([]store.Dump{
+		{ID: 51, RepositoryID: 42, Commit: "cafebabe", Root: "sub/"},
+	}, nil)
+
+	resolver := newResolver(mockDBStore, mockLSIFStore, mockGitserverClient, nil, nil, nil, nil, 50, &observation.TestContext, database.NewMockDB())
+	_, ok, err := resolver.SearchSymbols(context.Background(), 42, search.SymbolsParameters{CommitID: "deadbeef", Query: "Server"})
+	if err != nil {
+		t.Fatalf("unexpected error searching symbols: %s", err)
+	}
+	if ok {
+		t.Fatalf("expected no symbols to be searched")
+	}
+	if len(mockLSIFStore.SearchSymbolsFunc.History()) != 0 {
+		t.Errorf("unexpected calls to SearchSymbols")
+	}
+}
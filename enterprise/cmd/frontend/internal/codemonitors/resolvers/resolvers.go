@@ -3,6 +3,7 @@ package resolvers
 import (
 	"context"
 	"net/url"
+	"sort"
 	"time"
 
 	"github.com/graph-gophers/graphql-go"
@@ -288,8 +289,17 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 			if err != nil {
 				return err
 			}
+		case a.TemplatedWebhook != nil:
+			preset, args := templatedWebhookActionArgs(a.TemplatedWebhook)
+			if err := background.ValidateTemplatedWebhookAction(preset, args); err != nil {
+				return err
+			}
+			_, err := r.db.CodeMonitors().CreateTemplatedWebhookAction(ctx, preset, monitorID, args)
+			if err != nil {
+				return err
+			}
 		default:
-			return errors.New("exactly one of Email, Webhook, or SlackWebhook must be set")
+			return errors.New("exactly one of Email, Webhook, SlackWebhook, or TemplatedWebhook must be set")
 		}
 	}
 	return nil
@@ -297,6 +307,7 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 
 func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []graphql.ID) error {
 	var email, webhook, slackWebhook []int64
+	templatedWebhooks := make(map[edb.TemplatedWebhookPreset][]int64)
 	for _, id := range ids {
 		var intID int64
 		err := relay.UnmarshalSpec(id, &intID)
@@ -312,7 +323,11 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 		case monitorActionSlackWebhookKind:
 			slackWebhook = append(slackWebhook, intID)
 		default:
-			return errors.New("action IDs must be exactly one of email, webhook, or slack webhook")
+			preset, ok := templatedWebhookPresetForKind(relay.UnmarshalKind(id))
+			if !ok {
+				return errors.New("action IDs must be exactly one of email, webhook, slack webhook, or templated webhook")
+			}
+			templatedWebhooks[preset] = append(templatedWebhooks[preset], intID)
 		}
 	}
 
@@ -328,6 +343,12 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 		return err
 	}
 
+	for preset, ids := range templatedWebhooks {
+		if err := r.db.CodeMonitors().DeleteTemplatedWebhookActions(ctx, preset, monitorID, ids...); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -408,6 +429,32 @@ func (r *Resolver) TriggerTestSlackWebhookAction(ctx context.Context, args *grap
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) TriggerTestTemplatedWebhookAction(ctx context.Context, args *graphqlbackend.TriggerTestTemplatedWebhookActionArgs) (*graphqlbackend.EmptyResponse, error) {
+	err := r.isAllowedToCreate(ctx, args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	preset, actionArgs := templatedWebhookActionArgs(args.TemplatedWebhook)
+	if err := background.ValidateTemplatedWebhookAction(preset, actionArgs); err != nil {
+		return nil, err
+	}
+
+	action := &edb.TemplatedWebhookAction{
+		Preset:       preset,
+		URL:          actionArgs.URL,
+		RoutingKey:   actionArgs.RoutingKey,
+		BodyTemplate: actionArgs.BodyTemplate,
+		ContentType:  actionArgs.ContentType,
+		Headers:      actionArgs.Headers,
+	}
+	if err := background.SendTestTemplatedWebhook(ctx, httpcli.ExternalDoer, args.Description, action); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
 func sendTestEmail(ctx context.Context, recipient graphql.ID, description string) error {
 	var (
 		userID int32
@@ -439,7 +486,15 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	if err != nil {
 		return nil, err
 	}
-	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions))
+	var templatedWebhookActions []*edb.TemplatedWebhookAction
+	for _, preset := range edb.TemplatedWebhookPresets {
+		as, err := r.db.CodeMonitors().ListTemplatedWebhookActions(ctx, preset, opts)
+		if err != nil {
+			return nil, err
+		}
+		templatedWebhookActions = append(templatedWebhookActions, as...)
+	}
+	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions)+len(templatedWebhookActions))
 	for _, emailAction := range emailActions {
 		ids = append(ids, (&monitorEmail{EmailAction: emailAction}).ID())
 	}
@@ -449,6 +504,9 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	for _, slackWebhookAction := range slackWebhookActions {
 		ids = append(ids, (&monitorSlackWebhook{SlackWebhookAction: slackWebhookAction}).ID())
 	}
+	for _, templatedWebhookAction := range templatedWebhookActions {
+		ids = append(ids, (&monitorTemplatedWebhook{TemplatedWebhookAction: templatedWebhookAction}).ID())
+	}
 	return ids, nil
 }
 
@@ -493,6 +551,16 @@ func splitActionIDs(args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []grap
 			}
 			toUpdateActions = append(toUpdateActions, a)
 			delete(aMap, *a.SlackWebhook.Id)
+		case a.TemplatedWebhook != nil:
+			if a.TemplatedWebhook.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{TemplatedWebhook: a.TemplatedWebhook.Update})
+				continue
+			}
+			if _, ok := aMap[*a.TemplatedWebhook.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.TemplatedWebhook.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.TemplatedWebhook.Id)
 		}
 	}
 
@@ -577,8 +645,10 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 				return nil, err
 			}
 			err = r.updateSlackWebhookAction(ctx, *action.SlackWebhook)
+		case action.TemplatedWebhook != nil:
+			err = r.updateTemplatedWebhookAction(ctx, *action.TemplatedWebhook)
 		default:
-			err = errors.New("action must be one of email, webhook, or slack webhook")
+			err = errors.New("action must be one of email, webhook, slack webhook, or templated webhook")
 		}
 		if err != nil {
 			return nil, err
@@ -634,6 +704,55 @@ func (r *Resolver) updateSlackWebhookAction(ctx context.Context, args graphqlbac
 	return err
 }
 
+func (r *Resolver) updateTemplatedWebhookAction(ctx context.Context, args graphqlbackend.EditActionTemplatedWebhookArgs) error {
+	// The preset of an action can't be changed, since each preset is stored in
+	// its own table.
+	preset, ok := templatedWebhookPresetForKind(relay.UnmarshalKind(*args.Id))
+	if !ok || string(preset) != args.Update.Preset {
+		return errors.Errorf("expected graphql ID of a %s templated webhook action", args.Update.Preset)
+	}
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	_, actionArgs := templatedWebhookActionArgs(args.Update)
+	if err := background.ValidateTemplatedWebhookAction(preset, actionArgs); err != nil {
+		return err
+	}
+	_, err = r.db.CodeMonitors().UpdateTemplatedWebhookAction(ctx, preset, id, actionArgs)
+	return err
+}
+
+// templatedWebhookActionArgs converts the GraphQL arguments of a templated
+// webhook action into the arguments of the store.
+func templatedWebhookActionArgs(args *graphqlbackend.CreateActionTemplatedWebhookArgs) (edb.TemplatedWebhookPreset, *edb.TemplatedWebhookActionArgs) {
+	stringOrEmpty := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+
+	headers := make(map[string]string)
+	if args.Headers != nil {
+		for _, h := range *args.Headers {
+			headers[h.Name] = h.Value
+		}
+	}
+
+	return edb.TemplatedWebhookPreset(args.Preset), &edb.TemplatedWebhookActionArgs{
+		Enabled:        args.Enabled,
+		IncludeResults: args.IncludeResults,
+		URL:            stringOrEmpty(args.URL),
+		RoutingKey:     stringOrEmpty(args.RoutingKey),
+		BodyTemplate:   stringOrEmpty(args.BodyTemplate),
+		ContentType:    stringOrEmpty(args.ContentType),
+		Headers:        headers,
+	}
+}
+
 func (r *Resolver) transact(ctx context.Context) (*Resolver, error) {
 	tx, err := r.db.Transact(ctx)
 	if err != nil {
@@ -727,6 +846,24 @@ const (
 	monitorActionEmailRecipientKind    = "CodeMonitorActionEmailRecipient"
 )
 
+// monitorActionTemplatedWebhookKinds are the kinds of the IDs of templated
+// webhook actions. Each preset has its own kind because each preset is stored
+// in its own table.
+var monitorActionTemplatedWebhookKinds = map[edb.TemplatedWebhookPreset]string{
+	edb.TemplatedWebhookPresetTeams:      "CodeMonitorActionTeamsWebhook",
+	edb.TemplatedWebhookPresetMattermost: "CodeMonitorActionMattermostWebhook",
+	edb.TemplatedWebhookPresetPagerDuty:  "CodeMonitorActionPagerDutyWebhook",
+}
+
+func templatedWebhookPresetForKind(kind string) (edb.TemplatedWebhookPreset, bool) {
+	for preset, k := range monitorActionTemplatedWebhookKinds {
+		if k == kind {
+			return preset, true
+		}
+	}
+	return "", false
+}
+
 func unmarshalMonitorID(id graphql.ID) (int64, error) {
 	if kind := relay.UnmarshalKind(id); kind != MonitorKind {
 		return 0, errors.Errorf("expected graphql ID kind %s, got %s", MonitorKind, kind)
@@ -818,7 +955,16 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 		return nil, err
 	}
 
-	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws))
+	var tws []*edb.TemplatedWebhookAction
+	for _, preset := range edb.TemplatedWebhookPresets {
+		as, err := r.db.CodeMonitors().ListTemplatedWebhookActions(ctx, preset, opts)
+		if err != nil {
+			return nil, err
+		}
+		tws = append(tws, as...)
+	}
+
+	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws)+len(tws))
 	for _, e := range es {
 		actions = append(actions, &action{
 			email: &monitorEmail{
@@ -846,6 +992,15 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 			},
 		})
 	}
+	for _, tw := range tws {
+		actions = append(actions, &action{
+			templatedWebhook: &monitorTemplatedWebhook{
+				Resolver:               r,
+				TemplatedWebhookAction: tw,
+				triggerEventID:         triggerEventID,
+			},
+		})
+	}
 
 	totalCount := len(actions)
 	if args.After != nil {
@@ -982,6 +1137,7 @@ func (m *monitorTriggerEvent) ResultCount() int32 {
 	for _, cm := range m.TriggerJob.SearchResults {
 		count += cm.ResultCount()
 	}
+	count += len(m.TriggerJob.FileResults)
 	return int32(count)
 }
 
@@ -1030,9 +1186,10 @@ func (a *monitorActionConnection) PageInfo() *graphqlutil.PageInfo {
 // Action <<UNION>>
 //
 type action struct {
-	email        graphqlbackend.MonitorEmailResolver
-	webhook      graphqlbackend.MonitorWebhookResolver
-	slackWebhook graphqlbackend.MonitorSlackWebhookResolver
+	email            graphqlbackend.MonitorEmailResolver
+	webhook          graphqlbackend.MonitorWebhookResolver
+	slackWebhook     graphqlbackend.MonitorSlackWebhookResolver
+	templatedWebhook graphqlbackend.MonitorTemplatedWebhookResolver
 }
 
 func (a *action) ID() graphql.ID {
@@ -1043,6 +1200,8 @@ func (a *action) ID() graphql.ID {
 		return a.webhook.ID()
 	case a.slackWebhook != nil:
 		return a.slackWebhook.ID()
+	case a.templatedWebhook != nil:
+		return a.templatedWebhook.ID()
 	default:
 		panic("action must have a type")
 	}
@@ -1060,6 +1219,10 @@ func (a *action) ToMonitorSlackWebhook() (graphqlbackend.MonitorSlackWebhookReso
 	return a.slackWebhook, a.slackWebhook != nil
 }
 
+func (a *action) ToMonitorTemplatedWebhook() (graphqlbackend.MonitorTemplatedWebhookResolver, bool) {
+	return a.templatedWebhook, a.templatedWebhook != nil
+}
+
 //
 // Email
 //
@@ -1277,6 +1440,102 @@ func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.L
 	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
 }
 
+type monitorTemplatedWebhook struct {
+	*Resolver
+	*edb.TemplatedWebhookAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorTemplatedWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionTemplatedWebhookKinds[m.TemplatedWebhookAction.Preset], m.TemplatedWebhookAction.ID)
+}
+
+func (m *monitorTemplatedWebhook) Preset() string {
+	return string(m.TemplatedWebhookAction.Preset)
+}
+
+func (m *monitorTemplatedWebhook) Enabled() bool {
+	return m.TemplatedWebhookAction.Enabled
+}
+
+func (m *monitorTemplatedWebhook) IncludeResults() bool {
+	return m.TemplatedWebhookAction.IncludeResults
+}
+
+func (m *monitorTemplatedWebhook) URL() string {
+	return m.TemplatedWebhookAction.URL
+}
+
+func (m *monitorTemplatedWebhook) RoutingKey() string {
+	return m.TemplatedWebhookAction.RoutingKey
+}
+
+func (m *monitorTemplatedWebhook) BodyTemplate() string {
+	return m.TemplatedWebhookAction.BodyTemplate
+}
+
+func (m *monitorTemplatedWebhook) ContentType() string {
+	return m.TemplatedWebhookAction.ContentType
+}
+
+func (m *monitorTemplatedWebhook) Headers() []graphqlbackend.MonitorWebhookHeaderResolver {
+	names := make([]string, 0, len(m.TemplatedWebhookAction.Headers))
+	for name := range m.TemplatedWebhookAction.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	headers := make([]graphqlbackend.MonitorWebhookHeaderResolver, 0, len(names))
+	for _, name := range names {
+		headers = append(headers, &monitorWebhookHeader{name: name, value: m.TemplatedWebhookAction.Headers[name]})
+	}
+	return headers
+}
+
+func (m *monitorTemplatedWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, edb.ListActionJobsOpts{
+		TemplatedWebhookID:     intPtr(int(m.TemplatedWebhookAction.ID)),
+		TemplatedWebhookPreset: m.TemplatedWebhookAction.Preset,
+		TriggerEventID:         m.triggerEventID,
+		First:                  intPtr(int(args.First)),
+		After:                  after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, edb.ListActionJobsOpts{
+		TemplatedWebhookID:     intPtr(int(m.TemplatedWebhookAction.ID)),
+		TemplatedWebhookPreset: m.TemplatedWebhookAction.Preset,
+		TriggerEventID:         m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
+type monitorWebhookHeader struct {
+	name  string
+	value string
+}
+
+func (h *monitorWebhookHeader) Name() string  { return h.name }
+func (h *monitorWebhookHeader) Value() string { return h.value }
+
 func intPtr(i int) *int { return &i }
 func intPtrToInt64Ptr(i *int) *int64 {
 	if i == nil {
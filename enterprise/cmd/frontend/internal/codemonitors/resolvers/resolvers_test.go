@@ -369,7 +369,7 @@ func TestQueryMonitor(t *testing.T) {
 		// To have a consistent state we have to log the number of search results for
 		// each completed trigger job.
 		func() error {
-			return r.db.CodeMonitors().UpdateTriggerJobWithResults(ctx, 1, "", make([]*result.CommitMatch, 1))
+			return r.db.CodeMonitors().UpdateTriggerJobWithResults(ctx, 1, "", make([]*result.CommitMatch, 1), nil)
 		},
 	})
 	_, err = r.insertTestMonitorWithOpts(ctx, t, actionOpt, postHookOpt)
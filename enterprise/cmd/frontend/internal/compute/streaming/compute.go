@@ -2,6 +2,7 @@ package streaming
 
 import (
 	"context"
+	"time"
 
 	"github.com/sourcegraph/log"
 
@@ -106,7 +107,17 @@ func NewComputeStream(ctx context.Context, logger log.Logger, db database.DB, qu
 		final <- finalResult{alert: alert, err: err}
 	}()
 
-	return eventsC, func() (*search.Alert, error) {
+	var events <-chan Event = eventsC
+	switch cmd := computeQuery.Command.(type) {
+	case *compute.Count:
+		events = aggregateEvents(ctx, events, compute.NewAggregator(cmd), aggregationInterval)
+	case *compute.Replace:
+		if cmd.Diff {
+			events = bundlePatches(ctx, events)
+		}
+	}
+
+	return events, func() (*search.Alert, error) {
 		computeErr := <-errorC
 		if computeErr != nil {
 			return nil, computeErr
@@ -115,3 +126,94 @@ func NewComputeStream(ctx context.Context, logger log.Logger, db database.DB, qu
 		return f.alert, f.err
 	}
 }
+
+// aggregationInterval is how often partial aggregates are emitted while the
+// search of an aggregation command is running.
+const aggregationInterval = 200 * time.Millisecond
+
+// aggregateEvents folds the per-result tables of an aggregation command into a
+// single table. The current aggregate is emitted at most once per interval
+// while the source is open, so that clients can render partial aggregates,
+// and a final aggregate is emitted once the source is exhausted. Every table
+// emitted replaces the previous one. Once ctx is done, events are dropped
+// rather than sent, but the source is still drained until it is closed.
+func aggregateEvents(ctx context.Context, source <-chan Event, aggregator *compute.Aggregator, interval time.Duration) <-chan Event {
+	results := make(chan Event)
+	go func() {
+		defer close(results)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		emitted := false
+		emit := func() {
+			send(ctx, results, Event{Results: []compute.Result{aggregator.Table()}})
+			emitted = true
+		}
+
+		for {
+			select {
+			case event, ok := <-source:
+				if !ok {
+					if aggregator.Dirty() || !emitted {
+						emit()
+					}
+					return
+				}
+				for _, result := range event.Results {
+					if table, ok := result.(*compute.Table); ok {
+						aggregator.Add(table)
+					}
+				}
+				if !event.Stats.Zero() {
+					send(ctx, results, Event{Stats: event.Stats})
+				}
+			case <-ticker.C:
+				if aggregator.Dirty() {
+					emit()
+				}
+			}
+		}
+	}()
+	return results
+}
+
+// bundlePatches forwards the events of a replace command that emits file
+// diffs, and emits the diffs bundled into one patch per repository once the
+// source is exhausted. Like aggregateEvents, it drops events once ctx is done.
+func bundlePatches(ctx context.Context, source <-chan Event) <-chan Event {
+	results := make(chan Event)
+	go func() {
+		defer close(results)
+
+		var diffs []*compute.FileDiff
+		for event := range source {
+			for _, result := range event.Results {
+				if diff, ok := result.(*compute.FileDiff); ok {
+					diffs = append(diffs, diff)
+				}
+			}
+			send(ctx, results, event)
+		}
+
+		if len(diffs) == 0 {
+			return
+		}
+		patches := compute.NewPatches(diffs)
+		bundle := make([]compute.Result, 0, len(patches))
+		for _, patch := range patches {
+			bundle = append(bundle, patch)
+		}
+		send(ctx, results, Event{Results: bundle})
+	}()
+	return results
+}
+
+// send sends event on results, unless ctx is done first. The consumer of
+// results may have stopped reading once ctx is done.
+func send(ctx context.Context, results chan<- Event, event Event) {
+	select {
+	case results <- event:
+	case <-ctx.Done():
+	}
+}
@@ -2,17 +2,23 @@ package resolvers
 
 import (
 	"context"
+	"net/url"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
 
+	"github.com/sourcegraph/log"
+
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks/backend"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func NewResolver(db database.DB) graphqlbackend.NotebooksResolver {
@@ -486,6 +492,26 @@ func (r *notebookResolver) ViewerHasStarred(ctx context.Context) (bool, error) {
 	return star != nil, nil
 }
 
+func (r *notebookResolver) Export(ctx context.Context, args graphqlbackend.NotebookExportArgs) (string, error) {
+	format := notebooks.ExportFormat(args.Format)
+	if format != notebooks.ExportFormatMarkdown && format != notebooks.ExportFormatHTML {
+		return "", errors.Errorf("unsupported notebook export format: %s", args.Format)
+	}
+
+	externalURL, err := url.Parse(conf.ExternalURL())
+	if err != nil {
+		return "", err
+	}
+
+	settings := func(ctx context.Context) (*schema.Settings, error) {
+		return graphqlbackend.DecodedViewerFinalSettings(ctx, r.db)
+	}
+	blockBackend := backend.New(log.Scoped("notebookExport", "executes notebooks to export them"), r.db, settings)
+
+	executed := notebooks.NewExecutor(blockBackend).Execute(ctx, r.notebook)
+	return notebooks.Export(executed, format, externalURL)
+}
+
 type notebookBlockResolver struct {
 	block notebooks.NotebookBlock
 }
@@ -0,0 +1,273 @@
+package resolvers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func marshalNotebookSnapshotCursor(cursor int64) string {
+	return string(relay.MarshalID("NotebookSnapshotCursor", cursor))
+}
+
+func unmarshalNotebookSnapshotCursor(cursor *string) (int64, error) {
+	if cursor == nil {
+		return 0, nil
+	}
+	var after int64
+	err := relay.UnmarshalSpec(graphql.ID(*cursor), &after)
+	if err != nil {
+		return -1, err
+	}
+	return after, nil
+}
+
+type notebookSnapshotScheduleResolver struct {
+	schedule *notebooks.NotebookSnapshotSchedule
+	db       database.DB
+}
+
+func (r *notebookSnapshotScheduleResolver) Interval() string {
+	return string(r.schedule.Interval)
+}
+
+func (r *notebookSnapshotScheduleResolver) User(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.db, r.schedule.UserID)
+	if err != nil {
+		// Handle soft-deleted users
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *notebookSnapshotScheduleResolver) NextRunAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.schedule.NextRunAt}
+}
+
+func (r *notebookSnapshotScheduleResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.schedule.CreatedAt}
+}
+
+func (r *notebookSnapshotScheduleResolver) UpdatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.schedule.UpdatedAt}
+}
+
+type notebookSnapshotConnectionResolver struct {
+	afterCursor int64
+	snapshots   []graphqlbackend.NotebookSnapshotResolver
+	totalCount  int32
+	hasNextPage bool
+}
+
+func (n *notebookSnapshotConnectionResolver) Nodes() []graphqlbackend.NotebookSnapshotResolver {
+	return n.snapshots
+}
+
+func (n *notebookSnapshotConnectionResolver) TotalCount() int32 {
+	return n.totalCount
+}
+
+func (n *notebookSnapshotConnectionResolver) PageInfo() *graphqlutil.PageInfo {
+	if len(n.snapshots) == 0 || !n.hasNextPage {
+		return graphqlutil.HasNextPage(false)
+	}
+	// The after value (offset) for the next page is computed from the current after value + the number of retrieved snapshots
+	return graphqlutil.NextPageCursor(marshalNotebookSnapshotCursor(n.afterCursor + int64(len(n.snapshots))))
+}
+
+type notebookSnapshotResolver struct {
+	snapshot *notebooks.NotebookSnapshot
+	statuses map[string]notebooks.NotebookBlockSnapshotStatus
+}
+
+func newNotebookSnapshotResolver(snapshot, previous *notebooks.NotebookSnapshot) *notebookSnapshotResolver {
+	return &notebookSnapshotResolver{
+		snapshot: snapshot,
+		statuses: notebooks.DiffNotebookSnapshots(previous, snapshot),
+	}
+}
+
+func (r *notebookSnapshotResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.snapshot.CreatedAt}
+}
+
+func (r *notebookSnapshotResolver) Blocks() []graphqlbackend.NotebookBlockSnapshotResolver {
+	blockResolvers := make([]graphqlbackend.NotebookBlockSnapshotResolver, 0, len(r.snapshot.Blocks))
+	for _, block := range r.snapshot.Blocks {
+		blockResolvers = append(blockResolvers, &notebookBlockSnapshotResolver{block, r.statuses[block.ID]})
+	}
+	return blockResolvers
+}
+
+func (r *notebookSnapshotResolver) ChangedBlockIDs() []string {
+	changed := []string{}
+	for id, status := range r.statuses {
+		if status != notebooks.NotebookBlockSnapshotUnchanged {
+			changed = append(changed, id)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+type notebookBlockSnapshotResolver struct {
+	block  notebooks.NotebookBlockSnapshot
+	status notebooks.NotebookBlockSnapshotStatus
+}
+
+func (r *notebookBlockSnapshotResolver) BlockID() string {
+	return r.block.ID
+}
+
+func (r *notebookBlockSnapshotResolver) Status() string {
+	return string(r.status)
+}
+
+func (r *notebookBlockSnapshotResolver) ResultCount() int32 {
+	return int32(len(r.block.Matches))
+}
+
+func (r *notebookBlockSnapshotResolver) LimitHit() bool {
+	return r.block.LimitHit
+}
+
+func (r *notebookBlockSnapshotResolver) Error() *string {
+	if r.block.Error == "" {
+		return nil
+	}
+	return &r.block.Error
+}
+
+func (r *notebookBlockSnapshotResolver) ResultLabels() []string {
+	labels := make([]string, 0, len(r.block.Matches))
+	for _, match := range r.block.Matches {
+		labels = append(labels, match.Label)
+	}
+	return labels
+}
+
+func (r *notebookResolver) SnapshotSchedule(ctx context.Context) (graphqlbackend.NotebookSnapshotScheduleResolver, error) {
+	schedule, err := notebooks.Notebooks(r.db).GetNotebookSnapshotSchedule(ctx, r.notebook.ID)
+	if errors.Is(err, notebooks.ErrNotebookSnapshotScheduleNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &notebookSnapshotScheduleResolver{schedule, r.db}, nil
+}
+
+func (r *notebookResolver) Snapshots(ctx context.Context, args graphqlbackend.ListNotebookSnapshotsArgs) (graphqlbackend.NotebookSnapshotConnectionResolver, error) {
+	afterCursor, err := unmarshalNotebookSnapshotCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Snapshots are recorded as the user that scheduled them and
+	// can contain results from repositories other viewers of the notebook
+	// cannot access, so viewers only see the snapshots recorded as them.
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return &notebookSnapshotConnectionResolver{afterCursor: afterCursor, snapshots: []graphqlbackend.NotebookSnapshotResolver{}}, nil
+	}
+
+	// Request one extra to determine if there are more pages, and the
+	// snapshot after that to compare the last snapshot of the page to.
+	pageOpts := notebooks.ListNotebookSnapshotsPageOptions{First: args.First + 2, After: afterCursor}
+	store := notebooks.Notebooks(r.db)
+	snapshots, err := store.ListNotebookSnapshots(ctx, pageOpts, r.notebook.ID, a.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := store.CountNotebookSnapshots(ctx, r.notebook.ID, a.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNextPage := len(snapshots) > int(args.First)
+	resolvers := make([]graphqlbackend.NotebookSnapshotResolver, 0, args.First)
+	for i := 0; i < len(snapshots) && i < int(args.First); i++ {
+		var previous *notebooks.NotebookSnapshot
+		if i+1 < len(snapshots) {
+			previous = snapshots[i+1]
+		}
+		resolvers = append(resolvers, newNotebookSnapshotResolver(snapshots[i], previous))
+	}
+
+	return &notebookSnapshotConnectionResolver{
+		afterCursor: afterCursor,
+		snapshots:   resolvers,
+		totalCount:  int32(count),
+		hasNextPage: hasNextPage,
+	}, nil
+}
+
+// getWritableNotebook returns the notebook with the given ID if the current
+// user has permission to update it.
+func (r *Resolver) getWritableNotebook(ctx context.Context, id graphql.ID) (*notebooks.Notebook, int32, error) {
+	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	notebookID, err := unmarshalNotebookID(id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Ensure user has access to the notebook.
+	notebook, err := notebooks.Notebooks(r.db).GetNotebook(ctx, notebookID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = validateNotebookWritePermissionsForUser(ctx, r.db, notebook, user.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return notebook, user.ID, nil
+}
+
+func (r *Resolver) SetNotebookSnapshotSchedule(ctx context.Context, args graphqlbackend.SetNotebookSnapshotScheduleArgs) (graphqlbackend.NotebookSnapshotScheduleResolver, error) {
+	interval := notebooks.NotebookSnapshotInterval(args.Interval)
+	if interval != notebooks.NotebookSnapshotIntervalDaily && interval != notebooks.NotebookSnapshotIntervalWeekly {
+		return nil, errors.Errorf("invalid notebook snapshot interval: %s", args.Interval)
+	}
+
+	notebook, userID, err := r.getWritableNotebook(ctx, args.NotebookID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := notebooks.Notebooks(r.db).UpsertNotebookSnapshotSchedule(ctx, notebook.ID, userID, interval)
+	if err != nil {
+		return nil, err
+	}
+	return &notebookSnapshotScheduleResolver{schedule, r.db}, nil
+}
+
+func (r *Resolver) DeleteNotebookSnapshotSchedule(ctx context.Context, args graphqlbackend.DeleteNotebookSnapshotScheduleArgs) (*graphqlbackend.EmptyResponse, error) {
+	notebook, _, err := r.getWritableNotebook(ctx, args.NotebookID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = notebooks.Notebooks(r.db).DeleteNotebookSnapshotSchedule(ctx, notebook.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
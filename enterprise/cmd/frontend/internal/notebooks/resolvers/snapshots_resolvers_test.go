@@ -0,0 +1,265 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/resolvers/apitest"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+const setNotebookSnapshotScheduleMutation = `
+mutation SetNotebookSnapshotSchedule($notebookID: ID!, $interval: NotebookSnapshotInterval!) {
+	setNotebookSnapshotSchedule(notebookID: $notebookID, interval: $interval) {
+		interval
+		user {
+			username
+		}
+	}
+}
+`
+
+const deleteNotebookSnapshotScheduleMutation = `
+mutation DeleteNotebookSnapshotSchedule($notebookID: ID!) {
+	deleteNotebookSnapshotSchedule(notebookID: $notebookID) {
+		alwaysNil
+	}
+}
+`
+
+const notebookSnapshotScheduleQuery = `
+query NotebookSnapshotSchedule($id: ID!) {
+	node(id: $id) {
+		... on Notebook {
+			snapshotSchedule {
+				interval
+			}
+		}
+	}
+}
+`
+
+const listNotebookSnapshotsQuery = `
+query NotebookSnapshots($id: ID!, $first: Int!, $after: String) {
+	node(id: $id) {
+		... on Notebook {
+			snapshots(first: $first, after: $after) {
+				nodes {
+					changedBlockIDs
+					blocks {
+						blockID
+						status
+						resultCount
+						resultLabels
+					}
+				}
+				pageInfo {
+					endCursor
+					hasNextPage
+				}
+				totalCount
+			}
+		}
+	}
+}
+`
+
+type notebookSnapshotSchedule struct {
+	Interval string
+	User     struct{ Username string }
+}
+
+type notebookBlockSnapshot struct {
+	BlockID      string
+	Status       string
+	ResultCount  int32
+	ResultLabels []string
+}
+
+type notebookSnapshot struct {
+	ChangedBlockIDs []string
+	Blocks          []notebookBlockSnapshot
+}
+
+func TestSetAndDeleteNotebookSnapshotSchedule(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	internalCtx := actor.WithInternalActor(context.Background())
+	u := db.Users()
+
+	user1, err := u.Create(internalCtx, database.NewUser{Username: "u1", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	user2, err := u.Create(internalCtx, database.NewUser{Username: "u2", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	createdNotebooks := createNotebooks(t, db, []*notebooks.Notebook{userNotebookFixture(user1.ID, true)})
+	notebookID := marshalNotebookID(createdNotebooks[0].ID)
+
+	schema, err := graphqlbackend.NewSchema(db, nil, nil, nil, nil, nil, nil, nil, nil, nil, NewResolver(db), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// user2 cannot schedule snapshots of user1's notebook, since user2 cannot update it
+	input := map[string]any{"notebookID": notebookID, "interval": "DAILY"}
+	var response struct{ SetNotebookSnapshotSchedule notebookSnapshotSchedule }
+	apiError := apitest.Exec(actor.WithActor(context.Background(), actor.FromUser(user2.ID)), t, schema, input, &response, setNotebookSnapshotScheduleMutation)
+	if apiError == nil {
+		t.Fatalf("expected error when scheduling snapshots of a notebook without write access, got nil")
+	}
+
+	apitest.MustExec(actor.WithActor(context.Background(), actor.FromUser(user1.ID)), t, schema, input, &response, setNotebookSnapshotScheduleMutation)
+	want := notebookSnapshotSchedule{Interval: "DAILY", User: struct{ Username string }{Username: "u1"}}
+	if diff := cmp.Diff(want, response.SetNotebookSnapshotSchedule); diff != "" {
+		t.Fatalf("wrong notebook snapshot schedule (-want +got):\n%s", diff)
+	}
+
+	// Changing the interval updates the existing schedule
+	input["interval"] = "WEEKLY"
+	apitest.MustExec(actor.WithActor(context.Background(), actor.FromUser(user1.ID)), t, schema, input, &response, setNotebookSnapshotScheduleMutation)
+	if response.SetNotebookSnapshotSchedule.Interval != "WEEKLY" {
+		t.Fatalf("expected interval WEEKLY, got %s", response.SetNotebookSnapshotSchedule.Interval)
+	}
+
+	var deleteResponse struct{}
+	apitest.MustExec(actor.WithActor(context.Background(), actor.FromUser(user1.ID)), t, schema, map[string]any{"notebookID": notebookID}, &deleteResponse, deleteNotebookSnapshotScheduleMutation)
+
+	var queryResponse struct {
+		Node struct {
+			SnapshotSchedule *notebookSnapshotSchedule
+		}
+	}
+	apitest.MustExec(actor.WithActor(context.Background(), actor.FromUser(user1.ID)), t, schema, map[string]any{"id": notebookID}, &queryResponse, notebookSnapshotScheduleQuery)
+	if queryResponse.Node.SnapshotSchedule != nil {
+		t.Fatalf("expected no notebook snapshot schedule, got %+v", queryResponse.Node.SnapshotSchedule)
+	}
+}
+
+func TestListNotebookSnapshots(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	internalCtx := actor.WithInternalActor(context.Background())
+	u := db.Users()
+
+	user, err := u.Create(internalCtx, database.NewUser{Username: "u", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	otherUser, err := u.Create(internalCtx, database.NewUser{Username: "other", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	createdNotebooks := createNotebooks(t, db, []*notebooks.Notebook{userNotebookFixture(user.ID, true)})
+	notebookID := marshalNotebookID(createdNotebooks[0].ID)
+
+	store := notebooks.Notebooks(db)
+	createdAt := time.Now().Add(-time.Hour)
+	for _, labels := range [][]string{{"a"}, {"a"}, {"a", "b"}} {
+		matches := make([]notebooks.BlockMatch, 0, len(labels))
+		for _, label := range labels {
+			matches = append(matches, notebooks.BlockMatch{Label: label})
+		}
+		_, err := store.CreateNotebookSnapshot(internalCtx, &notebooks.NotebookSnapshot{
+			NotebookID: createdNotebooks[0].ID,
+			UserID:     user.ID,
+			Blocks:     notebooks.NotebookBlockSnapshots{{ID: "1", Type: notebooks.NotebookQueryBlockType, Matches: matches}},
+			CreatedAt:  createdAt,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		createdAt = createdAt.Add(time.Minute)
+	}
+
+	schema, err := graphqlbackend.NewSchema(db, nil, nil, nil, nil, nil, nil, nil, nil, nil, NewResolver(db), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		userID      int32
+		first       int
+		after       string
+		wantCount   int32
+		wantNext    bool
+		wantResults []notebookSnapshot
+	}{
+		{
+			name:      "first page",
+			userID:    user.ID,
+			first:     2,
+			wantCount: 3,
+			wantNext:  true,
+			wantResults: []notebookSnapshot{
+				{ChangedBlockIDs: []string{"1"}, Blocks: []notebookBlockSnapshot{{BlockID: "1", Status: "CHANGED", ResultCount: 2, ResultLabels: []string{"a", "b"}}}},
+				{ChangedBlockIDs: []string{}, Blocks: []notebookBlockSnapshot{{BlockID: "1", Status: "UNCHANGED", ResultCount: 1, ResultLabels: []string{"a"}}}},
+			},
+		},
+		{
+			name:      "second page",
+			userID:    user.ID,
+			first:     2,
+			after:     marshalNotebookSnapshotCursor(2),
+			wantCount: 3,
+			wantNext:  false,
+			wantResults: []notebookSnapshot{
+				{ChangedBlockIDs: []string{"1"}, Blocks: []notebookBlockSnapshot{{BlockID: "1", Status: "ADDED", ResultCount: 1, ResultLabels: []string{"a"}}}},
+			},
+		},
+		{
+			// The notebook is public, but the snapshots were recorded as user
+			name:        "other viewer",
+			userID:      otherUser.ID,
+			first:       2,
+			wantCount:   0,
+			wantNext:    false,
+			wantResults: []notebookSnapshot{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := map[string]any{"id": notebookID, "first": tt.first}
+			if tt.after != "" {
+				input["after"] = tt.after
+			}
+			var response struct {
+				Node struct {
+					Snapshots struct {
+						Nodes      []notebookSnapshot
+						TotalCount int32
+						PageInfo   apitest.PageInfo
+					}
+				}
+			}
+			apitest.MustExec(actor.WithActor(context.Background(), actor.FromUser(tt.userID)), t, schema, input, &response, listNotebookSnapshotsQuery)
+
+			if diff := cmp.Diff(tt.wantResults, response.Node.Snapshots.Nodes); diff != "" {
+				t.Fatalf("wrong notebook snapshots (-want +got):\n%s", diff)
+			}
+			if response.Node.Snapshots.TotalCount != tt.wantCount {
+				t.Fatalf("expected %d total snapshots, got %d", tt.wantCount, response.Node.Snapshots.TotalCount)
+			}
+			if response.Node.Snapshots.PageInfo.HasNextPage != tt.wantNext {
+				t.Fatalf("expected hasNextPage %t, got %t", tt.wantNext, response.Node.Snapshots.PageInfo.HasNextPage)
+			}
+		})
+	}
+}
@@ -0,0 +1,302 @@
+// Package httpapi implements the HTTP API of search exports under
+// /.api/search/exports:
+//
+//	GET  /.api/search/exports                  lists the exports of the current user
+//	POST /.api/search/exports                  queues an export
+//	GET  /.api/search/exports/{id}             returns the progress and manifest of an export
+//	GET  /.api/search/exports/{id}/download    downloads the file of an export, given a signed URL
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchexports"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/run"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// downloadURLTTL is how long the download URLs returned by the API are valid.
+const downloadURLTTL = time.Hour
+
+// planFunc parses the query of an export, to reject invalid queries before
+// they are queued.
+type planFunc func(ctx context.Context, job *searchexports.ExportJob) (*run.SearchInputs, error)
+
+type handler struct {
+	logger      log.Logger
+	db          database.DB
+	store       searchexports.ExportsStore
+	uploadStore uploadstore.Store
+	plan        planFunc
+	now         func() time.Time
+}
+
+func NewHandler(logger log.Logger, db database.DB, uploadStore uploadstore.Store, exporter *searchexports.Exporter) http.Handler {
+	return newHandler(logger, db, searchexports.Exports(db), uploadStore, exporter.Plan)
+}
+
+func newHandler(logger log.Logger, db database.DB, store searchexports.ExportsStore, uploadStore uploadstore.Store, plan planFunc) http.Handler {
+	h := &handler{
+		logger:      logger.Scoped("searchExportsHandler", "serves the search exports API"),
+		db:          db,
+		store:       store,
+		uploadStore: uploadStore,
+		plan:        plan,
+		now:         time.Now,
+	}
+
+	r := mux.NewRouter()
+	r.Path("/.api/search/exports").Methods("GET").HandlerFunc(h.serveList)
+	r.Path("/.api/search/exports").Methods("POST").HandlerFunc(h.serveCreate)
+	r.Path("/.api/search/exports/{id:[0-9]+}").Methods("GET").HandlerFunc(h.serveGet)
+	r.Path("/.api/search/exports/{id:[0-9]+}/download").Methods("GET").HandlerFunc(h.serveDownload)
+	return r
+}
+
+// exportResponse is the JSON representation of an export.
+type exportResponse struct {
+	ID             int32                  `json:"id"`
+	Query          string                 `json:"query"`
+	PatternType    string                 `json:"patternType"`
+	Format         searchexports.Format   `json:"format"`
+	State          string                 `json:"state"`
+	FailureMessage *string                `json:"failureMessage,omitempty"`
+	CreatedAt      time.Time              `json:"createdAt"`
+	FinishedAt     *time.Time             `json:"finishedAt,omitempty"`
+	Manifest       searchexports.Manifest `json:"manifest"`
+
+	// DownloadURL is set once the export completed. It is valid for
+	// downloadURLTTL.
+	DownloadURL string `json:"downloadURL,omitempty"`
+}
+
+func (h *handler) toResponse(job *searchexports.ExportJob) exportResponse {
+	resp := exportResponse{
+		ID:             job.ID,
+		Query:          job.Query,
+		PatternType:    job.PatternType,
+		Format:         job.Format,
+		State:          job.State,
+		FailureMessage: job.FailureMessage,
+		CreatedAt:      job.CreatedAt,
+		FinishedAt:     job.FinishedAt,
+		Manifest:       job.Manifest,
+	}
+	if job.ObjectKey != "" {
+		resp.DownloadURL = searchexports.DownloadURL(conf.ExternalURL(), job, h.now().Add(downloadURLTTL))
+	}
+	return resp
+}
+
+func (h *handler) serveList(w http.ResponseWriter, r *http.Request) {
+	a := actor.FromContext(r.Context())
+	if !a.IsAuthenticated() {
+		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	jobs, err := h.store.ListExportJobs(r.Context(), a.UID)
+	if err != nil {
+		h.serveError(w, err)
+		return
+	}
+
+	resp := make([]exportResponse, 0, len(jobs))
+	for _, job := range jobs {
+		resp = append(resp, h.toResponse(job))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+type createRequest struct {
+	Query       string `json:"query"`
+	PatternType string `json:"patternType"`
+	Format      string `json:"format"`
+}
+
+func (h *handler) serveCreate(w http.ResponseWriter, r *http.Request) {
+	a := actor.FromContext(r.Context())
+	if !a.IsAuthenticated() {
+		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	var req createRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	format, err := searchexports.ParseFormat(req.Format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.PatternType == "" {
+		req.PatternType = "standard"
+	}
+
+	// 🚨 SECURITY: exports are always run as the user who requested them.
+	job := &searchexports.ExportJob{
+		UserID:      a.UID,
+		Query:       req.Query,
+		PatternType: req.PatternType,
+		Format:      format,
+	}
+	if _, err := h.plan(r.Context(), job); err != nil {
+		var queryErr *run.QueryError
+		if errors.As(err, &queryErr) {
+			http.Error(w, queryErr.Error(), http.StatusBadRequest)
+			return
+		}
+		h.serveError(w, err)
+		return
+	}
+
+	job, err = h.store.CreateExportJob(r.Context(), job)
+	if err != nil {
+		h.serveError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, h.toResponse(job))
+}
+
+func (h *handler) serveGet(w http.ResponseWriter, r *http.Request) {
+	job, ok := h.getJob(w, r)
+	if !ok {
+		return
+	}
+
+	// 🚨 SECURITY: only the user who requested an export and site admins may
+	// see it. Others are told it does not exist, to not leak its existence.
+	if err := backend.CheckSiteAdminOrSameUser(r.Context(), h.db, job.UserID); err != nil {
+		http.Error(w, searchexports.ErrExportJobNotFound.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.toResponse(job))
+}
+
+func (h *handler) serveDownload(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		http.Error(w, "invalid search export ID", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.store.GetExportJob(r.Context(), int32(id))
+	if err != nil && !errors.Is(err, searchexports.ErrExportJobNotFound) {
+		h.serveError(w, err)
+		return
+	}
+
+	// 🚨 SECURITY: downloads are authorized by the signature of the URL
+	// alone, so that they work for clients without a session. The signature
+	// is keyed per export, so a missing export is reported exactly like an
+	// invalid signature, to not let IDs of exports be probed.
+	if job == nil {
+		http.Error(w, searchexports.ErrInvalidSignature.Error(), http.StatusForbidden)
+		return
+	}
+	q := r.URL.Query()
+	if err := searchexports.VerifyDownload(job, q.Get("expires"), q.Get("signature"), h.now()); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if job.ObjectKey == "" {
+		http.Error(w, "search export has not completed", http.StatusNotFound)
+		return
+	}
+
+	size := job.Manifest.SizeBytes
+	etag := `"` + strconv.Itoa(int(job.ID)) + "-" + strconv.FormatInt(size, 10) + `"`
+
+	// Exported files never change once the export completed, so an
+	// interrupted download is resumed by requesting the missing range.
+	start, end := int64(0), size-1
+	partial := false
+	if rangeHeader := r.Header.Get("Range"); rangeHeader != "" && ifRangeMatches(r, etag) {
+		var satisfiable bool
+		start, end, satisfiable = parseRange(rangeHeader, size)
+		if !satisfiable {
+			w.Header().Set("Content-Range", "bytes */"+strconv.FormatInt(size, 10))
+			http.Error(w, "requested range not satisfiable", http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		partial = true
+	}
+
+	rc, err := h.uploadStore.Get(r.Context(), job.ObjectKey)
+	if err != nil {
+		h.serveError(w, err)
+		return
+	}
+	defer rc.Close()
+
+	// The upload store streams objects from the start, so the beginning of a
+	// resumed download is skipped here.
+	if _, err := io.CopyN(io.Discard, rc, start); err != nil {
+		h.serveError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", job.Format.ContentType())
+	w.Header().Set("Content-Disposition", `attachment; filename="`+job.FileName()+`"`)
+	w.Header().Set("Accept-Ranges", "bytes")
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Content-Length", strconv.FormatInt(end-start+1, 10))
+	if partial {
+		w.Header().Set("Content-Range", "bytes "+strconv.FormatInt(start, 10)+"-"+strconv.FormatInt(end, 10)+"/"+strconv.FormatInt(size, 10))
+		w.WriteHeader(http.StatusPartialContent)
+	}
+
+	if _, err := io.CopyN(w, rc, end-start+1); err != nil {
+		// The response has started, so the client notices the truncated
+		// body by its length.
+		h.logger.Warn("failed to stream search export", log.Int32("id", job.ID), log.Error(err))
+	}
+}
+
+// getJob returns the export of the request, or writes an error response and
+// returns false.
+func (h *handler) getJob(w http.ResponseWriter, r *http.Request) (*searchexports.ExportJob, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		http.Error(w, "invalid search export ID", http.StatusBadRequest)
+		return nil, false
+	}
+
+	job, err := h.store.GetExportJob(r.Context(), int32(id))
+	if err != nil {
+		if errors.Is(err, searchexports.ErrExportJobNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return nil, false
+		}
+		h.serveError(w, err)
+		return nil, false
+	}
+	return job, true
+}
+
+func (h *handler) serveError(w http.ResponseWriter, err error) {
+	h.logger.Error("search exports API error", log.Error(err))
+	http.Error(w, "internal error", http.StatusInternalServerError)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,185 @@
+package httpapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchexports"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+)
+
+type fakeExportsStore struct {
+	searchexports.ExportsStore
+	jobs map[int32]*searchexports.ExportJob
+}
+
+func (s *fakeExportsStore) GetExportJob(_ context.Context, id int32) (*searchexports.ExportJob, error) {
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, searchexports.ErrExportJobNotFound
+	}
+	return job, nil
+}
+
+func TestServeDownload(t *testing.T) {
+	const content = "type,repository,commit,path,line,preview,url\n"
+	now := time.Now()
+
+	completed := &searchexports.ExportJob{
+		ID:          1,
+		Format:      searchexports.FormatCSV,
+		ObjectKey:   "search-exports/1.csv",
+		DownloadKey: []byte("secret"),
+		Manifest:    searchexports.Manifest{SizeBytes: int64(len(content))},
+	}
+	running := &searchexports.ExportJob{ID: 2, Format: searchexports.FormatCSV, DownloadKey: []byte("secret")}
+	store := &fakeExportsStore{jobs: map[int32]*searchexports.ExportJob{1: completed, 2: running}}
+
+	uploadStore := mocks.NewMockStore()
+	uploadStore.GetFunc.SetDefaultHook(func(context.Context, string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(content)), nil
+	})
+
+	h := newHandler(logtest.Scoped(t), nil, store, uploadStore, nil)
+
+	downloadPath := func(job *searchexports.ExportJob, expiresAt time.Time) string {
+		u, err := url.Parse(searchexports.DownloadURL("", job, expiresAt))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return u.RequestURI()
+	}
+	valid := downloadPath(completed, now.Add(time.Hour))
+	etag := `"1-45"`
+
+	tests := []struct {
+		name        string
+		path        string
+		headers     map[string]string
+		wantStatus  int
+		wantBody    string
+		wantHeaders map[string]string
+	}{
+		{
+			name:       "full",
+			path:       valid,
+			wantStatus: http.StatusOK,
+			wantBody:   content,
+			wantHeaders: map[string]string{
+				"Content-Type":        "text/csv; charset=utf-8",
+				"Content-Disposition": `attachment; filename="search-export-1.csv"`,
+				"Content-Length":      "45",
+				"Accept-Ranges":       "bytes",
+				"ETag":                etag,
+			},
+		},
+		{
+			name:       "resumed",
+			path:       valid,
+			headers:    map[string]string{"Range": "bytes=30-", "If-Range": etag},
+			wantStatus: http.StatusPartialContent,
+			wantBody:   content[30:],
+			wantHeaders: map[string]string{
+				"Content-Length": "15",
+				"Content-Range":  "bytes 30-44/45",
+			},
+		},
+		{
+			name:       "stale range",
+			path:       valid,
+			headers:    map[string]string{"Range": "bytes=30-", "If-Range": `"1-1"`},
+			wantStatus: http.StatusOK,
+			wantBody:   content,
+		},
+		{
+			name:       "unsatisfiable range",
+			path:       valid,
+			headers:    map[string]string{"Range": "bytes=100-"},
+			wantStatus: http.StatusRequestedRangeNotSatisfiable,
+			wantHeaders: map[string]string{
+				"Content-Range": "bytes */45",
+			},
+		},
+		{
+			name:       "expired",
+			path:       downloadPath(completed, now.Add(-time.Minute)),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "bad signature",
+			path:       strings.Replace(valid, "signature=", "signature=00", 1),
+			wantStatus: http.StatusForbidden,
+			wantBody:   "invalid download URL signature\n",
+		},
+		{
+			name:       "not completed",
+			path:       downloadPath(running, now.Add(time.Hour)),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "not found",
+			path:       strings.Replace(valid, "/exports/1/", "/exports/3/", 1),
+			wantStatus: http.StatusForbidden,
+			wantBody:   "invalid download URL signature\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", test.path, nil)
+			for k, v := range test.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != test.wantStatus {
+				t.Fatalf("unexpected status, want %d, got %d: %s", test.wantStatus, rec.Code, rec.Body.String())
+			}
+			if test.wantBody != "" && rec.Body.String() != test.wantBody {
+				t.Fatalf("unexpected body, want %q, got %q", test.wantBody, rec.Body.String())
+			}
+			for k, want := range test.wantHeaders {
+				if got := rec.Header().Get(k); got != want {
+					t.Errorf("unexpected %s header, want %q, got %q", k, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		header    string
+		wantStart int64
+		wantEnd   int64
+		wantOK    bool
+	}{
+		{header: "bytes=0-", wantStart: 0, wantEnd: 99, wantOK: true},
+		{header: "bytes=10-19", wantStart: 10, wantEnd: 19, wantOK: true},
+		{header: "bytes=90-200", wantStart: 90, wantEnd: 99, wantOK: true},
+		{header: "bytes=-10", wantStart: 90, wantEnd: 99, wantOK: true},
+		{header: "bytes=-200", wantStart: 0, wantEnd: 99, wantOK: true},
+		{header: "bytes=100-"},
+		{header: "bytes=20-10"},
+		{header: "bytes=0-1,5-6"},
+		{header: "bytes=-0"},
+		{header: "items=0-1"},
+		{header: "bytes=a-b"},
+	}
+	for _, test := range tests {
+		t.Run(test.header, func(t *testing.T) {
+			start, end, ok := parseRange(test.header, 100)
+			if ok != test.wantOK || start != test.wantStart || end != test.wantEnd {
+				t.Fatalf("unexpected range, want (%d, %d, %v), got (%d, %d, %v)", test.wantStart, test.wantEnd, test.wantOK, start, end, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package searchexports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/searchexports/httpapi"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchexports"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var configInst = &searchexports.Config{}
+
+func init() {
+	configInst.Load()
+}
+
+func Init(ctx context.Context, db database.DB, _ conftypes.UnifiedWatchable, enterpriseServices *enterprise.Services, observationContext *observation.Context) error {
+	if err := configInst.Validate(); err != nil {
+		return err
+	}
+
+	uploadStore, err := searchexports.NewUploadStore(ctx, configInst, observationContext)
+	if err != nil {
+		return err
+	}
+
+	logger := log.Scoped("searchexports", "")
+	// Queries are planned with the settings of the user requesting the
+	// export, to reject invalid queries before they are queued.
+	exporter := searchexports.NewExporter(logger, db, func(ctx context.Context) (*schema.Settings, error) {
+		return graphqlbackend.DecodedViewerFinalSettings(ctx, db)
+	})
+	enterpriseServices.NewSearchExportsHandler = func() http.Handler {
+		return httpapi.NewHandler(logger, db, uploadStore, exporter)
+	}
+	return nil
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/orgrepos"
 	_ "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/registry"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/searchexports"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -62,6 +63,7 @@ var initFunctions = map[string]EnterpriseInitializer{
 	"notebooks":      notebooks.Init,
 	"compute":        compute.Init,
 	"dependencies":   dependencies.Init,
+	"searchexports":  searchexports.Init,
 }
 
 var codeIntelConfig = &codeintel.Config{}
@@ -0,0 +1,90 @@
+package correlation
+
+import (
+	"bufio"
+	"context"
+	"io"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// Correlate reads an LSIF or SCIP index from the given reader and returns its data canonicalized
+// and pruned for storage. The format of the index is detected from its first bytes.
+//
+// If getChildren == nil, no pruning of irrelevant data is performed.
+func Correlate(ctx context.Context, r io.Reader, root string, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
+	br := bufio.NewReader(r)
+
+	isSCIP, err := isSCIPIndex(br)
+	if err != nil {
+		return nil, err
+	}
+	if isSCIP {
+		return correlateSCIP(ctx, br, root, getChildren)
+	}
+
+	return conversion.Correlate(ctx, br, root, getChildren)
+}
+
+// isSCIPIndex determines whether the given reader holds a SCIP index rather than an LSIF dump.
+// An LSIF dump is newline-delimited JSON and begins with the metadata vertex, while a SCIP index
+// is a protobuf message that begins with the tag of one of the fields of the Index message. The
+// tag of the metadata field is also a newline, so the whitespace an LSIF dump may begin with is
+// skipped, and the dump is recognized by the beginning of its first JSON object instead.
+func isSCIPIndex(r *bufio.Reader) (bool, error) {
+	i, c, err := peekNonSpace(r, 0)
+	if err != nil {
+		if err == io.EOF || err == bufio.ErrBufferFull {
+			// Let the LSIF correlator report the missing metadata
+			return false, nil
+		}
+		return false, err
+	}
+	if c == '{' {
+		_, c, err := peekNonSpace(r, i+1)
+		if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+			return false, err
+		}
+		if err != nil || c == '"' || c == '}' {
+			return false, nil
+		}
+	}
+
+	b, err := r.Peek(1)
+	if err != nil {
+		return false, err
+	}
+
+	switch b[0] {
+	case scipMetadataTag, scipDocumentsTag, scipExternalSymbolsTag:
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// peekNonSpace returns the offset and value of the first byte at or after the given offset of the
+// reader that is not JSON whitespace, without consuming any input.
+func peekNonSpace(r *bufio.Reader, offset int) (int, byte, error) {
+	for i := offset; ; i++ {
+		b, err := r.Peek(i + 1)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		switch b[i] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return i, b[i], nil
+	}
+}
+
+// Tags (field number and wire type) of the length-delimited fields of the SCIP Index message.
+const (
+	scipMetadataTag        = 1<<3 | 2
+	scipDocumentsTag       = 2<<3 | 2
+	scipExternalSymbolsTag = 3<<3 | 2
+)
@@ -0,0 +1,75 @@
+package correlation
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestCorrelateLSIF(t *testing.T) {
+	f, err := os.Open("../../testdata/dump1.lsif.gz")
+	if err != nil {
+		t.Fatalf("unexpected error opening test file: %s", err)
+	}
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("unexpected error reading test file: %s", err)
+	}
+	defer r.Close()
+
+	bundle, err := Correlate(context.Background(), r, "root/", nil)
+	if err != nil {
+		t.Fatalf("unexpected error correlating dump: %s", err)
+	}
+
+	documents := precise.GroupedBundleDataChansToMaps(bundle).Documents
+	for _, path := range []string{"foo.go", "bar.go"} {
+		if _, ok := documents[path]; !ok {
+			t.Errorf("expected document %q", path)
+		}
+	}
+}
+
+func TestIsSCIPIndex(t *testing.T) {
+	scipIndex, err := proto.Marshal(testSCIPIndex("file:///repo", testSCIPDocument("a.go")))
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	for name, testCase := range map[string]struct {
+		input    []byte
+		expected bool
+	}{
+		"LSIF":                 {input: []byte(`{"id":1,"type":"vertex","label":"metaData"}` + "\n"), expected: false},
+		"LSIF with blank line": {input: []byte("\n\r\n" + `{ "id":1,"type":"vertex","label":"metaData"}` + "\n"), expected: false},
+		"SCIP":                 {input: scipIndex, expected: true},
+		"empty":                {input: nil, expected: false},
+		"whitespace":           {input: []byte("\n\n"), expected: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			isSCIP, err := isSCIPIndex(bufio.NewReader(bytes.NewReader(testCase.input)))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if isSCIP != testCase.expected {
+				t.Errorf("unexpected result. want=%v have=%v", testCase.expected, isSCIP)
+			}
+		})
+	}
+}
+
+func TestCorrelateEmpty(t *testing.T) {
+	if _, err := Correlate(context.Background(), strings.NewReader(""), "", nil); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -0,0 +1,473 @@
+package correlation
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion/datastructures"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol/reader"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// correlateSCIP reads a SCIP index from the given reader and returns its data canonicalized and
+// pruned for storage.
+//
+// Unlike converting the index to LSIF first, the index is read directly into a correlation state.
+// This keeps data that has no LSIF equivalent, such as occurrence-specific documentation and the
+// diagnostics attached to occurrences. The index is read one document at a time, so that neither
+// its encoded nor its decoded form has to be held in memory as a whole.
+func correlateSCIP(ctx context.Context, r *bufio.Reader, root string, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
+	c := newSCIPCorrelator(root)
+	if err := readSCIPIndex(r, c); err != nil {
+		return nil, err
+	}
+
+	state, err := c.finish()
+	if err != nil {
+		return nil, err
+	}
+
+	return conversion.CorrelateState(ctx, state, root, getChildren)
+}
+
+// readSCIPIndex decodes the fields of the SCIP Index message in the given reader one at a time and
+// adds them to the given correlator. Unknown fields are skipped.
+func readSCIPIndex(r *bufio.Reader, c *scipCorrelator) error {
+	var buf []byte
+	for {
+		tag, err := binary.ReadUvarint(r)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrap(err, "failed to read SCIP index")
+		}
+
+		number, wireType := protowire.DecodeTag(tag)
+		switch wireType {
+		case protowire.VarintType:
+			if _, err := binary.ReadUvarint(r); err != nil {
+				return errors.Wrap(err, "failed to read SCIP index")
+			}
+			continue
+		case protowire.Fixed32Type, protowire.Fixed64Type:
+			n := int64(4)
+			if wireType == protowire.Fixed64Type {
+				n = 8
+			}
+			if _, err := io.CopyN(io.Discard, r, n); err != nil {
+				return errors.Wrap(err, "failed to read SCIP index")
+			}
+			continue
+		case protowire.BytesType:
+		default:
+			return errors.Errorf("failed to read SCIP index: unsupported wire type %d of field %d", wireType, number)
+		}
+
+		length, err := binary.ReadUvarint(r)
+		if err != nil {
+			return errors.Wrap(err, "failed to read SCIP index")
+		}
+		if uint64(cap(buf)) < length {
+			buf = make([]byte, length)
+		}
+		buf = buf[:length]
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return errors.Wrap(err, "failed to read SCIP index")
+		}
+
+		switch number {
+		case scipMetadataField:
+			var metadata scip.Metadata
+			if err := proto.Unmarshal(buf, &metadata); err != nil {
+				return errors.Wrap(err, "failed to unmarshal SCIP index metadata")
+			}
+			c.setMetadata(&metadata)
+
+		case scipDocumentsField:
+			var document scip.Document
+			if err := proto.Unmarshal(buf, &document); err != nil {
+				return errors.Wrap(err, "failed to unmarshal SCIP document")
+			}
+			c.correlateDocument(&document)
+
+		case scipExternalSymbolsField:
+			var info scip.SymbolInformation
+			if err := proto.Unmarshal(buf, &info); err != nil {
+				return errors.Wrap(err, "failed to unmarshal SCIP external symbol")
+			}
+			c.externalSymbols = append(c.externalSymbols, &info)
+		}
+	}
+}
+
+// Field numbers of the SCIP Index message.
+const (
+	scipMetadataField        protowire.Number = 1
+	scipDocumentsField       protowire.Number = 2
+	scipExternalSymbolsField protowire.Number = 3
+)
+
+type scipCorrelator struct {
+	state       *conversion.State
+	id          int
+	dumpRoot    string
+	hasMetadata bool
+	// symbols holds the result sets of global symbols, in the order in which they were created.
+	symbols         map[string]*scipSymbol
+	symbolOrder     []string
+	defined         map[string]struct{}
+	externalSymbols []*scip.SymbolInformation
+	documentIDs     []int
+	packages        map[string]int
+	relationships   []scipRelationship
+}
+
+// scipSymbol holds the identifiers of the result set of a symbol and the results attached to it.
+type scipSymbol struct {
+	resultSetID            int
+	definitionResultID     int
+	referenceResultID      int
+	implementationResultID int
+	typeDefinitionResultID int
+	hoverResultID          int
+}
+
+// scipRelationship is a relationship between the symbols of two result sets.
+type scipRelationship struct {
+	from         *scipSymbol
+	to           *scipSymbol
+	relationship *scip.Relationship
+}
+
+func newSCIPCorrelator(dumpRoot string) *scipCorrelator {
+	return &scipCorrelator{
+		state:    conversion.NewState(),
+		dumpRoot: strings.Trim(dumpRoot, "/"),
+		symbols:  map[string]*scipSymbol{},
+		defined:  map[string]struct{}{},
+		packages: map[string]int{},
+	}
+}
+
+func (c *scipCorrelator) setMetadata(metadata *scip.Metadata) {
+	c.hasMetadata = true
+	c.state.ProjectRoot = metadata.ProjectRoot
+}
+
+// finish adds the data that depends on the whole index to the correlation state once all documents
+// were read, and returns the correlation state.
+func (c *scipCorrelator) finish() (*conversion.State, error) {
+	if !c.hasMetadata {
+		return nil, conversion.ErrMissingMetaData
+	}
+
+	// We assume that the project root of the SCIP index is either the root of the upload or the
+	// root of the repository, which is what the LSIF correlator assumes as well. Document paths
+	// are relative to the project root, so we need to make them relative to the upload root in
+	// the latter case.
+	if c.dumpRoot != "" && !strings.HasSuffix(strings.TrimSuffix(c.state.ProjectRoot, "/"), "/"+c.dumpRoot) {
+		for _, documentID := range c.documentIDs {
+			path := c.state.DocumentData[documentID]
+			relativePath, err := filepath.Rel(c.dumpRoot, path)
+			if err != nil {
+				return nil, errors.Errorf("document path %q is not relative to upload root %q (%s)", path, c.dumpRoot, err)
+			}
+			c.state.DocumentData[documentID] = relativePath
+		}
+	}
+
+	// Documents of the index take precedence over external symbols
+	for _, info := range c.externalSymbols {
+		c.addSymbolInformation(c.globalSymbol(info.Symbol), info)
+	}
+
+	// Global symbols that are not defined in the index are imported from elsewhere
+	for _, symbol := range c.symbolOrder {
+		kind := "import"
+		if _, ok := c.defined[symbol]; ok {
+			kind = "export"
+		}
+		c.addMoniker(c.symbols[symbol], symbol, kind)
+	}
+
+	// Implementations of symbols defined in other indexes are found via monikers
+	for _, r := range c.relationships {
+		if _, ok := c.defined[r.relationship.Symbol]; r.relationship.IsImplementation && !ok && scip.IsGlobalSymbol(r.relationship.Symbol) {
+			c.addMoniker(r.from, r.relationship.Symbol, "implementation")
+		}
+	}
+
+	c.correlateRelationships()
+	return c.state, nil
+}
+
+func (c *scipCorrelator) nextID() int {
+	c.id++
+	return c.id
+}
+
+// globalSymbol returns the result set of the given global symbol, creating it if it does not exist
+// yet. Its moniker is attached once it is known whether the symbol is defined in the index.
+func (c *scipCorrelator) globalSymbol(symbol string) *scipSymbol {
+	if s, ok := c.symbols[symbol]; ok {
+		return s
+	}
+
+	s := c.newSymbol()
+	c.symbols[symbol] = s
+	c.symbolOrder = append(c.symbolOrder, symbol)
+	return s
+}
+
+// newSymbol creates a new result set with an attached reference result.
+func (c *scipCorrelator) newSymbol() *scipSymbol {
+	s := &scipSymbol{
+		resultSetID:       c.nextID(),
+		referenceResultID: c.nextID(),
+	}
+
+	c.state.ReferenceData[s.referenceResultID] = datastructures.NewDefaultIDSetMap()
+	c.state.ResultSetData[s.resultSetID] = conversion.ResultSet{}.SetReferenceResultID(s.referenceResultID)
+	return s
+}
+
+// addSymbolInformation attaches the documentation of the given symbol information to the result
+// set of the symbol.
+func (c *scipCorrelator) addSymbolInformation(s *scipSymbol, info *scip.SymbolInformation) {
+	if s.hoverResultID == 0 && len(info.Documentation) > 0 {
+		s.hoverResultID = c.addHoverResult(info.Documentation)
+		c.state.ResultSetData[s.resultSetID] = c.state.ResultSetData[s.resultSetID].SetHoverResultID(s.hoverResultID)
+	}
+}
+
+// addHoverResult adds a hover result for the given documentation sections.
+func (c *scipCorrelator) addHoverResult(documentation []string) int {
+	// Indexers emitting LSIF directly render the horizontal rule between documentation sections
+	// themselves, SCIP keeps the sections separate.
+	id := c.nextID()
+	c.state.HoverData[id] = strings.Join(documentation, "\n\n---\n\n")
+	return id
+}
+
+// addMoniker attaches a moniker of the given kind for the given symbol to the given result set.
+// Symbols without a scheme are silently ignored, as they are not meaningful outside of the index.
+func (c *scipCorrelator) addMoniker(s *scipSymbol, symbol, kind string) {
+	parsed, err := scip.ParsePartialSymbol(symbol, false)
+	if err != nil || parsed == nil || parsed.Scheme == "" {
+		return
+	}
+
+	scheme := parsed.Scheme
+	if parsed.Package != nil {
+		// The scheme of a moniker is used where the manager of its package should be used, so
+		// we have to translate the schemes of the indexers that produce packages we know about.
+		switch scheme {
+		case "scip-java", "lsif-java":
+			scheme = "semanticdb"
+		case "scip-typescript", "lsif-typescript":
+			scheme = "npm"
+		}
+	}
+
+	id := c.nextID()
+	moniker := conversion.Moniker{
+		Moniker: reader.Moniker{
+			Kind:       kind,
+			Scheme:     scheme,
+			Identifier: symbol,
+		},
+	}
+
+	if pkg := parsed.Package; pkg != nil && pkg.Manager != "" && pkg.Name != "" && pkg.Version != "" {
+		moniker = moniker.SetPackageInformationID(c.packageInformation(pkg))
+
+		switch kind {
+		case "import":
+			c.state.ImportedMonikers.Add(id)
+		case "export":
+			c.state.ExportedMonikers.Add(id)
+		case "implementation":
+			c.state.ImplementedMonikers.Add(id)
+		}
+	}
+
+	c.state.MonikerData[id] = moniker
+	c.state.Monikers.AddID(s.resultSetID, id)
+}
+
+// packageInformation returns the identifier of the package information for the given package.
+func (c *scipCorrelator) packageInformation(pkg *scip.Package) int {
+	if id, ok := c.packages[pkg.ID()]; ok {
+		return id
+	}
+
+	id := c.nextID()
+	c.state.PackageInformationData[id] = conversion.PackageInformation{
+		Name:    pkg.Name,
+		Version: pkg.Version,
+		Manager: pkg.Manager,
+	}
+	c.packages[pkg.ID()] = id
+	return id
+}
+
+// correlateDocument adds the given document, its symbols, and its occurrences to the correlation
+// state. Paths are made relative to the upload root once the metadata of the index is known.
+func (c *scipCorrelator) correlateDocument(document *scip.Document) {
+	documentID := c.nextID()
+	c.state.DocumentData[documentID] = filepath.Clean(document.RelativePath)
+	c.documentIDs = append(c.documentIDs, documentID)
+
+	// Local symbols are only unique within their document
+	localSymbols := map[string]*scipSymbol{}
+	symbolFor := func(symbol string) *scipSymbol {
+		if scip.IsLocalSymbol(symbol) {
+			s, ok := localSymbols[symbol]
+			if !ok {
+				s = c.newSymbol()
+				localSymbols[symbol] = s
+			}
+			return s
+		}
+
+		return c.globalSymbol(symbol)
+	}
+
+	for _, info := range document.Symbols {
+		s := symbolFor(info.Symbol)
+		c.addSymbolInformation(s, info)
+		if scip.IsGlobalSymbol(info.Symbol) {
+			c.defined[info.Symbol] = struct{}{}
+		}
+
+		for _, relationship := range info.Relationships {
+			c.relationships = append(c.relationships, scipRelationship{
+				from:         s,
+				to:           symbolFor(relationship.Symbol),
+				relationship: relationship,
+			})
+		}
+	}
+
+	for _, occurrence := range document.Occurrences {
+		if occurrence.Symbol == "" {
+			// Occurrences without a symbol only carry syntax highlighting data
+			continue
+		}
+
+		startLine, startCharacter, endLine, endCharacter, ok := scipRange(occurrence.Range)
+		if !ok {
+			// Invalid ranges are skipped, as they are when converting SCIP to LSIF
+			continue
+		}
+
+		rangeData := conversion.Range{
+			Range: reader.Range{
+				RangeData: protocol.RangeData{
+					Start: protocol.Pos{Line: startLine, Character: startCharacter},
+					End:   protocol.Pos{Line: endLine, Character: endCharacter},
+				},
+			},
+		}
+		if len(occurrence.OverrideDocumentation) > 0 {
+			rangeData = rangeData.SetHoverResultID(c.addHoverResult(occurrence.OverrideDocumentation))
+		}
+
+		rangeID := c.nextID()
+		c.state.RangeData[rangeID] = rangeData
+		c.state.Contains.AddID(documentID, rangeID)
+
+		s := symbolFor(occurrence.Symbol)
+		c.state.NextData[rangeID] = s.resultSetID
+
+		if occurrence.SymbolRoles&int32(scip.SymbolRole_Definition) != 0 {
+			if s.definitionResultID == 0 {
+				s.definitionResultID = c.nextID()
+				c.state.DefinitionData[s.definitionResultID] = datastructures.NewDefaultIDSetMap()
+				c.state.ResultSetData[s.resultSetID] = c.state.ResultSetData[s.resultSetID].SetDefinitionResultID(s.definitionResultID)
+			}
+			c.state.DefinitionData[s.definitionResultID].AddID(documentID, rangeID)
+		}
+		c.state.ReferenceData[s.referenceResultID].AddID(documentID, rangeID)
+
+		for _, diagnostic := range occurrence.Diagnostics {
+			diagnosticID := c.nextID()
+			c.state.DiagnosticResults[diagnosticID] = []conversion.Diagnostic{{
+				Severity:       int(diagnostic.Severity),
+				Code:           diagnostic.Code,
+				Message:        diagnostic.Message,
+				Source:         diagnostic.Source,
+				StartLine:      startLine,
+				StartCharacter: startCharacter,
+				EndLine:        endLine,
+				EndCharacter:   endCharacter,
+			}}
+			c.state.Diagnostics.AddID(documentID, diagnosticID)
+		}
+	}
+}
+
+// correlateRelationships links the results of related symbols once the definitions of all symbols
+// are known. The definitions of a symbol implementing another symbol are implementations of the
+// latter, the definitions of the type of a symbol are its type definitions, and the references of
+// symbols with a reference relationship are shared.
+func (c *scipCorrelator) correlateRelationships() {
+	for _, r := range c.relationships {
+		if r.relationship.IsImplementation && r.from.definitionResultID != 0 {
+			if r.to.implementationResultID == 0 {
+				r.to.implementationResultID = c.nextID()
+				c.state.ImplementationData[r.to.implementationResultID] = datastructures.NewDefaultIDSetMap()
+				c.state.ResultSetData[r.to.resultSetID] = c.state.ResultSetData[r.to.resultSetID].SetImplementationResultID(r.to.implementationResultID)
+			}
+
+			implementations := c.state.ImplementationData[r.to.implementationResultID]
+			c.state.DefinitionData[r.from.definitionResultID].Each(func(documentID int, rangeIDs *datastructures.IDSet) {
+				implementations.UnionIDSet(documentID, rangeIDs)
+			})
+		}
+
+		if r.relationship.IsTypeDefinition && r.to.definitionResultID != 0 {
+			if r.from.typeDefinitionResultID == 0 {
+				r.from.typeDefinitionResultID = c.nextID()
+				c.state.TypeDefinitionData[r.from.typeDefinitionResultID] = datastructures.NewDefaultIDSetMap()
+				c.state.ResultSetData[r.from.resultSetID] = c.state.ResultSetData[r.from.resultSetID].SetTypeDefinitionResultID(r.from.typeDefinitionResultID)
+			}
+
+			typeDefinitions := c.state.TypeDefinitionData[r.from.typeDefinitionResultID]
+			c.state.DefinitionData[r.to.definitionResultID].Each(func(documentID int, rangeIDs *datastructures.IDSet) {
+				typeDefinitions.UnionIDSet(documentID, rangeIDs)
+			})
+		}
+
+		if r.relationship.IsReference {
+			c.state.LinkedReferenceResults[r.from.referenceResultID] = append(c.state.LinkedReferenceResults[r.from.referenceResultID], r.to.referenceResultID)
+			c.state.LinkedReferenceResults[r.to.referenceResultID] = append(c.state.LinkedReferenceResults[r.to.referenceResultID], r.from.referenceResultID)
+		}
+	}
+}
+
+// scipRange interprets the given SCIP range, which has three elements for single-line ranges and
+// four elements for multi-line ranges.
+func scipRange(r []int32) (startLine, startCharacter, endLine, endCharacter int, ok bool) {
+	switch len(r) {
+	case 3:
+		return int(r[0]), int(r[1]), int(r[0]), int(r[2]), true
+	case 4:
+		return int(r[0]), int(r[1]), int(r[2]), int(r[3]), true
+	}
+
+	return 0, 0, 0, 0, false
+}
@@ -0,0 +1,284 @@
+package correlation
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+const (
+	testSymbolFoo     = "scip-go gomod example v1.0.0 `example`/Foo#"
+	testSymbolBar     = "scip-go gomod example v1.0.0 `example`/Bar#"
+	testSymbolPrintln = "scip-go gomod fmt v1.18.0 `fmt`/Println()."
+)
+
+func testSCIPIndex(projectRoot string, documents ...*scip.Document) *scip.Index {
+	return &scip.Index{
+		Metadata: &scip.Metadata{
+			ToolInfo:             &scip.ToolInfo{Name: "scip-go", Version: "0.1.0"},
+			ProjectRoot:          projectRoot,
+			TextDocumentEncoding: scip.TextEncoding_UTF8,
+		},
+		Documents: documents,
+		ExternalSymbols: []*scip.SymbolInformation{
+			{Symbol: testSymbolPrintln, Documentation: []string{"```go\nfunc Println(a ...any)\n```", "Println formats its operands."}},
+		},
+	}
+}
+
+func testSCIPDocument(relativePath string) *scip.Document {
+	return &scip.Document{
+		RelativePath: relativePath,
+		Symbols: []*scip.SymbolInformation{
+			{Symbol: testSymbolFoo, Documentation: []string{"Foo does things."}},
+			{Symbol: testSymbolBar, Relationships: []*scip.Relationship{{Symbol: testSymbolFoo, IsImplementation: true}}},
+			{Symbol: "local 1", Documentation: []string{"a local variable"}, Relationships: []*scip.Relationship{{Symbol: testSymbolFoo, IsTypeDefinition: true}}},
+		},
+		Occurrences: []*scip.Occurrence{
+			{Range: []int32{0, 5, 8}, Symbol: testSymbolFoo, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{2, 5, 8}, Symbol: testSymbolBar, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{3, 1, 2}, Symbol: "local 1", SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{
+				Range:                 []int32{4, 1, 2},
+				Symbol:                "local 1",
+				OverrideDocumentation: []string{"an unused local variable"},
+				Diagnostics:           []*scip.Diagnostic{{Severity: scip.Severity_Warning, Code: "unused", Message: "x is unused", Source: "vet"}},
+			},
+			{Range: []int32{5, 3, 6}, Symbol: testSymbolFoo},
+			{Range: []int32{6, 4, 7, 1}, Symbol: testSymbolPrintln},
+			{Range: []int32{7, 0, 2}, SyntaxKind: scip.SyntaxKind_IdentifierKeyword},
+			{Range: []int32{8}, Symbol: testSymbolFoo},
+		},
+	}
+}
+
+func correlateTestSCIPIndex(t *testing.T, index *scip.Index, root string) *precise.GroupedBundleDataMaps {
+	t.Helper()
+
+	contents, err := proto.Marshal(index)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	bundle, err := Correlate(context.Background(), bytes.NewReader(contents), root, nil)
+	if err != nil {
+		t.Fatalf("unexpected error correlating index: %s", err)
+	}
+
+	return precise.GroupedBundleDataChansToMaps(bundle)
+}
+
+func TestCorrelateSCIP(t *testing.T) {
+	bundle := correlateTestSCIPIndex(t, testSCIPIndex("file:///repo", testSCIPDocument("a.go")), "")
+
+	document, ok := bundle.Documents["a.go"]
+	if !ok {
+		t.Fatalf("expected document a.go")
+	}
+	if len(document.Ranges) != 6 {
+		t.Errorf("unexpected number of ranges. want=%d have=%d", 6, len(document.Ranges))
+	}
+
+	fooDefinition := precise.LocationData{URI: "a.go", StartLine: 0, StartCharacter: 5, EndLine: 0, EndCharacter: 8}
+	fooReference := precise.LocationData{URI: "a.go", StartLine: 5, StartCharacter: 3, EndLine: 5, EndCharacter: 6}
+	localDefinition := precise.LocationData{URI: "a.go", StartLine: 3, StartCharacter: 1, EndLine: 3, EndCharacter: 2}
+	localReference := precise.LocationData{URI: "a.go", StartLine: 4, StartCharacter: 1, EndLine: 4, EndCharacter: 2}
+
+	t.Run("global symbol", func(t *testing.T) {
+		result := queryOne(t, bundle, 5, 4)
+		if diff := cmp.Diff([]precise.LocationData{fooDefinition}, result.Definitions); diff != "" {
+			t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]precise.LocationData{fooDefinition, fooReference}, sortLocations(result.References)); diff != "" {
+			t.Errorf("unexpected references (-want +got):\n%s", diff)
+		}
+		if result.Hover != "Foo does things." {
+			t.Errorf("unexpected hover. want=%q have=%q", "Foo does things.", result.Hover)
+		}
+
+		expectedMonikers := []precise.QualifiedMonikerData{{
+			MonikerData:            precise.MonikerData{Kind: "export", Scheme: "scip-go", Identifier: testSymbolFoo, PackageInformationID: result.Monikers[0].PackageInformationID},
+			PackageInformationData: precise.PackageInformationData{Name: "example", Version: "v1.0.0"},
+		}}
+		if diff := cmp.Diff(expectedMonikers, result.Monikers); diff != "" {
+			t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("local symbol", func(t *testing.T) {
+		result := queryOne(t, bundle, 4, 1)
+		if diff := cmp.Diff([]precise.LocationData{localDefinition}, result.Definitions); diff != "" {
+			t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]precise.LocationData{localDefinition, localReference}, sortLocations(result.References)); diff != "" {
+			t.Errorf("unexpected references (-want +got):\n%s", diff)
+		}
+		if result.Hover != "an unused local variable" {
+			t.Errorf("unexpected hover. want=%q have=%q", "an unused local variable", result.Hover)
+		}
+		if len(result.Monikers) != 0 {
+			t.Errorf("unexpected monikers for local symbol: %v", result.Monikers)
+		}
+
+		if hover := queryOne(t, bundle, 3, 1).Hover; hover != "a local variable" {
+			t.Errorf("unexpected hover. want=%q have=%q", "a local variable", hover)
+		}
+	})
+
+	t.Run("external symbol", func(t *testing.T) {
+		result := queryOne(t, bundle, 6, 5)
+		if len(result.Definitions) != 0 {
+			t.Errorf("unexpected definitions for external symbol: %v", result.Definitions)
+		}
+
+		expectedHover := "```go\nfunc Println(a ...any)\n```\n\n---\n\nPrintln formats its operands."
+		if result.Hover != expectedHover {
+			t.Errorf("unexpected hover. want=%q have=%q", expectedHover, result.Hover)
+		}
+		if len(result.Monikers) != 1 || result.Monikers[0].Kind != "import" || result.Monikers[0].Identifier != testSymbolPrintln {
+			t.Errorf("unexpected monikers: %v", result.Monikers)
+		}
+	})
+
+	t.Run("implementations", func(t *testing.T) {
+		for _, r := range document.Ranges {
+			if r.StartLine != 0 {
+				continue
+			}
+
+			locations := resultLocations(bundle, r.ImplementationResultID)
+			expected := []precise.LocationData{{URI: "a.go", StartLine: 2, StartCharacter: 5, EndLine: 2, EndCharacter: 8}}
+			if diff := cmp.Diff(expected, locations); diff != "" {
+				t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+			}
+		}
+	})
+
+	t.Run("type definitions", func(t *testing.T) {
+		for _, r := range document.Ranges {
+			var expected []precise.LocationData
+			if r.StartLine == 3 || r.StartLine == 4 {
+				expected = []precise.LocationData{fooDefinition}
+			}
+
+			if diff := cmp.Diff(expected, resultLocations(bundle, r.TypeDefinitionResultID)); diff != "" {
+				t.Errorf("unexpected type definitions on line %d (-want +got):\n%s", r.StartLine, diff)
+			}
+		}
+	})
+
+	t.Run("diagnostics", func(t *testing.T) {
+		expected := []precise.DiagnosticData{{
+			Severity:       int(scip.Severity_Warning),
+			Code:           "unused",
+			Message:        "x is unused",
+			Source:         "vet",
+			StartLine:      4,
+			StartCharacter: 1,
+			EndLine:        4,
+			EndCharacter:   2,
+		}}
+		if diff := cmp.Diff(expected, document.Diagnostics); diff != "" {
+			t.Errorf("unexpected diagnostics (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("packages", func(t *testing.T) {
+		expectedPackages := []precise.Package{{Scheme: "scip-go", Name: "example", Version: "v1.0.0"}}
+		if diff := cmp.Diff(expectedPackages, bundle.Packages); diff != "" {
+			t.Errorf("unexpected packages (-want +got):\n%s", diff)
+		}
+
+		expectedPackageReferences := []precise.PackageReference{{
+			Package: precise.Package{Scheme: "scip-go", Name: "fmt", Version: "v1.18.0"},
+		}}
+		if diff := cmp.Diff(expectedPackageReferences, bundle.PackageReferences); diff != "" {
+			t.Errorf("unexpected package references (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestCorrelateSCIPRoot(t *testing.T) {
+	for name, projectRoot := range map[string]string{
+		"repository root": "file:///repo",
+		"upload root":     "file:///repo/sub",
+	} {
+		t.Run(name, func(t *testing.T) {
+			var documents []*scip.Document
+			if projectRoot == "file:///repo" {
+				documents = append(documents, testSCIPDocument("sub/a.go"), testSCIPDocument("other/b.go"))
+			} else {
+				documents = append(documents, testSCIPDocument("a.go"))
+			}
+
+			bundle := correlateTestSCIPIndex(t, testSCIPIndex(projectRoot, documents...), "sub/")
+
+			var paths []string
+			for path := range bundle.Documents {
+				paths = append(paths, path)
+			}
+			if diff := cmp.Diff([]string{"a.go"}, paths); diff != "" {
+				t.Errorf("unexpected documents (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCorrelateSCIPMissingMetadata(t *testing.T) {
+	contents, err := proto.Marshal(&scip.Index{Documents: []*scip.Document{testSCIPDocument("a.go")}})
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	if _, err := Correlate(context.Background(), bytes.NewReader(contents), "", nil); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func queryOne(t *testing.T, bundle *precise.GroupedBundleDataMaps, line, character int) precise.QueryResult {
+	t.Helper()
+
+	results, err := precise.Query(bundle, "a.go", line, character)
+	if err != nil {
+		t.Fatalf("unexpected error querying bundle: %s", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("unexpected number of results. want=%d have=%d", 1, len(results))
+	}
+
+	return results[0]
+}
+
+func resultLocations(bundle *precise.GroupedBundleDataMaps, id precise.ID) []precise.LocationData {
+	chunk := bundle.ResultChunks[precise.HashKey(id, bundle.Meta.NumResultChunks)]
+
+	var locations []precise.LocationData
+	for _, pair := range chunk.DocumentIDRangeIDs[id] {
+		path := chunk.DocumentPaths[pair.DocumentID]
+		r := bundle.Documents[path].Ranges[pair.RangeID]
+		locations = append(locations, precise.LocationData{
+			URI:            path,
+			StartLine:      r.StartLine,
+			StartCharacter: r.StartCharacter,
+			EndLine:        r.EndLine,
+			EndCharacter:   r.EndCharacter,
+		})
+	}
+
+	return locations
+}
+
+func sortLocations(locations []precise.LocationData) []precise.LocationData {
+	sort.Slice(locations, func(i, j int) bool {
+		return locations[i].StartLine < locations[j].StartLine
+	})
+
+	return locations
+}
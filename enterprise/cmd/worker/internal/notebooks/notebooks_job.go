@@ -0,0 +1,37 @@
+package notebooks
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks/background"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+type notebookSnapshotsJob struct{}
+
+func NewNotebookSnapshotsJob() job.Job {
+	return &notebookSnapshotsJob{}
+}
+
+func (j *notebookSnapshotsJob) Description() string {
+	return "Records snapshots of the results of notebooks on a snapshot schedule."
+}
+
+func (j *notebookSnapshotsJob) Config() []env.Config {
+	return []env.Config{}
+}
+
+func (j *notebookSnapshotsJob) Routines(ctx context.Context, logger log.Logger) ([]goroutine.BackgroundRoutine, error) {
+	sqlDB, err := workerdb.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return background.NewBackgroundJobs(logger, database.NewDB(logger, sqlDB)), nil
+}
@@ -0,0 +1,56 @@
+package searchexports
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchexports"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchexports/background"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+var configInst = &searchexports.Config{}
+
+type searchExportsJob struct{}
+
+func NewSearchExportsJob() job.Job {
+	return &searchExportsJob{}
+}
+
+func (j *searchExportsJob) Description() string {
+	return "Exports the complete results of searches to files in the upload store."
+}
+
+func (j *searchExportsJob) Config() []env.Config {
+	return []env.Config{configInst}
+}
+
+func (j *searchExportsJob) Routines(ctx context.Context, logger log.Logger) ([]goroutine.BackgroundRoutine, error) {
+	observationContext := &observation.Context{
+		Logger:     logger.Scoped("routines", "search exports job routines"),
+		Tracer:     &trace.Tracer{Tracer: opentracing.GlobalTracer()},
+		Registerer: prometheus.DefaultRegisterer,
+	}
+
+	sqlDB, err := workerdb.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	uploadStore, err := searchexports.NewUploadStore(ctx, configInst, observationContext)
+	if err != nil {
+		return nil, err
+	}
+
+	return background.NewBackgroundJobs(logger, database.NewDB(logger, sqlDB), uploadStore, configInst.TTL), nil
+}
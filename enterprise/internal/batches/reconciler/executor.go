@@ -21,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/repos"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -651,6 +652,16 @@ func buildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, pushOpts *pro
 		Push:         pushOpts,
 	}
 
+	// Gerrit creates changes from commits pushed to a magic ref, and
+	// identifies them by the Change-Id trailer of the commit message.
+	if repo.ExternalRepo.ServiceType == extsvc.TypeGerrit {
+		changeID := sources.GerritChangeID(repo, desc.HeadRef)
+		pushRef := sources.GerritPushRef(desc.BaseRef, desc.HeadRef)
+
+		opts.CommitInfo.Message = sources.AppendGerritChangeID(commitMessage, changeID)
+		opts.PushRef = &pushRef
+	}
+
 	return opts, nil
 }
 
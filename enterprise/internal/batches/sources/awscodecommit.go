@@ -0,0 +1,266 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	"golang.org/x/net/http2"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// AWSCodeCommitSource is a ChangesetSource for AWS CodeCommit pull requests.
+//
+// The AWS CodeCommit API is authenticated with the AWS access key of the code
+// host connection, while commits are pushed using Git credentials. Credentials
+// configured for Batch Changes are therefore HTTPS Git credentials, which are
+// only used to push to the repository.
+type AWSCodeCommitSource struct {
+	client *awscodecommit.Client
+	au     auth.Authenticator
+}
+
+var _ ChangesetSource = AWSCodeCommitSource{}
+
+func NewAWSCodeCommitSource(svc *types.ExternalService, cf *httpcli.Factory) (*AWSCodeCommitSource, error) {
+	var c schema.AWSCodeCommitConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer(func(c *http.Client) error {
+		tr := awshttp.NewBuildableClient().GetTransport()
+		if err := http2.ConfigureTransport(tr); err != nil {
+			return err
+		}
+		c.Transport = tr
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	awsConfig, err := config.LoadDefaultConfig(context.Background(),
+		config.WithRegion(c.Region),
+		config.WithCredentialsProvider(
+			awscredentials.StaticCredentialsProvider{
+				Value: aws.Credentials{
+					AccessKeyID:     c.AccessKeyID,
+					SecretAccessKey: c.SecretAccessKey,
+					Source:          "sourcegraph-site-configuration",
+				},
+			},
+		),
+		config.WithHTTPClient(cli),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading AWS config")
+	}
+
+	return &AWSCodeCommitSource{
+		client: awscodecommit.NewClient(awsConfig),
+		au: &auth.BasicAuth{
+			Username: c.GitCredentials.Username,
+			Password: c.GitCredentials.Password,
+		},
+	}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s AWSCodeCommitSource) GitserverPushConfig(ctx context.Context, store database.ExternalServiceStore, repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(ctx, store, repo, s.au)
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s AWSCodeCommitSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("AWSCodeCommitSource", a)
+	}
+
+	return &AWSCodeCommitSource{client: s.client, au: a}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+//
+// Git credentials can't be validated through the AWS CodeCommit API, so this
+// only checks that they are set and that the API is accessible.
+func (s AWSCodeCommitSource) ValidateAuthenticator(ctx context.Context) error {
+	var username, password string
+	switch a := s.au.(type) {
+	case *auth.BasicAuth:
+		username, password = a.Username, a.Password
+	case *auth.BasicAuthWithSSH:
+		username, password = a.Username, a.Password
+	}
+	if username == "" || password == "" {
+		return errors.New("AWS CodeCommit Git credentials require a username and password")
+	}
+
+	return s.client.ValidateCredentials(ctx)
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s AWSCodeCommitSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	pr, err := s.client.GetPullRequest(ctx, cs.ExternalID)
+	if err != nil {
+		if awscodecommit.IsPullRequestNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return s.setChangesetMetadata(pr, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s AWSCodeCommitSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	repo, ok := cs.TargetRepo.Metadata.(*awscodecommit.Repository)
+	if !ok {
+		return false, errors.Errorf("unexpected metadata type %T for AWS CodeCommit repo", cs.TargetRepo.Metadata)
+	}
+
+	sourceReference := gitdomain.AbbreviateRef(cs.HeadRef)
+	destinationReference := gitdomain.AbbreviateRef(cs.BaseRef)
+
+	// AWS CodeCommit happily creates multiple pull requests for the same
+	// branches, so we have to check for an existing one ourselves.
+	pr, err := s.client.FindOpenPullRequest(ctx, repo.Name, sourceReference, destinationReference)
+	if err == nil {
+		return true, s.setChangesetMetadata(pr, cs)
+	} else if !awscodecommit.IsPullRequestNotFound(err) {
+		return false, errors.Wrap(err, "finding existing pull request")
+	}
+
+	pr, err = s.client.CreatePullRequest(ctx, awscodecommit.CreatePullRequestInput{
+		RepositoryName:       repo.Name,
+		Title:                cs.Title,
+		Description:          cs.Body,
+		SourceReference:      sourceReference,
+		DestinationReference: destinationReference,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "creating pull request")
+	}
+
+	return false, s.setChangesetMetadata(pr, cs)
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "closed" on
+// AWS CodeCommit).
+func (s AWSCodeCommitSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	pr, err := s.client.ClosePullRequest(ctx, cs.ExternalID)
+	if err != nil {
+		return errors.Wrap(err, "closing pull request")
+	}
+
+	return s.setChangesetMetadata(pr, cs)
+}
+
+// UpdateChangeset can update Changesets.
+//
+// AWS CodeCommit doesn't support changing the destination of a pull request,
+// so only the title and description are updated.
+func (s AWSCodeCommitSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	current, ok := cs.Metadata.(*awscodecommit.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an AWS CodeCommit pull request")
+	}
+
+	pr, err := s.client.UpdatePullRequest(ctx, current, cs.Title, cs.Body)
+	if err != nil {
+		return errors.Wrap(err, "updating pull request")
+	}
+
+	return s.setChangesetMetadata(pr, cs)
+}
+
+// errCannotReopenPullRequest is returned when trying to reopen a closed AWS
+// CodeCommit pull request, which isn't possible.
+var errCannotReopenPullRequest = errcode.MakeNonRetryable(errors.New("closed AWS CodeCommit pull requests cannot be reopened"))
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+//
+// AWS CodeCommit doesn't support reopening pull requests, so this returns an
+// error for closed pull requests.
+func (s AWSCodeCommitSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	pr, ok := cs.Metadata.(*awscodecommit.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an AWS CodeCommit pull request")
+	}
+	if pr.Status == awscodecommit.PullRequestStatusOpen {
+		return nil
+	}
+
+	return errCannotReopenPullRequest
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s AWSCodeCommitSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	pr, ok := cs.Metadata.(*awscodecommit.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an AWS CodeCommit pull request")
+	}
+
+	return s.client.CreatePullRequestComment(ctx, pr, comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// If squash is true, a squash merge is performed, otherwise a three-way merge.
+// If the changeset cannot be merged, because it is in an unmergeable state,
+// ChangesetNotMergeableError is returned.
+func (s AWSCodeCommitSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	current, ok := cs.Metadata.(*awscodecommit.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an AWS CodeCommit pull request")
+	}
+
+	pr, err := s.client.MergePullRequest(ctx, current, squash)
+	if err != nil {
+		if awscodecommit.IsNotMergeable(err) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "merging pull request")
+	}
+
+	return s.setChangesetMetadata(pr, cs)
+}
+
+func (s AWSCodeCommitSource) setChangesetMetadata(pr *awscodecommit.PullRequest, cs *Changeset) error {
+	if err := cs.SetMetadata(pr); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
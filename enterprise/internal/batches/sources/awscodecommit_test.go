@@ -0,0 +1,271 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewAWSCodeCommitSource(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		for name, input := range map[string]string{
+			"invalid JSON":   "invalid JSON",
+			"invalid schema": `{"region": ["not a string"]}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				s, err := NewAWSCodeCommitSource(&types.ExternalService{
+					Config: input,
+				}, nil)
+				assert.Nil(t, s)
+				assert.NotNil(t, err)
+			})
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		s, err := NewAWSCodeCommitSource(&types.ExternalService{}, nil)
+		assert.NotNil(t, s)
+		assert.Nil(t, err)
+	})
+}
+
+func TestAWSCodeCommitSource_LoadChangeset(t *testing.T) {
+	testCases := []struct {
+		name string
+		cs   *Changeset
+		err  string
+	}{
+		{
+			name: "found",
+			cs: &Changeset{
+				Changeset: &btypes.Changeset{ExternalID: "3"},
+			},
+		},
+		{
+			name: "not-found",
+			cs: &Changeset{
+				Changeset: &btypes.Changeset{ExternalID: "999"},
+			},
+			err: "Changeset with external ID 999 not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AWSCodeCommitSource_LoadChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			cf, save := newClientFactory(t, tc.name)
+			defer save(t)
+
+			src := newAWSCodeCommitSource(t, cf)
+
+			if tc.err == "" {
+				tc.err = "<nil>"
+			}
+
+			err := src.LoadChangeset(context.Background(), tc.cs)
+			if have, want := fmt.Sprint(err), tc.err; have != want {
+				t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+			}
+
+			if err != nil {
+				return
+			}
+
+			pr := tc.cs.Changeset.Metadata.(*awscodecommit.PullRequest)
+			assert.Equal(t, "Update README", pr.Title)
+			assert.Equal(t, awscodecommit.PullRequestStatusOpen, pr.Status)
+			assert.Equal(t, "sourcegraph-bot", pr.AuthorName())
+			assert.Equal(t, "https://us-west-1.console.aws.amazon.com/codesuite/codecommit/repositories/test/pull-requests/3/details?region=us-west-1", pr.URL())
+			assert.Len(t, pr.Approvals, 1)
+			assert.Len(t, pr.Events, 2)
+			assert.Equal(t, "refs/heads/batch-changes/update-readme", tc.cs.Changeset.ExternalBranch)
+			assert.Equal(t, extsvc.TypeAWSCodeCommit, tc.cs.Changeset.ExternalServiceType)
+		})
+	}
+}
+
+func TestAWSCodeCommitSource_CreateChangeset(t *testing.T) {
+	for name, want := range map[string]bool{
+		"success":        false,
+		"already-exists": true,
+	} {
+		name := "AWSCodeCommitSource_CreateChangeset_" + name
+		want := want
+
+		t.Run(name, func(t *testing.T) {
+			cf, save := newClientFactory(t, name)
+			defer save(t)
+
+			src := newAWSCodeCommitSource(t, cf)
+
+			cs := &Changeset{
+				Title:   "Update README",
+				Body:    "This updates the README.",
+				HeadRef: "refs/heads/batch-changes/update-readme",
+				BaseRef: "refs/heads/master",
+				TargetRepo: &types.Repo{
+					Metadata: &awscodecommit.Repository{Name: "test"},
+				},
+				Changeset: &btypes.Changeset{},
+			}
+
+			exists, err := src.CreateChangeset(context.Background(), cs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, want, exists)
+			assert.Equal(t, "3", cs.Changeset.ExternalID)
+			assert.Equal(t, "refs/heads/batch-changes/update-readme", cs.Changeset.ExternalBranch)
+		})
+	}
+}
+
+func TestAWSCodeCommitSource_CloseChangeset(t *testing.T) {
+	name := "AWSCodeCommitSource_CloseChangeset_success"
+
+	cf, save := newClientFactory(t, name)
+	defer save(t)
+
+	src := newAWSCodeCommitSource(t, cf)
+
+	cs := &Changeset{
+		Changeset: &btypes.Changeset{
+			ExternalID: "3",
+			Metadata: &awscodecommit.PullRequest{
+				ID:     "3",
+				Status: awscodecommit.PullRequestStatusOpen,
+			},
+		},
+	}
+
+	if err := src.CloseChangeset(context.Background(), cs); err != nil {
+		t.Fatal(err)
+	}
+
+	pr := cs.Changeset.Metadata.(*awscodecommit.PullRequest)
+	assert.Equal(t, awscodecommit.PullRequestStatusClosed, pr.Status)
+	assert.Len(t, pr.Events, 3)
+}
+
+func TestAWSCodeCommitSource_ReopenChangeset(t *testing.T) {
+	src := newAWSCodeCommitSource(t, nil)
+
+	t.Run("open", func(t *testing.T) {
+		cs := &Changeset{
+			Changeset: &btypes.Changeset{
+				Metadata: &awscodecommit.PullRequest{Status: awscodecommit.PullRequestStatusOpen},
+			},
+		}
+		assert.Nil(t, src.ReopenChangeset(context.Background(), cs))
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		cs := &Changeset{
+			Changeset: &btypes.Changeset{
+				Metadata: &awscodecommit.PullRequest{Status: awscodecommit.PullRequestStatusClosed},
+			},
+		}
+		err := src.ReopenChangeset(context.Background(), cs)
+		assert.NotNil(t, err)
+		assert.True(t, errcode.IsNonRetryable(err))
+	})
+}
+
+func TestAWSCodeCommitSource_InvalidMetadata(t *testing.T) {
+	src := newAWSCodeCommitSource(t, nil)
+	ctx := context.Background()
+
+	cs := &Changeset{
+		Changeset: &btypes.Changeset{
+			Metadata: &awscodecommit.Repository{},
+		},
+	}
+
+	want := "Changeset is not an AWS CodeCommit pull request"
+	assert.EqualError(t, src.UpdateChangeset(ctx, cs), want)
+	assert.EqualError(t, src.ReopenChangeset(ctx, cs), want)
+	assert.EqualError(t, src.CreateComment(ctx, cs, "comment"), want)
+	assert.EqualError(t, src.MergeChangeset(ctx, cs, false), want)
+}
+
+func TestAWSCodeCommitSource_WithAuthenticator(t *testing.T) {
+	src := newAWSCodeCommitSource(t, nil)
+
+	t.Run("supported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"BasicAuth":        &auth.BasicAuth{},
+			"BasicAuthWithSSH": &auth.BasicAuthWithSSH{},
+		} {
+			t.Run(name, func(t *testing.T) {
+				out, err := src.WithAuthenticator(tc)
+				assert.Nil(t, err)
+				if as, ok := out.(*AWSCodeCommitSource); !ok {
+					t.Error("cannot coerce Source into AWSCodeCommitSource")
+				} else {
+					assert.NotNil(t, as)
+					assert.Equal(t, tc, as.au)
+				}
+			})
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"nil":                     nil,
+			"OAuthBearerToken":        &auth.OAuthBearerToken{},
+			"OAuthBearerTokenWithSSH": &auth.OAuthBearerTokenWithSSH{},
+		} {
+			t.Run(name, func(t *testing.T) {
+				out, err := src.WithAuthenticator(tc)
+				assert.Nil(t, out)
+				assert.True(t, errors.HasType(err, UnsupportedAuthenticatorError{}))
+			})
+		}
+	})
+}
+
+func newAWSCodeCommitSource(t *testing.T, cf *httpcli.Factory) *AWSCodeCommitSource {
+	t.Helper()
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindAWSCodeCommit,
+		Config: marshalJSON(t, &schema.AWSCodeCommitConnection{
+			Region:          "us-west-1",
+			AccessKeyID:     getAWSEnv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: getAWSEnv("AWS_SECRET_ACCESS_KEY"),
+			GitCredentials: schema.AWSCodeCommitGitCredentials{
+				Username: "git-user",
+				Password: "git-password",
+			},
+		}),
+	}
+
+	src, err := NewAWSCodeCommitSource(svc, cf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return src
+}
+
+func getAWSEnv(envVar string) string {
+	s := os.Getenv(envVar)
+	if s == "" {
+		s = fmt.Sprintf("BOGUS-%s", envVar)
+	}
+	return s
+}
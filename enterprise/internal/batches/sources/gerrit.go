@@ -0,0 +1,289 @@
+package sources
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GerritSource is a ChangesetSource for Gerrit changes.
+//
+// Gerrit doesn't have pull requests: a change is created by pushing a commit
+// with a new Change-Id trailer to the magic refs/for/<branch> ref, and every
+// subsequent push of a commit with the same Change-Id creates a new patch set
+// of that change. The reconciler takes care of the push (see GerritPushRef and
+// GerritChangeID), so this source only ever has to load changes, not create
+// them.
+type GerritSource struct {
+	client *gerrit.Client
+}
+
+var _ ChangesetSource = GerritSource{}
+
+func NewGerritSource(svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := gerrit.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gerrit client")
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GerritSource) GitserverPushConfig(ctx context.Context, store database.ExternalServiceStore, repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(ctx, store, repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GerritSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GerritSource", a)
+	}
+
+	return &GerritSource{client: s.client.WithAuthenticator(a)}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GerritSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedAccount(ctx)
+	return err
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GerritSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, s.changeID(cs))
+	if err != nil {
+		if gerrit.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting change")
+	}
+
+	return s.setChangesetMetadata(change, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s GerritSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	project, err := gerritProjectName(cs.TargetRepo)
+	if err != nil {
+		return false, err
+	}
+	changeID := gerrit.ChangeTriplet(project, gitdomain.AbbreviateRef(cs.BaseRef), GerritChangeID(cs.TargetRepo, cs.HeadRef))
+
+	// The change has been created when the commit was pushed, so all we need
+	// to do here is to make sure that the push did in fact create it.
+	change, err := s.client.GetChange(ctx, changeID)
+	if err != nil {
+		if gerrit.IsNotFound(err) {
+			return false, errors.Wrap(err, "change was not created by push")
+		}
+		return false, errors.Wrap(err, "getting change")
+	}
+
+	if err := s.setChangesetMetadata(change, cs); err != nil {
+		return false, err
+	}
+
+	// The push of a commit with a new Change-Id creates the change with its
+	// first patch set, while pushing to an existing change adds another one.
+	// Only in the latter case did the change exist before, and may need to be
+	// updated by the caller.
+	rev, ok := change.CurrentPatchSet()
+	return !ok || rev.Number > 1, nil
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "abandoned" on
+// Gerrit).
+func (s GerritSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	updated, err := s.client.AbandonChange(ctx, s.changeID(cs))
+	if err != nil {
+		return errors.Wrap(err, "abandoning change")
+	}
+
+	return s.reloadChangeset(ctx, updated, cs)
+}
+
+// UpdateChangeset can update Changesets.
+//
+// The title and body of a Gerrit change are its commit message, so updating
+// them creates a new patch set.
+func (s GerritSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	change, ok := cs.Metadata.(*gerritbatches.AnnotatedChange)
+	if !ok {
+		return errors.New("Changeset is not a Gerrit change")
+	}
+	message := AppendGerritChangeID(cs.Title+"\n\n"+cs.Body, change.ChangeID)
+
+	if err := s.client.SetCommitMessage(ctx, s.changeID(cs), message); err != nil {
+		return errors.Wrap(err, "setting commit message")
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GerritSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	change, ok := cs.Metadata.(*gerritbatches.AnnotatedChange)
+	if !ok {
+		return errors.New("Changeset is not a Gerrit change")
+	}
+	if change.Status != gerrit.ChangeStatusAbandoned {
+		return nil
+	}
+
+	updated, err := s.client.RestoreChange(ctx, s.changeID(cs))
+	if err != nil {
+		return errors.Wrap(err, "restoring change")
+	}
+
+	return s.reloadChangeset(ctx, updated, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GerritSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	return s.client.SetReview(ctx, s.changeID(cs), gerrit.ReviewInput{Message: comment})
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// A Gerrit change always consists of a single commit, so there's no difference
+// between a squash merge and a regular merge and squash is ignored. If the
+// changeset cannot be merged, because it is in an unmergeable state,
+// ChangesetNotMergeableError is returned.
+func (s GerritSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	updated, err := s.client.SubmitChange(ctx, s.changeID(cs))
+	if err != nil {
+		if gerrit.IsConflict(err) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "submitting change")
+	}
+
+	return s.reloadChangeset(ctx, updated, cs)
+}
+
+// changeID returns the identifier used to address the change of the given
+// changeset in API requests. The change number is unique on a Gerrit instance,
+// so we prefer that over the Change-Id where we have it.
+func (s GerritSource) changeID(cs *Changeset) string {
+	if change, ok := cs.Metadata.(*gerritbatches.AnnotatedChange); ok && change.Change != nil && change.Number != 0 {
+		return strconv.Itoa(change.Number)
+	}
+	return cs.ExternalID
+}
+
+// reloadChangeset loads the full change after an action on it. The change
+// returned by actions such as abandoning a change doesn't include the labels
+// and messages that we need to derive the changeset state and events.
+func (s GerritSource) reloadChangeset(ctx context.Context, change *gerrit.Change, cs *Changeset) error {
+	full, err := s.client.GetChange(ctx, strconv.Itoa(change.Number))
+	if err != nil {
+		return errors.Wrap(err, "getting change")
+	}
+
+	return s.setChangesetMetadata(full, cs)
+}
+
+func (s GerritSource) setChangesetMetadata(change *gerrit.Change, cs *Changeset) error {
+	if err := cs.SetMetadata(&gerritbatches.AnnotatedChange{
+		Change:      change,
+		CodeHostURL: s.client.URL.String(),
+	}); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+// GerritChangeID returns the Change-Id of the change that batch changes
+// creates for the given head ref in the given repo.
+//
+// Gerrit identifies changes by the Change-Id trailer in their commit message,
+// so we derive it from the repo and the branch name of the changeset. That way
+// the same Change-Id is used for every push of the changeset, which turns each
+// push into a new patch set of the same change.
+func GerritChangeID(repo *types.Repo, headRef string) string {
+	h := sha1.New()
+	h.Write([]byte(repo.ExternalRepo.ServiceID))
+	h.Write([]byte{0})
+	h.Write([]byte(repo.ExternalRepo.ID))
+	h.Write([]byte{0})
+	h.Write([]byte(gitdomain.EnsureRefPrefix(headRef)))
+	return "I" + hex.EncodeToString(h.Sum(nil))
+}
+
+// AppendGerritChangeID appends the Change-Id trailer for the given Change-Id
+// to the commit message, replacing any Change-Id trailer already present.
+func AppendGerritChangeID(message, changeID string) string {
+	lines := strings.Split(strings.TrimRight(message, "\n"), "\n")
+	if n := len(lines); n > 0 && strings.HasPrefix(lines[n-1], gerrit.ChangeIDTrailer+": ") {
+		lines = lines[:n-1]
+	}
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n\n" + gerrit.ChangeIDTrailer + ": " + changeID + "\n"
+}
+
+// GerritPushRef returns the magic ref that commits for a changeset with the
+// given base and head refs have to be pushed to in order to create or update a
+// Gerrit change. The name of the head branch is used as the topic of the
+// change, which is how we keep track of the branch of a change.
+func GerritPushRef(baseRef, headRef string) string {
+	return "refs/for/" + gitdomain.AbbreviateRef(baseRef) + "%topic=" + gitdomain.AbbreviateRef(headRef)
+}
+
+// gerritProjectName returns the name of the Gerrit project of the given repo.
+func gerritProjectName(repo *types.Repo) (string, error) {
+	project, ok := repo.Metadata.(*gerrit.Project)
+	if !ok {
+		return "", errors.Errorf("unexpected metadata type %T for Gerrit repo", repo.Metadata)
+	}
+
+	// Gerrit URL encodes the project name to derive the project ID.
+	name, err := url.PathUnescape(project.ID)
+	if err != nil {
+		return "", errors.Wrap(err, "decoding project ID")
+	}
+	return name, nil
+}
@@ -0,0 +1,30 @@
+package gerrit
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+)
+
+// AnnotatedChange adds metadata we need that lives outside the main Change
+// type returned by the Gerrit API alongside the change. This type is used as
+// the primary metadata type for Gerrit changesets.
+type AnnotatedChange struct {
+	*gerrit.Change
+	// CodeHostURL is the base URL of the Gerrit instance the change lives on.
+	// Gerrit doesn't include a link to the change in its API responses, so we
+	// need to keep track of it to be able to link to the change.
+	CodeHostURL string `json:"code_host_url"`
+}
+
+// URL returns the web URL of the change.
+func (c *AnnotatedChange) URL() (string, error) {
+	u, err := url.Parse(c.CodeHostURL)
+	if err != nil {
+		return "", err
+	}
+	u.Path = path.Join(u.Path, "c", c.Project, "+", strconv.Itoa(c.Number))
+	return u.String(), nil
+}
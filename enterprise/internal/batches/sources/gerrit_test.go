@@ -0,0 +1,259 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewGerritSource(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		for name, input := range map[string]string{
+			"invalid JSON":   "invalid JSON",
+			"invalid schema": `{"password": ["not a string"]}`,
+			"bad URL":        `{"url": "http://[::1]:namedport"}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				s, err := NewGerritSource(&types.ExternalService{
+					Config: input,
+				}, nil)
+				assert.Nil(t, s)
+				assert.NotNil(t, err)
+			})
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		s, err := NewGerritSource(&types.ExternalService{}, nil)
+		assert.NotNil(t, s)
+		assert.Nil(t, err)
+	})
+}
+
+func TestGerritSource_LoadChangeset(t *testing.T) {
+	testCases := []struct {
+		name string
+		cs   *Changeset
+		err  string
+	}{
+		{
+			name: "found",
+			cs: &Changeset{
+				Changeset: &btypes.Changeset{ExternalID: "I5e2b2a6f6e6f7e3c0c6cb4b1ba0ce5dd5cdb0d4e"},
+			},
+		},
+		{
+			name: "not-found",
+			cs: &Changeset{
+				Changeset: &btypes.Changeset{ExternalID: "I0000000000000000000000000000000000000000"},
+			},
+			err: "Changeset with external ID I0000000000000000000000000000000000000000 not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "GerritSource_LoadChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			cf, save := newClientFactory(t, tc.name)
+			defer save(t)
+
+			src := newGerritSource(t, cf)
+
+			if tc.err == "" {
+				tc.err = "<nil>"
+			}
+
+			err := src.LoadChangeset(context.Background(), tc.cs)
+			if have, want := fmt.Sprint(err), tc.err; have != want {
+				t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+			}
+
+			if err != nil {
+				return
+			}
+
+			meta := tc.cs.Changeset.Metadata.(*gerritbatches.AnnotatedChange)
+			assert.Equal(t, 340120, meta.Number)
+			assert.Equal(t, "https://gerrit-review.googlesource.com", meta.CodeHostURL)
+			url, err := meta.URL()
+			assert.Nil(t, err)
+			assert.Equal(t, "https://gerrit-review.googlesource.com/c/TestRepo/+/340120", url)
+			assert.Equal(t, "refs/heads/batch-changes/update-readme", tc.cs.Changeset.ExternalBranch)
+			assert.Equal(t, extsvc.TypeGerrit, tc.cs.Changeset.ExternalServiceType)
+		})
+	}
+}
+
+func TestGerritSource_CloseChangeset(t *testing.T) {
+	name := "GerritSource_CloseChangeset_success"
+
+	cf, save := newClientFactory(t, name)
+	defer save(t)
+
+	src := newGerritSource(t, cf)
+
+	cs := &Changeset{
+		Changeset: &btypes.Changeset{
+			ExternalID: "I5e2b2a6f6e6f7e3c0c6cb4b1ba0ce5dd5cdb0d4e",
+			Metadata: &gerritbatches.AnnotatedChange{
+				Change: &gerrit.Change{
+					ChangeID: "I5e2b2a6f6e6f7e3c0c6cb4b1ba0ce5dd5cdb0d4e",
+					Number:   340120,
+					Status:   gerrit.ChangeStatusNew,
+				},
+			},
+		},
+	}
+
+	if err := src.CloseChangeset(context.Background(), cs); err != nil {
+		t.Fatal(err)
+	}
+
+	meta := cs.Changeset.Metadata.(*gerritbatches.AnnotatedChange)
+	assert.Equal(t, gerrit.ChangeStatusAbandoned, meta.Status)
+	assert.NotEmpty(t, meta.Messages)
+}
+
+func TestGerritSource_InvalidMetadata(t *testing.T) {
+	src, err := NewGerritSource(&types.ExternalService{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cs := &Changeset{
+		Changeset: &btypes.Changeset{
+			ExternalID: "I5e2b2a6f6e6f7e3c0c6cb4b1ba0ce5dd5cdb0d4e",
+			Metadata:   &gerrit.Change{},
+		},
+	}
+
+	assert.EqualError(t, src.UpdateChangeset(context.Background(), cs), "Changeset is not a Gerrit change")
+	assert.EqualError(t, src.ReopenChangeset(context.Background(), cs), "Changeset is not a Gerrit change")
+}
+
+func TestGerritSource_WithAuthenticator(t *testing.T) {
+	src := newGerritSource(t, nil)
+
+	t.Run("supported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"BasicAuth":        &auth.BasicAuth{},
+			"BasicAuthWithSSH": &auth.BasicAuthWithSSH{},
+		} {
+			t.Run(name, func(t *testing.T) {
+				out, err := src.WithAuthenticator(tc)
+				assert.Nil(t, err)
+				if gs, ok := out.(*GerritSource); !ok {
+					t.Error("cannot coerce Source into GerritSource")
+				} else {
+					assert.NotNil(t, gs)
+					assert.Equal(t, tc, gs.client.Authenticator())
+				}
+			})
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"nil":                     nil,
+			"OAuthBearerToken":        &auth.OAuthBearerToken{},
+			"OAuthBearerTokenWithSSH": &auth.OAuthBearerTokenWithSSH{},
+		} {
+			t.Run(name, func(t *testing.T) {
+				out, err := src.WithAuthenticator(tc)
+				assert.Nil(t, out)
+				assert.True(t, errors.HasType(err, UnsupportedAuthenticatorError{}))
+			})
+		}
+	})
+}
+
+func TestGerritChangeID(t *testing.T) {
+	repo := &types.Repo{
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "TestRepo",
+			ServiceType: extsvc.TypeGerrit,
+			ServiceID:   "https://gerrit-review.googlesource.com/",
+		},
+	}
+	other := &types.Repo{
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "OtherRepo",
+			ServiceType: extsvc.TypeGerrit,
+			ServiceID:   "https://gerrit-review.googlesource.com/",
+		},
+	}
+
+	id := GerritChangeID(repo, "refs/heads/batch-changes/update-readme")
+	assert.Len(t, id, 41)
+	assert.Equal(t, "I", id[:1])
+
+	// The Change-Id must be stable, regardless of whether the head ref is
+	// abbreviated, and unique per repo and branch.
+	assert.Equal(t, id, GerritChangeID(repo, "batch-changes/update-readme"))
+	assert.NotEqual(t, id, GerritChangeID(repo, "batch-changes/other"))
+	assert.NotEqual(t, id, GerritChangeID(other, "batch-changes/update-readme"))
+}
+
+func TestAppendGerritChangeID(t *testing.T) {
+	for name, tc := range map[string]struct {
+		message string
+		want    string
+	}{
+		"subject only": {
+			message: "Update README",
+			want:    "Update README\n\nChange-Id: I123\n",
+		},
+		"with body": {
+			message: "Update README\n\nThis updates the README.\n",
+			want:    "Update README\n\nThis updates the README.\n\nChange-Id: I123\n",
+		},
+		"existing trailer": {
+			message: "Update README\n\nChange-Id: I456\n",
+			want:    "Update README\n\nChange-Id: I123\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, AppendGerritChangeID(tc.message, "I123"))
+		})
+	}
+}
+
+func TestGerritPushRef(t *testing.T) {
+	assert.Equal(t, "refs/for/main%topic=batch-changes/update-readme", GerritPushRef("refs/heads/main", "refs/heads/batch-changes/update-readme"))
+	assert.Equal(t, "refs/for/main%topic=my-branch", GerritPushRef("main", "my-branch"))
+}
+
+func newGerritSource(t *testing.T, cf *httpcli.Factory) *GerritSource {
+	t.Helper()
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindGerrit,
+		Config: marshalJSON(t, &schema.GerritConnection{
+			Url:      "https://gerrit-review.googlesource.com",
+			Username: os.Getenv("GERRIT_USERNAME"),
+			Password: os.Getenv("GERRIT_PASSWORD"),
+		}),
+	}
+
+	src, err := NewGerritSource(svc, cf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return src
+}
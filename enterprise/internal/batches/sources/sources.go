@@ -324,6 +324,14 @@ func loadExternalService(ctx context.Context, s database.ExternalServiceStore, o
 			if cfg.AppPassword != "" {
 				return e, nil
 			}
+		case *schema.GerritConnection:
+			if cfg.Password != "" {
+				return e, nil
+			}
+		case *schema.AWSCodeCommitConnection:
+			if cfg.AccessKeyID != "" {
+				return e, nil
+			}
 		}
 	}
 
@@ -343,6 +351,10 @@ func buildChangesetSource(cf *httpcli.Factory, externalService *types.ExternalSe
 		return NewBitbucketServerSource(externalService, cf)
 	case extsvc.KindBitbucketCloud:
 		return NewBitbucketCloudSource(externalService, cf)
+	case extsvc.KindGerrit:
+		return NewGerritSource(externalService, cf)
+	case extsvc.KindAWSCodeCommit:
+		return NewAWSCodeCommitSource(externalService, cf)
 	default:
 		return nil, errors.Errorf("unsupported external service type %q", extsvc.KindToType(externalService.Kind))
 	}
@@ -395,6 +407,12 @@ func setOAuthTokenAuth(u *vcs.URL, extSvcType, token string) error {
 	case extsvc.TypeBitbucketServer:
 		return errors.New("require username/token to push commits to BitbucketServer")
 
+	case extsvc.TypeGerrit:
+		return errors.New("require username/password to push commits to Gerrit")
+
+	case extsvc.TypeAWSCodeCommit:
+		return errors.New("require Git credentials to push commits to AWS CodeCommit")
+
 	default:
 		panic(fmt.Sprintf("setOAuthTokenAuth: invalid external service type %q", extSvcType))
 	}
@@ -408,7 +426,7 @@ func setBasicAuth(u *vcs.URL, extSvcType, username, password string) error {
 	case extsvc.TypeGitHub, extsvc.TypeGitLab:
 		return errors.New("need token to push commits to " + extSvcType)
 
-	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud:
+	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud, extsvc.TypeGerrit, extsvc.TypeAWSCodeCommit:
 		u.User = url.UserPassword(username, password)
 
 	default:
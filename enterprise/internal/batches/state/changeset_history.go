@@ -58,17 +58,22 @@ var RequiredEventTypesForHistory = []btypes.ChangesetEventKind{
 	// Closed, unmerged.
 	btypes.ChangesetEventKindBitbucketCloudPullRequestRejected,
 	btypes.ChangesetEventKindBitbucketServerDeclined,
+	btypes.ChangesetEventKindAWSCodeCommitPullRequestClosed,
+	btypes.ChangesetEventKindGerritChangeAbandoned,
 	btypes.ChangesetEventKindGitHubClosed,
 	btypes.ChangesetEventKindGitLabClosed,
 
 	// Closed, merged.
+	btypes.ChangesetEventKindAWSCodeCommitPullRequestMerged,
 	btypes.ChangesetEventKindBitbucketCloudPullRequestFulfilled,
 	btypes.ChangesetEventKindBitbucketServerMerged,
+	btypes.ChangesetEventKindGerritChangeMerged,
 	btypes.ChangesetEventKindGitHubMerged,
 	btypes.ChangesetEventKindGitLabMerged,
 
 	// Reopened
 	btypes.ChangesetEventKindBitbucketServerReopened,
+	btypes.ChangesetEventKindGerritChangeRestored,
 	btypes.ChangesetEventKindGitHubReopened,
 	btypes.ChangesetEventKindGitLabReopened,
 
@@ -76,13 +81,17 @@ var RequiredEventTypesForHistory = []btypes.ChangesetEventKind{
 	btypes.ChangesetEventKindGitHubReviewed,
 
 	// Reviewed, approved.
+	btypes.ChangesetEventKindAWSCodeCommitPullRequestApproved,
 	btypes.ChangesetEventKindBitbucketCloudApproved,
 	btypes.ChangesetEventKindBitbucketCloudPullRequestApproved,
 	btypes.ChangesetEventKindBitbucketServerApproved,
 	btypes.ChangesetEventKindBitbucketServerReviewed,
+	btypes.ChangesetEventKindGerritChangeApproved,
+	btypes.ChangesetEventKindGerritChangeRejected,
 	btypes.ChangesetEventKindGitLabApproved,
 
 	// Reviewed, not approved.
+	btypes.ChangesetEventKindAWSCodeCommitPullRequestApprovalRevoked,
 	btypes.ChangesetEventKindBitbucketCloudPullRequestChangesRequestRemoved,
 	btypes.ChangesetEventKindBitbucketCloudPullRequestUnapproved,
 	btypes.ChangesetEventKindBitbucketServerUnapproved,
@@ -143,7 +152,9 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 		case btypes.ChangesetEventKindGitHubClosed,
 			btypes.ChangesetEventKindBitbucketServerDeclined,
 			btypes.ChangesetEventKindGitLabClosed,
-			btypes.ChangesetEventKindBitbucketCloudPullRequestRejected:
+			btypes.ChangesetEventKindBitbucketCloudPullRequestRejected,
+			btypes.ChangesetEventKindGerritChangeAbandoned,
+			btypes.ChangesetEventKindAWSCodeCommitPullRequestClosed:
 			// Merged and ReadOnly are final states. We can ignore everything after.
 			if currentExtState != btypes.ChangesetExternalStateMerged &&
 				currentExtState != btypes.ChangesetExternalStateReadOnly {
@@ -154,7 +165,9 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 		case btypes.ChangesetEventKindGitHubMerged,
 			btypes.ChangesetEventKindBitbucketServerMerged,
 			btypes.ChangesetEventKindGitLabMerged,
-			btypes.ChangesetEventKindBitbucketCloudPullRequestFulfilled:
+			btypes.ChangesetEventKindBitbucketCloudPullRequestFulfilled,
+			btypes.ChangesetEventKindGerritChangeMerged,
+			btypes.ChangesetEventKindAWSCodeCommitPullRequestMerged:
 			currentExtState = btypes.ChangesetExternalStateMerged
 			pushStates(et)
 
@@ -186,7 +199,8 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 
 		case btypes.ChangesetEventKindGitHubReopened,
 			btypes.ChangesetEventKindBitbucketServerReopened,
-			btypes.ChangesetEventKindGitLabReopened:
+			btypes.ChangesetEventKindGitLabReopened,
+			btypes.ChangesetEventKindGerritChangeRestored:
 			// Merged and ReadOnly are final states. We can ignore everything after.
 			if currentExtState != btypes.ChangesetExternalStateMerged &&
 				currentExtState != btypes.ChangesetExternalStateReadOnly {
@@ -203,7 +217,10 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 			btypes.ChangesetEventKindBitbucketServerReviewed,
 			btypes.ChangesetEventKindGitLabApproved,
 			btypes.ChangesetEventKindBitbucketCloudApproved,
-			btypes.ChangesetEventKindBitbucketCloudPullRequestApproved:
+			btypes.ChangesetEventKindBitbucketCloudPullRequestApproved,
+			btypes.ChangesetEventKindGerritChangeApproved,
+			btypes.ChangesetEventKindGerritChangeRejected,
+			btypes.ChangesetEventKindAWSCodeCommitPullRequestApproved:
 			s, err := e.ReviewState()
 			if err != nil {
 				return nil, err
@@ -246,7 +263,8 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 			btypes.ChangesetEventKindBitbucketServerDismissed,
 			btypes.ChangesetEventKindGitLabUnapproved,
 			btypes.ChangesetEventKindBitbucketCloudPullRequestChangesRequestRemoved,
-			btypes.ChangesetEventKindBitbucketCloudPullRequestUnapproved:
+			btypes.ChangesetEventKindBitbucketCloudPullRequestUnapproved,
+			btypes.ChangesetEventKindAWSCodeCommitPullRequestApprovalRevoked:
 			author := e.ReviewAuthor()
 			// If the user has been deleted, skip their reviews, as they don't count towards the final state anymore.
 			if author == "" {
@@ -12,14 +12,17 @@ import (
 	"github.com/sourcegraph/log"
 
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -113,6 +116,9 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 
 	case *bbcs.AnnotatedPullRequest:
 		return computeBitbucketCloudBuildState(c.UpdatedAt, m, events)
+
+	case *gerritbatches.AnnotatedChange:
+		return computeGerritVerifiedState(m)
 	}
 
 	return btypes.ChangesetCheckStateUnknown
@@ -252,6 +258,31 @@ func parseBitbucketCloudBuildState(s bitbucketcloud.PullRequestStatusState) btyp
 	}
 }
 
+// computeGerritVerifiedState computes the check state of a Gerrit change based
+// on the votes on its Verified label, which is where CI systems conventionally
+// report their results. Gerrit doesn't send webhooks, so there are no events
+// to take into account.
+func computeGerritVerifiedState(c *gerritbatches.AnnotatedChange) btypes.ChangesetCheckState {
+	label, ok := c.Labels[gerrit.VerifiedLabel]
+	if !ok {
+		return btypes.ChangesetCheckStateUnknown
+	}
+
+	states := make([]btypes.ChangesetCheckState, 0, len(label.All))
+	for _, vote := range label.All {
+		switch {
+		case vote.Value > 0:
+			states = append(states, btypes.ChangesetCheckStatePassed)
+		case vote.Value < 0:
+			states = append(states, btypes.ChangesetCheckStateFailed)
+		default:
+			states = append(states, btypes.ChangesetCheckStatePending)
+		}
+	}
+
+	return combineCheckStates(states)
+}
+
 func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	// We should only consider the latest commit. This could be from a sync or a webhook that
 	// has occurred later
@@ -516,6 +547,32 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Bitbucket Cloud pull request state: %s", m.State)
 		}
+	case *gerritbatches.AnnotatedChange:
+		switch m.Status {
+		case gerrit.ChangeStatusAbandoned:
+			s = btypes.ChangesetExternalStateClosed
+		case gerrit.ChangeStatusMerged:
+			s = btypes.ChangesetExternalStateMerged
+		case gerrit.ChangeStatusNew:
+			if m.WorkInProgress {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Gerrit change status: %s", m.Status)
+		}
+	case *awscodecommit.PullRequest:
+		switch {
+		case m.Target.IsMerged:
+			s = btypes.ChangesetExternalStateMerged
+		case m.Status == awscodecommit.PullRequestStatusClosed:
+			s = btypes.ChangesetExternalStateClosed
+		case m.Status == awscodecommit.PullRequestStatusOpen:
+			s = btypes.ChangesetExternalStateOpen
+		default:
+			return "", errors.Errorf("unknown AWS CodeCommit pull request status: %s", m.Status)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -585,6 +642,30 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 		}
 
+	case *gerritbatches.AnnotatedChange:
+		// Only a +2 vote on the Code-Review label approves a change, while any
+		// negative vote means that changes were requested.
+		for _, vote := range m.Labels[gerrit.CodeReviewLabel].All {
+			switch {
+			case vote.Value >= 2:
+				states[btypes.ChangesetReviewStateApproved] = true
+			case vote.Value < 0:
+				states[btypes.ChangesetReviewStateChangesRequested] = true
+			default:
+				states[btypes.ChangesetReviewStatePending] = true
+			}
+		}
+
+	case *awscodecommit.PullRequest:
+		// AWS CodeCommit has no concept of requesting changes: reviewers can
+		// only approve the current revision of a pull request, or revoke their
+		// approval.
+		for _, approval := range m.Approvals {
+			if approval.State == awscodecommit.ApprovalStateApprove {
+				states[btypes.ChangesetReviewStateApproved] = true
+			}
+		}
+
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -9,10 +9,13 @@ import (
 	"github.com/sourcegraph/go-diff/diff"
 
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -420,6 +423,27 @@ func (c *Changeset) SetMetadata(meta any) error {
 		} else {
 			c.ExternalForkNamespace = ""
 		}
+	case *gerritbatches.AnnotatedChange:
+		c.Metadata = pr
+		c.ExternalID = pr.ChangeID
+		c.ExternalServiceType = extsvc.TypeGerrit
+		// Gerrit changes don't have a head branch. Changesets published by
+		// batch changes use the branch name as the topic of the change, so we
+		// use that where it's available.
+		if pr.Topic != "" {
+			c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.Topic)
+		} else {
+			c.ExternalBranch = ""
+		}
+		c.ExternalUpdatedAt = pr.Updated.Time
+		c.ExternalForkNamespace = ""
+	case *awscodecommit.PullRequest:
+		c.Metadata = pr
+		c.ExternalID = pr.ID
+		c.ExternalServiceType = extsvc.TypeAWSCodeCommit
+		c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.Target.SourceReference)
+		c.ExternalUpdatedAt = pr.LastActivityDate
+		c.ExternalForkNamespace = ""
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -447,6 +471,10 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Title, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Subject, nil
+	case *awscodecommit.PullRequest:
+		return m.Title, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -466,6 +494,10 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.Author.Username, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Author.Username, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Username, nil
+	case *awscodecommit.PullRequest:
+		return m.AuthorName(), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -493,6 +525,11 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		// Bitbucket Cloud does not provide the e-mail of the author under any
 		// circumstances.
 		return "", nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Email, nil
+	case *awscodecommit.PullRequest:
+		// AWS CodeCommit only knows the IAM identity of the author.
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -511,6 +548,10 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt.Time
 	case *bbcs.AnnotatedPullRequest:
 		return m.CreatedOn
+	case *gerritbatches.AnnotatedChange:
+		return m.Created.Time
+	case *awscodecommit.PullRequest:
+		return m.CreationDate
 	default:
 		return time.Time{}
 	}
@@ -527,6 +568,10 @@ func (c *Changeset) Body() (string, error) {
 		return m.Description, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Rendered.Description.Raw, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Description(), nil
+	case *awscodecommit.PullRequest:
+		return m.Description, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -572,6 +617,10 @@ func (c *Changeset) URL() (s string, err error) {
 		// pull request ID, but since the link _should_ be there, we'll error
 		// instead.
 		return "", errors.New("Bitbucket Cloud pull request does not have a html link")
+	case *gerritbatches.AnnotatedChange:
+		return m.URL()
+	case *awscodecommit.PullRequest:
+		return m.URL(), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -744,6 +793,58 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 				Metadata:    status,
 			})
 		}
+
+	case *gerritbatches.AnnotatedChange:
+		// Review events are created from the votes on the Code-Review label,
+		// everything else (new patch sets, abandoning, restoring and merging
+		// the change, and comments) is recorded by Gerrit as change messages.
+		events = make([]*ChangesetEvent, 0, len(m.Messages)+len(m.Labels[gerrit.CodeReviewLabel].All))
+		var kind ChangesetEventKind
+
+		for _, vote := range m.Labels[gerrit.CodeReviewLabel].All {
+			// Reviewers without a date haven't voted yet.
+			if vote.Date.IsZero() {
+				continue
+			}
+			reviewer := &gerrit.Reviewer{ApprovalInfo: vote, ChangeID: m.ChangeID}
+			if kind, err = ChangesetEventKindFor(reviewer); err != nil {
+				return
+			}
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         reviewer.Key(),
+				Kind:        kind,
+				Metadata:    reviewer,
+			})
+		}
+
+		for _, message := range m.Messages {
+			if kind, err = ChangesetEventKindFor(message); err != nil {
+				return
+			}
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         message.Key(),
+				Kind:        kind,
+				Metadata:    message,
+			})
+		}
+
+	case *awscodecommit.PullRequest:
+		events = make([]*ChangesetEvent, 0, len(m.Events))
+		var kind ChangesetEventKind
+
+		for _, e := range m.Events {
+			if kind, err = ChangesetEventKindFor(e); err != nil {
+				return
+			}
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         e.Key(),
+				Kind:        kind,
+				Metadata:    e,
+			})
+		}
 	}
 	return events, nil
 }
@@ -761,6 +862,10 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.DiffRefs.HeadSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Source.Commit.Hash, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.CurrentRevision, nil
+	case *awscodecommit.PullRequest:
+		return m.Target.SourceCommit, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -778,6 +883,15 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.SourceBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Source.Branch.Name, nil
+	case *gerritbatches.AnnotatedChange:
+		// Gerrit changes don't have a head branch, but every patch set is
+		// available under its own ref.
+		if rev, ok := m.CurrentPatchSet(); ok {
+			return rev.Ref, nil
+		}
+		return "", nil
+	case *awscodecommit.PullRequest:
+		return gitdomain.EnsureRefPrefix(m.Target.SourceReference), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -796,6 +910,13 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.DiffRefs.BaseSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Destination.Commit.Hash, nil
+	case *gerritbatches.AnnotatedChange:
+		if rev, ok := m.CurrentPatchSet(); ok && rev.Commit != nil && len(rev.Commit.Parents) > 0 {
+			return rev.Commit.Parents[0].Commit, nil
+		}
+		return "", nil
+	case *awscodecommit.PullRequest:
+		return m.Target.DestinationCommit, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -813,6 +934,10 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.TargetBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Destination.Branch.Name, nil
+	case *gerritbatches.AnnotatedChange:
+		return "refs/heads/" + m.Branch, nil
+	case *awscodecommit.PullRequest:
+		return gitdomain.EnsureRefPrefix(m.Target.DestinationReference), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -1127,6 +1252,50 @@ func ChangesetEventKindFor(e any) (ChangesetEventKind, error) {
 		return ChangesetEventKindBitbucketCloudRepoCommitStatusCreated, nil
 	case *bitbucketcloud.RepoCommitStatusUpdatedEvent:
 		return ChangesetEventKindBitbucketCloudRepoCommitStatusUpdated, nil
+
+	case *gerrit.Reviewer:
+		switch {
+		case e.Value >= 2:
+			return ChangesetEventKindGerritChangeApproved, nil
+		case e.Value < 0:
+			return ChangesetEventKindGerritChangeRejected, nil
+		default:
+			return ChangesetEventKindGerritChangeReviewed, nil
+		}
+	case *gerrit.ChangeMessage:
+		switch e.Tag {
+		case gerrit.MessageTagAbandon:
+			return ChangesetEventKindGerritChangeAbandoned, nil
+		case gerrit.MessageTagRestore:
+			return ChangesetEventKindGerritChangeRestored, nil
+		case gerrit.MessageTagMerged:
+			return ChangesetEventKindGerritChangeMerged, nil
+		case gerrit.MessageTagNewPatchSet:
+			return ChangesetEventKindGerritPatchSetCreated, nil
+		default:
+			return ChangesetEventKindGerritChangeCommented, nil
+		}
+
+	case *awscodecommit.PullRequestEvent:
+		switch e.Type {
+		case awscodecommit.PullRequestEventTypeStatusChanged:
+			// Closed pull requests cannot be reopened, so the status can only
+			// ever change to closed.
+			return ChangesetEventKindAWSCodeCommitPullRequestClosed, nil
+		case awscodecommit.PullRequestEventTypeApprovalStateChanged:
+			if e.ApprovalState == awscodecommit.ApprovalStateApprove {
+				return ChangesetEventKindAWSCodeCommitPullRequestApproved, nil
+			}
+			return ChangesetEventKindAWSCodeCommitPullRequestApprovalRevoked, nil
+		case awscodecommit.PullRequestEventTypeCreated:
+			return ChangesetEventKindAWSCodeCommitPullRequestCreated, nil
+		case awscodecommit.PullRequestEventTypeMergeStateChanged:
+			return ChangesetEventKindAWSCodeCommitPullRequestMerged, nil
+		case awscodecommit.PullRequestEventTypeSourceReferenceUpdated:
+			return ChangesetEventKindAWSCodeCommitPullRequestSourceReferenceUpdated, nil
+		default:
+			return ChangesetEventKind("awscodecommit:" + strings.ToLower(string(e.Type))), nil
+		}
 	}
 
 	return ChangesetEventKindInvalid, errors.Errorf("unknown changeset event kind for %T", e)
@@ -1170,6 +1339,17 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (any, error) {
 		case ChangesetEventKindBitbucketCloudRepoCommitStatusUpdated:
 			return new(bitbucketcloud.RepoCommitStatusUpdatedEvent), nil
 		}
+	case strings.HasPrefix(string(k), "awscodecommit"):
+		return new(awscodecommit.PullRequestEvent), nil
+	case strings.HasPrefix(string(k), "gerrit"):
+		switch k {
+		case ChangesetEventKindGerritChangeApproved,
+			ChangesetEventKindGerritChangeRejected,
+			ChangesetEventKindGerritChangeReviewed:
+			return new(gerrit.Reviewer), nil
+		default:
+			return new(gerrit.ChangeMessage), nil
+		}
 	case strings.HasPrefix(string(k), "bitbucketserver"):
 		switch k {
 		case ChangesetEventKindBitbucketServerCommitStatus:
@@ -8,8 +8,10 @@ import (
 
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
@@ -102,6 +104,29 @@ const (
 	ChangesetEventKindBitbucketCloudRepoCommitStatusCreated          ChangesetEventKind = "bitbucketcloud:repo:commit_status_created"          // RepoCommitStatusCreatedEvent
 	ChangesetEventKindBitbucketCloudRepoCommitStatusUpdated          ChangesetEventKind = "bitbucketcloud:repo:commit_status_updated"          // RepoCommitStatusUpdatedEvent
 
+	// These changeset events are created as the result of regular syncs with
+	// Gerrit. Review events are based on the votes on the Code-Review label,
+	// all other events on the messages Gerrit records on the change.
+	ChangesetEventKindGerritChangeApproved  ChangesetEventKind = "gerrit:change:approved"
+	ChangesetEventKindGerritChangeRejected  ChangesetEventKind = "gerrit:change:rejected"
+	ChangesetEventKindGerritChangeReviewed  ChangesetEventKind = "gerrit:change:reviewed"
+	ChangesetEventKindGerritChangeAbandoned ChangesetEventKind = "gerrit:change:abandoned"
+	ChangesetEventKindGerritChangeRestored  ChangesetEventKind = "gerrit:change:restored"
+	ChangesetEventKindGerritChangeMerged    ChangesetEventKind = "gerrit:change:merged"
+	ChangesetEventKindGerritChangeCommented ChangesetEventKind = "gerrit:change:commented"
+	ChangesetEventKindGerritPatchSetCreated ChangesetEventKind = "gerrit:patchset:created"
+
+	// These changeset events are created from the pull request events
+	// returned by AWS CodeCommit on regular syncs. Events without a constant
+	// here, such as changes to approval rules, use the lower-cased event type
+	// as their kind.
+	ChangesetEventKindAWSCodeCommitPullRequestCreated                ChangesetEventKind = "awscodecommit:pull_request_created"
+	ChangesetEventKindAWSCodeCommitPullRequestClosed                 ChangesetEventKind = "awscodecommit:pull_request_closed"
+	ChangesetEventKindAWSCodeCommitPullRequestMerged                 ChangesetEventKind = "awscodecommit:pull_request_merge_state_changed"
+	ChangesetEventKindAWSCodeCommitPullRequestSourceReferenceUpdated ChangesetEventKind = "awscodecommit:pull_request_source_reference_updated"
+	ChangesetEventKindAWSCodeCommitPullRequestApproved               ChangesetEventKind = "awscodecommit:pull_request_approved"
+	ChangesetEventKindAWSCodeCommitPullRequestApprovalRevoked        ChangesetEventKind = "awscodecommit:pull_request_approval_revoked"
+
 	ChangesetEventKindInvalid ChangesetEventKind = "invalid"
 )
 
@@ -163,6 +188,12 @@ func (e *ChangesetEvent) ReviewAuthor() string {
 	case *bitbucketcloud.PullRequestChangesRequestRemovedEvent:
 		return meta.ChangesRequest.User.UUID
 
+	case *gerrit.Reviewer:
+		return meta.Username
+
+	case *awscodecommit.PullRequestEvent:
+		return meta.ActorARN
+
 	default:
 		return ""
 	}
@@ -174,14 +205,17 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 	case ChangesetEventKindBitbucketServerApproved,
 		ChangesetEventKindGitLabApproved,
 		ChangesetEventKindBitbucketCloudApproved,
-		ChangesetEventKindBitbucketCloudPullRequestApproved:
+		ChangesetEventKindBitbucketCloudPullRequestApproved,
+		ChangesetEventKindGerritChangeApproved,
+		ChangesetEventKindAWSCodeCommitPullRequestApproved:
 		return ChangesetReviewStateApproved, nil
 
 	// BitbucketServer's "REVIEWED" activity is created when someone clicks
 	// the "Needs work" button in the UI, which is why we map it to "Changes Requested"
 	case ChangesetEventKindBitbucketServerReviewed,
 		ChangesetEventKindBitbucketCloudChangesRequested,
-		ChangesetEventKindBitbucketCloudPullRequestChangesRequestCreated:
+		ChangesetEventKindBitbucketCloudPullRequestChangesRequestCreated,
+		ChangesetEventKindGerritChangeRejected:
 		return ChangesetReviewStateChangesRequested, nil
 
 	case ChangesetEventKindGitHubReviewed:
@@ -203,7 +237,8 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 		ChangesetEventKindBitbucketServerDismissed,
 		ChangesetEventKindGitLabUnapproved,
 		ChangesetEventKindBitbucketCloudPullRequestUnapproved,
-		ChangesetEventKindBitbucketCloudPullRequestChangesRequestRemoved:
+		ChangesetEventKindBitbucketCloudPullRequestChangesRequestRemoved,
+		ChangesetEventKindAWSCodeCommitPullRequestApprovalRevoked:
 		return ChangesetReviewStateDismissed, nil
 
 	default:
@@ -316,6 +351,12 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = ev.CommitStatus.CreatedOn
 	case *bitbucketcloud.RepoCommitStatusUpdatedEvent:
 		t = ev.CommitStatus.UpdatedOn
+	case *gerrit.Reviewer:
+		t = ev.Date.Time
+	case *gerrit.ChangeMessage:
+		t = ev.Date.Time
+	case *awscodecommit.PullRequestEvent:
+		t = ev.Date
 	}
 
 	return t
@@ -854,6 +895,18 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 		o := o.Metadata.(*bitbucketcloud.RepoCommitStatusUpdatedEvent)
 		*e = *o
 
+	case *gerrit.Reviewer:
+		o := o.Metadata.(*gerrit.Reviewer)
+		*e = *o
+
+	case *gerrit.ChangeMessage:
+		o := o.Metadata.(*gerrit.ChangeMessage)
+		*e = *o
+
+	case *awscodecommit.PullRequestEvent:
+		o := o.Metadata.(*awscodecommit.PullRequestEvent)
+		*e = *o
+
 	default:
 		return errors.Errorf("unknown changeset event metadata %T", e)
 	}
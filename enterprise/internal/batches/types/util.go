@@ -25,6 +25,8 @@ var SupportedExternalServices = map[string]CodehostCapabilities{
 	extsvc.TypeBitbucketServer: {},
 	extsvc.TypeGitLab:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
 	extsvc.TypeBitbucketCloud:  {},
+	extsvc.TypeGerrit:          {},
+	extsvc.TypeAWSCodeCommit:   {},
 }
 
 // IsRepoSupported returns whether the given ExternalRepoSpec is supported by
@@ -3,6 +3,7 @@ package background
 import (
 	"net/url"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -17,5 +18,6 @@ type actionArgs struct {
 
 	Query          string
 	Results        []*result.CommitMatch
+	FileResults    []*edb.FileResult
 	IncludeResults bool
 }
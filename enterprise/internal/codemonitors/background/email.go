@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"fmt"
 	"net/url"
+	"strings"
 	"sync"
 
 	"github.com/graph-gophers/graphql-go/relay"
@@ -77,10 +78,16 @@ func NewTemplateDataForNewSearchResults(args actionArgs, email *edb.EmailAction)
 	}
 
 	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	truncatedFileResults, fileTotalCount, fileTruncatedCount := truncateFileResults(args.FileResults, 5)
+	totalCount += fileTotalCount
+	truncatedCount += fileTruncatedCount
 
-	displayResults := make([]*DisplayResult, len(truncatedResults))
-	for i, result := range truncatedResults {
-		displayResults[i] = toDisplayResult(result, args.ExternalURL)
+	displayResults := make([]*DisplayResult, 0, len(truncatedResults)+len(truncatedFileResults))
+	for _, result := range truncatedResults {
+		displayResults = append(displayResults, toDisplayResult(result, args.ExternalURL))
+	}
+	for _, result := range truncatedFileResults {
+		displayResults = append(displayResults, toFileDisplayResult(result, args.ExternalURL))
 	}
 
 	return &TemplateDataNewSearchResults{
@@ -149,6 +156,10 @@ func getCommitURL(externalURL *url.URL, repoName, oid, utmSource string) string
 	return sourcegraphURL(externalURL, fmt.Sprintf("%s/-/commit/%s", repoName, oid), "", utmSource)
 }
 
+func getFileURL(externalURL *url.URL, repoName, path, utmSource string) string {
+	return sourcegraphURL(externalURL, fmt.Sprintf("%s/-/blob/%s", repoName, path), "", utmSource)
+}
+
 var (
 	externalURLOnce  sync.Once
 	externalURLValue *url.URL
@@ -197,6 +208,11 @@ type DisplayResult struct {
 	RepoName   string
 	CommitID   string
 	Content    string
+
+	// FileURL and Path are only set for the file results of content and
+	// symbol searches.
+	FileURL string
+	Path    string
 }
 
 func toDisplayResult(result *result.CommitMatch, externalURL *url.URL) *DisplayResult {
@@ -220,3 +236,27 @@ func toDisplayResult(result *result.CommitMatch, externalURL *url.URL) *DisplayR
 		Content:    content,
 	}
 }
+
+func toFileDisplayResult(result *edb.FileResult, externalURL *url.URL) *DisplayResult {
+	resultType, content := describeFileResult(result)
+	return &DisplayResult{
+		ResultType: resultType,
+		FileURL:    getFileURL(externalURL, string(result.Repo.Name), result.Path, utmSourceEmail),
+		RepoName:   string(result.Repo.Name),
+		Path:       result.Path,
+		Content:    content,
+	}
+}
+
+// describeFileResult returns the type of a file result for display, along with
+// its truncated content. Removed results have no content.
+func describeFileResult(result *edb.FileResult) (resultType, content string) {
+	switch {
+	case result.Removed:
+		return "Removed", ""
+	case len(result.Symbols) > 0:
+		return "Symbol", truncateString(strings.Join(result.Symbols, "\n"), 10)
+	default:
+		return "Content", truncateString(result.Preview, 10)
+	}
+}
@@ -185,4 +185,34 @@ func TestEmail(t *testing.T) {
 		})
 	})
 
+	t.Run("file results with results", func(t *testing.T) {
+		templateData := &TemplateDataNewSearchResults{
+			Priority:                  "",
+			CodeMonitorURL:            "https://sourcegraph.com/your/code/monitor",
+			SearchURL:                 "https://sourcegraph.com/search",
+			Description:               "My test monitor",
+			TotalCount:                2,
+			ResultPluralized:          "results",
+			IncludeResults:            true,
+			TruncatedCount:            0,
+			TruncatedResults:          []*DisplayResult{contentFileDisplayResultMock, removedFileDisplayResultMock},
+			TruncatedResultPluralized: "results",
+			DisplayMoreLink:           false,
+		}
+
+		t.Run("html", func(t *testing.T) {
+			var buf bytes.Buffer
+			err := template.Html.Execute(&buf, templateData)
+			require.NoError(t, err)
+			autogold.Equal(t, autogold.Raw(buf.String()))
+		})
+
+		t.Run("text", func(t *testing.T) {
+			var buf bytes.Buffer
+			err := template.Text.Execute(&buf, templateData)
+			require.NoError(t, err)
+			autogold.Equal(t, autogold.Raw(buf.String()))
+		})
+	})
+
 }
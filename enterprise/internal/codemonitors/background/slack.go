@@ -11,6 +11,7 @@ import (
 
 	"github.com/slack-go/slack"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -26,6 +27,9 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 	}
 
 	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	truncatedFileResults, fileTotalCount, fileTruncatedCount := truncateFileResults(args.FileResults, 5)
+	totalCount += fileTotalCount
+	truncatedCount += fileTruncatedCount
 
 	blocks := []slack.Block{
 		newMarkdownSection(fmt.Sprintf(
@@ -57,6 +61,19 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 			}
 			blocks = append(blocks, newMarkdownSection(fmt.Sprintf("```%s```", contentRaw)))
 		}
+		for _, result := range truncatedFileResults {
+			resultType, content := describeFileResult(result)
+			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
+				"%s match: <%s|%s/%s>",
+				resultType,
+				getFileURL(args.ExternalURL, string(result.Repo.Name), result.Path, args.UTMSource),
+				result.Repo.Name,
+				result.Path,
+			)))
+			if content != "" {
+				blocks = append(blocks, newMarkdownSection(fmt.Sprintf("```%s```", content)))
+			}
+		}
 		if truncatedCount > 0 {
 			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
 				"...and <%s|%d more matches>.",
@@ -110,6 +127,16 @@ func truncateResults(results []*result.CommitMatch, maxResults int) (_ []*result
 	return output, totalCount, totalCount - outputCount
 }
 
+// truncateFileResults truncates the file results of a content or symbol search
+// code monitor. Unlike commit matches, every file result counts as a single
+// result.
+func truncateFileResults(results []*edb.FileResult, maxResults int) (_ []*edb.FileResult, totalCount, truncatedCount int) {
+	if len(results) <= maxResults {
+		return results, len(results), 0
+	}
+	return results[:maxResults], len(results), len(results) - maxResults
+}
+
 // adapted from slack.PostWebhookCustomHTTPContext
 func postSlackWebhook(ctx context.Context, doer httpcli.Doer, url string, msg *slack.WebhookMessage) error {
 	raw, err := json.Marshal(msg)
@@ -12,6 +12,7 @@ import (
 	"github.com/hexops/autogold"
 	"github.com/stretchr/testify/require"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -83,6 +84,14 @@ func TestSlackWebhook(t *testing.T) {
 	t.Run("golden without results", func(t *testing.T) {
 		autogold.Equal(t, jsonSlackPayload(action))
 	})
+
+	t.Run("golden with file results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		actionCopy.Results = nil
+		actionCopy.FileResults = []*edb.FileResult{&contentFileResultMock, &removedFileResultMock}
+		autogold.Equal(t, jsonSlackPayload(actionCopy))
+	})
 }
 
 func TestTriggerTestSlackWebhookAction(t *testing.T) {
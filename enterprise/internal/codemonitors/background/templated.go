@@ -0,0 +1,356 @@
+package background
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+
+	"golang.org/x/net/http/httpguts"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// templatedWebhookPreset holds the defaults of a templated webhook action,
+// which are used when the action doesn't override them.
+type templatedWebhookPreset struct {
+	utmSource    string
+	url          string
+	contentType  string
+	bodyTemplate string
+}
+
+var templatedWebhookPresets = map[edb.TemplatedWebhookPreset]templatedWebhookPreset{
+	// Microsoft Teams incoming webhooks accept legacy actionable message cards.
+	edb.TemplatedWebhookPresetTeams: {
+		utmSource:   "code-monitor-teams-webhook",
+		contentType: "application/json",
+		bodyTemplate: `{
+	"@type": "MessageCard",
+	"@context": "https://schema.org/extensions",
+	"themeColor": "0076D7",
+	"summary": {{ json .Summary }},
+	"title": {{ json .Summary }},
+	"text": {{ json .Text }},
+	"potentialAction": [
+		{
+			"@type": "OpenUri",
+			"name": "View results",
+			"targets": [{"os": "default", "uri": {{ json .SearchURL }}}]
+		},
+		{
+			"@type": "OpenUri",
+			"name": "Edit code monitor",
+			"targets": [{"os": "default", "uri": {{ json .MonitorURL }}}]
+		}
+	]
+}`,
+	},
+	edb.TemplatedWebhookPresetMattermost: {
+		utmSource:   "code-monitor-mattermost-webhook",
+		contentType: "application/json",
+		bodyTemplate: `{
+	"username": "Sourcegraph",
+	"text": {{ json .Text }}
+}`,
+	},
+	edb.TemplatedWebhookPresetPagerDuty: {
+		utmSource:   "code-monitor-pagerduty-webhook",
+		url:         "https://events.pagerduty.com/v2/enqueue",
+		contentType: "application/json",
+		bodyTemplate: `{
+	"routing_key": {{ json .RoutingKey }},
+	"event_action": "trigger",
+	"payload": {
+		"summary": {{ json .Summary }},
+		"source": "Sourcegraph",
+		"severity": "warning",
+		"custom_details": {
+			"query": {{ json .Query }},
+			"totalCount": {{ .TotalCount }},
+			"results": {{ json .Results }}
+		}
+	},
+	"links": [
+		{"href": {{ json .SearchURL }}, "text": "View results"},
+		{"href": {{ json .MonitorURL }}, "text": "Edit code monitor"}
+	]
+}`,
+	},
+}
+
+// templatedWebhookFuncs are the functions available to the body template of
+// templated webhook actions.
+var templatedWebhookFuncs = template.FuncMap{
+	// json encodes a value as JSON, which is the safe way to embed strings
+	// in JSON request bodies.
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// templatedWebhookData is the data the body template of a templated webhook
+// action is rendered with.
+type templatedWebhookData struct {
+	MonitorDescription string
+	MonitorOwnerName   string
+	MonitorURL         string
+	Query              string
+	SearchURL          string
+	RoutingKey         string
+
+	// Summary is a single line of plain text describing the event.
+	Summary string
+	// Text is a Markdown description of the event. It includes the first few
+	// results if the action includes results.
+	Text string
+
+	TotalCount int
+	// Results is only set if the action includes results.
+	Results []templatedWebhookResult
+}
+
+type templatedWebhookResult struct {
+	Type       string `json:"type"`
+	Repository string `json:"repository"`
+	Commit     string `json:"commit,omitempty"`
+	Path       string `json:"path,omitempty"`
+	URL        string `json:"url"`
+	Content    string `json:"content,omitempty"`
+}
+
+// ValidateTemplatedWebhookAction returns an error if the templated webhook
+// action described by args can't be sent.
+func ValidateTemplatedWebhookAction(preset edb.TemplatedWebhookPreset, args *edb.TemplatedWebhookActionArgs) error {
+	p, ok := templatedWebhookPresets[preset]
+	if !ok {
+		return errors.Errorf("unknown templated webhook preset %q", preset)
+	}
+
+	rawURL := args.URL
+	if rawURL == "" {
+		rawURL = p.url
+	}
+	if rawURL == "" {
+		return errors.New("templated webhook URL must be set")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return errors.New("templated webhook URL must begin with 'https://' or 'http://'")
+	}
+
+	if preset == edb.TemplatedWebhookPresetPagerDuty && args.BodyTemplate == "" && args.RoutingKey == "" {
+		return errors.New("PagerDuty actions require a routing key")
+	}
+
+	if _, err := parseTemplatedWebhookBody(args.BodyTemplate, p); err != nil {
+		return err
+	}
+
+	for name, value := range args.Headers {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return errors.Errorf("invalid header name %q", name)
+		}
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return errors.Errorf("invalid value for header %q", name)
+		}
+	}
+	return nil
+}
+
+func parseTemplatedWebhookBody(bodyTemplate string, p templatedWebhookPreset) (*template.Template, error) {
+	if bodyTemplate == "" {
+		bodyTemplate = p.bodyTemplate
+	}
+	tmpl, err := template.New("body").Funcs(templatedWebhookFuncs).Option("missingkey=error").Parse(bodyTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid body template")
+	}
+	return tmpl, nil
+}
+
+func sendTemplatedWebhookNotification(ctx context.Context, action *edb.TemplatedWebhookAction, args actionArgs) error {
+	req, err := newTemplatedWebhookRequest(ctx, action, generateTemplatedWebhookData(action, args))
+	if err != nil {
+		return err
+	}
+	return postTemplatedWebhook(httpcli.ExternalDoer, req)
+}
+
+// newTemplatedWebhookRequest renders the request of a templated webhook
+// action, falling back to the defaults of its preset.
+func newTemplatedWebhookRequest(ctx context.Context, action *edb.TemplatedWebhookAction, data templatedWebhookData) (*http.Request, error) {
+	p, ok := templatedWebhookPresets[action.Preset]
+	if !ok {
+		return nil, errors.Errorf("unknown templated webhook preset %q", action.Preset)
+	}
+
+	tmpl, err := parseTemplatedWebhookBody(action.BodyTemplate, p)
+	if err != nil {
+		return nil, err
+	}
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return nil, errors.Wrap(err, "failed to render body template")
+	}
+
+	u := action.URL
+	if u == "" {
+		u = p.url
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, &body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed new request")
+	}
+
+	contentType := action.ContentType
+	if contentType == "" {
+		contentType = p.contentType
+	}
+	req.Header.Set("Content-Type", contentType)
+	for name, value := range action.Headers {
+		req.Header.Set(name, value)
+	}
+	return req, nil
+}
+
+func postTemplatedWebhook(doer httpcli.Doer, req *http.Request) error {
+	resp, err := doer.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to post webhook")
+	}
+	defer resp.Body.Close()
+
+	// Unlike Slack, some services acknowledge events with other 2xx status
+	// codes, such as 202 Accepted for PagerDuty.
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return StatusCodeError{
+			Code:   resp.StatusCode,
+			Status: resp.Status,
+			Body:   string(body),
+		}
+	}
+
+	return nil
+}
+
+func SendTestTemplatedWebhook(ctx context.Context, doer httpcli.Doer, description string, action *edb.TemplatedWebhookAction) error {
+	args := actionArgs{
+		ExternalURL:        &url.URL{},
+		MonitorDescription: description,
+		Query:              "test query",
+	}
+	data := generateTemplatedWebhookData(action, args)
+	data.Summary = fmt.Sprintf("Test message for Code Monitor '%s'", description)
+	data.Text = data.Summary
+
+	req, err := newTemplatedWebhookRequest(ctx, action, data)
+	if err != nil {
+		return err
+	}
+	return postTemplatedWebhook(doer, req)
+}
+
+func generateTemplatedWebhookData(action *edb.TemplatedWebhookAction, args actionArgs) templatedWebhookData {
+	utmSource := args.UTMSource
+	if utmSource == "" {
+		utmSource = templatedWebhookPresets[action.Preset].utmSource
+	}
+
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	truncatedFileResults, fileTotalCount, fileTruncatedCount := truncateFileResults(args.FileResults, 5)
+	totalCount += fileTotalCount
+	truncatedCount += fileTruncatedCount
+
+	data := templatedWebhookData{
+		MonitorDescription: args.MonitorDescription,
+		MonitorOwnerName:   args.MonitorOwnerName,
+		MonitorURL:         getCodeMonitorURL(args.ExternalURL, args.MonitorID, utmSource),
+		Query:              args.Query,
+		SearchURL:          getSearchURL(args.ExternalURL, args.Query, utmSource),
+		RoutingKey:         action.RoutingKey,
+		Summary:            fmt.Sprintf("Sourcegraph code monitor %s detected %d new %s", args.MonitorDescription, totalCount, pluralize("result", totalCount)),
+		TotalCount:         totalCount,
+	}
+	if args.IncludeResults {
+		data.Results = templatedWebhookResults(args.Results, args.FileResults, args.ExternalURL, utmSource)
+	}
+	data.Text = templatedWebhookText(data, templatedWebhookResults(truncatedResults, truncatedFileResults, args.ExternalURL, utmSource), truncatedCount, args.IncludeResults)
+	return data
+}
+
+func templatedWebhookResults(commitResults []*result.CommitMatch, fileResults []*edb.FileResult, externalURL *url.URL, utmSource string) []templatedWebhookResult {
+	results := make([]templatedWebhookResult, 0, len(commitResults)+len(fileResults))
+	for _, r := range commitResults {
+		resultType, content := "Message", r.MessagePreview
+		if r.DiffPreview != nil {
+			resultType, content = "Diff", r.DiffPreview
+		}
+		res := templatedWebhookResult{
+			Type:       resultType,
+			Repository: string(r.Repo.Name),
+			Commit:     string(r.Commit.ID),
+			URL:        getCommitURL(externalURL, string(r.Repo.Name), string(r.Commit.ID), utmSource),
+		}
+		if content != nil {
+			res.Content = truncateString(content.Content, 10)
+		}
+		results = append(results, res)
+	}
+	for _, r := range fileResults {
+		resultType, content := describeFileResult(r)
+		results = append(results, templatedWebhookResult{
+			Type:       resultType,
+			Repository: string(r.Repo.Name),
+			Commit:     string(r.CommitID),
+			Path:       r.Path,
+			URL:        getFileURL(externalURL, string(r.Repo.Name), r.Path, utmSource),
+			Content:    content,
+		})
+	}
+	return results
+}
+
+// templatedWebhookText renders the Markdown description of an event, which
+// mirrors the message sent by Slack webhook actions.
+func templatedWebhookText(data templatedWebhookData, truncatedResults []templatedWebhookResult, truncatedCount int, includeResults bool) string {
+	var b strings.Builder
+	if data.MonitorOwnerName != "" {
+		fmt.Fprintf(&b, "%s's ", data.MonitorOwnerName)
+	}
+	fmt.Fprintf(&b, "Sourcegraph code monitor, **%s**, detected **%d** new %s.", data.MonitorDescription, data.TotalCount, pluralize("result", data.TotalCount))
+
+	if !includeResults {
+		fmt.Fprintf(&b, "\n\n[View results](%s)", data.SearchURL)
+		return b.String()
+	}
+
+	for _, r := range truncatedResults {
+		name := r.Repository + "/" + r.Path
+		if r.Path == "" {
+			name = r.Repository + "@" + string(api.CommitID(r.Commit).Short())
+		}
+		fmt.Fprintf(&b, "\n\n%s match: [%s](%s)", r.Type, name, r.URL)
+		if r.Content != "" {
+			fmt.Fprintf(&b, "\n```\n%s\n```", strings.TrimSuffix(r.Content, "\n"))
+		}
+	}
+	if truncatedCount > 0 {
+		fmt.Fprintf(&b, "\n\n...and [%d more %s](%s).", truncatedCount, pluralize("result", truncatedCount), data.SearchURL)
+	}
+	return b.String()
+}
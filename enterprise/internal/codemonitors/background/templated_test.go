@@ -0,0 +1,179 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hexops/autogold"
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestTemplatedWebhook(t *testing.T) {
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	args := actionArgs{
+		MonitorDescription: "My test monitor",
+		ExternalURL:        eu,
+		MonitorID:          42,
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+		FileResults:        []*edb.FileResult{&contentFileResultMock, &removedFileResultMock},
+		IncludeResults:     true,
+	}
+
+	for _, preset := range edb.TemplatedWebhookPresets {
+		preset := preset
+		t.Run(string(preset), func(t *testing.T) {
+			action := &edb.TemplatedWebhookAction{
+				Preset:     preset,
+				URL:        "https://example.com/hook",
+				RoutingKey: "abc123",
+				Headers:    map[string]string{"X-Custom": "value"},
+			}
+			if preset != edb.TemplatedWebhookPresetPagerDuty {
+				action.RoutingKey = ""
+			}
+
+			req, err := newTemplatedWebhookRequest(context.Background(), action, generateTemplatedWebhookData(action, args))
+			require.NoError(t, err)
+			require.Equal(t, "https://example.com/hook", req.URL.String())
+			require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+			require.Equal(t, "value", req.Header.Get("X-Custom"))
+
+			b, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			require.True(t, json.Valid(b), "body is not valid JSON: %s", b)
+			autogold.Equal(t, autogold.Raw(b))
+		})
+	}
+
+	t.Run("custom template", func(t *testing.T) {
+		action := &edb.TemplatedWebhookAction{
+			Preset:       edb.TemplatedWebhookPresetMattermost,
+			URL:          "https://example.com/hook",
+			BodyTemplate: `{{ .TotalCount }} new results for {{ .Query }}`,
+			ContentType:  "text/plain",
+		}
+
+		req, err := newTemplatedWebhookRequest(context.Background(), action, generateTemplatedWebhookData(action, args))
+		require.NoError(t, err)
+		require.Equal(t, "text/plain", req.Header.Get("Content-Type"))
+
+		b, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.Equal(t, "5 new results for repo:camdentest -file:id_rsa.pub BEGIN", string(b))
+	})
+
+	t.Run("default PagerDuty URL", func(t *testing.T) {
+		action := &edb.TemplatedWebhookAction{
+			Preset:     edb.TemplatedWebhookPresetPagerDuty,
+			RoutingKey: "abc123",
+		}
+
+		req, err := newTemplatedWebhookRequest(context.Background(), action, generateTemplatedWebhookData(action, args))
+		require.NoError(t, err)
+		require.Equal(t, "https://events.pagerduty.com/v2/enqueue", req.URL.String())
+	})
+
+	t.Run("accepts 202", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(202)
+		}))
+		defer s.Close()
+
+		action := &edb.TemplatedWebhookAction{Preset: edb.TemplatedWebhookPresetPagerDuty, URL: s.URL, RoutingKey: "abc123"}
+		req, err := newTemplatedWebhookRequest(context.Background(), action, generateTemplatedWebhookData(action, args))
+		require.NoError(t, err)
+		require.NoError(t, postTemplatedWebhook(s.Client(), req))
+	})
+
+	t.Run("error is returned", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(500)
+		}))
+		defer s.Close()
+
+		action := &edb.TemplatedWebhookAction{Preset: edb.TemplatedWebhookPresetTeams, URL: s.URL}
+		req, err := newTemplatedWebhookRequest(context.Background(), action, generateTemplatedWebhookData(action, args))
+		require.NoError(t, err)
+		err = postTemplatedWebhook(s.Client(), req)
+		require.Error(t, err)
+		var statusErr StatusCodeError
+		require.ErrorAs(t, err, &statusErr)
+		require.Equal(t, 500, statusErr.Code)
+	})
+}
+
+func TestValidateTemplatedWebhookAction(t *testing.T) {
+	cases := []struct {
+		name    string
+		preset  edb.TemplatedWebhookPreset
+		args    edb.TemplatedWebhookActionArgs
+		wantErr bool
+	}{
+		{
+			name:   "teams",
+			preset: edb.TemplatedWebhookPresetTeams,
+			args:   edb.TemplatedWebhookActionArgs{URL: "https://example.webhook.office.com/webhookb2/abc"},
+		},
+		{
+			name:    "teams without URL",
+			preset:  edb.TemplatedWebhookPresetTeams,
+			wantErr: true,
+		},
+		{
+			name:    "invalid scheme",
+			preset:  edb.TemplatedWebhookPresetMattermost,
+			args:    edb.TemplatedWebhookActionArgs{URL: "ftp://example.com"},
+			wantErr: true,
+		},
+		{
+			name:   "pagerduty with default URL",
+			preset: edb.TemplatedWebhookPresetPagerDuty,
+			args:   edb.TemplatedWebhookActionArgs{RoutingKey: "abc123"},
+		},
+		{
+			name:    "pagerduty without routing key",
+			preset:  edb.TemplatedWebhookPresetPagerDuty,
+			wantErr: true,
+		},
+		{
+			name:    "invalid template",
+			preset:  edb.TemplatedWebhookPresetMattermost,
+			args:    edb.TemplatedWebhookActionArgs{URL: "https://example.com", BodyTemplate: "{{ .Query "},
+			wantErr: true,
+		},
+		{
+			name:    "invalid header",
+			preset:  edb.TemplatedWebhookPresetMattermost,
+			args:    edb.TemplatedWebhookActionArgs{URL: "https://example.com", Headers: map[string]string{"X Bad": "value"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown preset",
+			preset:  "DISCORD",
+			args:    edb.TemplatedWebhookActionArgs{URL: "https://example.com"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateTemplatedWebhookAction(tc.preset, &tc.args)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package background
 import (
 	"net/url"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
@@ -50,3 +51,31 @@ var commitResultMock = result.CommitMatch{
 }
 
 var commitDisplayResultMock = toDisplayResult(&commitResultMock, externalURLMock)
+
+var contentFileResultMock = edb.FileResult{
+	Repo: types.MinimalRepo{
+		Name: api.RepoName("github.com/test/test"),
+	},
+	CommitID: api.CommitID("7815187511872asbasdfgasd"),
+	Path:     "cmd/main.go",
+	Preview:  "\t// TODO: handle errors\n\tlog.Fatal(run()) // TODO: exit code",
+	MatchedRanges: result.Ranges{{
+		Start: result.Location{Line: 0, Offset: 4, Column: 4},
+		End:   result.Location{Line: 0, Offset: 8, Column: 8},
+	}, {
+		Start: result.Location{Line: 1, Offset: 45, Column: 21},
+		End:   result.Location{Line: 1, Offset: 49, Column: 25},
+	}},
+}
+
+var contentFileDisplayResultMock = toFileDisplayResult(&contentFileResultMock, externalURLMock)
+
+var removedFileResultMock = edb.FileResult{
+	Repo: types.MinimalRepo{
+		Name: api.RepoName("github.com/test/test"),
+	},
+	Path:    "internal/legacy.go",
+	Removed: true,
+}
+
+var removedFileDisplayResultMock = toFileDisplayResult(&removedFileResultMock, externalURLMock)
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -71,7 +72,7 @@ func generateWebhookPayload(args actionArgs) webhookPayload {
 	}
 
 	if args.IncludeResults {
-		p.Results = generateResults(args.Results)
+		p.Results = append(generateResults(args.Results), generateFileResults(args.FileResults)...)
 	}
 
 	return p
@@ -84,6 +85,13 @@ type webhookResult struct {
 	MatchedMessageRanges [][2]int `json:"matchedMessageRanges,omitempty"`
 	Diff                 string   `json:"diff,omitempty"`
 	MatchedDiffRanges    [][2]int `json:"matchedDiffRanges,omitempty"`
+
+	// Only set for content and symbol searches
+	Path                 string   `json:"path,omitempty"`
+	Removed              bool     `json:"removed,omitempty"`
+	Content              string   `json:"content,omitempty"`
+	MatchedContentRanges [][2]int `json:"matchedContentRanges,omitempty"`
+	Symbols              []string `json:"symbols,omitempty"`
 }
 
 func generateResults(in []*result.CommitMatch) []webhookResult {
@@ -106,6 +114,22 @@ func generateResults(in []*result.CommitMatch) []webhookResult {
 	return out
 }
 
+func generateFileResults(in []*edb.FileResult) []webhookResult {
+	out := make([]webhookResult, len(in))
+	for i, fr := range in {
+		out[i] = webhookResult{
+			Repository:           string(fr.Repo.Name),
+			Commit:               string(fr.CommitID),
+			Path:                 fr.Path,
+			Removed:              fr.Removed,
+			Content:              fr.Preview,
+			MatchedContentRanges: rangesToInts(fr.MatchedRanges),
+			Symbols:              fr.Symbols,
+		}
+	}
+	return out
+}
+
 func rangesToInts(ranges result.Ranges) [][2]int {
 	out := make([][2]int, len(ranges))
 	for i, r := range ranges {
@@ -12,11 +12,11 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/viewersettings"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
-	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -165,7 +165,7 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, record work
 	ctx = actor.WithActor(ctx, actor.FromUser(m.UserID))
 	ctx = featureflag.WithFlags(ctx, r.db.FeatureFlags())
 
-	settings, err := codemonitors.Settings(ctx)
+	settings, err := viewersettings.Settings(ctx)
 	if err != nil {
 		return errors.Wrap(err, "query settings")
 	}
@@ -190,12 +190,12 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, record work
 	}
 
 	// Log the actual query we ran and whether we got any new results.
-	err = s.UpdateTriggerJobWithResults(ctx, triggerJob.ID, query, results)
+	err = s.UpdateTriggerJobWithResults(ctx, triggerJob.ID, query, results.CommitMatches, results.FileResults)
 	if err != nil {
 		return errors.Wrap(err, "UpdateTriggerJobWithResults")
 	}
 
-	if len(results) > 0 {
+	if results.Len() > 0 {
 		_, err := s.EnqueueActionJobsForMonitor(ctx, m.ID, triggerJob.ID)
 		if err != nil {
 			return errors.Wrap(err, "store.EnqueueActionJobsForQuery")
@@ -228,9 +228,11 @@ func (r *actionRunner) Handle(ctx context.Context, logger log.Logger, record wor
 		return r.handleWebhook(ctx, j)
 	case j.SlackWebhook != nil:
 		return r.handleSlackWebhook(ctx, j)
-	default:
-		return errors.New("job must be one of type email, webhook, or slack webhook")
 	}
+	if preset, id, ok := j.TemplatedWebhook(); ok {
+		return r.handleTemplatedWebhook(ctx, j, preset, id)
+	}
+	return errors.New("job must be one of type email, webhook, slack webhook, or templated webhook")
 }
 
 func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) error {
@@ -268,6 +270,7 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) error
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		FileResults:        m.FileResults,
 		IncludeResults:     e.IncludeResults,
 	}
 
@@ -321,6 +324,7 @@ func (r *actionRunner) handleWebhook(ctx context.Context, j *edb.ActionJob) erro
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		FileResults:        m.FileResults,
 		IncludeResults:     w.IncludeResults,
 	}
 
@@ -357,12 +361,50 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob)
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		FileResults:        m.FileResults,
 		IncludeResults:     w.IncludeResults,
 	}
 
 	return sendSlackNotification(ctx, w.URL, args)
 }
 
+func (r *actionRunner) handleTemplatedWebhook(ctx context.Context, j *edb.ActionJob, preset edb.TemplatedWebhookPreset, id int64) error {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	w, err := s.GetTemplatedWebhookAction(ctx, preset, id)
+	if err != nil {
+		return errors.Wrap(err, "GetTemplatedWebhookAction")
+	}
+
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          w.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          templatedWebhookPresets[preset].utmSource,
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		FileResults:        m.FileResults,
+		IncludeResults:     w.IncludeResults,
+	}
+
+	return sendTemplatedWebhookNotification(ctx, w, args)
+}
+
 type StatusCodeError struct {
 	Code   int
 	Status string
@@ -392,8 +434,8 @@ func newQueryWithAfterFilter(q *edb.QueryTrigger) string {
 	return strings.Join([]string{q.QueryString, fmt.Sprintf(`after:"%s"`, afterTime)}, " ")
 }
 
-func latestResultTime(previousLastResult *time.Time, results []*result.CommitMatch, searchErr error) time.Time {
-	if searchErr != nil || len(results) == 0 {
+func latestResultTime(previousLastResult *time.Time, results *codemonitors.Results, searchErr error) time.Time {
+	if searchErr != nil || results.Len() == 0 {
 		// Error performing the search, or there were no results. Assume the
 		// previous info's result time.
 		if previousLastResult != nil {
@@ -402,8 +444,8 @@ func latestResultTime(previousLastResult *time.Time, results []*result.CommitMat
 		return time.Now()
 	}
 
-	if results[0].Commit.Committer != nil {
-		return results[0].Commit.Committer.Date
+	if len(results.CommitMatches) > 0 && results.CommitMatches[0].Commit.Committer != nil {
+		return results.CommitMatches[0].Commit.Committer.Date
 	}
 	return time.Now()
 }
@@ -21,6 +21,7 @@ func TestActionRunner(t *testing.T) {
 	tests := []struct {
 		name           string
 		results        []*result.CommitMatch
+		fileResults    []*edb.FileResult
 		wantNumResults int
 		wantResults    []*DisplayResult
 	}{
@@ -36,6 +37,12 @@ func TestActionRunner(t *testing.T) {
 			wantNumResults: 1,
 			wantResults:    []*DisplayResult{commitDisplayResultMock},
 		},
+		{
+			name:           "2 file results",
+			fileResults:    []*edb.FileResult{&contentFileResultMock, &removedFileResultMock},
+			wantNumResults: 2,
+			wantResults:    []*DisplayResult{contentFileDisplayResultMock, removedFileDisplayResultMock},
+		},
 	}
 
 	for _, tt := range tests {
@@ -68,7 +75,7 @@ func TestActionRunner(t *testing.T) {
 			require.Len(t, triggerJobs, 1)
 			triggerEventID := triggerJobs[0].ID
 
-			err = ts.UpdateTriggerJobWithResults(ctx, triggerEventID, testQuery, tt.results)
+			err = ts.UpdateTriggerJobWithResults(ctx, triggerEventID, testQuery, tt.results, tt.fileResults)
 			require.NoError(t, err)
 
 			_, err = ts.EnqueueActionJobsForMonitor(ctx, 1, triggerEventID)
@@ -0,0 +1,117 @@
+package codemonitors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// diffFileMatches compares the file matches of the current run of a content or symbol
+// search code monitor with the results of its last run. It returns the file results that
+// appeared or disappeared since the last run, along with the last results to store for
+// the next run.
+func diffFileMatches(lastResults []*edb.LastResult, matches []*result.FileMatch) (fileResults []*edb.FileResult, newLastResults []*edb.LastResult) {
+	previous := make(map[string]struct{}, len(lastResults))
+	for _, lr := range lastResults {
+		previous[lr.Fingerprint] = struct{}{}
+	}
+
+	current := make(map[string]struct{}, len(matches))
+	for _, fm := range matches {
+		fp := fingerprint(fm)
+		if _, ok := current[fp]; ok {
+			continue
+		}
+		current[fp] = struct{}{}
+
+		newLastResults = append(newLastResults, &edb.LastResult{
+			RepoID:      fm.Repo.ID,
+			Path:        fm.Path,
+			Fingerprint: fp,
+		})
+		if _, ok := previous[fp]; !ok {
+			fileResults = append(fileResults, newFileResult(fm))
+		}
+	}
+
+	for _, lr := range lastResults {
+		if _, ok := current[lr.Fingerprint]; !ok {
+			fileResults = append(fileResults, &edb.FileResult{
+				Repo:    types.MinimalRepo{ID: lr.RepoID, Name: lr.RepoName},
+				Path:    lr.Path,
+				Removed: true,
+			})
+		}
+	}
+
+	return fileResults, newLastResults
+}
+
+// lastResultsFromFileMatches returns the last results to store for the given file matches.
+func lastResultsFromFileMatches(matches []*result.FileMatch) []*edb.LastResult {
+	_, lastResults := diffFileMatches(nil, matches)
+	return lastResults
+}
+
+// fingerprint identifies a file match across runs of a code monitor. It covers the file
+// and the matched lines or symbols, but not the commit or the position of the matches,
+// so that unrelated changes to the repository or to the file don't produce new results.
+func fingerprint(fm *result.FileMatch) string {
+	parts := make([]string, 0, len(fm.ChunkMatches)+len(fm.Symbols))
+	for _, cm := range fm.ChunkMatches {
+		parts = append(parts, "c:"+cm.Content)
+	}
+	for _, sm := range fm.Symbols {
+		parts = append(parts, "s:"+sm.Symbol.Kind+":"+sm.Symbol.Name)
+	}
+	sort.Strings(parts)
+
+	h := sha256.New()
+	h.Write([]byte(strconv.Itoa(int(fm.Repo.ID))))
+	h.Write([]byte{0})
+	h.Write([]byte(fm.Path))
+	for _, part := range parts {
+		h.Write([]byte{0})
+		h.Write([]byte(part))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func newFileResult(fm *result.FileMatch) *edb.FileResult {
+	fr := &edb.FileResult{
+		Repo:     fm.Repo,
+		CommitID: fm.CommitID,
+		Path:     fm.Path,
+	}
+
+	// Concatenate the matched lines, translating the matched ranges so that they
+	// are relative to the start of the preview.
+	var preview strings.Builder
+	lines := 0
+	for _, cm := range fm.ChunkMatches {
+		if preview.Len() > 0 {
+			preview.WriteByte('\n')
+			lines++
+		}
+		offset := result.Location{Offset: preview.Len(), Line: lines}
+		for _, r := range cm.Ranges {
+			fr.MatchedRanges = append(fr.MatchedRanges, r.Sub(cm.ContentStart).Add(offset))
+		}
+		content := strings.TrimSuffix(cm.Content, "\n")
+		preview.WriteString(content)
+		lines += strings.Count(content, "\n")
+	}
+	fr.Preview = preview.String()
+
+	for _, sm := range fm.Symbols {
+		fr.Symbols = append(fr.Symbols, sm.Symbol.Name)
+	}
+
+	return fr
+}
@@ -0,0 +1,111 @@
+package codemonitors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestDiffFileMatches(t *testing.T) {
+	t.Parallel()
+
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/test/test"}
+	newFileMatch := func(path string, lines ...string) *result.FileMatch {
+		fm := &result.FileMatch{File: result.File{Repo: repo, CommitID: "deadbeef", Path: path}}
+		for i, line := range lines {
+			fm.ChunkMatches = append(fm.ChunkMatches, result.ChunkMatch{
+				Content:      line,
+				ContentStart: result.Location{Line: i * 10},
+				Ranges:       result.Ranges{{Start: result.Location{Line: i * 10}, End: result.Location{Offset: 4, Line: i * 10, Column: 4}}},
+			})
+		}
+		return fm
+	}
+
+	unchanged := newFileMatch("unchanged.go", "TODO: a")
+	removed := newFileMatch("removed.go", "TODO: b")
+	changed := newFileMatch("changed.go", "TODO: c")
+	lastResults := lastResultsFromFileMatches([]*result.FileMatch{unchanged, removed, changed})
+	for _, lr := range lastResults {
+		lr.RepoName = repo.Name
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		fileResults, newLastResults := diffFileMatches(lastResults, []*result.FileMatch{unchanged, removed, changed})
+		require.Empty(t, fileResults)
+		require.Len(t, newLastResults, 3)
+	})
+
+	t.Run("moved matches", func(t *testing.T) {
+		// Moving the matched lines within the file or committing to the
+		// repository does not produce new results.
+		moved := newFileMatch("unchanged.go", "TODO: a")
+		moved.CommitID = "cafebabe"
+		moved.ChunkMatches[0].ContentStart.Line = 42
+
+		fileResults, _ := diffFileMatches(lastResults, []*result.FileMatch{moved, removed, changed})
+		require.Empty(t, fileResults)
+	})
+
+	t.Run("added and removed matches", func(t *testing.T) {
+		fileResults, newLastResults := diffFileMatches(lastResults, []*result.FileMatch{
+			unchanged,
+			newFileMatch("changed.go", "TODO: c", "TODO: d"),
+			newFileMatch("added.go", "TODO: e"),
+		})
+
+		require.Equal(t, []*edb.FileResult{
+			{
+				Repo:     repo,
+				CommitID: "deadbeef",
+				Path:     "changed.go",
+				Preview:  "TODO: c\nTODO: d",
+				MatchedRanges: result.Ranges{
+					{Start: result.Location{Offset: 0, Line: 0}, End: result.Location{Offset: 4, Line: 0, Column: 4}},
+					{Start: result.Location{Offset: 8, Line: 1}, End: result.Location{Offset: 12, Line: 1, Column: 4}},
+				},
+			},
+			{
+				Repo:     repo,
+				CommitID: "deadbeef",
+				Path:     "added.go",
+				Preview:  "TODO: e",
+				MatchedRanges: result.Ranges{
+					{Start: result.Location{Offset: 0, Line: 0}, End: result.Location{Offset: 4, Line: 0, Column: 4}},
+				},
+			},
+			{
+				Repo:    repo,
+				Path:    "removed.go",
+				Removed: true,
+			},
+			{
+				Repo:    repo,
+				Path:    "changed.go",
+				Removed: true,
+			},
+		}, fileResults)
+		require.Len(t, newLastResults, 3)
+	})
+
+	t.Run("symbol matches", func(t *testing.T) {
+		symbolMatch := &result.FileMatch{
+			File: result.File{Repo: repo, Path: "symbols.go"},
+			Symbols: []*result.SymbolMatch{
+				{Symbol: result.Symbol{Name: "Foo", Kind: "function"}},
+				{Symbol: result.Symbol{Name: "Bar", Kind: "struct"}},
+			},
+		}
+
+		fileResults, _ := diffFileMatches(nil, []*result.FileMatch{symbolMatch})
+		require.Equal(t, []*edb.FileResult{{
+			Repo:    repo,
+			Path:    "symbols.go",
+			Symbols: []string{"Foo", "Bar"},
+		}}, fileResults)
+	})
+}
@@ -1,27 +1,18 @@
 package codemonitors
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
-	"net/url"
 	"sort"
 
-	"github.com/graphql-go/graphql/gqlerrors"
-	"github.com/opentracing/opentracing-go"
-	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/api"
-	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	gitprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
-	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
 	"github.com/sourcegraph/sourcegraph/internal/search/commit"
@@ -35,80 +26,21 @@ import (
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
-const gqlSettingsQuery = `query CodeMonitorSettings{
-	viewerSettings {
-		final
-	}
-}`
-
-type gqlSettingsResponse struct {
-	Data struct {
-		ViewerSettings struct {
-			Final string `json:"final"`
-		} `json:"viewerSettings"`
-	} `json:"data"`
-	Errors []gqlerrors.FormattedError
+// Results are the new results of a single run of a code monitor. Commit searches
+// (type:commit and type:diff) produce the commits that were added since the last run,
+// while content and symbol searches produce the file matches that appeared or
+// disappeared since the last run.
+type Results struct {
+	CommitMatches []*result.CommitMatch
+	FileResults   []*edb.FileResult
 }
 
-// Settings queries for the computed Settings for the current actor
-func Settings(ctx context.Context) (_ *schema.Settings, err error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "CodeMonitorSearch")
-	defer func() {
-		span.LogFields(otlog.Error(err))
-		span.Finish()
-	}()
-
-	reqBody, err := json.Marshal(map[string]any{"query": gqlSettingsQuery})
-	if err != nil {
-		return nil, errors.Wrap(err, "marshal request body")
-	}
-
-	url, err := gqlURL("CodeMonitorSettings")
-	if err != nil {
-		return nil, errors.Wrap(err, "construct frontend URL")
-	}
-
-	req, err := http.NewRequest("POST", url, bytes.NewReader(reqBody))
-	if err != nil {
-		return nil, errors.Wrap(err, "construct request")
-	}
-	req.Header.Set("Content-Type", "application/json")
-	if span != nil {
-		carrier := opentracing.HTTPHeadersCarrier(req.Header)
-		span.Tracer().Inject(
-			span.Context(),
-			opentracing.HTTPHeaders,
-			carrier,
-		)
-	}
-
-	resp, err := httpcli.InternalDoer.Do(req.WithContext(ctx))
-	if err != nil {
-		return nil, errors.Wrap(err, "do request")
-	}
-	defer resp.Body.Close()
-
-	var res gqlSettingsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, errors.Wrap(err, "decode response")
-	}
-
-	if len(res.Errors) > 0 {
-		var combined error
-		for _, err := range res.Errors {
-			combined = errors.Append(combined, err)
-		}
-		return nil, combined
-	}
-
-	var unmarshaledSettings schema.Settings
-	if err := json.Unmarshal([]byte(res.Data.ViewerSettings.Final), &unmarshaledSettings); err != nil {
-		return nil, err
-	}
-	return &unmarshaledSettings, nil
+// Len returns the number of new results.
+func (r *Results) Len() int {
+	return len(r.CommitMatches) + len(r.FileResults)
 }
 
-func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) (_ []*result.CommitMatch, err error) {
+func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) (_ *Results, err error) {
 	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
 	inputs, err := searchClient.Plan(ctx, "V2", nil, query, search.Streaming, settings, envvar.SourcegraphDotComMode())
 	if err != nil {
@@ -127,6 +59,14 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		return nil, errcode.MakeNonRetryable(err)
 	}
 
+	if !job.HasDescendent[*commit.SearchJob](planJob) {
+		fileResults, err := searchFiles(ctx, db, clients, planJob, monitorID)
+		if err != nil {
+			return nil, err
+		}
+		return &Results{FileResults: fileResults}, nil
+	}
+
 	if featureflag.FromContext(ctx).GetBoolOr("cc-repo-aware-monitors", true) {
 		hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, doSearch commit.DoSearchFunc) error {
 			return hookWithID(ctx, db, gs, monitorID, repoID, args, doSearch)
@@ -178,12 +118,63 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		results[i] = cm
 	}
 
-	return results, nil
+	return &Results{CommitMatches: results}, nil
+}
+
+// searchFiles runs a content or symbol search and returns the file matches that appeared
+// or disappeared since the last run of the code monitor. If the search did not return all
+// matching files, nothing is reported and the last results are kept, as the missing files
+// would otherwise be reported as removed, and as added again on the next run.
+func searchFiles(ctx context.Context, db database.DB, clients job.RuntimeClients, planJob job.Job, monitorID int64) ([]*edb.FileResult, error) {
+	matches, stats, err := runFileSearch(ctx, clients, planJob)
+	if err != nil {
+		return nil, err
+	}
+	if !isCompleteSearch(stats) {
+		return nil, nil
+	}
+
+	cm := edb.NewEnterpriseDB(db).CodeMonitors()
+	lastResults, err := cm.GetLastResults(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	fileResults, newLastResults := diffFileMatches(lastResults, matches)
+	if err := cm.ReplaceLastResults(ctx, monitorID, newLastResults); err != nil {
+		return nil, err
+	}
+	return fileResults, nil
+}
+
+// runFileSearch runs a content or symbol search and returns its file matches along with
+// the stats of the search. Other types of matches, like repository name matches, are ignored.
+func runFileSearch(ctx context.Context, clients job.RuntimeClients, planJob job.Job) ([]*result.FileMatch, streaming.Stats, error) {
+	agg := streaming.NewAggregatingStream()
+	_, err := planJob.Run(ctx, clients, agg)
+	if err != nil {
+		return nil, streaming.Stats{}, err
+	}
+
+	matches := make([]*result.FileMatch, 0, len(agg.Results))
+	for _, res := range agg.Results {
+		if fm, ok := res.(*result.FileMatch); ok {
+			matches = append(matches, fm)
+		}
+	}
+	return matches, agg.Stats, nil
+}
+
+// isCompleteSearch returns true if the given stats show that a search returned all
+// matches, that is it did not hit a limit and all repositories were searched.
+func isCompleteSearch(stats streaming.Stats) bool {
+	return !stats.IsLimitHit && !stats.Status.Any(search.RepoStatusLimitHit|search.RepoStatusTimedout|search.RepoStatusMissing|search.RepoStatusCloning)
 }
 
 // Snapshot runs a dummy search that just saves the current state of the searched repos in the database.
 // On subsequent runs, this allows us to treat all new repos or sets of args as something new that should
-// be searched from the beginning.
+// be searched from the beginning. For content and symbol searches, it saves the current file matches so
+// that only the matches that appear or disappear afterwards are reported.
 func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) error {
 	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
 	inputs, err := searchClient.Plan(ctx, "V2", nil, query, search.Streaming, settings, envvar.SourcegraphDotComMode())
@@ -202,6 +193,14 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query stri
 		return err
 	}
 
+	if !job.HasDescendent[*commit.SearchJob](planJob) {
+		matches, _, err := runFileSearch(ctx, clients, planJob)
+		if err != nil {
+			return err
+		}
+		return edb.NewEnterpriseDB(db).CodeMonitors().ReplaceLastResults(ctx, monitorID, lastResultsFromFileMatches(matches))
+	}
+
 	hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, _ commit.DoSearchFunc) error {
 		return snapshotHook(ctx, db, gs, args, monitorID, repoID)
 	}
@@ -334,16 +333,6 @@ func snapshotHook(
 	return cm.UpsertLastSearched(ctx, monitorID, repoID, commitHashes)
 }
 
-func gqlURL(queryName string) (string, error) {
-	u, err := url.Parse(internalapi.Client.URL)
-	if err != nil {
-		return "", err
-	}
-	u.Path = "/.internal/graphql"
-	u.RawQuery = queryName
-	return u.String(), nil
-}
-
 func stringsEqual(left, right []string) bool {
 	if len(left) != len(right) {
 		return false
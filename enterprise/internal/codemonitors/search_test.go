@@ -21,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/run"
 	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -158,3 +159,17 @@ func TestCodeMonitorHook(t *testing.T) {
 	err = hookWithID(ctx, db, gs, fixtures.Monitor.ID, fixtures.Repo.ID, &gitprotocol.SearchRequest{}, doSearch)
 	require.NoError(t, err)
 }
+
+func TestIsCompleteSearch(t *testing.T) {
+	withStatus := func(status search.RepoStatus) streaming.Stats {
+		var stats streaming.Stats
+		stats.Status.Update(1, status)
+		return stats
+	}
+
+	require.True(t, isCompleteSearch(streaming.Stats{}))
+	require.False(t, isCompleteSearch(streaming.Stats{IsLimitHit: true}))
+	for _, status := range []search.RepoStatus{search.RepoStatusLimitHit, search.RepoStatusTimedout, search.RepoStatusMissing, search.RepoStatusCloning} {
+		require.False(t, isCompleteSearch(withStatus(status)), status.String())
+	}
+}
@@ -0,0 +1,148 @@
+package compute
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// groupByKeys maps the values of the `group-by:` parameter to the key
+// templates they correspond to.
+var groupByKeys = map[string]string{
+	"repo":   "$repo",
+	"path":   "$path",
+	"lang":   "$lang",
+	"author": "$author",
+	"email":  "$email",
+	"commit": "$commit",
+	"date":   "$date",
+}
+
+// aggregationParameters are the compute-only parameters `group-by:`, `top:`
+// and `histogram:` of aggregation commands.
+type aggregationParameters struct {
+	groupBy   string
+	top       int
+	histogram string
+}
+
+// scanAggregationParameters removes the `group-by:`, `top:` and `histogram:`
+// parameters from a compute query, since the search query parser does not
+// recognize them.
+// Parameters are only recognized outside of quotes and parentheses.
+func scanAggregationParameters(q string) (string, *aggregationParameters, error) {
+	var (
+		params aggregationParameters
+		rest   strings.Builder
+		quote  rune
+		depth  int
+		start  = true
+	)
+
+	set := func(field, value string) error {
+		switch field {
+		case "group-by":
+			if _, ok := groupByKeys[value]; !ok {
+				return errors.Errorf("invalid value %q for group-by:, expected one of repo, path, lang, author, email, commit or date", value)
+			}
+			params.groupBy = value
+		case "top":
+			top, err := strconv.Atoi(value)
+			if err != nil || top <= 0 {
+				return errors.Errorf("invalid value %q for top:, expected a positive number", value)
+			}
+			params.top = top
+		case "histogram":
+			if _, ok := histogramIntervals[value]; !ok {
+				return errors.Errorf("invalid value %q for histogram:, expected one of day, week, month or year", value)
+			}
+			params.histogram = value
+		}
+		return nil
+	}
+
+	runes := []rune(q)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if quote == 0 && depth == 0 && start {
+			token := string(runes[i:])
+			if end := strings.IndexFunc(token, unicode.IsSpace); end >= 0 {
+				token = token[:end]
+			}
+			field, value, ok := strings.Cut(token, ":")
+			if ok && (strings.EqualFold(field, "group-by") || strings.EqualFold(field, "top") || strings.EqualFold(field, "histogram")) {
+				if err := set(strings.ToLower(field), value); err != nil {
+					return "", nil, err
+				}
+				i += len([]rune(token)) - 1
+				continue
+			}
+		}
+
+		switch {
+		case quote != 0:
+			if r == '\\' && i+1 < len(runes) {
+				rest.WriteRune(r)
+				i++
+				r = runes[i]
+			} else if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		}
+		start = unicode.IsSpace(r)
+		rest.WriteRune(r)
+	}
+	return rest.String(), &params, nil
+}
+
+// apply applies the aggregation parameters to the command of q. Match-only
+// commands are turned into count commands. A histogram counts matches by the
+// date of their commit.
+func (p *aggregationParameters) apply(q *Query) (*Query, error) {
+	if p.histogram != "" && (p.groupBy != "" || p.top > 0) {
+		return nil, errors.New("histogram: cannot be combined with group-by: or top:")
+	}
+
+	if p.groupBy != "" || p.top > 0 || p.histogram != "" {
+		var count *Count
+		switch c := q.Command.(type) {
+		case *Count:
+			if p.groupBy != "" && c.KeyPattern != wholeMatch {
+				return nil, errors.New("group-by: cannot be combined with the key of a count command")
+			}
+			if p.histogram != "" && c.KeyPattern != wholeMatch {
+				return nil, errors.New("histogram: cannot be combined with the key of a count command")
+			}
+			count = c
+		case *MatchOnly:
+			count = &Count{SearchPattern: c.ComputePattern, KeyPattern: wholeMatch}
+		default:
+			return nil, errors.New("group-by:, top: and histogram: are only supported for count and match-only commands")
+		}
+
+		if p.groupBy != "" {
+			count.KeyPattern = groupByKeys[p.groupBy]
+		}
+		if p.histogram != "" {
+			count.KeyPattern = groupByKeys["date"]
+			count.Histogram = p.histogram
+		}
+		count.Top = p.top
+		q.Command = count
+	}
+
+	if c, ok := q.Command.(*Count); ok {
+		if _, ok := c.SearchPattern.(*Comby); ok && c.KeyPattern == wholeMatch {
+			return nil, errors.New("count command: structural patterns require a key, as in `content:count.structural(:[x] -> :[x])` or `group-by:repo`")
+		}
+	}
+	return q, nil
+}
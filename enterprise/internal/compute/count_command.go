@@ -0,0 +1,135 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/comby"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Count is an aggregation command. It counts the matches of SearchPattern,
+// grouped by the value of KeyPattern for each match. KeyPattern may refer to
+// capture groups (e.g., $1) and metavariables (e.g., $repo). Running Count on
+// a single search result returns a Table for that result only. Tables of
+// several results are folded into one with an Aggregator.
+type Count struct {
+	SearchPattern MatchPattern
+	KeyPattern    string
+
+	// Top, if positive, limits aggregates to the Top groups with the most
+	// matches.
+	Top int
+
+	// Histogram, if set, is the interval (day, week, month or year) by which
+	// the dates that KeyPattern expands to are bucketed. The rows of a
+	// histogram are ordered by date instead of by count.
+	Histogram string
+}
+
+// wholeMatch is the default key of Count, which groups regexp matches by
+// their matched value.
+const wholeMatch = "$0"
+
+func (c *Count) ToSearchPattern() string {
+	return c.SearchPattern.String()
+}
+
+func (c *Count) String() string {
+	s := fmt.Sprintf("Count: (%s) by (%s)", c.SearchPattern.String(), c.KeyPattern)
+	if c.Top > 0 {
+		s += fmt.Sprintf(" top: %d", c.Top)
+	}
+	if c.Histogram != "" {
+		s += fmt.Sprintf(" histogram: %s", c.Histogram)
+	}
+	return s
+}
+
+// countRegexp counts the matches of r in content by the expansion of
+// keyPattern for each match.
+func countRegexp(content string, r *regexp.Regexp, keyPattern string, counts map[string]int) {
+	for _, submatches := range r.FindAllStringSubmatchIndex(content, -1) {
+		key := r.ExpandString([]byte{}, keyPattern, content, submatches)
+		counts[string(key)]++
+	}
+}
+
+func countStructural(ctx context.Context, content string, matchTemplate, keyPattern string, counts map[string]int) error {
+	keys, err := comby.Outputs(ctx, comby.Args{
+		Input:           comby.FileContent(content),
+		MatchTemplate:   matchTemplate,
+		RewriteTemplate: keyPattern,
+		Matcher:         ".generic", // TODO(rvantonder): use language or file filter
+		ResultKind:      comby.NewlineSeparatedOutput,
+		NumWorkers:      0,
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range strings.Split(keys, "\n") {
+		if key != "" {
+			counts[key]++
+		}
+	}
+	return nil
+}
+
+func (c *Count) Run(ctx context.Context, db database.DB, r result.Match) (Result, error) {
+	counts := map[string]int{}
+	switch match := c.SearchPattern.(type) {
+	case *Regexp:
+		if fm, ok := r.(*result.FileMatch); ok {
+			// Only count matches on the lines search matched, so that we
+			// don't have to fetch the contents of every file.
+			keyPattern, err := substituteMetaVariables(c.KeyPattern, NewMetaEnvironment(r, ""))
+			if err != nil {
+				return nil, err
+			}
+			for _, l := range fm.ChunkMatches.AsLineMatches() {
+				countRegexp(l.Preview, match.Value, keyPattern, counts)
+			}
+			break
+		}
+
+		content, ok, err := resultContent(ctx, db, r, false)
+		if err != nil || !ok {
+			return nil, err
+		}
+		keyPattern, err := substituteMetaVariables(c.KeyPattern, NewMetaEnvironment(r, content))
+		if err != nil {
+			return nil, err
+		}
+		countRegexp(content, match.Value, keyPattern, counts)
+	case *Comby:
+		content, ok, err := resultContent(ctx, db, r, false)
+		if err != nil || !ok {
+			return nil, err
+		}
+		keyPattern, err := substituteMetaVariables(c.KeyPattern, NewMetaEnvironment(r, content))
+		if err != nil {
+			return nil, err
+		}
+		if err := countStructural(ctx, content, match.Value, keyPattern, counts); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.Errorf("unsupported count operation for match pattern %T", match)
+	}
+
+	if c.Histogram != "" {
+		counts = bucketDates(counts, c.Histogram)
+	}
+	if len(counts) == 0 {
+		return nil, nil
+	}
+	if c.Histogram != "" {
+		return newHistogram(counts), nil
+	}
+	return newTable(counts, 0), nil
+}
@@ -0,0 +1,138 @@
+package compute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCount(t *testing.T) {
+	data := &result.FileMatch{
+		File: result.File{Path: "main.go", Repo: types.MinimalRepo{
+			ID:   5,
+			Name: "codehost.com/myorg/myrepo",
+		}},
+		ChunkMatches: result.ChunkMatches{{
+			Content:      "return ParseError\nreturn ParseError, IOError",
+			ContentStart: result.Location{Line: 1},
+			Ranges: result.Ranges{{
+				Start: result.Location{Line: 1},
+				End:   result.Location{Line: 2},
+			}},
+		}},
+	}
+
+	test := func(t *testing.T, cmd *Count, want *Table) {
+		t.Helper()
+		got, err := cmd.Run(context.Background(), nil, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want == nil {
+			if got != nil {
+				t.Fatalf("expected no result, got %v", got)
+			}
+			return
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("unexpected table (-want +got):\n%s", diff)
+		}
+	}
+
+	t.Run("count by matched value", func(t *testing.T) {
+		test(t, &Count{SearchPattern: &Regexp{Value: regexp.MustCompile(`\w+Error`)}, KeyPattern: wholeMatch}, &Table{
+			Rows:  []Row{{Key: "ParseError", Count: 2}, {Key: "IOError", Count: 1}},
+			Total: 3,
+			Kind:  "count",
+		})
+	})
+
+	t.Run("count by capture group", func(t *testing.T) {
+		test(t, &Count{SearchPattern: &Regexp{Value: regexp.MustCompile(`(\w+)Error`)}, KeyPattern: "$1"}, &Table{
+			Rows:  []Row{{Key: "Parse", Count: 2}, {Key: "IO", Count: 1}},
+			Total: 3,
+			Kind:  "count",
+		})
+	})
+
+	t.Run("count by metavariable", func(t *testing.T) {
+		test(t, &Count{SearchPattern: &Regexp{Value: regexp.MustCompile(`\w+Error`)}, KeyPattern: "$repo"}, &Table{
+			Rows:  []Row{{Key: "codehost.com/myorg/myrepo", Count: 3}},
+			Total: 3,
+			Kind:  "count",
+		})
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		test(t, &Count{SearchPattern: &Regexp{Value: regexp.MustCompile(`panic`)}, KeyPattern: wholeMatch}, nil)
+	})
+}
+
+func TestAggregator(t *testing.T) {
+	aggregator := NewAggregator(&Count{Top: 2})
+	if aggregator.Dirty() {
+		t.Fatal("expected new aggregator not to be dirty")
+	}
+
+	aggregator.Add(&Table{Rows: []Row{{Key: "a", Count: 1}, {Key: "b", Count: 2}}})
+	aggregator.Add(&Table{Rows: []Row{{Key: "c", Count: 2}, {Key: "a", Count: 3}}})
+	if !aggregator.Dirty() {
+		t.Fatal("expected aggregator to be dirty after adding tables")
+	}
+
+	want := &Table{
+		Rows:  []Row{{Key: "a", Count: 4}, {Key: "b", Count: 2}},
+		Total: 8,
+		Kind:  "count",
+	}
+	if diff := cmp.Diff(want, aggregator.Table()); diff != "" {
+		t.Fatalf("unexpected aggregate (-want +got):\n%s", diff)
+	}
+	if aggregator.Dirty() {
+		t.Fatal("expected aggregator not to be dirty after reading the aggregate")
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	counts := map[string]int{
+		"2022-07-11": 1, // Monday
+		"2022-07-17": 2, // Sunday
+		"2022-07-18": 3, // Monday
+		"2022-08-01": 4,
+		"2023-01-01": 5,
+		"":           6, // file matches have no date
+	}
+
+	for interval, want := range map[string]map[string]int{
+		"day":   {"2022-07-11": 1, "2022-07-17": 2, "2022-07-18": 3, "2022-08-01": 4, "2023-01-01": 5},
+		"week":  {"2022-07-11": 3, "2022-07-18": 3, "2022-08-01": 4, "2022-12-26": 5},
+		"month": {"2022-07": 6, "2022-08": 4, "2023-01": 5},
+		"year":  {"2022": 10, "2023": 5},
+	} {
+		t.Run(interval, func(t *testing.T) {
+			if diff := cmp.Diff(want, bucketDates(counts, interval)); diff != "" {
+				t.Fatalf("unexpected buckets (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("aggregate", func(t *testing.T) {
+		aggregator := NewAggregator(&Count{Histogram: "month"})
+		aggregator.Add(&Table{Rows: []Row{{Key: "2022-08", Count: 1}, {Key: "2022-07", Count: 2}}})
+		aggregator.Add(&Table{Rows: []Row{{Key: "2022-08", Count: 3}}})
+
+		want := &Table{
+			Rows:  []Row{{Key: "2022-07", Count: 2}, {Key: "2022-08", Count: 4}},
+			Total: 6,
+			Kind:  "histogram",
+		}
+		if diff := cmp.Diff(want, aggregator.Table()); diff != "" {
+			t.Fatalf("unexpected aggregate (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package compute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hexops/gotextdiff"
+	"github.com/hexops/gotextdiff/myers"
+	"github.com/hexops/gotextdiff/span"
+)
+
+// FileDiff is a unified diff of the changes a command makes to a file.
+type FileDiff struct {
+	Value        string `json:"value"`
+	Path         string `json:"path"`
+	Commit       string `json:"commit"`
+	RepositoryID int32  `json:"repositoryID"`
+	Repository   string `json:"repository"`
+	Kind         string `json:"kind"`
+}
+
+// Patch bundles the file diffs of a repository into a single patch, which
+// can be applied with `git apply` or used in a batch change.
+type Patch struct {
+	Value        string `json:"value"`
+	Commit       string `json:"commit"`
+	RepositoryID int32  `json:"repositoryID"`
+	Repository   string `json:"repository"`
+	Kind         string `json:"kind"`
+}
+
+// unifiedDiff returns a git-style unified diff of the changes from before to
+// after for the file at path, or the empty string if there are none.
+func unifiedDiff(path, before, after string) string {
+	if before == after {
+		return ""
+	}
+	edits := myers.ComputeEdits(span.URIFromPath(path), before, after)
+	unified := gotextdiff.ToUnified("a/"+path, "b/"+path, before, edits)
+	return fmt.Sprintf("diff --git a/%s b/%s\n%v", path, path, unified)
+}
+
+// NewPatches bundles file diffs into one patch per repository and commit.
+// Patches are ordered by repository, and the diffs of a patch by path.
+func NewPatches(diffs []*FileDiff) []*Patch {
+	type key struct {
+		repository string
+		commit     string
+	}
+	byKey := map[key][]*FileDiff{}
+	for _, d := range diffs {
+		k := key{d.Repository, d.Commit}
+		byKey[k] = append(byKey[k], d)
+	}
+
+	patches := make([]*Patch, 0, len(byKey))
+	for k, diffs := range byKey {
+		sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+		var value strings.Builder
+		for _, d := range diffs {
+			value.WriteString(d.Value)
+		}
+		patches = append(patches, &Patch{
+			Value:        value.String(),
+			Commit:       k.commit,
+			RepositoryID: diffs[0].RepositoryID,
+			Repository:   k.repository,
+			Kind:         "patch",
+		})
+	}
+	sort.Slice(patches, func(i, j int) bool {
+		if patches[i].Repository != patches[j].Repository {
+			return patches[i].Repository < patches[j].Repository
+		}
+		return patches[i].Commit < patches[j].Commit
+	})
+	return patches
+}
@@ -2,6 +2,7 @@ package compute
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/grafana/regexp"
 
@@ -97,13 +98,19 @@ func toRegexpPattern(value string) (MatchPattern, error) {
 
 var ComputePredicateRegistry = query.PredicateRegistry{
 	query.FieldContent: {
-		"replace":            func() query.Predicate { return query.EmptyPredicate{} },
-		"replace.regexp":     func() query.Predicate { return query.EmptyPredicate{} },
-		"replace.structural": func() query.Predicate { return query.EmptyPredicate{} },
-		"output":             func() query.Predicate { return query.EmptyPredicate{} },
-		"output.regexp":      func() query.Predicate { return query.EmptyPredicate{} },
-		"output.structural":  func() query.Predicate { return query.EmptyPredicate{} },
-		"output.extra":       func() query.Predicate { return query.EmptyPredicate{} },
+		"replace":                 func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.regexp":          func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.structural":      func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.diff":            func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.regexp.diff":     func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.structural.diff": func() query.Predicate { return query.EmptyPredicate{} },
+		"output":                  func() query.Predicate { return query.EmptyPredicate{} },
+		"output.regexp":           func() query.Predicate { return query.EmptyPredicate{} },
+		"output.structural":       func() query.Predicate { return query.EmptyPredicate{} },
+		"output.extra":            func() query.Predicate { return query.EmptyPredicate{} },
+		"count":                   func() query.Predicate { return query.EmptyPredicate{} },
+		"count.regexp":            func() query.Predicate { return query.EmptyPredicate{} },
+		"count.structural":        func() query.Predicate { return query.EmptyPredicate{} },
 	},
 }
 
@@ -147,13 +154,13 @@ func parseReplace(q *query.Basic) (Command, bool, error) {
 
 	var matchPattern MatchPattern
 	switch name {
-	case "replace", "replace.regexp":
+	case "replace", "replace.regexp", "replace.diff", "replace.regexp.diff":
 		var err error
 		matchPattern, err = toRegexpPattern(left)
 		if err != nil {
 			return nil, false, errors.Wrap(err, "replace command")
 		}
-	case "replace.structural":
+	case "replace.structural", "replace.structural.diff":
 		// structural search doesn't do any match pattern validation
 		matchPattern = &Comby{Value: left}
 	default:
@@ -161,7 +168,11 @@ func parseReplace(q *query.Basic) (Command, bool, error) {
 		return nil, false, nil
 	}
 
-	return &Replace{SearchPattern: matchPattern, ReplacePattern: right}, true, nil
+	return &Replace{
+		SearchPattern:  matchPattern,
+		ReplacePattern: right,
+		Diff:           strings.HasSuffix(name, ".diff"),
+	}, true, nil
 }
 
 func parseOutput(q *query.Basic) (Command, bool, error) {
@@ -217,6 +228,46 @@ func parseOutput(q *query.Basic) (Command, bool, error) {
 	}, true, nil
 }
 
+func parseCount(q *query.Basic) (Command, bool, error) {
+	pattern, err := extractPattern(q)
+	if err != nil {
+		return nil, false, err
+	}
+
+	name, args, ok := parseContentPredicate(pattern)
+	if !ok {
+		return nil, false, nil
+	}
+
+	// The key template is optional for regexp patterns, which are counted
+	// by their matched value by default.
+	left, right := args, wholeMatch
+	if arrowSyntax.MatchString(args) {
+		left, right, err = parseArrowSyntax(args)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	var matchPattern MatchPattern
+	switch name {
+	case "count", "count.regexp":
+		var err error
+		matchPattern, err = toRegexpPattern(left)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "count command")
+		}
+	case "count.structural":
+		// structural search doesn't do any match pattern validation
+		matchPattern = &Comby{Value: left}
+	default:
+		// unrecognized name
+		return nil, false, nil
+	}
+
+	return &Count{SearchPattern: matchPattern, KeyPattern: right}, true, nil
+}
+
 func parseMatchOnly(q *query.Basic) (Command, bool, error) {
 	pattern, err := extractPattern(q)
 	if err != nil {
@@ -258,6 +309,9 @@ func first(parsers ...commandParser) commandParser {
 }
 
 var parseCommand = first(
+	// parseCount comes first because its key is optional, while other
+	// commands fail to parse predicates without an arrow.
+	parseCount,
 	parseReplace,
 	parseOutput,
 	parseMatchOnly,
@@ -284,6 +338,11 @@ func toComputeQuery(plan query.Plan) (*Query, error) {
 }
 
 func Parse(q string) (*Query, error) {
+	q, aggregation, err := scanAggregationParameters(q)
+	if err != nil {
+		return nil, err
+	}
+
 	parseTree, err := query.ParseRegexp(q)
 	if err != nil {
 		return nil, err
@@ -301,5 +360,9 @@ func Parse(q string) (*Query, error) {
 	if err != nil {
 		return nil, err
 	}
-	return toComputeQuery(plan)
+	computeQuery, err := toComputeQuery(plan)
+	if err != nil {
+		return nil, err
+	}
+	return aggregation.apply(computeQuery)
 }
@@ -50,6 +50,62 @@ func TestParse(t *testing.T) {
 	autogold.Want("replace no left hand side",
 		"Command: `Replace in place: () -> (b)`").
 		Equal(t, test("content:replace(->b)"))
+
+	autogold.Want("replace with diff",
+		"Command: `Replace with diff: (Println) -> (Print)`, Parameters: `repo:foo`").
+		Equal(t, test("content:replace.diff(Println -> Print) repo:foo"))
+
+	autogold.Want("structural replace with diff",
+		"Command: `Replace with diff: (foo(:[x])) -> (bar(:[x]))`").
+		Equal(t, test("content:replace.structural.diff(foo(:[x]) -> bar(:[x]))"))
+
+	autogold.Want("count by capture group",
+		"Command: `Count: ((\\w+)Error) by ($1)`").
+		Equal(t, test(`content:count((\w+)Error -> $1)`))
+
+	autogold.Want("count by matched value",
+		"Command: `Count: (TODO) by ($0) top: 3`, Parameters: `repo:foo`").
+		Equal(t, test("content:count(TODO) top:3 repo:foo"))
+
+	autogold.Want("group-by turns match only into count",
+		"Command: `Count: ((?i:TODO)) by ($repo) top: 10`").
+		Equal(t, test("TODO group-by:repo top:10"))
+
+	autogold.Want("group-by for structural count",
+		"Command: `Count: (foo(:[x])) by ($repo)`").
+		Equal(t, test("content:count.structural(foo(:[x])) group-by:repo"))
+
+	autogold.Want("structural count without key",
+		"count command: structural patterns require a key, as in `content:count.structural(:[x] -> :[x])` or `group-by:repo`").
+		Equal(t, test("content:count.structural(foo(:[x]))"))
+
+	autogold.Want("group-by with count key",
+		"group-by: cannot be combined with the key of a count command").
+		Equal(t, test("content:count(a -> $1) group-by:repo"))
+
+	autogold.Want("top with replace",
+		"group-by:, top: and histogram: are only supported for count and match-only commands").
+		Equal(t, test("content:replace(a -> b) top:3"))
+
+	autogold.Want("histogram turns match only into count by date",
+		"Command: `Count: ((?i:TODO)) by ($date) histogram: month`, Parameters: `type:diff`").
+		Equal(t, test("TODO type:diff histogram:month"))
+
+	autogold.Want("histogram with group-by",
+		"histogram: cannot be combined with group-by: or top:").
+		Equal(t, test("TODO histogram:week group-by:repo"))
+
+	autogold.Want("invalid histogram",
+		`invalid value "hour" for histogram:, expected one of day, week, month or year`).
+		Equal(t, test("TODO histogram:hour"))
+
+	autogold.Want("invalid group-by",
+		`invalid value "owner" for group-by:, expected one of repo, path, lang, author, email, commit or date`).
+		Equal(t, test("foo group-by:owner"))
+
+	autogold.Want("top in quoted pattern",
+		"Command: `Match only search pattern: foo top:3, compute pattern: (?i:foo top:3)`, Parameters: `repo:bar`").
+		Equal(t, test(`"foo top:3" repo:bar`))
 }
 
 func TestToSearchQuery(t *testing.T) {
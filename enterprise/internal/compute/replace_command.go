@@ -15,6 +15,10 @@ import (
 type Replace struct {
 	SearchPattern  MatchPattern
 	ReplacePattern string
+
+	// Diff, if true, makes Replace apply the rewrite to whole files and
+	// return a unified diff of the changes instead of the new content.
+	Diff bool
 }
 
 func (c *Replace) ToSearchPattern() string {
@@ -22,6 +26,9 @@ func (c *Replace) ToSearchPattern() string {
 }
 
 func (c *Replace) String() string {
+	if c.Diff {
+		return fmt.Sprintf("Replace with diff: (%s) -> (%s)", c.SearchPattern.String(), c.ReplacePattern)
+	}
 	return fmt.Sprintf("Replace in place: (%s) -> (%s)", c.SearchPattern.String(), c.ReplacePattern)
 }
 
@@ -57,7 +64,23 @@ func (c *Replace) Run(ctx context.Context, db database.DB, r result.Match) (Resu
 		if err != nil {
 			return nil, err
 		}
-		return replace(ctx, content, c.SearchPattern, c.ReplacePattern)
+		text, err := replace(ctx, content, c.SearchPattern, c.ReplacePattern)
+		if err != nil || !c.Diff {
+			return text, err
+		}
+		value := unifiedDiff(m.Path, string(content), text.Value)
+		if value == "" {
+			// The rewrite did not change the file.
+			return nil, nil
+		}
+		return &FileDiff{
+			Value:        value,
+			Path:         m.Path,
+			Commit:       string(m.CommitID),
+			RepositoryID: int32(m.Repo.ID),
+			Repository:   string(m.Repo.Name),
+			Kind:         "diff",
+		}, nil
 	}
 	return nil, nil
 }
@@ -5,10 +5,16 @@ import (
 	"os"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/grafana/regexp"
 	"github.com/hexops/autogold"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/comby"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 func Test_replace(t *testing.T) {
@@ -41,3 +47,69 @@ func Test_replace(t *testing.T) {
 			ReplacePattern: "foo(:[y], :[x])",
 		}))
 }
+
+func TestReplaceDiff(t *testing.T) {
+	test := func(content string, cmd *Replace) string {
+		gitserver.Mocks.ReadFile = func(_ api.CommitID, _ string) ([]byte, error) {
+			return []byte(content), nil
+		}
+		defer gitserver.ResetMocks()
+
+		res, err := cmd.Run(context.Background(), database.NewMockDB(), &result.FileMatch{
+			File: result.File{
+				Repo:     types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"},
+				CommitID: "deadbeef",
+				Path:     "main.go",
+			},
+		})
+		if err != nil {
+			return err.Error()
+		}
+		if res == nil {
+			return "no result"
+		}
+		return res.(*FileDiff).Value
+	}
+
+	autogold.Want(
+		"regexp replace diff",
+		"diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -1,5 +1,5 @@\n package main\n \n func main() {\n-\tfmt.Println(\"hello\")\n+\tfmt.Print(\"hello\")\n }\n").
+		Equal(t, test("package main\n\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n", &Replace{
+			SearchPattern:  &Regexp{Value: regexp.MustCompile(`Println`)},
+			ReplacePattern: "Print",
+			Diff:           true,
+		}))
+
+	autogold.Want(
+		"regexp replace diff without trailing newline",
+		"diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n").
+		Equal(t, test("a\nb", &Replace{
+			SearchPattern:  &Regexp{Value: regexp.MustCompile(`b`)},
+			ReplacePattern: "c",
+			Diff:           true,
+		}))
+
+	autogold.Want(
+		"unchanged file has no diff",
+		"no result").
+		Equal(t, test("a\nb\n", &Replace{
+			SearchPattern:  &Regexp{Value: regexp.MustCompile(`x`)},
+			ReplacePattern: "y",
+			Diff:           true,
+		}))
+}
+
+func TestNewPatches(t *testing.T) {
+	diff := func(repo, path string) *FileDiff {
+		return &FileDiff{Value: "diff --git a/" + path + " b/" + path + "\n", Path: path, Commit: "deadbeef", Repository: repo, Kind: "diff"}
+	}
+
+	got := NewPatches([]*FileDiff{diff("b", "y.go"), diff("a", "z.go"), diff("b", "x.go")})
+	want := []*Patch{
+		{Value: "diff --git a/z.go b/z.go\n", Commit: "deadbeef", Repository: "a", Kind: "patch"},
+		{Value: "diff --git a/x.go b/x.go\ndiff --git a/y.go b/y.go\n", Commit: "deadbeef", Repository: "b", Kind: "patch"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected patches (-want +got):\n%s", diff)
+	}
+}
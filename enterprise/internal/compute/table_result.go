@@ -0,0 +1,127 @@
+package compute
+
+import (
+	"sort"
+	"time"
+)
+
+// Row is a group of an aggregate and the number of matches in it.
+type Row struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+// Table is the result of an aggregation command. Rows are sorted by count in
+// descending order, except for histograms, whose rows are sorted by date.
+type Table struct {
+	Rows []Row `json:"rows"`
+
+	// Total is the number of matches over all groups, including groups
+	// that are omitted from Rows by a `top:` limit.
+	Total int    `json:"total"`
+	Kind  string `json:"kind"`
+}
+
+// newTable returns a table of counts keyed by group. If top is positive, only
+// the top groups with the most matches are part of the table.
+func newTable(counts map[string]int, top int) *Table {
+	rows := make([]Row, 0, len(counts))
+	total := 0
+	for key, count := range counts {
+		rows = append(rows, Row{Key: key, Count: count})
+		total += count
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return rows[i].Key < rows[j].Key
+	})
+	if top > 0 && len(rows) > top {
+		rows = rows[:top]
+	}
+	return &Table{Rows: rows, Total: total, Kind: "count"}
+}
+
+// newHistogram returns a table of counts keyed by the date buckets of
+// bucketDates, in chronological order.
+func newHistogram(counts map[string]int) *Table {
+	rows := make([]Row, 0, len(counts))
+	total := 0
+	for key, count := range counts {
+		rows = append(rows, Row{Key: key, Count: count})
+		total += count
+	}
+	// Bucket keys have a fixed width per interval, so they sort by date.
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Key < rows[j].Key
+	})
+	return &Table{Rows: rows, Total: total, Kind: "histogram"}
+}
+
+// histogramIntervals maps the values of the `histogram:` parameter to the
+// layout of the keys of their buckets.
+var histogramIntervals = map[string]string{
+	"day":   "2006-01-02",
+	"week":  "2006-01-02",
+	"month": "2006-01",
+	"year":  "2006",
+}
+
+// bucketDates sums counts keyed by dates in the format of $date into buckets
+// of the given interval. A bucket is keyed by its first day, in a format
+// depending on the interval, and weeks start on Monday. Keys that are not
+// dates, such as the empty $date of file matches, are dropped.
+func bucketDates(counts map[string]int, interval string) map[string]int {
+	buckets := make(map[string]int, len(counts))
+	for key, count := range counts {
+		date, err := time.Parse("2006-01-02", key)
+		if err != nil {
+			continue
+		}
+		if interval == "week" {
+			date = date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+		}
+		buckets[date.Format(histogramIntervals[interval])] += count
+	}
+	return buckets
+}
+
+// Aggregator folds the tables of individual search results into a single
+// table. It is not safe for concurrent use.
+type Aggregator struct {
+	top       int
+	histogram bool
+	counts    map[string]int
+	dirty     bool
+}
+
+// NewAggregator returns an aggregator for the results of an aggregation
+// command.
+func NewAggregator(cmd *Count) *Aggregator {
+	return &Aggregator{top: cmd.Top, histogram: cmd.Histogram != "", counts: map[string]int{}}
+}
+
+// Add folds t into the aggregate.
+func (a *Aggregator) Add(t *Table) {
+	for _, row := range t.Rows {
+		a.counts[row.Key] += row.Count
+	}
+	if len(t.Rows) > 0 {
+		a.dirty = true
+	}
+}
+
+// Dirty returns true if the aggregate changed since the last call to Table.
+func (a *Aggregator) Dirty() bool {
+	return a.dirty
+}
+
+// Table returns the current aggregate.
+func (a *Aggregator) Table() *Table {
+	a.dirty = false
+	if a.histogram {
+		return newHistogram(a.counts)
+	}
+	return newTable(a.counts, a.top)
+}
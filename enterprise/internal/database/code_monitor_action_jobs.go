@@ -20,6 +20,12 @@ type ActionJob struct {
 	SlackWebhook *int64
 	TriggerEvent int32
 
+	// Exactly one of the action columns above or below is set. The columns
+	// below reference templated webhook actions, one per preset.
+	TeamsWebhook      *int64
+	MattermostWebhook *int64
+	PagerDutyWebhook  *int64
+
 	// Fields demanded by any dbworker.
 	State          string
 	FailureMessage *string
@@ -35,10 +41,26 @@ func (a *ActionJob) RecordID() int {
 	return int(a.ID)
 }
 
+// TemplatedWebhook returns the preset and the ID of the templated webhook
+// action executed by the job, if any.
+func (a *ActionJob) TemplatedWebhook() (TemplatedWebhookPreset, int64, bool) {
+	switch {
+	case a.TeamsWebhook != nil:
+		return TemplatedWebhookPresetTeams, *a.TeamsWebhook, true
+	case a.MattermostWebhook != nil:
+		return TemplatedWebhookPresetMattermost, *a.MattermostWebhook, true
+	case a.PagerDutyWebhook != nil:
+		return TemplatedWebhookPresetPagerDuty, *a.PagerDutyWebhook, true
+	default:
+		return "", 0, false
+	}
+}
+
 type ActionJobMetadata struct {
 	Description string
 	MonitorID   int64
 	Results     []*result.CommitMatch
+	FileResults []*FileResult
 	OwnerName   string
 
 	// The query with after: filter.
@@ -53,6 +75,9 @@ var ActionJobColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.webhook"),
 	sqlf.Sprintf("cm_action_jobs.slack_webhook"),
 	sqlf.Sprintf("cm_action_jobs.trigger_event"),
+	sqlf.Sprintf("cm_action_jobs.teams_webhook"),
+	sqlf.Sprintf("cm_action_jobs.mattermost_webhook"),
+	sqlf.Sprintf("cm_action_jobs.pagerduty_webhook"),
 	sqlf.Sprintf("cm_action_jobs.state"),
 	sqlf.Sprintf("cm_action_jobs.failure_message"),
 	sqlf.Sprintf("cm_action_jobs.started_at"),
@@ -83,6 +108,12 @@ type ListActionJobsOpts struct {
 	// the given slack webhook action. Refers to cm_slack_webhooks(id)
 	SlackWebhookID *int
 
+	// TemplatedWebhookID, if set, will filter to only actions jobs that are
+	// executing the given templated webhook action. Refers to the table of
+	// TemplatedWebhookPreset, which must be set as well.
+	TemplatedWebhookID     *int
+	TemplatedWebhookPreset TemplatedWebhookPreset
+
 	// First, if defined, limits the operation to only the first n results
 	First *int
 
@@ -106,6 +137,13 @@ func (o ListActionJobsOpts) Conds() *sqlf.Query {
 	if o.SlackWebhookID != nil {
 		conds = append(conds, sqlf.Sprintf("slack_webhook = %s", *o.SlackWebhookID))
 	}
+	if o.TemplatedWebhookID != nil {
+		if column := o.TemplatedWebhookPreset.actionJobColumn(); column != "" {
+			conds = append(conds, sqlf.Sprintf(column+" = %s", *o.TemplatedWebhookID))
+		} else {
+			conds = append(conds, sqlf.Sprintf("FALSE"))
+		}
+	}
 	if o.After != nil {
 		conds = append(conds, sqlf.Sprintf("id > %s", *o.After))
 	}
@@ -193,14 +231,47 @@ WITH due_emails AS (
 	SELECT DISTINCT slack_webhook as id FROM cm_action_jobs
 	WHERE state = 'queued'
 		OR state = 'processing'
+), due_teams_webhooks AS (
+	SELECT id
+	FROM cm_teams_webhooks
+	WHERE monitor = %s
+		AND enabled = true
+	EXCEPT
+	SELECT DISTINCT teams_webhook as id FROM cm_action_jobs
+	WHERE state = 'queued'
+		OR state = 'processing'
+), due_mattermost_webhooks AS (
+	SELECT id
+	FROM cm_mattermost_webhooks
+	WHERE monitor = %s
+		AND enabled = true
+	EXCEPT
+	SELECT DISTINCT mattermost_webhook as id FROM cm_action_jobs
+	WHERE state = 'queued'
+		OR state = 'processing'
+), due_pagerduty_webhooks AS (
+	SELECT id
+	FROM cm_pagerduty_webhooks
+	WHERE monitor = %s
+		AND enabled = true
+	EXCEPT
+	SELECT DISTINCT pagerduty_webhook as id FROM cm_action_jobs
+	WHERE state = 'queued'
+		OR state = 'processing'
 )
-INSERT INTO cm_action_jobs (email, webhook, slack_webhook, trigger_event)
-SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_emails
+INSERT INTO cm_action_jobs (email, webhook, slack_webhook, teams_webhook, mattermost_webhook, pagerduty_webhook, trigger_event)
+SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_emails
+UNION
+SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_webhooks
+UNION
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_slack_webhooks
+UNION
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_teams_webhooks
 UNION
-SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer from due_webhooks
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer from due_mattermost_webhooks
 UNION
-SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer from due_slack_webhooks
-ORDER BY 1, 2, 3
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer from due_pagerduty_webhooks
+ORDER BY 1, 2, 3, 4, 5, 6
 RETURNING %s
 `
 
@@ -210,6 +281,12 @@ func (s *codeMonitorStore) EnqueueActionJobsForMonitor(ctx context.Context, moni
 		monitorID,
 		monitorID,
 		monitorID,
+		monitorID,
+		monitorID,
+		monitorID,
+		triggerJobID,
+		triggerJobID,
+		triggerJobID,
 		triggerJobID,
 		triggerJobID,
 		triggerJobID,
@@ -229,6 +306,7 @@ SELECT
 	ctj.query_string,
 	cm.id AS monitorID,
 	ctj.search_results,
+	ctj.file_results,
 	CASE WHEN LENGTH(users.display_name) > 0 THEN users.display_name ELSE users.username END
 FROM cm_action_jobs caj
 INNER JOIN cm_trigger_jobs ctj on caj.trigger_event = ctj.id
@@ -241,15 +319,20 @@ WHERE caj.id = %s
 // GetActionJobMetada returns the set of fields needed to execute all action jobs
 func (s *codeMonitorStore) GetActionJobMetadata(ctx context.Context, jobID int32) (*ActionJobMetadata, error) {
 	row := s.Store.QueryRow(ctx, sqlf.Sprintf(getActionJobMetadataFmtStr, jobID))
-	var resultsJSON []byte
+	var resultsJSON, fileResultsJSON []byte
 	m := &ActionJobMetadata{}
-	err := row.Scan(&m.Description, &m.Query, &m.MonitorID, &resultsJSON, &m.OwnerName)
+	err := row.Scan(&m.Description, &m.Query, &m.MonitorID, &resultsJSON, &fileResultsJSON, &m.OwnerName)
 	if err != nil {
 		return nil, err
 	}
 	if err := json.Unmarshal(resultsJSON, &m.Results); err != nil {
 		return nil, err
 	}
+	if len(fileResultsJSON) > 0 {
+		if err := json.Unmarshal(fileResultsJSON, &m.FileResults); err != nil {
+			return nil, err
+		}
+	}
 	return m, nil
 }
 
@@ -299,6 +382,9 @@ func scanActionJob(row dbutil.Scanner) (*ActionJob, error) {
 		&aj.Webhook,
 		&aj.SlackWebhook,
 		&aj.TriggerEvent,
+		&aj.TeamsWebhook,
+		&aj.MattermostWebhook,
+		&aj.PagerDutyWebhook,
 		&aj.State,
 		&aj.FailureMessage,
 		&aj.StartedAt,
@@ -50,10 +50,11 @@ func TestGetActionJobMetadata(t *testing.T) {
 	triggerJobID := triggerJobs[0].ID
 
 	var (
-		wantResults = make([]*result.CommitMatch, 42)
-		wantQuery   = testQuery + " after:\"" + s.Now().UTC().Format(time.RFC3339) + "\""
+		wantResults     = make([]*result.CommitMatch, 42)
+		wantFileResults = []*FileResult{{Path: "removed.go", Removed: true}}
+		wantQuery       = testQuery + " after:\"" + s.Now().UTC().Format(time.RFC3339) + "\""
 	)
-	err = s.UpdateTriggerJobWithResults(ctx, triggerJobID, wantQuery, wantResults)
+	err = s.UpdateTriggerJobWithResults(ctx, triggerJobID, wantQuery, wantResults, wantFileResults)
 	require.NoError(t, err)
 
 	actionJobs, err := s.EnqueueActionJobsForMonitor(ctx, fixtures.monitor.ID, triggerJobID)
@@ -67,6 +68,7 @@ func TestGetActionJobMetadata(t *testing.T) {
 		Description: testDescription,
 		Query:       wantQuery,
 		Results:     wantResults,
+		FileResults: wantFileResults,
 		MonitorID:   fixtures.monitor.ID,
 		OwnerName:   userName,
 	}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+// LastResult is a file match found by the last run of a content or symbol
+// search code monitor.
+type LastResult struct {
+	RepoID api.RepoID
+	// RepoName is only populated when reading last results.
+	RepoName api.RepoName
+	Path     string
+
+	// Fingerprint identifies the file match and its matched content across
+	// runs of the code monitor.
+	Fingerprint string
+}
+
+const getLastResultsFmtStr = `
+SELECT cm_last_results.repo_id, repo.name, cm_last_results.path, cm_last_results.fingerprint
+FROM cm_last_results
+INNER JOIN repo ON repo.id = cm_last_results.repo_id
+WHERE cm_last_results.monitor_id = %s
+ORDER BY repo.name, cm_last_results.path, cm_last_results.fingerprint
+`
+
+func (s *codeMonitorStore) GetLastResults(ctx context.Context, monitorID int64) (_ []*LastResult, err error) {
+	rows, err := s.Store.Query(ctx, sqlf.Sprintf(getLastResultsFmtStr, monitorID))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var results []*LastResult
+	for rows.Next() {
+		var r LastResult
+		if err := rows.Scan(&r.RepoID, &r.RepoName, &r.Path, &r.Fingerprint); err != nil {
+			return nil, err
+		}
+		results = append(results, &r)
+	}
+	return results, nil
+}
+
+const deleteLastResultsFmtStr = `
+DELETE FROM cm_last_results
+WHERE monitor_id = %s
+`
+
+const insertLastResultsFmtStr = `
+INSERT INTO cm_last_results (monitor_id, repo_id, path, fingerprint)
+SELECT %s, unnest(%s::integer[]), unnest(%s::text[]), unnest(%s::text[])
+ON CONFLICT DO NOTHING
+`
+
+func (s *codeMonitorStore) ReplaceLastResults(ctx context.Context, monitorID int64, results []*LastResult) (err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(deleteLastResultsFmtStr, monitorID)); err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	repoIDs := make([]int64, len(results))
+	paths := make([]string, len(results))
+	fingerprints := make([]string, len(results))
+	for i, r := range results {
+		repoIDs[i] = int64(r.RepoID)
+		paths[i] = r.Path
+		fingerprints[i] = r.Fingerprint
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf(
+		insertLastResultsFmtStr,
+		monitorID,
+		pq.Int64Array(repoIDs),
+		pq.StringArray(paths),
+		pq.StringArray(fingerprints),
+	))
+}
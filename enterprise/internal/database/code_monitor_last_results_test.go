@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestCodeMonitorStoreLastResults(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	t.Run("replace get replace get", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		// Replace
+		insertResults := []*LastResult{
+			{RepoID: fixtures.Repo.ID, Path: "a.go", Fingerprint: "fingerprint1"},
+			{RepoID: fixtures.Repo.ID, Path: "b.go", Fingerprint: "fingerprint2"},
+		}
+		err := cm.ReplaceLastResults(ctx, fixtures.Monitor.ID, insertResults)
+		require.NoError(t, err)
+
+		// Get
+		lastResults, err := cm.GetLastResults(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, []*LastResult{
+			{RepoID: fixtures.Repo.ID, RepoName: fixtures.Repo.Name, Path: "a.go", Fingerprint: "fingerprint1"},
+			{RepoID: fixtures.Repo.ID, RepoName: fixtures.Repo.Name, Path: "b.go", Fingerprint: "fingerprint2"},
+		}, lastResults)
+
+		// Replace
+		updateResults := []*LastResult{
+			{RepoID: fixtures.Repo.ID, Path: "c.go", Fingerprint: "fingerprint3"},
+		}
+		err = cm.ReplaceLastResults(ctx, fixtures.Monitor.ID, updateResults)
+		require.NoError(t, err)
+
+		// Get
+		lastResults, err = cm.GetLastResults(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, []*LastResult{
+			{RepoID: fixtures.Repo.ID, RepoName: fixtures.Repo.Name, Path: "c.go", Fingerprint: "fingerprint3"},
+		}, lastResults)
+
+		// Replace with nothing
+		err = cm.ReplaceLastResults(ctx, fixtures.Monitor.ID, nil)
+		require.NoError(t, err)
+
+		// Get
+		lastResults, err = cm.GetLastResults(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Empty(t, lastResults)
+	})
+
+	t.Run("no error for missing get", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		lastResults, err := cm.GetLastResults(ctx, fixtures.Monitor.ID+1)
+		require.NoError(t, err)
+		require.Empty(t, lastResults)
+	})
+}
@@ -0,0 +1,397 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// TemplatedWebhookPreset identifies the kind of service a templated webhook
+// action sends its events to. Each preset is stored in its own table.
+type TemplatedWebhookPreset string
+
+const (
+	TemplatedWebhookPresetTeams      TemplatedWebhookPreset = "TEAMS"
+	TemplatedWebhookPresetMattermost TemplatedWebhookPreset = "MATTERMOST"
+	TemplatedWebhookPresetPagerDuty  TemplatedWebhookPreset = "PAGERDUTY"
+)
+
+// TemplatedWebhookPresets is the list of all supported presets.
+var TemplatedWebhookPresets = []TemplatedWebhookPreset{
+	TemplatedWebhookPresetTeams,
+	TemplatedWebhookPresetMattermost,
+	TemplatedWebhookPresetPagerDuty,
+}
+
+// Valid returns true if p is one of the supported presets.
+func (p TemplatedWebhookPreset) Valid() bool {
+	return p.tableName() != ""
+}
+
+func (p TemplatedWebhookPreset) tableName() string {
+	switch p {
+	case TemplatedWebhookPresetTeams:
+		return "cm_teams_webhooks"
+	case TemplatedWebhookPresetMattermost:
+		return "cm_mattermost_webhooks"
+	case TemplatedWebhookPresetPagerDuty:
+		return "cm_pagerduty_webhooks"
+	default:
+		return ""
+	}
+}
+
+// actionJobColumn returns the column of cm_action_jobs that references
+// actions of this preset.
+func (p TemplatedWebhookPreset) actionJobColumn() string {
+	switch p {
+	case TemplatedWebhookPresetTeams:
+		return "teams_webhook"
+	case TemplatedWebhookPresetMattermost:
+		return "mattermost_webhook"
+	case TemplatedWebhookPresetPagerDuty:
+		return "pagerduty_webhook"
+	default:
+		return ""
+	}
+}
+
+// hasRoutingKey returns true if the table of this preset stores a routing key.
+func (p TemplatedWebhookPreset) hasRoutingKey() bool {
+	return p == TemplatedWebhookPresetPagerDuty
+}
+
+type TemplatedWebhookAction struct {
+	ID             int64
+	Monitor        int64
+	Preset         TemplatedWebhookPreset
+	Enabled        bool
+	IncludeResults bool
+
+	// URL, BodyTemplate and ContentType fall back to the defaults of the
+	// preset when empty. RoutingKey is only stored for PagerDuty actions.
+	URL          string
+	RoutingKey   string
+	BodyTemplate string
+	ContentType  string
+	Headers      map[string]string
+
+	CreatedBy int32
+	CreatedAt time.Time
+	ChangedBy int32
+	ChangedAt time.Time
+}
+
+type TemplatedWebhookActionArgs struct {
+	Enabled        bool
+	IncludeResults bool
+	URL            string
+	RoutingKey     string
+	BodyTemplate   string
+	ContentType    string
+	Headers        map[string]string
+}
+
+// settableColumns returns the columns and values set by a create or update of
+// an action of the given preset. The headers and the routing key may contain
+// credentials, so they are encrypted with the given key, if any.
+func (a *TemplatedWebhookActionArgs) settableColumns(ctx context.Context, key encryption.Key, p TemplatedWebhookPreset) (columns []string, values []*sqlf.Query, err error) {
+	if !p.hasRoutingKey() && a.RoutingKey != "" {
+		return nil, nil, errors.Errorf("routing keys are not supported by %s actions", p)
+	}
+
+	headers := a.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	rawHeaders, err := json.Marshal(headers)
+	if err != nil {
+		return nil, nil, err
+	}
+	encryptedHeaders, keyID, err := database.MaybeEncrypt(ctx, key, string(rawHeaders))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "encrypting headers")
+	}
+
+	columns = []string{"enabled", "include_results", "url", "body_template", "content_type", "headers", "encryption_key_id"}
+	values = []*sqlf.Query{
+		sqlf.Sprintf("%s", a.Enabled),
+		sqlf.Sprintf("%s", a.IncludeResults),
+		sqlf.Sprintf("%s", a.URL),
+		sqlf.Sprintf("%s", a.BodyTemplate),
+		sqlf.Sprintf("%s", a.ContentType),
+		sqlf.Sprintf("%s", encryptedHeaders),
+		sqlf.Sprintf("%s", keyID),
+	}
+	if p.hasRoutingKey() {
+		// An empty routing key is stored as is, like MaybeDecrypt returns it.
+		encryptedRoutingKey := a.RoutingKey
+		if encryptedRoutingKey != "" {
+			if encryptedRoutingKey, _, err = database.MaybeEncrypt(ctx, key, a.RoutingKey); err != nil {
+				return nil, nil, errors.Wrap(err, "encrypting routing key")
+			}
+		}
+		columns = append(columns, "routing_key")
+		values = append(values, sqlf.Sprintf("%s", encryptedRoutingKey))
+	}
+	return columns, values, nil
+}
+
+const updateTemplatedWebhookActionQuery = `
+UPDATE %s
+SET %s,
+	changed_by = %s,
+	changed_at = %s
+WHERE
+	id = %s
+	AND EXISTS (
+		SELECT 1 FROM cm_monitors
+		WHERE cm_monitors.id = %s.monitor
+			AND cm_monitors.namespace_user_id = %s
+	)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) UpdateTemplatedWebhookAction(ctx context.Context, preset TemplatedWebhookPreset, id int64, args *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error) {
+	if !preset.Valid() {
+		return nil, errors.Errorf("unknown templated webhook preset %q", preset)
+	}
+	columns, values, err := args.settableColumns(ctx, s.getEncryptionKey(), preset)
+	if err != nil {
+		return nil, err
+	}
+	sets := make([]*sqlf.Query, 0, len(columns))
+	for i, column := range columns {
+		sets = append(sets, sqlf.Sprintf(column+" = %s", values[i]))
+	}
+
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		updateTemplatedWebhookActionQuery,
+		sqlf.Sprintf(preset.tableName()),
+		sqlf.Join(sets, ",\n\t"),
+		a.UID,
+		s.Now(),
+		id,
+		sqlf.Sprintf(preset.tableName()),
+		a.UID,
+		sqlf.Join(templatedWebhookActionColumns(preset), ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanTemplatedWebhookAction(ctx, s.getEncryptionKey(), preset, row)
+}
+
+const createTemplatedWebhookActionQuery = `
+INSERT INTO %s
+(monitor, %s, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) CreateTemplatedWebhookAction(ctx context.Context, preset TemplatedWebhookPreset, monitorID int64, args *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error) {
+	if !preset.Valid() {
+		return nil, errors.Errorf("unknown templated webhook preset %q", preset)
+	}
+	columns, values, err := args.settableColumns(ctx, s.getEncryptionKey(), preset)
+	if err != nil {
+		return nil, err
+	}
+	columnNames := make([]*sqlf.Query, 0, len(columns))
+	for _, column := range columns {
+		columnNames = append(columnNames, sqlf.Sprintf(column))
+	}
+
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		createTemplatedWebhookActionQuery,
+		sqlf.Sprintf(preset.tableName()),
+		sqlf.Join(columnNames, ", "),
+		monitorID,
+		sqlf.Join(values, ","),
+		a.UID,
+		now,
+		a.UID,
+		now,
+		sqlf.Join(templatedWebhookActionColumns(preset), ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanTemplatedWebhookAction(ctx, s.getEncryptionKey(), preset, row)
+}
+
+const deleteTemplatedWebhookActionQuery = `
+DELETE FROM %s
+WHERE id in (%s)
+	AND MONITOR = %s
+`
+
+func (s *codeMonitorStore) DeleteTemplatedWebhookActions(ctx context.Context, preset TemplatedWebhookPreset, monitorID int64, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if !preset.Valid() {
+		return errors.Errorf("unknown templated webhook preset %q", preset)
+	}
+
+	deleteIDs := make([]*sqlf.Query, 0, len(ids))
+	for _, id := range ids {
+		deleteIDs = append(deleteIDs, sqlf.Sprintf("%d", id))
+	}
+	q := sqlf.Sprintf(
+		deleteTemplatedWebhookActionQuery,
+		sqlf.Sprintf(preset.tableName()),
+		sqlf.Join(deleteIDs, ","),
+		monitorID,
+	)
+
+	return s.Exec(ctx, q)
+}
+
+const countTemplatedWebhookActionsQuery = `
+SELECT COUNT(*)
+FROM %s
+WHERE monitor = %s;
+`
+
+func (s *codeMonitorStore) CountTemplatedWebhookActions(ctx context.Context, preset TemplatedWebhookPreset, monitorID int64) (int, error) {
+	if !preset.Valid() {
+		return 0, errors.Errorf("unknown templated webhook preset %q", preset)
+	}
+
+	var count int
+	err := s.QueryRow(ctx, sqlf.Sprintf(countTemplatedWebhookActionsQuery, sqlf.Sprintf(preset.tableName()), monitorID)).Scan(&count)
+	return count, err
+}
+
+const getTemplatedWebhookActionQuery = `
+SELECT %s -- TemplatedWebhookActionColumns
+FROM %s
+WHERE id = %s
+`
+
+func (s *codeMonitorStore) GetTemplatedWebhookAction(ctx context.Context, preset TemplatedWebhookPreset, id int64) (*TemplatedWebhookAction, error) {
+	if !preset.Valid() {
+		return nil, errors.Errorf("unknown templated webhook preset %q", preset)
+	}
+
+	q := sqlf.Sprintf(
+		getTemplatedWebhookActionQuery,
+		sqlf.Join(templatedWebhookActionColumns(preset), ","),
+		sqlf.Sprintf(preset.tableName()),
+		id,
+	)
+	row := s.QueryRow(ctx, q)
+	return scanTemplatedWebhookAction(ctx, s.getEncryptionKey(), preset, row)
+}
+
+const listTemplatedWebhookActionsQuery = `
+SELECT %s -- TemplatedWebhookActionColumns
+FROM %s
+WHERE %s
+ORDER BY id ASC
+LIMIT %s;
+`
+
+func (s *codeMonitorStore) ListTemplatedWebhookActions(ctx context.Context, preset TemplatedWebhookPreset, opts ListActionsOpts) ([]*TemplatedWebhookAction, error) {
+	if !preset.Valid() {
+		return nil, errors.Errorf("unknown templated webhook preset %q", preset)
+	}
+
+	q := sqlf.Sprintf(
+		listTemplatedWebhookActionsQuery,
+		sqlf.Join(templatedWebhookActionColumns(preset), ","),
+		sqlf.Sprintf(preset.tableName()),
+		opts.Conds(),
+		opts.Limit(),
+	)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanTemplatedWebhookActions(ctx, s.getEncryptionKey(), preset, rows)
+}
+
+// templatedWebhookActionColumns is the set of columns in the table of the
+// given preset. This must be kept in sync with scanTemplatedWebhookAction.
+func templatedWebhookActionColumns(preset TemplatedWebhookPreset) []*sqlf.Query {
+	table := preset.tableName()
+	routingKey := sqlf.Sprintf("''")
+	if preset.hasRoutingKey() {
+		routingKey = sqlf.Sprintf(table + ".routing_key")
+	}
+	return []*sqlf.Query{
+		sqlf.Sprintf(table + ".id"),
+		sqlf.Sprintf(table + ".monitor"),
+		sqlf.Sprintf(table + ".enabled"),
+		sqlf.Sprintf(table + ".include_results"),
+		sqlf.Sprintf(table + ".url"),
+		routingKey,
+		sqlf.Sprintf(table + ".body_template"),
+		sqlf.Sprintf(table + ".content_type"),
+		sqlf.Sprintf(table + ".headers"),
+		sqlf.Sprintf(table + ".encryption_key_id"),
+		sqlf.Sprintf(table + ".created_by"),
+		sqlf.Sprintf(table + ".created_at"),
+		sqlf.Sprintf(table + ".changed_by"),
+		sqlf.Sprintf(table + ".changed_at"),
+	}
+}
+
+func scanTemplatedWebhookActions(ctx context.Context, key encryption.Key, preset TemplatedWebhookPreset, rows *sql.Rows) ([]*TemplatedWebhookAction, error) {
+	var ws []*TemplatedWebhookAction
+	for rows.Next() {
+		w, err := scanTemplatedWebhookAction(ctx, key, preset, rows)
+		if err != nil {
+			return nil, err
+		}
+		ws = append(ws, w)
+	}
+	return ws, rows.Err()
+}
+
+// scanTemplatedWebhookAction scans a TemplatedWebhookAction from a *sql.Row
+// or *sql.Rows, and decrypts its secrets with the given key. It must be kept
+// in sync with templatedWebhookActionColumns.
+func scanTemplatedWebhookAction(ctx context.Context, key encryption.Key, preset TemplatedWebhookPreset, scanner dbutil.Scanner) (*TemplatedWebhookAction, error) {
+	w := TemplatedWebhookAction{Preset: preset}
+	var rawHeaders, keyID string
+	err := scanner.Scan(
+		&w.ID,
+		&w.Monitor,
+		&w.Enabled,
+		&w.IncludeResults,
+		&w.URL,
+		&w.RoutingKey,
+		&w.BodyTemplate,
+		&w.ContentType,
+		&rawHeaders,
+		&keyID,
+		&w.CreatedBy,
+		&w.CreatedAt,
+		&w.ChangedBy,
+		&w.ChangedAt,
+	)
+	if err != nil {
+		return &w, err
+	}
+
+	if w.RoutingKey, err = database.MaybeDecrypt(ctx, key, w.RoutingKey, keyID); err != nil {
+		return &w, errors.Wrap(err, "decrypting routing key")
+	}
+	if rawHeaders, err = database.MaybeDecrypt(ctx, key, rawHeaders, keyID); err != nil {
+		return &w, errors.Wrap(err, "decrypting headers")
+	}
+	return &w, json.Unmarshal([]byte(rawHeaders), &w.Headers)
+}
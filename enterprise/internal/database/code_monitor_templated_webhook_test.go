@@ -0,0 +1,221 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
+)
+
+func TestCodeMonitorStoreTemplatedWebhooks(t *testing.T) {
+	ctx := context.Background()
+	args1 := &TemplatedWebhookActionArgs{
+		Enabled:      true,
+		URL:          "https://icanhazcheezburger.com/templated_webhook",
+		BodyTemplate: `{"text": {{ json .Summary }}}`,
+		ContentType:  "application/json",
+		Headers:      map[string]string{"X-Cheez": "burger"},
+	}
+	args2 := &TemplatedWebhookActionArgs{
+		Enabled: false,
+		URL:     "https://icanthazcheezburger.com/templated_webhook",
+		Headers: map[string]string{},
+	}
+
+	logger := logtest.Scoped(t)
+
+	for _, preset := range TemplatedWebhookPresets {
+		preset := preset
+
+		t.Run(string(preset), func(t *testing.T) {
+			t.Run("CreateThenGet", func(t *testing.T) {
+				t.Parallel()
+
+				db := database.NewDB(logger, dbtest.NewDB(logger, t))
+				_, _, ctx := newTestUser(ctx, t, db)
+				s := CodeMonitors(db)
+				fixtures := s.insertTestMonitor(ctx, t)
+
+				action, err := s.CreateTemplatedWebhookAction(ctx, preset, fixtures.monitor.ID, args1)
+				require.NoError(t, err)
+				require.Equal(t, preset, action.Preset)
+				require.Equal(t, args1.Headers, action.Headers)
+
+				got, err := s.GetTemplatedWebhookAction(ctx, preset, action.ID)
+				require.NoError(t, err)
+
+				require.Equal(t, action, got)
+			})
+
+			t.Run("CreateUpdateGet", func(t *testing.T) {
+				t.Parallel()
+
+				db := database.NewDB(logger, dbtest.NewDB(logger, t))
+				_, _, ctx := newTestUser(ctx, t, db)
+				s := CodeMonitors(db)
+				fixtures := s.insertTestMonitor(ctx, t)
+
+				action, err := s.CreateTemplatedWebhookAction(ctx, preset, fixtures.monitor.ID, args1)
+				require.NoError(t, err)
+
+				updated, err := s.UpdateTemplatedWebhookAction(ctx, preset, action.ID, args2)
+				require.NoError(t, err)
+				require.Equal(t, false, updated.Enabled)
+				require.Equal(t, args2.URL, updated.URL)
+				require.Equal(t, "", updated.BodyTemplate)
+				require.Empty(t, updated.Headers)
+
+				got, err := s.GetTemplatedWebhookAction(ctx, preset, action.ID)
+				require.NoError(t, err)
+				require.Equal(t, updated, got)
+			})
+
+			t.Run("ErrorOnUpdateNonexistent", func(t *testing.T) {
+				t.Parallel()
+
+				db := database.NewDB(logger, dbtest.NewDB(logger, t))
+				_, _, ctx := newTestUser(ctx, t, db)
+				s := CodeMonitors(db)
+
+				_, err := s.UpdateTemplatedWebhookAction(ctx, preset, 383838, args2)
+				require.Error(t, err)
+			})
+
+			t.Run("CreateDeleteGet", func(t *testing.T) {
+				t.Parallel()
+
+				db := database.NewDB(logger, dbtest.NewDB(logger, t))
+				_, _, ctx := newTestUser(ctx, t, db)
+				s := CodeMonitors(db)
+				fixtures := s.insertTestMonitor(ctx, t)
+
+				action1, err := s.CreateTemplatedWebhookAction(ctx, preset, fixtures.monitor.ID, args1)
+				require.NoError(t, err)
+
+				action2, err := s.CreateTemplatedWebhookAction(ctx, preset, fixtures.monitor.ID, args1)
+				require.NoError(t, err)
+
+				err = s.DeleteTemplatedWebhookActions(ctx, preset, fixtures.monitor.ID, action1.ID)
+				require.NoError(t, err)
+
+				_, err = s.GetTemplatedWebhookAction(ctx, preset, action1.ID)
+				require.Error(t, err)
+
+				_, err = s.GetTemplatedWebhookAction(ctx, preset, action2.ID)
+				require.NoError(t, err)
+			})
+
+			t.Run("ListCountCreateListCount", func(t *testing.T) {
+				t.Parallel()
+
+				db := database.NewDB(logger, dbtest.NewDB(logger, t))
+				_, _, ctx := newTestUser(ctx, t, db)
+				s := CodeMonitors(db)
+				fixtures := s.insertTestMonitor(ctx, t)
+
+				actions, err := s.ListTemplatedWebhookActions(ctx, preset, ListActionsOpts{MonitorID: &fixtures.monitor.ID})
+				require.NoError(t, err)
+				require.Len(t, actions, 0)
+
+				_, err = s.CreateTemplatedWebhookAction(ctx, preset, fixtures.monitor.ID, args1)
+				require.NoError(t, err)
+
+				_, err = s.CreateTemplatedWebhookAction(ctx, preset, fixtures.monitor.ID, args2)
+				require.NoError(t, err)
+
+				actions2, err := s.ListTemplatedWebhookActions(ctx, preset, ListActionsOpts{MonitorID: &fixtures.monitor.ID})
+				require.NoError(t, err)
+				require.Len(t, actions2, 2)
+
+				first := 1
+				actions3, err := s.ListTemplatedWebhookActions(ctx, preset, ListActionsOpts{MonitorID: &fixtures.monitor.ID, First: &first})
+				require.NoError(t, err)
+				require.Len(t, actions3, 1)
+
+				count, err := s.CountTemplatedWebhookActions(ctx, preset, fixtures.monitor.ID)
+				require.NoError(t, err)
+				require.Equal(t, 2, count)
+			})
+
+			t.Run("Update permissions", func(t *testing.T) {
+				ctx, db, s := newTestStore(t)
+				uid1 := insertTestUser(ctx, t, db, "u1", false)
+				ctx1 := actor.WithActor(ctx, actor.FromUser(uid1))
+				uid2 := insertTestUser(ctx, t, db, "u2", false)
+				ctx2 := actor.WithActor(ctx, actor.FromUser(uid2))
+				fixtures := s.insertTestMonitor(ctx1, t)
+				_ = s.insertTestMonitor(ctx2, t)
+
+				wa, err := s.CreateTemplatedWebhookAction(ctx1, preset, fixtures.monitor.ID, &TemplatedWebhookActionArgs{Enabled: true, URL: "https://true.com"})
+				require.NoError(t, err)
+
+				// User1 can update it
+				_, err = s.UpdateTemplatedWebhookAction(ctx1, preset, wa.ID, &TemplatedWebhookActionArgs{Enabled: true, URL: "https://false.com"})
+				require.NoError(t, err)
+
+				// User2 cannot update it
+				_, err = s.UpdateTemplatedWebhookAction(ctx2, preset, wa.ID, &TemplatedWebhookActionArgs{Enabled: true, URL: "https://truer.com"})
+				require.Error(t, err)
+
+				wa, err = s.GetTemplatedWebhookAction(ctx1, preset, wa.ID)
+				require.NoError(t, err)
+				require.Equal(t, wa.URL, "https://false.com")
+			})
+		})
+	}
+
+	t.Run("RoutingKey", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action, err := s.CreateTemplatedWebhookAction(ctx, TemplatedWebhookPresetPagerDuty, fixtures.monitor.ID, &TemplatedWebhookActionArgs{Enabled: true, RoutingKey: "abc123"})
+		require.NoError(t, err)
+		require.Equal(t, "abc123", action.RoutingKey)
+
+		// Only PagerDuty actions have a routing key.
+		_, err = s.CreateTemplatedWebhookAction(ctx, TemplatedWebhookPresetTeams, fixtures.monitor.ID, &TemplatedWebhookActionArgs{Enabled: true, RoutingKey: "abc123"})
+		require.Error(t, err)
+	})
+	t.Run("Encryption", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		s.key = et.TestKey{}
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action, err := s.CreateTemplatedWebhookAction(ctx, TemplatedWebhookPresetPagerDuty, fixtures.monitor.ID, &TemplatedWebhookActionArgs{
+			Enabled:    true,
+			RoutingKey: "abc123",
+			Headers:    map[string]string{"Authorization": "Bearer s3cr3t"},
+		})
+		require.NoError(t, err)
+
+		// The secrets are not stored in plaintext...
+		var rawRoutingKey, rawHeaders, keyID string
+		err = s.QueryRow(ctx, sqlf.Sprintf("SELECT routing_key, headers, encryption_key_id FROM cm_pagerduty_webhooks WHERE id = %s", action.ID)).Scan(&rawRoutingKey, &rawHeaders, &keyID)
+		require.NoError(t, err)
+		require.NotEmpty(t, keyID)
+		require.NotContains(t, rawRoutingKey, "abc123")
+		require.NotContains(t, rawHeaders, "s3cr3t")
+
+		// ...but read back decrypted.
+		got, err := s.GetTemplatedWebhookAction(ctx, TemplatedWebhookPresetPagerDuty, action.ID)
+		require.NoError(t, err)
+		require.Equal(t, "abc123", got.RoutingKey)
+		require.Equal(t, map[string]string{"Authorization": "Bearer s3cr3t"}, got.Headers)
+	})
+}
@@ -8,8 +8,10 @@ import (
 
 	"github.com/keegancsmith/sqlf"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 )
 
@@ -21,6 +23,7 @@ type TriggerJob struct {
 	QueryString *string
 
 	SearchResults []*result.CommitMatch
+	FileResults   []*FileResult
 
 	// Fields demanded for any dbworker.
 	State          string
@@ -37,6 +40,24 @@ func (r *TriggerJob) RecordID() int {
 	return int(r.ID)
 }
 
+// FileResult is a file match of a content or symbol search code monitor that
+// appeared since the previous run of the monitor, or that disappeared if
+// Removed is set.
+type FileResult struct {
+	Repo     types.MinimalRepo
+	CommitID api.CommitID
+	Path     string
+	Removed  bool
+
+	// Preview holds the matched lines of a content match, and MatchedRanges
+	// the location of the matches within Preview.
+	Preview       string
+	MatchedRanges result.Ranges
+
+	// Symbols holds the names of the matched symbols of a symbol match.
+	Symbols []string
+}
+
 const enqueueTriggerQueryFmtStr = `
 WITH due AS (
     SELECT cm_queries.id as id
@@ -66,21 +87,29 @@ func (s *codeMonitorStore) EnqueueQueryTriggerJobs(ctx context.Context) ([]*Trig
 const logSearchFmtStr = `
 UPDATE cm_trigger_jobs
 SET query_string = %s,
-    search_results = %s
+    search_results = %s,
+    file_results = %s
 WHERE id = %s
 `
 
-func (s *codeMonitorStore) UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.CommitMatch) error {
+func (s *codeMonitorStore) UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.CommitMatch, fileResults []*FileResult) error {
 	if results == nil {
 		// appease db non-null constraint
 		results = []*result.CommitMatch{}
 	}
+	if fileResults == nil {
+		fileResults = []*FileResult{}
+	}
 
 	resultsJSON, err := json.Marshal(results)
 	if err != nil {
 		return err
 	}
-	return s.Store.Exec(ctx, sqlf.Sprintf(logSearchFmtStr, queryString, resultsJSON, triggerJobID))
+	fileResultsJSON, err := json.Marshal(fileResults)
+	if err != nil {
+		return err
+	}
+	return s.Store.Exec(ctx, sqlf.Sprintf(logSearchFmtStr, queryString, resultsJSON, fileResultsJSON, triggerJobID))
 }
 
 const deleteOldJobLogsFmtStr = `
@@ -144,7 +173,7 @@ func (s *codeMonitorStore) ListQueryTriggerJobs(ctx context.Context, opts ListTr
 const totalCountEventsForQueryIDInt64FmtStr = `
 SELECT COUNT(*)
 FROM cm_trigger_jobs
-WHERE ((state = 'completed' AND jsonb_array_length(search_results) > 0) OR (state != 'completed'))
+WHERE ((state = 'completed' AND (jsonb_array_length(search_results) > 0 OR COALESCE(jsonb_array_length(file_results), 0) > 0)) OR (state != 'completed'))
 AND query = %s
 `
 
@@ -182,13 +211,14 @@ func scanTriggerJobs(rows *sql.Rows) ([]*TriggerJob, error) {
 }
 
 func scanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
-	var resultsJSON []byte
+	var resultsJSON, fileResultsJSON []byte
 	m := &TriggerJob{}
 	err := scanner.Scan(
 		&m.ID,
 		&m.Query,
 		&m.QueryString,
 		&resultsJSON,
+		&fileResultsJSON,
 		&m.State,
 		&m.FailureMessage,
 		&m.StartedAt,
@@ -207,6 +237,11 @@ func scanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
 			return nil, err
 		}
 	}
+	if len(fileResultsJSON) > 0 {
+		if err := json.Unmarshal(fileResultsJSON, &m.FileResults); err != nil {
+			return nil, err
+		}
+	}
 
 	return m, nil
 }
@@ -216,6 +251,7 @@ var TriggerJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_trigger_jobs.query"),
 	sqlf.Sprintf("cm_trigger_jobs.query_string"),
 	sqlf.Sprintf("cm_trigger_jobs.search_results"),
+	sqlf.Sprintf("cm_trigger_jobs.file_results"),
 	sqlf.Sprintf("cm_trigger_jobs.state"),
 	sqlf.Sprintf("cm_trigger_jobs.failure_message"),
 	sqlf.Sprintf("cm_trigger_jobs.started_at"),
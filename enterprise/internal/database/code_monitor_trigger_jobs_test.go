@@ -81,7 +81,7 @@ func TestUpdateTriggerJob(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, jobs, 1)
 
-		err = db.CodeMonitors().UpdateTriggerJobWithResults(ctx, jobs[0].ID, "", nil)
+		err = db.CodeMonitors().UpdateTriggerJobWithResults(ctx, jobs[0].ID, "", nil, nil)
 		require.NoError(t, err)
 	})
 }
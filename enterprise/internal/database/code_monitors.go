@@ -18,6 +18,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 )
@@ -49,7 +51,7 @@ type CodeMonitorStore interface {
 	ListQueryTriggerJobs(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)
 	CountQueryTriggerJobs(ctx context.Context, queryID int64) (int32, error)
 
-	UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.CommitMatch) error
+	UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.CommitMatch, fileResults []*FileResult) error
 	DeleteOldTriggerJobs(ctx context.Context, retentionInDays int) error
 
 	UpdateEmailAction(_ context.Context, id int64, _ *EmailActionArgs) (*EmailAction, error)
@@ -72,6 +74,13 @@ type CodeMonitorStore interface {
 	GetSlackWebhookAction(ctx context.Context, id int64) (*SlackWebhookAction, error)
 	ListSlackWebhookActions(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)
 
+	UpdateTemplatedWebhookAction(_ context.Context, _ TemplatedWebhookPreset, id int64, _ *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error)
+	CreateTemplatedWebhookAction(ctx context.Context, _ TemplatedWebhookPreset, monitorID int64, _ *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error)
+	DeleteTemplatedWebhookActions(ctx context.Context, _ TemplatedWebhookPreset, monitorID int64, ids ...int64) error
+	CountTemplatedWebhookActions(ctx context.Context, _ TemplatedWebhookPreset, monitorID int64) (int, error)
+	GetTemplatedWebhookAction(ctx context.Context, _ TemplatedWebhookPreset, id int64) (*TemplatedWebhookAction, error)
+	ListTemplatedWebhookActions(context.Context, TemplatedWebhookPreset, ListActionsOpts) ([]*TemplatedWebhookAction, error)
+
 	CreateRecipient(ctx context.Context, emailID int64, userID, orgID *int32) (*Recipient, error)
 	DeleteRecipients(ctx context.Context, emailID int64) error
 	ListRecipients(context.Context, ListRecipientsOpts) ([]*Recipient, error)
@@ -90,6 +99,11 @@ type CodeMonitorStore interface {
 	HasAnyLastSearched(ctx context.Context, monitorID int64) (bool, error)
 	UpsertLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID, lastSearched []string) error
 	GetLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error)
+
+	// GetLastResults returns the file matches found by the last run of a content or symbol
+	// search code monitor, and ReplaceLastResults replaces them with the matches of a new run.
+	GetLastResults(ctx context.Context, monitorID int64) ([]*LastResult, error)
+	ReplaceLastResults(ctx context.Context, monitorID int64, results []*LastResult) error
 }
 
 // codeMonitorStore exposes methods to read and write codemonitors domain models
@@ -97,6 +111,10 @@ type CodeMonitorStore interface {
 type codeMonitorStore struct {
 	*basestore.Store
 	now func() time.Time
+
+	// key encrypts the secrets of actions. It defaults to the code monitor
+	// action key of the keyring, and is only set by tests.
+	key encryption.Key
 }
 
 var _ CodeMonitorStore = (*codeMonitorStore)(nil)
@@ -121,6 +139,13 @@ func (s *codeMonitorStore) Now() time.Time {
 	return s.now()
 }
 
+func (s *codeMonitorStore) getEncryptionKey() encryption.Key {
+	if s.key != nil {
+		return s.key
+	}
+	return keyring.Default().CodeMonitorActionKey
+}
+
 // Transact creates a new transaction.
 // It's required to implement this method and wrap the Transact method of the
 // underlying basestore.Store.
@@ -129,7 +154,7 @@ func (s *codeMonitorStore) Transact(ctx context.Context) (CodeMonitorStore, erro
 	if err != nil {
 		return nil, err
 	}
-	return &codeMonitorStore{Store: txBase, now: s.now}, nil
+	return &codeMonitorStore{Store: txBase, now: s.now, key: s.key}, nil
 }
 
 type JobTable int
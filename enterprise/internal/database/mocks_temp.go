@@ -46,6 +46,10 @@ type MockCodeMonitorStore struct {
 	// CountSlackWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountSlackWebhookActions.
 	CountSlackWebhookActionsFunc *CodeMonitorStoreCountSlackWebhookActionsFunc
+	// CountTemplatedWebhookActionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// CountTemplatedWebhookActions.
+	CountTemplatedWebhookActionsFunc *CodeMonitorStoreCountTemplatedWebhookActionsFunc
 	// CountWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountWebhookActions.
 	CountWebhookActionsFunc *CodeMonitorStoreCountWebhookActionsFunc
@@ -64,6 +68,10 @@ type MockCodeMonitorStore struct {
 	// CreateSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateSlackWebhookAction.
 	CreateSlackWebhookActionFunc *CodeMonitorStoreCreateSlackWebhookActionFunc
+	// CreateTemplatedWebhookActionFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// CreateTemplatedWebhookAction.
+	CreateTemplatedWebhookActionFunc *CodeMonitorStoreCreateTemplatedWebhookActionFunc
 	// CreateWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateWebhookAction.
 	CreateWebhookActionFunc *CodeMonitorStoreCreateWebhookActionFunc
@@ -83,6 +91,10 @@ type MockCodeMonitorStore struct {
 	// object controlling the behavior of the method
 	// DeleteSlackWebhookActions.
 	DeleteSlackWebhookActionsFunc *CodeMonitorStoreDeleteSlackWebhookActionsFunc
+	// DeleteTemplatedWebhookActionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteTemplatedWebhookActions.
+	DeleteTemplatedWebhookActionsFunc *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc
 	// DeleteWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteWebhookActions.
 	DeleteWebhookActionsFunc *CodeMonitorStoreDeleteWebhookActionsFunc
@@ -108,6 +120,9 @@ type MockCodeMonitorStore struct {
 	// GetEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetEmailAction.
 	GetEmailActionFunc *CodeMonitorStoreGetEmailActionFunc
+	// GetLastResultsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetLastResults.
+	GetLastResultsFunc *CodeMonitorStoreGetLastResultsFunc
 	// GetLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method GetLastSearched.
 	GetLastSearchedFunc *CodeMonitorStoreGetLastSearchedFunc
@@ -124,6 +139,10 @@ type MockCodeMonitorStore struct {
 	// GetSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetSlackWebhookAction.
 	GetSlackWebhookActionFunc *CodeMonitorStoreGetSlackWebhookActionFunc
+	// GetTemplatedWebhookActionFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetTemplatedWebhookAction.
+	GetTemplatedWebhookActionFunc *CodeMonitorStoreGetTemplatedWebhookActionFunc
 	// GetWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetWebhookAction.
 	GetWebhookActionFunc *CodeMonitorStoreGetWebhookActionFunc
@@ -151,12 +170,19 @@ type MockCodeMonitorStore struct {
 	// ListSlackWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListSlackWebhookActions.
 	ListSlackWebhookActionsFunc *CodeMonitorStoreListSlackWebhookActionsFunc
+	// ListTemplatedWebhookActionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ListTemplatedWebhookActions.
+	ListTemplatedWebhookActionsFunc *CodeMonitorStoreListTemplatedWebhookActionsFunc
 	// ListWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListWebhookActions.
 	ListWebhookActionsFunc *CodeMonitorStoreListWebhookActionsFunc
 	// NowFunc is an instance of a mock function object controlling the
 	// behavior of the method Now.
 	NowFunc *CodeMonitorStoreNowFunc
+	// ReplaceLastResultsFunc is an instance of a mock function object
+	// controlling the behavior of the method ReplaceLastResults.
+	ReplaceLastResultsFunc *CodeMonitorStoreReplaceLastResultsFunc
 	// ResetQueryTriggerTimestampsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// ResetQueryTriggerTimestamps.
@@ -182,6 +208,10 @@ type MockCodeMonitorStore struct {
 	// UpdateSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateSlackWebhookAction.
 	UpdateSlackWebhookActionFunc *CodeMonitorStoreUpdateSlackWebhookActionFunc
+	// UpdateTemplatedWebhookActionFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpdateTemplatedWebhookAction.
+	UpdateTemplatedWebhookActionFunc *CodeMonitorStoreUpdateTemplatedWebhookActionFunc
 	// UpdateTriggerJobWithResultsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// UpdateTriggerJobWithResults.
@@ -229,6 +259,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CountTemplatedWebhookActionsFunc: &CodeMonitorStoreCountTemplatedWebhookActionsFunc{
+			defaultHook: func(context.Context, TemplatedWebhookPreset, int64) (r0 int, r1 error) {
+				return
+			},
+		},
 		CountWebhookActionsFunc: &CodeMonitorStoreCountWebhookActionsFunc{
 			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
 				return
@@ -259,6 +294,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CreateTemplatedWebhookActionFunc: &CodeMonitorStoreCreateTemplatedWebhookActionFunc{
+			defaultHook: func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (r0 *TemplatedWebhookAction, r1 error) {
+				return
+			},
+		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
 			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *WebhookAction, r1 error) {
 				return
@@ -289,6 +329,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		DeleteTemplatedWebhookActionsFunc: &CodeMonitorStoreDeleteTemplatedWebhookActionsFunc{
+			defaultHook: func(context.Context, TemplatedWebhookPreset, int64, ...int64) (r0 error) {
+				return
+			},
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
 				return
@@ -329,6 +374,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetLastResultsFunc: &CodeMonitorStoreGetLastResultsFunc{
+			defaultHook: func(context.Context, int64) (r0 []*LastResult, r1 error) {
+				return
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) (r0 []string, r1 error) {
 				return
@@ -354,6 +404,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetTemplatedWebhookActionFunc: &CodeMonitorStoreGetTemplatedWebhookActionFunc{
+			defaultHook: func(context.Context, TemplatedWebhookPreset, int64) (r0 *TemplatedWebhookAction, r1 error) {
+				return
+			},
+		},
 		GetWebhookActionFunc: &CodeMonitorStoreGetWebhookActionFunc{
 			defaultHook: func(context.Context, int64) (r0 *WebhookAction, r1 error) {
 				return
@@ -399,6 +454,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		ListTemplatedWebhookActionsFunc: &CodeMonitorStoreListTemplatedWebhookActionsFunc{
+			defaultHook: func(context.Context, TemplatedWebhookPreset, ListActionsOpts) (r0 []*TemplatedWebhookAction, r1 error) {
+				return
+			},
+		},
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: func(context.Context, ListActionsOpts) (r0 []*WebhookAction, r1 error) {
 				return
@@ -409,6 +469,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		ReplaceLastResultsFunc: &CodeMonitorStoreReplaceLastResultsFunc{
+			defaultHook: func(context.Context, int64, []*LastResult) (r0 error) {
+				return
+			},
+		},
 		ResetQueryTriggerTimestampsFunc: &CodeMonitorStoreResetQueryTriggerTimestampsFunc{
 			defaultHook: func(context.Context, int64) (r0 error) {
 				return
@@ -449,8 +514,13 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpdateTemplatedWebhookActionFunc: &CodeMonitorStoreUpdateTemplatedWebhookActionFunc{
+			defaultHook: func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (r0 *TemplatedWebhookAction, r1 error) {
+				return
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
-			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) (r0 error) {
+			defaultHook: func(context.Context, int32, string, []*result.CommitMatch, []*FileResult) (r0 error) {
 				return
 			},
 		},
@@ -501,6 +571,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CountSlackWebhookActions")
 			},
 		},
+		CountTemplatedWebhookActionsFunc: &CodeMonitorStoreCountTemplatedWebhookActionsFunc{
+			defaultHook: func(context.Context, TemplatedWebhookPreset, int64) (int, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CountTemplatedWebhookActions")
+			},
+		},
 		CountWebhookActionsFunc: &CodeMonitorStoreCountWebhookActionsFunc{
 			defaultHook: func(context.Context, int64) (int, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CountWebhookActions")
@@ -531,6 +606,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateSlackWebhookAction")
 			},
 		},
+		CreateTemplatedWebhookActionFunc: &CodeMonitorStoreCreateTemplatedWebhookActionFunc{
+			defaultHook: func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CreateTemplatedWebhookAction")
+			},
+		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
 			defaultHook: func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateWebhookAction")
@@ -561,6 +641,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteSlackWebhookActions")
 			},
 		},
+		DeleteTemplatedWebhookActionsFunc: &CodeMonitorStoreDeleteTemplatedWebhookActionsFunc{
+			defaultHook: func(context.Context, TemplatedWebhookPreset, int64, ...int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteTemplatedWebhookActions")
+			},
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: func(context.Context, int64, ...int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteWebhookActions")
@@ -601,6 +686,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetEmailAction")
 			},
 		},
+		GetLastResultsFunc: &CodeMonitorStoreGetLastResultsFunc{
+			defaultHook: func(context.Context, int64) ([]*LastResult, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetLastResults")
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) ([]string, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetLastSearched")
@@ -626,6 +716,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetSlackWebhookAction")
 			},
 		},
+		GetTemplatedWebhookActionFunc: &CodeMonitorStoreGetTemplatedWebhookActionFunc{
+			defaultHook: func(context.Context, TemplatedWebhookPreset, int64) (*TemplatedWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetTemplatedWebhookAction")
+			},
+		},
 		GetWebhookActionFunc: &CodeMonitorStoreGetWebhookActionFunc{
 			defaultHook: func(context.Context, int64) (*WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetWebhookAction")
@@ -671,6 +766,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ListSlackWebhookActions")
 			},
 		},
+		ListTemplatedWebhookActionsFunc: &CodeMonitorStoreListTemplatedWebhookActionsFunc{
+			defaultHook: func(context.Context, TemplatedWebhookPreset, ListActionsOpts) ([]*TemplatedWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListTemplatedWebhookActions")
+			},
+		},
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: func(context.Context, ListActionsOpts) ([]*WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.ListWebhookActions")
@@ -681,6 +781,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.Now")
 			},
 		},
+		ReplaceLastResultsFunc: &CodeMonitorStoreReplaceLastResultsFunc{
+			defaultHook: func(context.Context, int64, []*LastResult) error {
+				panic("unexpected invocation of MockCodeMonitorStore.ReplaceLastResults")
+			},
+		},
 		ResetQueryTriggerTimestampsFunc: &CodeMonitorStoreResetQueryTriggerTimestampsFunc{
 			defaultHook: func(context.Context, int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.ResetQueryTriggerTimestamps")
@@ -721,8 +826,13 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateSlackWebhookAction")
 			},
 		},
+		UpdateTemplatedWebhookActionFunc: &CodeMonitorStoreUpdateTemplatedWebhookActionFunc{
+			defaultHook: func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateTemplatedWebhookAction")
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
-			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) error {
+			defaultHook: func(context.Context, int32, string, []*result.CommitMatch, []*FileResult) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithResults")
 			},
 		},
@@ -762,6 +872,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		CountSlackWebhookActionsFunc: &CodeMonitorStoreCountSlackWebhookActionsFunc{
 			defaultHook: i.CountSlackWebhookActions,
 		},
+		CountTemplatedWebhookActionsFunc: &CodeMonitorStoreCountTemplatedWebhookActionsFunc{
+			defaultHook: i.CountTemplatedWebhookActions,
+		},
 		CountWebhookActionsFunc: &CodeMonitorStoreCountWebhookActionsFunc{
 			defaultHook: i.CountWebhookActions,
 		},
@@ -780,6 +893,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		CreateSlackWebhookActionFunc: &CodeMonitorStoreCreateSlackWebhookActionFunc{
 			defaultHook: i.CreateSlackWebhookAction,
 		},
+		CreateTemplatedWebhookActionFunc: &CodeMonitorStoreCreateTemplatedWebhookActionFunc{
+			defaultHook: i.CreateTemplatedWebhookAction,
+		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
 			defaultHook: i.CreateWebhookAction,
 		},
@@ -798,6 +914,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		DeleteSlackWebhookActionsFunc: &CodeMonitorStoreDeleteSlackWebhookActionsFunc{
 			defaultHook: i.DeleteSlackWebhookActions,
 		},
+		DeleteTemplatedWebhookActionsFunc: &CodeMonitorStoreDeleteTemplatedWebhookActionsFunc{
+			defaultHook: i.DeleteTemplatedWebhookActions,
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: i.DeleteWebhookActions,
 		},
@@ -822,6 +941,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		GetEmailActionFunc: &CodeMonitorStoreGetEmailActionFunc{
 			defaultHook: i.GetEmailAction,
 		},
+		GetLastResultsFunc: &CodeMonitorStoreGetLastResultsFunc{
+			defaultHook: i.GetLastResults,
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: i.GetLastSearched,
 		},
@@ -837,6 +959,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		GetSlackWebhookActionFunc: &CodeMonitorStoreGetSlackWebhookActionFunc{
 			defaultHook: i.GetSlackWebhookAction,
 		},
+		GetTemplatedWebhookActionFunc: &CodeMonitorStoreGetTemplatedWebhookActionFunc{
+			defaultHook: i.GetTemplatedWebhookAction,
+		},
 		GetWebhookActionFunc: &CodeMonitorStoreGetWebhookActionFunc{
 			defaultHook: i.GetWebhookAction,
 		},
@@ -864,12 +989,18 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		ListSlackWebhookActionsFunc: &CodeMonitorStoreListSlackWebhookActionsFunc{
 			defaultHook: i.ListSlackWebhookActions,
 		},
+		ListTemplatedWebhookActionsFunc: &CodeMonitorStoreListTemplatedWebhookActionsFunc{
+			defaultHook: i.ListTemplatedWebhookActions,
+		},
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: i.ListWebhookActions,
 		},
 		NowFunc: &CodeMonitorStoreNowFunc{
 			defaultHook: i.Now,
 		},
+		ReplaceLastResultsFunc: &CodeMonitorStoreReplaceLastResultsFunc{
+			defaultHook: i.ReplaceLastResults,
+		},
 		ResetQueryTriggerTimestampsFunc: &CodeMonitorStoreResetQueryTriggerTimestampsFunc{
 			defaultHook: i.ResetQueryTriggerTimestamps,
 		},
@@ -894,6 +1025,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
 			defaultHook: i.UpdateSlackWebhookAction,
 		},
+		UpdateTemplatedWebhookActionFunc: &CodeMonitorStoreUpdateTemplatedWebhookActionFunc{
+			defaultHook: i.UpdateTemplatedWebhookAction,
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: i.UpdateTriggerJobWithResults,
 		},
@@ -1561,6 +1695,121 @@ func (c CodeMonitorStoreCountSlackWebhookActionsFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreCountTemplatedWebhookActionsFunc describes the behavior
+// when the CountTemplatedWebhookActions method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreCountTemplatedWebhookActionsFunc struct {
+	defaultHook func(context.Context, TemplatedWebhookPreset, int64) (int, error)
+	hooks       []func(context.Context, TemplatedWebhookPreset, int64) (int, error)
+	history     []CodeMonitorStoreCountTemplatedWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// CountTemplatedWebhookActions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CountTemplatedWebhookActions(v0 context.Context, v1 TemplatedWebhookPreset, v2 int64) (int, error) {
+	r0, r1 := m.CountTemplatedWebhookActionsFunc.nextHook()(v0, v1, v2)
+	m.CountTemplatedWebhookActionsFunc.appendCall(CodeMonitorStoreCountTemplatedWebhookActionsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CountTemplatedWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCountTemplatedWebhookActionsFunc) SetDefaultHook(hook func(context.Context, TemplatedWebhookPreset, int64) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountTemplatedWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreCountTemplatedWebhookActionsFunc) PushHook(hook func(context.Context, TemplatedWebhookPreset, int64) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCountTemplatedWebhookActionsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, TemplatedWebhookPreset, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCountTemplatedWebhookActionsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, TemplatedWebhookPreset, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCountTemplatedWebhookActionsFunc) nextHook() func(context.Context, TemplatedWebhookPreset, int64) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCountTemplatedWebhookActionsFunc) appendCall(r0 CodeMonitorStoreCountTemplatedWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreCountTemplatedWebhookActionsFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreCountTemplatedWebhookActionsFunc) History() []CodeMonitorStoreCountTemplatedWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCountTemplatedWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCountTemplatedWebhookActionsFuncCall is an object that
+// describes an invocation of method CountTemplatedWebhookActions on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreCountTemplatedWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 TemplatedWebhookPreset
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCountTemplatedWebhookActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCountTemplatedWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCountWebhookActionsFunc describes the behavior when the
 // CountWebhookActions method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -2248,6 +2497,124 @@ func (c CodeMonitorStoreCreateSlackWebhookActionFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreCreateTemplatedWebhookActionFunc describes the behavior
+// when the CreateTemplatedWebhookAction method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreCreateTemplatedWebhookActionFunc struct {
+	defaultHook func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error)
+	hooks       []func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error)
+	history     []CodeMonitorStoreCreateTemplatedWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateTemplatedWebhookAction delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateTemplatedWebhookAction(v0 context.Context, v1 TemplatedWebhookPreset, v2 int64, v3 *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error) {
+	r0, r1 := m.CreateTemplatedWebhookActionFunc.nextHook()(v0, v1, v2, v3)
+	m.CreateTemplatedWebhookActionFunc.appendCall(CodeMonitorStoreCreateTemplatedWebhookActionFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CreateTemplatedWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCreateTemplatedWebhookActionFunc) SetDefaultHook(hook func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateTemplatedWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreCreateTemplatedWebhookActionFunc) PushHook(hook func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCreateTemplatedWebhookActionFunc) SetDefaultReturn(r0 *TemplatedWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCreateTemplatedWebhookActionFunc) PushReturn(r0 *TemplatedWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCreateTemplatedWebhookActionFunc) nextHook() func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCreateTemplatedWebhookActionFunc) appendCall(r0 CodeMonitorStoreCreateTemplatedWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreCreateTemplatedWebhookActionFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreCreateTemplatedWebhookActionFunc) History() []CodeMonitorStoreCreateTemplatedWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCreateTemplatedWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCreateTemplatedWebhookActionFuncCall is an object that
+// describes an invocation of method CreateTemplatedWebhookAction on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreCreateTemplatedWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 TemplatedWebhookPreset
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 *TemplatedWebhookActionArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *TemplatedWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCreateTemplatedWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCreateTemplatedWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCreateWebhookActionFunc describes the behavior when the
 // CreateWebhookAction method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -2922,37 +3289,37 @@ func (c CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) Results() []interface
 	return []interface{}{c.Result0}
 }
 
-// CodeMonitorStoreDeleteWebhookActionsFunc describes the behavior when the
-// DeleteWebhookActions method of the parent MockCodeMonitorStore instance
-// is invoked.
-type CodeMonitorStoreDeleteWebhookActionsFunc struct {
-	defaultHook func(context.Context, int64, ...int64) error
-	hooks       []func(context.Context, int64, ...int64) error
-	history     []CodeMonitorStoreDeleteWebhookActionsFuncCall
+// CodeMonitorStoreDeleteTemplatedWebhookActionsFunc describes the behavior
+// when the DeleteTemplatedWebhookActions method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreDeleteTemplatedWebhookActionsFunc struct {
+	defaultHook func(context.Context, TemplatedWebhookPreset, int64, ...int64) error
+	hooks       []func(context.Context, TemplatedWebhookPreset, int64, ...int64) error
+	history     []CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall
 	mutex       sync.Mutex
 }
 
-// DeleteWebhookActions delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) DeleteWebhookActions(v0 context.Context, v1 int64, v2 ...int64) error {
-	r0 := m.DeleteWebhookActionsFunc.nextHook()(v0, v1, v2...)
-	m.DeleteWebhookActionsFunc.appendCall(CodeMonitorStoreDeleteWebhookActionsFuncCall{v0, v1, v2, r0})
+// DeleteTemplatedWebhookActions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteTemplatedWebhookActions(v0 context.Context, v1 TemplatedWebhookPreset, v2 int64, v3 ...int64) error {
+	r0 := m.DeleteTemplatedWebhookActionsFunc.nextHook()(v0, v1, v2, v3...)
+	m.DeleteTemplatedWebhookActionsFunc.appendCall(CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall{v0, v1, v2, v3, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the DeleteWebhookActions
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreDeleteWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
+// SetDefaultHook sets function that is called when the
+// DeleteTemplatedWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc) SetDefaultHook(hook func(context.Context, TemplatedWebhookPreset, int64, ...int64) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// DeleteWebhookActions method of the parent MockCodeMonitorStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *CodeMonitorStoreDeleteWebhookActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
+// DeleteTemplatedWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc) PushHook(hook func(context.Context, TemplatedWebhookPreset, int64, ...int64) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2960,7 +3327,129 @@ func (f *CodeMonitorStoreDeleteWebhookActionsFunc) PushHook(hook func(context.Co
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreDeleteWebhookActionsFunc) SetDefaultReturn(r0 error) {
+func (f *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, TemplatedWebhookPreset, int64, ...int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, TemplatedWebhookPreset, int64, ...int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc) nextHook() func(context.Context, TemplatedWebhookPreset, int64, ...int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc) appendCall(r0 CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc) History() []CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall is an object that
+// describes an invocation of method DeleteTemplatedWebhookActions on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 TemplatedWebhookPreset
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Arg3 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg3 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg3 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1, c.Arg2}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreDeleteWebhookActionsFunc describes the behavior when the
+// DeleteWebhookActions method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreDeleteWebhookActionsFunc struct {
+	defaultHook func(context.Context, int64, ...int64) error
+	hooks       []func(context.Context, int64, ...int64) error
+	history     []CodeMonitorStoreDeleteWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteWebhookActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteWebhookActions(v0 context.Context, v1 int64, v2 ...int64) error {
+	r0 := m.DeleteWebhookActionsFunc.nextHook()(v0, v1, v2...)
+	m.DeleteWebhookActionsFunc.appendCall(CodeMonitorStoreDeleteWebhookActionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteWebhookActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreDeleteWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteWebhookActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreDeleteWebhookActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteWebhookActionsFunc) SetDefaultReturn(r0 error) {
 	f.SetDefaultHook(func(context.Context, int64, ...int64) error {
 		return r0
 	})
@@ -3803,6 +4292,116 @@ func (c CodeMonitorStoreGetEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreGetLastResultsFunc describes the behavior when the
+// GetLastResults method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreGetLastResultsFunc struct {
+	defaultHook func(context.Context, int64) ([]*LastResult, error)
+	hooks       []func(context.Context, int64) ([]*LastResult, error)
+	history     []CodeMonitorStoreGetLastResultsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetLastResults delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetLastResults(v0 context.Context, v1 int64) ([]*LastResult, error) {
+	r0, r1 := m.GetLastResultsFunc.nextHook()(v0, v1)
+	m.GetLastResultsFunc.appendCall(CodeMonitorStoreGetLastResultsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetLastResults
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreGetLastResultsFunc) SetDefaultHook(hook func(context.Context, int64) ([]*LastResult, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetLastResults method of the parent MockCodeMonitorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeMonitorStoreGetLastResultsFunc) PushHook(hook func(context.Context, int64) ([]*LastResult, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetLastResultsFunc) SetDefaultReturn(r0 []*LastResult, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*LastResult, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetLastResultsFunc) PushReturn(r0 []*LastResult, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*LastResult, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetLastResultsFunc) nextHook() func(context.Context, int64) ([]*LastResult, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetLastResultsFunc) appendCall(r0 CodeMonitorStoreGetLastResultsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreGetLastResultsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreGetLastResultsFunc) History() []CodeMonitorStoreGetLastResultsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetLastResultsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetLastResultsFuncCall is an object that describes an
+// invocation of method GetLastResults on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetLastResultsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*LastResult
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetLastResultsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetLastResultsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreGetLastSearchedFunc describes the behavior when the
 // GetLastSearched method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -4361,37 +4960,37 @@ func (c CodeMonitorStoreGetSlackWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreGetWebhookActionFunc describes the behavior when the
-// GetWebhookAction method of the parent MockCodeMonitorStore instance is
-// invoked.
-type CodeMonitorStoreGetWebhookActionFunc struct {
-	defaultHook func(context.Context, int64) (*WebhookAction, error)
-	hooks       []func(context.Context, int64) (*WebhookAction, error)
-	history     []CodeMonitorStoreGetWebhookActionFuncCall
+// CodeMonitorStoreGetTemplatedWebhookActionFunc describes the behavior when
+// the GetTemplatedWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreGetTemplatedWebhookActionFunc struct {
+	defaultHook func(context.Context, TemplatedWebhookPreset, int64) (*TemplatedWebhookAction, error)
+	hooks       []func(context.Context, TemplatedWebhookPreset, int64) (*TemplatedWebhookAction, error)
+	history     []CodeMonitorStoreGetTemplatedWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
-// GetWebhookAction delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) GetWebhookAction(v0 context.Context, v1 int64) (*WebhookAction, error) {
-	r0, r1 := m.GetWebhookActionFunc.nextHook()(v0, v1)
-	m.GetWebhookActionFunc.appendCall(CodeMonitorStoreGetWebhookActionFuncCall{v0, v1, r0, r1})
+// GetTemplatedWebhookAction delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetTemplatedWebhookAction(v0 context.Context, v1 TemplatedWebhookPreset, v2 int64) (*TemplatedWebhookAction, error) {
+	r0, r1 := m.GetTemplatedWebhookActionFunc.nextHook()(v0, v1, v2)
+	m.GetTemplatedWebhookActionFunc.appendCall(CodeMonitorStoreGetTemplatedWebhookActionFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetWebhookAction
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreGetWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64) (*WebhookAction, error)) {
+// SetDefaultHook sets function that is called when the
+// GetTemplatedWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreGetTemplatedWebhookActionFunc) SetDefaultHook(hook func(context.Context, TemplatedWebhookPreset, int64) (*TemplatedWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetWebhookAction method of the parent MockCodeMonitorStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *CodeMonitorStoreGetWebhookActionFunc) PushHook(hook func(context.Context, int64) (*WebhookAction, error)) {
+// GetTemplatedWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreGetTemplatedWebhookActionFunc) PushHook(hook func(context.Context, TemplatedWebhookPreset, int64) (*TemplatedWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4399,20 +4998,20 @@ func (f *CodeMonitorStoreGetWebhookActionFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreGetWebhookActionFunc) SetDefaultReturn(r0 *WebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) (*WebhookAction, error) {
+func (f *CodeMonitorStoreGetTemplatedWebhookActionFunc) SetDefaultReturn(r0 *TemplatedWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, TemplatedWebhookPreset, int64) (*TemplatedWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreGetWebhookActionFunc) PushReturn(r0 *WebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64) (*WebhookAction, error) {
+func (f *CodeMonitorStoreGetTemplatedWebhookActionFunc) PushReturn(r0 *TemplatedWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, TemplatedWebhookPreset, int64) (*TemplatedWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreGetWebhookActionFunc) nextHook() func(context.Context, int64) (*WebhookAction, error) {
+func (f *CodeMonitorStoreGetTemplatedWebhookActionFunc) nextHook() func(context.Context, TemplatedWebhookPreset, int64) (*TemplatedWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4425,36 +5024,40 @@ func (f *CodeMonitorStoreGetWebhookActionFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *CodeMonitorStoreGetWebhookActionFunc) appendCall(r0 CodeMonitorStoreGetWebhookActionFuncCall) {
+func (f *CodeMonitorStoreGetTemplatedWebhookActionFunc) appendCall(r0 CodeMonitorStoreGetTemplatedWebhookActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreGetWebhookActionFuncCall
-// objects describing the invocations of this function.
-func (f *CodeMonitorStoreGetWebhookActionFunc) History() []CodeMonitorStoreGetWebhookActionFuncCall {
+// History returns a sequence of
+// CodeMonitorStoreGetTemplatedWebhookActionFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreGetTemplatedWebhookActionFunc) History() []CodeMonitorStoreGetTemplatedWebhookActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreGetWebhookActionFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreGetTemplatedWebhookActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreGetWebhookActionFuncCall is an object that describes an
-// invocation of method GetWebhookAction on an instance of
-// MockCodeMonitorStore.
-type CodeMonitorStoreGetWebhookActionFuncCall struct {
+// CodeMonitorStoreGetTemplatedWebhookActionFuncCall is an object that
+// describes an invocation of method GetTemplatedWebhookAction on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreGetTemplatedWebhookActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int64
+	Arg1 TemplatedWebhookPreset
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *WebhookAction
+	Result0 *TemplatedWebhookAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -4462,29 +5065,140 @@ type CodeMonitorStoreGetWebhookActionFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreGetWebhookActionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c CodeMonitorStoreGetTemplatedWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreGetWebhookActionFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreGetTemplatedWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreHandleFunc describes the behavior when the Handle method
-// of the parent MockCodeMonitorStore instance is invoked.
-type CodeMonitorStoreHandleFunc struct {
-	defaultHook func() basestore.TransactableHandle
-	hooks       []func() basestore.TransactableHandle
-	history     []CodeMonitorStoreHandleFuncCall
+// CodeMonitorStoreGetWebhookActionFunc describes the behavior when the
+// GetWebhookAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreGetWebhookActionFunc struct {
+	defaultHook func(context.Context, int64) (*WebhookAction, error)
+	hooks       []func(context.Context, int64) (*WebhookAction, error)
+	history     []CodeMonitorStoreGetWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
-// Handle delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) Handle() basestore.TransactableHandle {
-	r0 := m.HandleFunc.nextHook()()
+// GetWebhookAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetWebhookAction(v0 context.Context, v1 int64) (*WebhookAction, error) {
+	r0, r1 := m.GetWebhookActionFunc.nextHook()(v0, v1)
+	m.GetWebhookActionFunc.appendCall(CodeMonitorStoreGetWebhookActionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetWebhookAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreGetWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64) (*WebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetWebhookAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreGetWebhookActionFunc) PushHook(hook func(context.Context, int64) (*WebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetWebhookActionFunc) SetDefaultReturn(r0 *WebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*WebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetWebhookActionFunc) PushReturn(r0 *WebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*WebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetWebhookActionFunc) nextHook() func(context.Context, int64) (*WebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetWebhookActionFunc) appendCall(r0 CodeMonitorStoreGetWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreGetWebhookActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreGetWebhookActionFunc) History() []CodeMonitorStoreGetWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetWebhookActionFuncCall is an object that describes an
+// invocation of method GetWebhookAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *WebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreHandleFunc describes the behavior when the Handle method
+// of the parent MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []CodeMonitorStoreHandleFuncCall
+	mutex       sync.Mutex
+}
+
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
 	m.HandleFunc.appendCall(CodeMonitorStoreHandleFuncCall{r0})
 	return r0
 }
@@ -5346,6 +6060,121 @@ func (c CodeMonitorStoreListSlackWebhookActionsFuncCall) Results() []interface{}
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreListTemplatedWebhookActionsFunc describes the behavior
+// when the ListTemplatedWebhookActions method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreListTemplatedWebhookActionsFunc struct {
+	defaultHook func(context.Context, TemplatedWebhookPreset, ListActionsOpts) ([]*TemplatedWebhookAction, error)
+	hooks       []func(context.Context, TemplatedWebhookPreset, ListActionsOpts) ([]*TemplatedWebhookAction, error)
+	history     []CodeMonitorStoreListTemplatedWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListTemplatedWebhookActions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListTemplatedWebhookActions(v0 context.Context, v1 TemplatedWebhookPreset, v2 ListActionsOpts) ([]*TemplatedWebhookAction, error) {
+	r0, r1 := m.ListTemplatedWebhookActionsFunc.nextHook()(v0, v1, v2)
+	m.ListTemplatedWebhookActionsFunc.appendCall(CodeMonitorStoreListTemplatedWebhookActionsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListTemplatedWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreListTemplatedWebhookActionsFunc) SetDefaultHook(hook func(context.Context, TemplatedWebhookPreset, ListActionsOpts) ([]*TemplatedWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListTemplatedWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreListTemplatedWebhookActionsFunc) PushHook(hook func(context.Context, TemplatedWebhookPreset, ListActionsOpts) ([]*TemplatedWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListTemplatedWebhookActionsFunc) SetDefaultReturn(r0 []*TemplatedWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, TemplatedWebhookPreset, ListActionsOpts) ([]*TemplatedWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListTemplatedWebhookActionsFunc) PushReturn(r0 []*TemplatedWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, TemplatedWebhookPreset, ListActionsOpts) ([]*TemplatedWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListTemplatedWebhookActionsFunc) nextHook() func(context.Context, TemplatedWebhookPreset, ListActionsOpts) ([]*TemplatedWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListTemplatedWebhookActionsFunc) appendCall(r0 CodeMonitorStoreListTemplatedWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreListTemplatedWebhookActionsFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreListTemplatedWebhookActionsFunc) History() []CodeMonitorStoreListTemplatedWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListTemplatedWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListTemplatedWebhookActionsFuncCall is an object that
+// describes an invocation of method ListTemplatedWebhookActions on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreListTemplatedWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 TemplatedWebhookPreset
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 ListActionsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*TemplatedWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListTemplatedWebhookActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListTemplatedWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreListWebhookActionsFunc describes the behavior when the
 // ListWebhookActions method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -5556,6 +6385,117 @@ func (c CodeMonitorStoreNowFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreReplaceLastResultsFunc describes the behavior when the
+// ReplaceLastResults method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreReplaceLastResultsFunc struct {
+	defaultHook func(context.Context, int64, []*LastResult) error
+	hooks       []func(context.Context, int64, []*LastResult) error
+	history     []CodeMonitorStoreReplaceLastResultsFuncCall
+	mutex       sync.Mutex
+}
+
+// ReplaceLastResults delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ReplaceLastResults(v0 context.Context, v1 int64, v2 []*LastResult) error {
+	r0 := m.ReplaceLastResultsFunc.nextHook()(v0, v1, v2)
+	m.ReplaceLastResultsFunc.appendCall(CodeMonitorStoreReplaceLastResultsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ReplaceLastResults
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreReplaceLastResultsFunc) SetDefaultHook(hook func(context.Context, int64, []*LastResult) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReplaceLastResults method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreReplaceLastResultsFunc) PushHook(hook func(context.Context, int64, []*LastResult) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreReplaceLastResultsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []*LastResult) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreReplaceLastResultsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []*LastResult) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreReplaceLastResultsFunc) nextHook() func(context.Context, int64, []*LastResult) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreReplaceLastResultsFunc) appendCall(r0 CodeMonitorStoreReplaceLastResultsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreReplaceLastResultsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreReplaceLastResultsFunc) History() []CodeMonitorStoreReplaceLastResultsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreReplaceLastResultsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreReplaceLastResultsFuncCall is an object that describes an
+// invocation of method ReplaceLastResults on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreReplaceLastResultsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []*LastResult
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreReplaceLastResultsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreReplaceLastResultsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreResetQueryTriggerTimestampsFunc describes the behavior
 // when the ResetQueryTriggerTimestamps method of the parent
 // MockCodeMonitorStore instance is invoked.
@@ -6459,28 +7399,146 @@ func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpdateTemplatedWebhookActionFunc describes the behavior
+// when the UpdateTemplatedWebhookAction method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreUpdateTemplatedWebhookActionFunc struct {
+	defaultHook func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error)
+	hooks       []func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error)
+	history     []CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateTemplatedWebhookAction delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateTemplatedWebhookAction(v0 context.Context, v1 TemplatedWebhookPreset, v2 int64, v3 *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error) {
+	r0, r1 := m.UpdateTemplatedWebhookActionFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateTemplatedWebhookActionFunc.appendCall(CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateTemplatedWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateTemplatedWebhookActionFunc) SetDefaultHook(hook func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateTemplatedWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpdateTemplatedWebhookActionFunc) PushHook(hook func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateTemplatedWebhookActionFunc) SetDefaultReturn(r0 *TemplatedWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateTemplatedWebhookActionFunc) PushReturn(r0 *TemplatedWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreUpdateTemplatedWebhookActionFunc) nextHook() func(context.Context, TemplatedWebhookPreset, int64, *TemplatedWebhookActionArgs) (*TemplatedWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateTemplatedWebhookActionFunc) appendCall(r0 CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreUpdateTemplatedWebhookActionFunc) History() []CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall is an object that
+// describes an invocation of method UpdateTemplatedWebhookAction on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 TemplatedWebhookPreset
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 *TemplatedWebhookActionArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *TemplatedWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreUpdateTriggerJobWithResultsFunc describes the behavior
 // when the UpdateTriggerJobWithResults method of the parent
 // MockCodeMonitorStore instance is invoked.
 type CodeMonitorStoreUpdateTriggerJobWithResultsFunc struct {
-	defaultHook func(context.Context, int32, string, []*result.CommitMatch) error
-	hooks       []func(context.Context, int32, string, []*result.CommitMatch) error
+	defaultHook func(context.Context, int32, string, []*result.CommitMatch, []*FileResult) error
+	hooks       []func(context.Context, int32, string, []*result.CommitMatch, []*FileResult) error
 	history     []CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall
 	mutex       sync.Mutex
 }
 
 // UpdateTriggerJobWithResults delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateTriggerJobWithResults(v0 context.Context, v1 int32, v2 string, v3 []*result.CommitMatch) error {
-	r0 := m.UpdateTriggerJobWithResultsFunc.nextHook()(v0, v1, v2, v3)
-	m.UpdateTriggerJobWithResultsFunc.appendCall(CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall{v0, v1, v2, v3, r0})
+func (m *MockCodeMonitorStore) UpdateTriggerJobWithResults(v0 context.Context, v1 int32, v2 string, v3 []*result.CommitMatch, v4 []*FileResult) error {
+	r0 := m.UpdateTriggerJobWithResultsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateTriggerJobWithResultsFunc.appendCall(CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall{v0, v1, v2, v3, v4, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the
 // UpdateTriggerJobWithResults method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultHook(hook func(context.Context, int32, string, []*result.CommitMatch) error) {
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultHook(hook func(context.Context, int32, string, []*result.CommitMatch, []*FileResult) error) {
 	f.defaultHook = hook
 }
 
@@ -6489,7 +7547,7 @@ func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultHook(hook fu
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushHook(hook func(context.Context, int32, string, []*result.CommitMatch) error) {
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushHook(hook func(context.Context, int32, string, []*result.CommitMatch, []*FileResult) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -6498,19 +7556,19 @@ func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushHook(hook func(con
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, string, []*result.CommitMatch) error {
+	f.SetDefaultHook(func(context.Context, int32, string, []*result.CommitMatch, []*FileResult) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, string, []*result.CommitMatch) error {
+	f.PushHook(func(context.Context, int32, string, []*result.CommitMatch, []*FileResult) error {
 		return r0
 	})
 }
 
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) nextHook() func(context.Context, int32, string, []*result.CommitMatch) error {
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) nextHook() func(context.Context, int32, string, []*result.CommitMatch, []*FileResult) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -6557,6 +7615,9 @@ type CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall struct {
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
 	Arg3 []*result.CommitMatch
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 []*FileResult
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -6565,7 +7626,7 @@ type CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
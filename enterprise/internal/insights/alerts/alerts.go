@@ -0,0 +1,154 @@
+// Package alerts evaluates user defined alert rules against code insights series, and notifies the creator of a
+// rule when it triggers.
+package alerts
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+// weekOverWeek is how far back the previous value is looked up for INCREASE_PERCENT alerts.
+const weekOverWeek = 7 * 24 * time.Hour
+
+// Result is the outcome of evaluating an alert against the points of a series.
+type Result struct {
+	Firing bool
+
+	// Time and Value describe the latest point of the series. Value is summed over all capture group values.
+	Time  time.Time
+	Value float64
+
+	// PreviousValue is the value the latest point was compared against for INCREASE_PERCENT alerts.
+	PreviousValue *float64
+
+	// NewCaptureValues are the capture group values that appeared in the latest point for NEW_CAPTURE_VALUE alerts.
+	NewCaptureValues []string
+}
+
+// vector is the value of a series at a single point in time.
+type vector struct {
+	time     time.Time
+	total    float64
+	captures map[string]float64
+}
+
+// Evaluate returns whether alert is triggered by the latest of the given points.
+func Evaluate(alert types.SeriesAlert, points []store.SeriesPoint) Result {
+	vectors := toVectors(points)
+	if len(vectors) == 0 {
+		return Result{}
+	}
+	latest := vectors[len(vectors)-1]
+	result := Result{Time: latest.time, Value: latest.total}
+
+	threshold := 0.0
+	if alert.Threshold != nil {
+		threshold = *alert.Threshold
+	}
+
+	switch alert.Kind {
+	case types.AlertAbove:
+		result.Firing = latest.total > threshold
+
+	case types.AlertBelow:
+		result.Firing = latest.total < threshold
+
+	case types.AlertIncreasePercent:
+		previous, ok := previousVector(vectors, latest.time.Add(-weekOverWeek))
+		if !ok {
+			return result
+		}
+		result.PreviousValue = &previous.total
+		if previous.total == 0 {
+			// Any increase from zero is infinitely large.
+			result.Firing = latest.total > 0
+			return result
+		}
+		result.Firing = (latest.total-previous.total)/previous.total*100 >= threshold
+
+	case types.AlertNewCaptureValue:
+		if len(vectors) < 2 {
+			// Every value of the first point is new, which is not worth an alert.
+			return result
+		}
+		seen := make(map[string]struct{})
+		for _, v := range vectors[:len(vectors)-1] {
+			for capture, value := range v.captures {
+				if value > 0 {
+					seen[capture] = struct{}{}
+				}
+			}
+		}
+		for capture, value := range latest.captures {
+			if _, ok := seen[capture]; !ok && value > 0 {
+				result.NewCaptureValues = append(result.NewCaptureValues, capture)
+			}
+		}
+		sort.Strings(result.NewCaptureValues)
+		result.Firing = len(result.NewCaptureValues) > 0
+	}
+
+	return result
+}
+
+// toVectors groups points by time, in ascending order.
+func toVectors(points []store.SeriesPoint) []vector {
+	byTime := make(map[time.Time]*vector)
+	for _, p := range points {
+		t := p.Time.UTC()
+		v, ok := byTime[t]
+		if !ok {
+			v = &vector{time: t, captures: make(map[string]float64)}
+			byTime[t] = v
+		}
+		v.total += p.Value
+		if p.Capture != nil {
+			v.captures[*p.Capture] += p.Value
+		}
+	}
+
+	vectors := make([]vector, 0, len(byTime))
+	for _, v := range byTime {
+		vectors = append(vectors, *v)
+	}
+	sort.Slice(vectors, func(i, j int) bool { return vectors[i].time.Before(vectors[j].time) })
+	return vectors
+}
+
+// previousVector returns the most recent vector at or before t.
+func previousVector(vectors []vector, t time.Time) (vector, bool) {
+	for i := len(vectors) - 1; i >= 0; i-- {
+		if !vectors[i].time.After(t) {
+			return vectors[i], true
+		}
+	}
+	return vector{}, false
+}
+
+// describe returns a human readable description of the condition of alert, such as "is above 100".
+func describe(alert types.SeriesAlert) string {
+	threshold := "0"
+	if alert.Threshold != nil {
+		threshold = formatValue(*alert.Threshold)
+	}
+	switch alert.Kind {
+	case types.AlertAbove:
+		return "is above " + threshold
+	case types.AlertBelow:
+		return "is below " + threshold
+	case types.AlertIncreasePercent:
+		return fmt.Sprintf("increased by %s%% or more week-over-week", threshold)
+	case types.AlertNewCaptureValue:
+		return "has new values"
+	}
+	return string(alert.Kind)
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,130 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestEvaluate(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2022, 7, d, 0, 0, 0, 0, time.UTC) }
+	point := func(d int, value float64) store.SeriesPoint {
+		return store.SeriesPoint{SeriesID: "s", Time: day(d), Value: value}
+	}
+	capture := func(d int, capture string, value float64) store.SeriesPoint {
+		return store.SeriesPoint{SeriesID: "s", Time: day(d), Value: value, Capture: &capture}
+	}
+	threshold := func(v float64) *float64 { return &v }
+
+	cases := []struct {
+		name   string
+		kind   types.AlertKind
+		thresh *float64
+		points []store.SeriesPoint
+		want   Result
+	}{
+		{
+			name: "no points",
+			kind: types.AlertAbove,
+			want: Result{},
+		},
+		{
+			name:   "above",
+			kind:   types.AlertAbove,
+			thresh: threshold(10),
+			points: []store.SeriesPoint{point(1, 5), point(8, 11)},
+			want:   Result{Firing: true, Time: day(8), Value: 11},
+		},
+		{
+			name:   "not above",
+			kind:   types.AlertAbove,
+			thresh: threshold(10),
+			points: []store.SeriesPoint{point(1, 50), point(8, 10)},
+			want:   Result{Time: day(8), Value: 10},
+		},
+		{
+			name:   "below sums captures",
+			kind:   types.AlertBelow,
+			thresh: threshold(10),
+			points: []store.SeriesPoint{capture(8, "a", 4), capture(8, "b", 5)},
+			want:   Result{Firing: true, Time: day(8), Value: 9},
+		},
+		{
+			name:   "increase percent",
+			kind:   types.AlertIncreasePercent,
+			thresh: threshold(20),
+			points: []store.SeriesPoint{point(1, 100), point(5, 110), point(8, 120)},
+			want:   Result{Firing: true, Time: day(8), Value: 120, PreviousValue: threshold(100)},
+		},
+		{
+			name:   "increase percent below threshold",
+			kind:   types.AlertIncreasePercent,
+			thresh: threshold(20),
+			points: []store.SeriesPoint{point(1, 100), point(8, 119)},
+			want:   Result{Time: day(8), Value: 119, PreviousValue: threshold(100)},
+		},
+		{
+			name:   "increase percent from zero",
+			kind:   types.AlertIncreasePercent,
+			thresh: threshold(1000),
+			points: []store.SeriesPoint{point(1, 0), point(8, 1)},
+			want:   Result{Firing: true, Time: day(8), Value: 1, PreviousValue: threshold(0)},
+		},
+		{
+			name:   "increase percent without a week of history",
+			kind:   types.AlertIncreasePercent,
+			thresh: threshold(20),
+			points: []store.SeriesPoint{point(3, 1), point(8, 100)},
+			want:   Result{Time: day(8), Value: 100},
+		},
+		{
+			name: "new capture value",
+			kind: types.AlertNewCaptureValue,
+			points: []store.SeriesPoint{
+				capture(1, "1.17", 3), capture(1, "1.18", 0),
+				capture(8, "1.17", 2), capture(8, "1.18", 1), capture(8, "1.19", 1),
+			},
+			want: Result{Firing: true, Time: day(8), Value: 4, NewCaptureValues: []string{"1.18", "1.19"}},
+		},
+		{
+			name:   "no new capture value",
+			kind:   types.AlertNewCaptureValue,
+			points: []store.SeriesPoint{capture(1, "1.17", 3), capture(2, "1.18", 1), capture(8, "1.18", 4)},
+			want:   Result{Time: day(8), Value: 4},
+		},
+		{
+			name:   "new capture value on first point",
+			kind:   types.AlertNewCaptureValue,
+			points: []store.SeriesPoint{capture(8, "1.17", 3)},
+			want:   Result{Time: day(8), Value: 3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Evaluate(types.SeriesAlert{Kind: tc.kind, Threshold: tc.thresh}, tc.points)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	threshold := 12.5
+	cases := map[types.AlertKind]string{
+		types.AlertAbove:           "is above 12.5",
+		types.AlertBelow:           "is below 12.5",
+		types.AlertIncreasePercent: "increased by 12.5% or more week-over-week",
+		types.AlertNewCaptureValue: "has new values",
+	}
+	for kind, want := range cases {
+		if got := describe(types.SeriesAlert{Kind: kind, Threshold: &threshold}); got != want {
+			t.Errorf("describe(%s) = %q, want %q", kind, got, want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package alerts
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Evaluator evaluates the alerts of a series after a new point has been recorded for it.
+type Evaluator struct {
+	alertStore  *store.AlertStore
+	seriesStore store.Interface
+
+	// notify is replaced in tests.
+	notify func(ctx context.Context, alert types.SeriesAlert, result Result) error
+}
+
+func NewEvaluator(alertStore *store.AlertStore, seriesStore store.Interface) *Evaluator {
+	return &Evaluator{
+		alertStore:  alertStore,
+		seriesStore: seriesStore,
+		notify:      notify,
+	}
+}
+
+// Run evaluates every alert of series against its points up to recordTime, and notifies the alerts that trigger.
+//
+// Threshold alerts only notify when their condition starts to hold, so a series that stays above a threshold does
+// not notify on every recording. NEW_CAPTURE_VALUE alerts notify every time, since each time they trigger it is for
+// values that were not seen before.
+func (e *Evaluator) Run(ctx context.Context, series *types.InsightSeries, recordTime time.Time) error {
+	alerts, err := e.alertStore.GetAlerts(ctx, store.AlertQueryArgs{InsightSeriesID: series.ID})
+	if err != nil {
+		return errors.Wrap(err, "GetAlerts")
+	}
+
+	var errs error
+	for _, alert := range alerts {
+		if err := e.evaluate(ctx, alert, recordTime); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "alert %d", alert.ID))
+		}
+	}
+	return errs
+}
+
+func (e *Evaluator) evaluate(ctx context.Context, alert types.SeriesAlert, recordTime time.Time) error {
+	// 🚨 SECURITY: The series values are read as the creator of the alert, so that the notification they receive only
+	// reflects the repositories they have access to.
+	userCtx := actor.WithActor(ctx, actor.FromUser(alert.UserID))
+	points, err := e.seriesStore.SeriesPoints(userCtx, store.SeriesPointsOpts{
+		SeriesID: &alert.SeriesID,
+		To:       &recordTime,
+	})
+	if err != nil {
+		return errors.Wrap(err, "SeriesPoints")
+	}
+
+	result := Evaluate(alert, points)
+	shouldNotify := result.Firing && (alert.Kind == types.AlertNewCaptureValue || !alert.Firing)
+	if shouldNotify {
+		// If the notification fails the state is left untouched, so the next recording will try again.
+		if err := e.notify(ctx, alert, result); err != nil {
+			return errors.Wrap(err, "notify")
+		}
+	}
+	if shouldNotify || result.Firing != alert.Firing {
+		return e.alertStore.SetAlertFiring(ctx, alert.ID, result.Firing, shouldNotify)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// To avoid a circular dependency with the insights/resolvers package we have to redeclare the insight view kind.
+const insightKind = "insight_view"
+
+const utmSource = "code-insights-alert"
+
+var MockExternalURL func() *url.URL
+
+var (
+	//go:embed email_template.html.tmpl
+	htmlTemplate string
+
+	//go:embed email_template.txt.tmpl
+	textTemplate string
+)
+
+var alertEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Sourcegraph code insight {{.InsightTitle}}: {{.SeriesLabel}} {{.Condition}}`,
+	Text:    textTemplate,
+	HTML:    htmlTemplate,
+})
+
+// TemplateDataAlert is the data of the alert notification email.
+type TemplateDataAlert struct {
+	InsightTitle     string
+	InsightURL       string
+	SeriesLabel      string
+	Condition        string
+	Value            string
+	PreviousValue    string
+	NewCaptureValues []string
+}
+
+// notify sends the notification of a triggered alert through its channel.
+func notify(ctx context.Context, alert types.SeriesAlert, result Result) error {
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return errors.Wrap(err, "getExternalURL")
+	}
+	insightURL := getInsightURL(externalURL, alert.ViewUniqueID)
+
+	switch alert.Channel {
+	case types.AlertEmail:
+		return sendEmail(ctx, alert.UserID, alertEmailTemplates, newTemplateData(alert, result, insightURL))
+	case types.AlertWebhook:
+		if alert.WebhookURL == nil {
+			return errors.Newf("alert %d has no webhook URL", alert.ID)
+		}
+		return postWebhook(ctx, httpcli.ExternalDoer, *alert.WebhookURL, generateWebhookPayload(alert, result, insightURL))
+	}
+	return errors.Newf("unsupported alert channel %q", alert.Channel)
+}
+
+func newTemplateData(alert types.SeriesAlert, result Result, insightURL string) *TemplateDataAlert {
+	data := &TemplateDataAlert{
+		InsightTitle:     alert.ViewTitle,
+		InsightURL:       insightURL,
+		SeriesLabel:      alert.Label,
+		Condition:        describe(alert),
+		Value:            formatValue(result.Value),
+		NewCaptureValues: result.NewCaptureValues,
+	}
+	if result.PreviousValue != nil {
+		data.PreviousValue = formatValue(*result.PreviousValue)
+	}
+	return data
+}
+
+func sendEmail(ctx context.Context, userID int32, template txtypes.Templates, data any) error {
+	email, err := internalapi.Client.UserEmailsGetEmail(ctx, userID)
+	if err != nil {
+		return errors.Errorf("internalapi.Client.UserEmailsGetEmail for userID=%d: %w", userID, err)
+	}
+	if email == nil {
+		return errors.Errorf("unable to send email to user ID %d with unknown email address", userID)
+	}
+	if err := internalapi.Client.SendEmail(ctx, txtypes.Message{
+		To:       []string{*email},
+		Template: template,
+		Data:     data,
+	}); err != nil {
+		return errors.Errorf("internalapi.Client.SendEmail to email=%q userID=%d: %w", *email, userID, err)
+	}
+	return nil
+}
+
+type webhookPayload struct {
+	InsightTitle     string    `json:"insightTitle"`
+	InsightURL       string    `json:"insightURL"`
+	SeriesID         string    `json:"seriesId"`
+	SeriesLabel      string    `json:"seriesLabel"`
+	Kind             string    `json:"kind"`
+	Threshold        *float64  `json:"threshold,omitempty"`
+	Condition        string    `json:"condition"`
+	Time             time.Time `json:"time"`
+	Value            float64   `json:"value"`
+	PreviousValue    *float64  `json:"previousValue,omitempty"`
+	NewCaptureValues []string  `json:"newCaptureValues,omitempty"`
+}
+
+func generateWebhookPayload(alert types.SeriesAlert, result Result, insightURL string) webhookPayload {
+	return webhookPayload{
+		InsightTitle:     alert.ViewTitle,
+		InsightURL:       insightURL,
+		SeriesID:         alert.SeriesID,
+		SeriesLabel:      alert.Label,
+		Kind:             string(alert.Kind),
+		Threshold:        alert.Threshold,
+		Condition:        describe(alert),
+		Time:             result.Time,
+		Value:            result.Value,
+		PreviousValue:    result.PreviousValue,
+		NewCaptureValues: result.NewCaptureValues,
+	}
+}
+
+// StatusCodeError is returned when a webhook responds with a status other than 200.
+type StatusCodeError struct {
+	Code   int
+	Status string
+	Body   string
+}
+
+func (s StatusCodeError) Error() string {
+	return fmt.Sprintf("non-200 response %d %s with body %q", s.Code, s.Status, s.Body)
+}
+
+func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload webhookPayload) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal failed")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(raw))
+	if err != nil {
+		return errors.Wrap(err, "failed new request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to post webhook")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return StatusCodeError{
+			Code:   resp.StatusCode,
+			Status: resp.Status,
+			Body:   string(body),
+		}
+	}
+
+	return nil
+}
+
+func getInsightURL(externalURL *url.URL, viewUniqueID string) string {
+	u := externalURL.ResolveReference(&url.URL{Path: fmt.Sprintf("insights/insight/%s", relay.MarshalID(insightKind, viewUniqueID))})
+	q := u.Query()
+	q.Set("utm_source", utmSource)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+var (
+	externalURLOnce  sync.Once
+	externalURLValue *url.URL
+	externalURLError error
+)
+
+func getExternalURL(ctx context.Context) (*url.URL, error) {
+	if MockExternalURL != nil {
+		return MockExternalURL(), nil
+	}
+
+	externalURLOnce.Do(func() {
+		externalURLStr, err := internalapi.Client.ExternalURL(ctx)
+		if err != nil {
+			externalURLError = err
+			return
+		}
+		externalURLValue, externalURLError = url.Parse(strings.TrimSuffix(externalURLStr, "/") + "/")
+	})
+	return externalURLValue, externalURLError
+}
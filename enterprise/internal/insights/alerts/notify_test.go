@@ -0,0 +1,79 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestPostWebhook(t *testing.T) {
+	threshold := 10.0
+	alert := types.SeriesAlert{
+		ViewUniqueID: "view-1",
+		ViewTitle:    "Migration",
+		SeriesID:     "series-1",
+		Label:        "old API calls",
+		Kind:         types.AlertAbove,
+		Threshold:    &threshold,
+	}
+	result := Result{Firing: true, Time: time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC), Value: 12}
+	externalURL, _ := url.Parse("https://sourcegraph.test/")
+	insightURL := getInsightURL(externalURL, alert.ViewUniqueID)
+
+	var got map[string]any
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+
+	err := postWebhook(context.Background(), httpcli.ExternalDoer, s.URL, generateWebhookPayload(alert, result, insightURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]any{
+		"insightTitle": "Migration",
+		"insightURL":   "https://sourcegraph.test/insights/insight/aW5zaWdodF92aWV3OiJ2aWV3LTEi?utm_source=code-insights-alert",
+		"seriesId":     "series-1",
+		"seriesLabel":  "old API calls",
+		"kind":         "ABOVE",
+		"threshold":    10.0,
+		"condition":    "is above 10",
+		"time":         "2022-07-01T00:00:00Z",
+		"value":        12.0,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected payload (-want +got):\n%s", diff)
+	}
+}
+
+func TestPostWebhook_statusCode(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer s.Close()
+
+	err := postWebhook(context.Background(), httpcli.ExternalDoer, s.URL, webhookPayload{})
+	var statusErr StatusCodeError
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusInternalServerError {
+		t.Fatalf("expected StatusCodeError, got %v", err)
+	}
+}
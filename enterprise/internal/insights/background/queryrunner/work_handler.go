@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/alerts"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/discovery"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/streaming"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
@@ -35,6 +36,7 @@ type workHandler struct {
 	repoStore       discovery.RepoStore
 	metadadataStore *store.InsightStore
 	limiter         *ratelimit.InstrumentedLimiter
+	alertEvaluator  *alerts.Evaluator
 
 	mu          sync.RWMutex
 	seriesCache map[string]*types.InsightSeries
@@ -343,5 +345,16 @@ func (r *workHandler) Handle(ctx context.Context, logger log.Logger, record work
 	if err != nil {
 		return err
 	}
-	return r.persistRecordings(ctx, job, series, recordings)
+	if err := r.persistRecordings(ctx, job, series, recordings); err != nil {
+		return err
+	}
+
+	// Alerts only watch new values of a series, so backfilled points and snapshots are skipped.
+	if r.alertEvaluator != nil && job.RecordTime == nil && store.PersistMode(job.PersistMode) == store.RecordMode {
+		// The recording has been persisted, so failing to notify should not fail (and retry) the job.
+		if err := r.alertEvaluator.Run(ctx, series, recordTime); err != nil {
+			logger.Error("failed to evaluate insight series alerts", log.String("seriesID", series.SeriesID), log.Error(err))
+		}
+	}
+	return nil
 }
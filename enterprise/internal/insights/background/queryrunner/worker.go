@@ -14,6 +14,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/alerts"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/compression"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/discovery"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/streaming"
@@ -83,6 +84,7 @@ func NewWorker(ctx context.Context, logger log.Logger, workerStore dbworkerstore
 		insightsStore:   insightsStore,
 		repoStore:       repoStore,
 		limiter:         limiter,
+		alertEvaluator:  alerts.NewEvaluator(store.NewAlertStoreWith(insightsStore), insightsStore),
 		metadadataStore: store.NewInsightStoreWith(insightsStore),
 		seriesCache:     sharedCache,
 		searchStream: func(ctx context.Context, query string) (*streaming.TabulationResult, error) {
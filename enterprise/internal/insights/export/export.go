@@ -0,0 +1,189 @@
+// Package export writes the recorded data points of code insights as CSV or JSON files.
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Format is the file format of an export.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// ParseFormat returns the format with the given name. An empty name is the CSV format.
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(name)) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	}
+	return "", errors.Newf("unsupported export format %q", name)
+}
+
+// ContentType returns the MIME type of files in the format.
+func (f Format) ContentType() string {
+	if f == FormatJSON {
+		return "application/json"
+	}
+	return "text/csv"
+}
+
+// Filters are the repository filters of an insight view that were applied to the exported points.
+type Filters struct {
+	IncludeRepoRegex string   `json:"includeRepoRegex,omitempty"`
+	ExcludeRepoRegex string   `json:"excludeRepoRegex,omitempty"`
+	SearchContexts   []string `json:"searchContexts,omitempty"`
+}
+
+// Row is the value of an insight series in a single repository at a point in time.
+type Row struct {
+	// InsightID is the GraphQL ID of the insight view.
+	InsightID    string `json:"insightId"`
+	InsightTitle string `json:"insightTitle"`
+	SeriesID     string `json:"seriesId"`
+	SeriesLabel  string `json:"seriesLabel"`
+
+	// Capture is the value of the capture group for series generated from capture groups.
+	Capture *string `json:"capture,omitempty"`
+
+	Time         time.Time `json:"time"`
+	Repository   string    `json:"repository"`
+	RepositoryID int32     `json:"repositoryId"`
+	Value        float64   `json:"value"`
+	Filters      Filters   `json:"filters"`
+}
+
+// NewRow returns the row of a point recorded for a series of an insight view.
+func NewRow(insight types.Insight, series types.InsightViewSeries, point store.RepoSeriesPoint) Row {
+	row := Row{
+		InsightID:    string(relay.MarshalID("insight_view", insight.UniqueID)),
+		InsightTitle: insight.Title,
+		SeriesID:     series.SeriesID,
+		SeriesLabel:  series.Label,
+		Capture:      point.Capture,
+		Time:         point.Time.UTC(),
+		Repository:   point.RepoName,
+		RepositoryID: int32(point.RepoID),
+		Value:        point.Value,
+		Filters:      Filters{SearchContexts: insight.Filters.SearchContexts},
+	}
+	if insight.Filters.IncludeRepoRegex != nil {
+		row.Filters.IncludeRepoRegex = *insight.Filters.IncludeRepoRegex
+	}
+	if insight.Filters.ExcludeRepoRegex != nil {
+		row.Filters.ExcludeRepoRegex = *insight.Filters.ExcludeRepoRegex
+	}
+	return row
+}
+
+var csvHeader = []string{
+	"insight_id",
+	"insight_title",
+	"series_id",
+	"series_label",
+	"capture",
+	"time",
+	"repository",
+	"repository_id",
+	"value",
+	"include_repo_regex",
+	"exclude_repo_regex",
+	"search_contexts",
+}
+
+// RowWriter writes the rows of an export in its format.
+type RowWriter interface {
+	WriteRow(Row) error
+
+	// Close writes any buffered rows and terminates the file. It does not close the underlying writer.
+	Close() error
+}
+
+// NewRowWriter returns a RowWriter that writes rows to w in the given format.
+func NewRowWriter(format Format, w io.Writer) (RowWriter, error) {
+	if format == FormatCSV {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return nil, err
+		}
+		return &csvRowWriter{w: cw}, nil
+	}
+
+	// JSON exports are a single array, which is written incrementally so that exports of large dashboards are never
+	// held in memory.
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString("["); err != nil {
+		return nil, err
+	}
+	return &jsonRowWriter{w: bw, enc: json.NewEncoder(bw)}, nil
+}
+
+type csvRowWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvRowWriter) WriteRow(row Row) error {
+	capture := ""
+	if row.Capture != nil {
+		capture = *row.Capture
+	}
+	return w.w.Write([]string{
+		row.InsightID,
+		row.InsightTitle,
+		row.SeriesID,
+		row.SeriesLabel,
+		capture,
+		row.Time.UTC().Format(time.RFC3339),
+		row.Repository,
+		strconv.Itoa(int(row.RepositoryID)),
+		strconv.FormatFloat(row.Value, 'f', -1, 64),
+		row.Filters.IncludeRepoRegex,
+		row.Filters.ExcludeRepoRegex,
+		strings.Join(row.Filters.SearchContexts, " "),
+	})
+}
+
+func (w *csvRowWriter) Close() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type jsonRowWriter struct {
+	w    *bufio.Writer
+	enc  *json.Encoder
+	rows int
+}
+
+func (w *jsonRowWriter) WriteRow(row Row) error {
+	if w.rows > 0 {
+		if _, err := w.w.WriteString(","); err != nil {
+			return err
+		}
+	}
+	w.rows++
+	// Encode terminates every row with a newline, which keeps large exports readable line by line.
+	return w.enc.Encode(row)
+}
+
+func (w *jsonRowWriter) Close() error {
+	if _, err := w.w.WriteString("]\n"); err != nil {
+		return err
+	}
+	return w.w.Flush()
+}
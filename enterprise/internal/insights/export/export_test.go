@@ -0,0 +1,114 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestNewRow(t *testing.T) {
+	include := "^github.com/sourcegraph/"
+	capture := "1.18"
+	insight := types.Insight{
+		UniqueID: "view-1",
+		Title:    "Go versions",
+		Filters:  types.InsightViewFilters{IncludeRepoRegex: &include, SearchContexts: []string{"@sourcegraph/backend"}},
+	}
+	series := types.InsightViewSeries{SeriesID: "series-1", Label: "go.mod"}
+	point := store.RepoSeriesPoint{
+		SeriesID: "series-1",
+		Time:     time.Date(2022, 7, 1, 0, 0, 0, 0, time.FixedZone("CEST", 2*60*60)),
+		RepoID:   3,
+		RepoName: "github.com/sourcegraph/sourcegraph",
+		Value:    2,
+		Capture:  &capture,
+	}
+
+	want := Row{
+		InsightID:    "aW5zaWdodF92aWV3OiJ2aWV3LTEi",
+		InsightTitle: "Go versions",
+		SeriesID:     "series-1",
+		SeriesLabel:  "go.mod",
+		Capture:      &capture,
+		Time:         time.Date(2022, 6, 30, 22, 0, 0, 0, time.UTC),
+		Repository:   "github.com/sourcegraph/sourcegraph",
+		RepositoryID: 3,
+		Value:        2,
+		Filters:      Filters{IncludeRepoRegex: include, SearchContexts: []string{"@sourcegraph/backend"}},
+	}
+	if diff := cmp.Diff(want, NewRow(insight, series, point)); diff != "" {
+		t.Fatalf("unexpected row (-want +got):\n%s", diff)
+	}
+}
+
+func TestRowWriter(t *testing.T) {
+	capture := "1.18"
+	day := time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)
+	rows := []Row{
+		{InsightID: "i", InsightTitle: "Go, versions", SeriesID: "s", SeriesLabel: "go.mod", Capture: &capture, Time: day, Repository: "r", RepositoryID: 1, Value: 2, Filters: Filters{SearchContexts: []string{"a", "b"}}},
+		{InsightID: "i", InsightTitle: "Go, versions", SeriesID: "s", SeriesLabel: "go.mod", Time: day, Repository: "r", RepositoryID: 1, Value: 0.5, Filters: Filters{ExcludeRepoRegex: "^r$"}},
+	}
+
+	tests := []struct {
+		format Format
+		rows   []Row
+		want   string
+	}{
+		{
+			format: FormatCSV,
+			rows:   rows,
+			want: `insight_id,insight_title,series_id,series_label,capture,time,repository,repository_id,value,include_repo_regex,exclude_repo_regex,search_contexts
+i,"Go, versions",s,go.mod,1.18,2022-07-01T00:00:00Z,r,1,2,,,a b
+i,"Go, versions",s,go.mod,,2022-07-01T00:00:00Z,r,1,0.5,,^r$,
+`,
+		},
+		{
+			format: FormatJSON,
+			rows:   rows,
+			want: `[{"insightId":"i","insightTitle":"Go, versions","seriesId":"s","seriesLabel":"go.mod","capture":"1.18","time":"2022-07-01T00:00:00Z","repository":"r","repositoryId":1,"value":2,"filters":{"searchContexts":["a","b"]}}
+,{"insightId":"i","insightTitle":"Go, versions","seriesId":"s","seriesLabel":"go.mod","time":"2022-07-01T00:00:00Z","repository":"r","repositoryId":1,"value":0.5,"filters":{"excludeRepoRegex":"^r$"}}
+]
+`,
+		},
+		{
+			format: FormatJSON,
+			want:   "[]\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(string(test.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewRowWriter(test.format, &buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, row := range test.rows {
+				if err := w.WriteRow(row); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, buf.String()); diff != "" {
+				t.Fatalf("unexpected output (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for name, want := range map[string]Format{"": FormatCSV, "csv": FormatCSV, "JSON": FormatJSON} {
+		if got, err := ParseFormat(name); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v, want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("xlsx"); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,199 @@
+package resolvers
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var _ graphqlbackend.InsightSeriesAlertResolver = &insightSeriesAlertResolver{}
+
+const alertKind = "InsightSeriesAlert"
+
+func (r *Resolver) InsightSeriesAlerts(ctx context.Context, args *graphqlbackend.InsightSeriesAlertsArgs) ([]graphqlbackend.InsightSeriesAlertResolver, error) {
+	userID := actor.FromContext(ctx).UID
+	if userID == 0 {
+		return nil, backend.ErrNotAuthenticated
+	}
+	view, err := r.accessibleInsightView(ctx, args.InsightViewId)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := r.alertStore.GetAlerts(ctx, store.AlertQueryArgs{InsightViewID: view.ViewID, UserID: userID})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetAlerts")
+	}
+	resolvers := make([]graphqlbackend.InsightSeriesAlertResolver, 0, len(alerts))
+	for _, alert := range alerts {
+		resolvers = append(resolvers, &insightSeriesAlertResolver{alert: alert})
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) CreateInsightSeriesAlert(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertArgs) (graphqlbackend.InsightSeriesAlertResolver, error) {
+	userID := actor.FromContext(ctx).UID
+	if userID == 0 {
+		return nil, backend.ErrNotAuthenticated
+	}
+	input := args.Input
+	if err := validateAlertInput(input); err != nil {
+		return nil, err
+	}
+	view, err := r.accessibleInsightView(ctx, input.InsightViewId)
+	if err != nil {
+		return nil, err
+	}
+
+	var series *types.InsightViewSeries
+	for _, s := range view.Series {
+		if s.SeriesID == input.SeriesId {
+			series = &s
+			break
+		}
+	}
+	if series == nil {
+		return nil, errors.Newf("series %q not found in insight", input.SeriesId)
+	}
+	seriesMetadata, err := r.insightStore.GetDataSeries(ctx, store.GetDataSeriesArgs{SeriesID: series.SeriesID})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDataSeries")
+	}
+	if len(seriesMetadata) == 0 {
+		return nil, errors.Newf("series %q not found in insight", input.SeriesId)
+	}
+	if seriesMetadata[0].JustInTime {
+		return nil, errors.New("alerts are not supported on series that are not recorded in the background")
+	}
+	if types.AlertKind(input.Kind) == types.AlertNewCaptureValue && !seriesMetadata[0].GeneratedFromCaptureGroups {
+		return nil, errors.New("NEW_CAPTURE_VALUE alerts are only supported on capture group series")
+	}
+
+	alert, err := r.alertStore.CreateAlert(ctx, types.SeriesAlert{
+		InsightViewID:   view.ViewID,
+		InsightSeriesID: seriesMetadata[0].ID,
+		UserID:          userID,
+		Kind:            types.AlertKind(input.Kind),
+		Threshold:       input.Threshold,
+		Channel:         types.AlertChannel(input.Channel),
+		WebhookURL:      input.WebhookURL,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateAlert")
+	}
+	return &insightSeriesAlertResolver{alert: alert}, nil
+}
+
+func (r *Resolver) DeleteInsightSeriesAlert(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	userID := actor.FromContext(ctx).UID
+	if userID == 0 {
+		return nil, backend.ErrNotAuthenticated
+	}
+	var id int
+	if err := relay.UnmarshalSpec(args.Id, &id); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the alert id")
+	}
+
+	// 🚨 SECURITY: Users can only delete their own alerts. We return a generic not found error to prevent leaking
+	// the existence of alerts of other users.
+	alerts, err := r.alertStore.GetAlerts(ctx, store.AlertQueryArgs{ID: id, UserID: userID})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetAlerts")
+	}
+	if len(alerts) == 0 {
+		return nil, errors.New("alert not found")
+	}
+
+	if err := r.alertStore.DeleteAlert(ctx, id); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+// accessibleInsightView returns the insight view with the given GraphQL ID, if it is visible to the current user.
+func (r *Resolver) accessibleInsightView(ctx context.Context, id graphql.ID) (types.Insight, error) {
+	var viewId string
+	if err := relay.UnmarshalSpec(id, &viewId); err != nil {
+		return types.Insight{}, errors.Wrap(err, "error unmarshalling the insight view id")
+	}
+	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
+	if err := permissionsValidator.validateUserAccessForView(ctx, viewId); err != nil {
+		return types.Insight{}, err
+	}
+
+	insights, err := r.insightStore.GetMapped(ctx, store.InsightQueryArgs{WithoutAuthorization: true, UniqueID: viewId})
+	if err != nil {
+		return types.Insight{}, errors.Wrap(err, "GetMapped")
+	}
+	if len(insights) != 1 {
+		return types.Insight{}, errors.New("insight not found")
+	}
+	return insights[0], nil
+}
+
+func validateAlertInput(input graphqlbackend.CreateInsightSeriesAlertInput) error {
+	switch types.AlertKind(input.Kind) {
+	case types.AlertAbove, types.AlertBelow, types.AlertIncreasePercent:
+		if input.Threshold == nil {
+			return errors.Newf("a threshold is required for %s alerts", input.Kind)
+		}
+	case types.AlertNewCaptureValue:
+	default:
+		return errors.Newf("unsupported alert kind %q", input.Kind)
+	}
+
+	switch types.AlertChannel(input.Channel) {
+	case types.AlertEmail:
+		if input.WebhookURL != nil {
+			return errors.New("a webhook URL can only be set for the WEBHOOK channel")
+		}
+	case types.AlertWebhook:
+		if input.WebhookURL == nil {
+			return errors.New("a webhook URL is required for the WEBHOOK channel")
+		}
+		u, err := url.Parse(*input.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Newf("invalid webhook URL %q", *input.WebhookURL)
+		}
+	default:
+		return errors.Newf("unsupported alert channel %q", input.Channel)
+	}
+	return nil
+}
+
+type insightSeriesAlertResolver struct {
+	alert types.SeriesAlert
+}
+
+func (r *insightSeriesAlertResolver) ID() graphql.ID {
+	return relay.MarshalID(alertKind, r.alert.ID)
+}
+
+func (r *insightSeriesAlertResolver) SeriesId() string { return r.alert.SeriesID }
+
+func (r *insightSeriesAlertResolver) Kind() string { return string(r.alert.Kind) }
+
+func (r *insightSeriesAlertResolver) Threshold() *float64 { return r.alert.Threshold }
+
+func (r *insightSeriesAlertResolver) Channel() string { return string(r.alert.Channel) }
+
+func (r *insightSeriesAlertResolver) WebhookURL() *string { return r.alert.WebhookURL }
+
+func (r *insightSeriesAlertResolver) Firing() bool { return r.alert.Firing }
+
+func (r *insightSeriesAlertResolver) LastNotifiedAt() *graphqlbackend.DateTime {
+	return graphqlbackend.DateTimeOrNil(r.alert.LastNotifiedAt)
+}
+
+func (r *insightSeriesAlertResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.alert.CreatedAt}
+}
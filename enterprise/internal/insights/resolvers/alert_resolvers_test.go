@@ -0,0 +1,68 @@
+package resolvers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+)
+
+func TestValidateAlertInput(t *testing.T) {
+	threshold := 10.0
+	webhookURL := func(u string) *string { return &u }
+
+	cases := []struct {
+		name    string
+		input   graphqlbackend.CreateInsightSeriesAlertInput
+		wantErr string
+	}{
+		{
+			name:  "email threshold",
+			input: graphqlbackend.CreateInsightSeriesAlertInput{Kind: "ABOVE", Threshold: &threshold, Channel: "EMAIL"},
+		},
+		{
+			name:  "webhook new capture value",
+			input: graphqlbackend.CreateInsightSeriesAlertInput{Kind: "NEW_CAPTURE_VALUE", Channel: "WEBHOOK", WebhookURL: webhookURL("https://example.com/hook")},
+		},
+		{
+			name:    "missing threshold",
+			input:   graphqlbackend.CreateInsightSeriesAlertInput{Kind: "INCREASE_PERCENT", Channel: "EMAIL"},
+			wantErr: "a threshold is required",
+		},
+		{
+			name:    "unknown kind",
+			input:   graphqlbackend.CreateInsightSeriesAlertInput{Kind: "SIDEWAYS", Channel: "EMAIL"},
+			wantErr: "unsupported alert kind",
+		},
+		{
+			name:    "missing webhook URL",
+			input:   graphqlbackend.CreateInsightSeriesAlertInput{Kind: "BELOW", Threshold: &threshold, Channel: "WEBHOOK"},
+			wantErr: "a webhook URL is required",
+		},
+		{
+			name:    "invalid webhook URL",
+			input:   graphqlbackend.CreateInsightSeriesAlertInput{Kind: "BELOW", Threshold: &threshold, Channel: "WEBHOOK", WebhookURL: webhookURL("file:///etc/passwd")},
+			wantErr: "invalid webhook URL",
+		},
+		{
+			name:    "webhook URL for email",
+			input:   graphqlbackend.CreateInsightSeriesAlertInput{Kind: "BELOW", Threshold: &threshold, Channel: "EMAIL", WebhookURL: webhookURL("https://example.com/hook")},
+			wantErr: "can only be set for the WEBHOOK channel",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateAlertInput(tc.input)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -47,6 +47,22 @@ func (r *disabledResolver) UpdateInsightSeries(ctx context.Context, args *graphq
 	return nil, errors.New(r.reason)
 }
 
+func (r *disabledResolver) InsightSeriesAlerts(ctx context.Context, args *graphqlbackend.InsightSeriesAlertsArgs) ([]graphqlbackend.InsightSeriesAlertResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) CreateInsightSeriesAlert(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertArgs) (graphqlbackend.InsightSeriesAlertResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) DeleteInsightSeriesAlert(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) InsightSeriesRepositoryBreakdown(ctx context.Context, args *graphqlbackend.InsightSeriesRepositoryBreakdownArgs) (graphqlbackend.InsightSeriesRepositoryBreakdownResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
 func (r *disabledResolver) InsightSeriesQueryStatus(ctx context.Context) ([]graphqlbackend.InsightSeriesQueryStatusResolver, error) {
 	return nil, errors.New(r.reason)
 }
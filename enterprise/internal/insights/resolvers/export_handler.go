@@ -0,0 +1,167 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gorilla/mux"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/log"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/export"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewExportHandler returns the handler of GET /.api/insights/export/{id}, which streams every recorded data point of
+// an insight view or of all the insights on a dashboard, given the GraphQL ID of the view or dashboard. The format is
+// chosen with the format query parameter, either csv (the default) or json.
+func NewExportHandler(db edb.InsightsDB, postgres database.DB) http.Handler {
+	h := &exportHandler{
+		logger: log.Scoped("insightsExportHandler", "serves the code insights export API"),
+		base:   WithBase(db, postgres, timeutil.Now),
+	}
+
+	r := mux.NewRouter()
+	r.Path("/.api/insights/export/{id}").Methods("GET").HandlerFunc(h.serveExport)
+	return r
+}
+
+type exportHandler struct {
+	logger log.Logger
+	base   *baseInsightResolver
+}
+
+func (h *exportHandler) serveExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !actor.FromContext(ctx).IsAuthenticated() {
+		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	format, err := export.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	insights, err := h.exportedInsights(ctx, graphql.ID(mux.Vars(r)["id"]))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		h.logger.Error("insights export API error", log.Error(err))
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "insights-export."+string(format)))
+	w.WriteHeader(http.StatusOK)
+
+	// Once the response started the status can no longer change, so errors only stop the export. The file is then
+	// left truncated, which invalidates JSON exports.
+	rw, err := export.NewRowWriter(format, w)
+	if err != nil {
+		h.logger.Warn("failed to write insights export", log.Error(err))
+		return
+	}
+	if err := h.writeInsights(ctx, rw, insights); err != nil {
+		h.logger.Error("failed to write insights export", log.Error(err))
+		return
+	}
+	if err := rw.Close(); err != nil {
+		h.logger.Warn("failed to write insights export", log.Error(err))
+	}
+}
+
+// exportedInsights returns the insight view with the given ID, or the insights on the dashboard with the given ID, if
+// the current user can see them.
+func (h *exportHandler) exportedInsights(ctx context.Context, id graphql.ID) ([]types.Insight, error) {
+	permissionsValidator := PermissionsValidatorFromBase(h.base)
+
+	switch relay.UnmarshalKind(id) {
+	case "insight_view":
+		var viewID string
+		if err := relay.UnmarshalSpec(id, &viewID); err != nil {
+			return nil, &exportNotFoundError{id: id}
+		}
+		if err := permissionsValidator.validateUserAccessForView(ctx, viewID); err != nil {
+			return nil, &exportNotFoundError{id: id}
+		}
+		return h.base.insightStore.GetMapped(ctx, store.InsightQueryArgs{WithoutAuthorization: true, UniqueID: viewID})
+
+	case dashboardKind:
+		dashboardID, err := unmarshalDashboardID(id)
+		if err != nil || !dashboardID.isReal() {
+			// Virtual dashboards are generated views over all the insights of a user or organization, which are not
+			// supported by exports.
+			return nil, &exportNotFoundError{id: id}
+		}
+		if err := permissionsValidator.validateUserAccessForDashboard(ctx, int(dashboardID.Arg)); err != nil {
+			return nil, &exportNotFoundError{id: id}
+		}
+		viewSeries, err := h.base.insightStore.GetAllOnDashboard(ctx, store.InsightsOnDashboardQueryArgs{DashboardID: int(dashboardID.Arg)})
+		if err != nil {
+			return nil, errors.Wrap(err, "GetAllOnDashboard")
+		}
+		insights := h.base.insightStore.GroupByView(ctx, viewSeries)
+		sort.Slice(insights, func(i, j int) bool {
+			return insights[i].DashboardViewId < insights[j].DashboardViewId
+		})
+		return insights, nil
+	}
+
+	return nil, &exportNotFoundError{id: id}
+}
+
+func (h *exportHandler) writeInsights(ctx context.Context, rw export.RowWriter, insights []types.Insight) error {
+	db := database.NewDBWith(h.logger, h.base.workerBaseStore)
+	for _, insight := range insights {
+		for _, series := range insight.Series {
+			if series.JustInTime {
+				// Just in time series are computed on every request and have no recorded points.
+				continue
+			}
+
+			opts, err := getRecordedSeriesPointOpts(ctx, db, series, insight.Filters)
+			if err != nil {
+				return errors.Wrap(err, "getRecordedSeriesPointOpts")
+			}
+			// Unlike the GraphQL API, exports include every recorded point and not only the recent ones.
+			opts.From = nil
+
+			// 🚨 SECURITY: ExportSeriesPoints drops the points of repositories the current user cannot access.
+			err = h.base.timeSeriesStore.ExportSeriesPoints(ctx, *opts, func(point store.RepoSeriesPoint) error {
+				return rw.WriteRow(export.NewRow(insight, series, point))
+			})
+			if err != nil {
+				return errors.Wrapf(err, "ExportSeriesPoints for series %q", series.SeriesID)
+			}
+		}
+	}
+	return nil
+}
+
+// exportNotFoundError is returned for insights and dashboards that do not exist or that the current user cannot see.
+// Both cases return the same error to not leak the existence of insights.
+type exportNotFoundError struct {
+	id graphql.ID
+}
+
+func (e *exportNotFoundError) Error() string {
+	return fmt.Sprintf("insight or dashboard %q not found", e.id)
+}
+
+func (e *exportNotFoundError) NotFound() bool { return true }
@@ -0,0 +1,121 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var _ graphqlbackend.InsightSeriesRepositoryBreakdownResolver = &repositoryBreakdownResolver{}
+var _ graphqlbackend.InsightSeriesRepositoryContributionResolver = &repositoryContributionResolver{}
+
+func (r *Resolver) InsightSeriesRepositoryBreakdown(ctx context.Context, args *graphqlbackend.InsightSeriesRepositoryBreakdownArgs) (graphqlbackend.InsightSeriesRepositoryBreakdownResolver, error) {
+	if args.To.Before(args.From.Time) {
+		return nil, errors.New("from must not be after to")
+	}
+	if args.First < 0 {
+		return nil, errors.New("first must not be negative")
+	}
+
+	view, err := r.accessibleInsightView(ctx, args.InsightViewId)
+	if err != nil {
+		return nil, err
+	}
+	var series *types.InsightViewSeries
+	for _, s := range view.Series {
+		if s.SeriesID == args.SeriesId {
+			series = &s
+			break
+		}
+	}
+	if series == nil {
+		return nil, errors.Newf("series %q not found in insight", args.SeriesId)
+	}
+	if series.JustInTime {
+		return nil, errors.New("repository breakdowns are not supported on series that are not recorded in the background")
+	}
+
+	opts, err := getRecordedSeriesPointOpts(ctx, database.NewDBWith(r.logger, r.workerBaseStore), *series, view.Filters)
+	if err != nil {
+		return nil, errors.Wrap(err, "getRecordedSeriesPointOpts")
+	}
+	// 🚨 SECURITY: RepoSeriesDiff drops the points of repositories the current user cannot access.
+	diff, err := r.baseInsightResolver.timeSeriesStore.RepoSeriesDiff(ctx, *opts, args.From.Time, args.To.Time)
+	if err != nil {
+		return nil, errors.Wrap(err, "RepoSeriesDiff")
+	}
+
+	return newRepositoryBreakdownResolver(diff, int(args.First), args.IncludeUnchanged), nil
+}
+
+func newRepositoryBreakdownResolver(diff store.RepoSeriesDiff, first int, includeUnchanged bool) *repositoryBreakdownResolver {
+	resolver := &repositoryBreakdownResolver{
+		from:  diff.From,
+		to:    diff.To,
+		nodes: []graphqlbackend.InsightSeriesRepositoryContributionResolver{},
+	}
+	for _, repo := range diff.Repos {
+		// The totals include unchanged repositories, so that they match the values of the series.
+		resolver.fromValue += repo.FromValue
+		resolver.toValue += repo.ToValue
+
+		if repo.Delta() == 0 && !includeUnchanged {
+			continue
+		}
+		resolver.totalCount++
+		if len(resolver.nodes) < first {
+			resolver.nodes = append(resolver.nodes, &repositoryContributionResolver{delta: repo})
+		}
+	}
+	return resolver
+}
+
+type repositoryBreakdownResolver struct {
+	from, to           *time.Time
+	fromValue, toValue float64
+	nodes              []graphqlbackend.InsightSeriesRepositoryContributionResolver
+	totalCount         int32
+}
+
+func (r *repositoryBreakdownResolver) From() *graphqlbackend.DateTime {
+	return graphqlbackend.DateTimeOrNil(r.from)
+}
+
+func (r *repositoryBreakdownResolver) To() *graphqlbackend.DateTime {
+	return graphqlbackend.DateTimeOrNil(r.to)
+}
+
+func (r *repositoryBreakdownResolver) FromValue() float64 { return r.fromValue }
+
+func (r *repositoryBreakdownResolver) ToValue() float64 { return r.toValue }
+
+func (r *repositoryBreakdownResolver) Nodes() []graphqlbackend.InsightSeriesRepositoryContributionResolver {
+	return r.nodes
+}
+
+func (r *repositoryBreakdownResolver) TotalCount() int32 { return r.totalCount }
+
+type repositoryContributionResolver struct {
+	delta store.RepoSeriesDelta
+}
+
+func (r *repositoryContributionResolver) RepositoryId() graphql.ID {
+	return graphqlbackend.MarshalRepositoryID(r.delta.RepoID)
+}
+
+func (r *repositoryContributionResolver) RepositoryName() string { return r.delta.RepoName }
+
+func (r *repositoryContributionResolver) Capture() *string { return r.delta.Capture }
+
+func (r *repositoryContributionResolver) FromValue() float64 { return r.delta.FromValue }
+
+func (r *repositoryContributionResolver) ToValue() float64 { return r.delta.ToValue }
+
+func (r *repositoryContributionResolver) Delta() float64 { return r.delta.Delta() }
@@ -0,0 +1,83 @@
+package resolvers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+)
+
+func TestNewRepositoryBreakdownResolver(t *testing.T) {
+	from := time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 7, 8, 0, 0, 0, 0, time.UTC)
+	diff := store.RepoSeriesDiff{
+		From: &from,
+		To:   &to,
+		Repos: []store.RepoSeriesDelta{
+			{RepoID: 1, RepoName: "a", FromValue: 10, ToValue: 3},
+			{RepoID: 2, RepoName: "b", FromValue: 0, ToValue: 2},
+			{RepoID: 3, RepoName: "c", FromValue: 4, ToValue: 4},
+		},
+	}
+
+	type contribution struct {
+		Name  string
+		Delta float64
+	}
+	contributions := func(r *repositoryBreakdownResolver) []contribution {
+		var got []contribution
+		for _, node := range r.Nodes() {
+			got = append(got, contribution{node.RepositoryName(), node.Delta()})
+		}
+		return got
+	}
+
+	cases := []struct {
+		name             string
+		first            int
+		includeUnchanged bool
+		want             []contribution
+		wantTotalCount   int32
+	}{
+		{
+			name:           "changed repositories",
+			first:          50,
+			want:           []contribution{{"a", -7}, {"b", 2}},
+			wantTotalCount: 2,
+		},
+		{
+			name:             "include unchanged",
+			first:            50,
+			includeUnchanged: true,
+			want:             []contribution{{"a", -7}, {"b", 2}, {"c", 0}},
+			wantTotalCount:   3,
+		},
+		{
+			name:           "first",
+			first:          1,
+			want:           []contribution{{"a", -7}},
+			wantTotalCount: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newRepositoryBreakdownResolver(diff, tc.first, tc.includeUnchanged)
+			if diff := cmp.Diff(tc.want, contributions(r)); diff != "" {
+				t.Errorf("unexpected nodes (-want +got):\n%s", diff)
+			}
+			if r.TotalCount() != tc.wantTotalCount {
+				t.Errorf("unexpected total count: want %d, got %d", tc.wantTotalCount, r.TotalCount())
+			}
+			// Totals always include every repository, to match the values of the series.
+			if r.FromValue() != 14 || r.ToValue() != 9 {
+				t.Errorf("unexpected totals: from %v, to %v", r.FromValue(), r.ToValue())
+			}
+			if !r.From().Time.Equal(from) || !r.To().Time.Equal(to) {
+				t.Errorf("unexpected times: from %v, to %v", r.From(), r.To())
+			}
+		})
+	}
+}
@@ -27,6 +27,7 @@ type baseInsightResolver struct {
 	insightStore    *store.InsightStore
 	timeSeriesStore *store.Store
 	dashboardStore  *store.DBDashboardStore
+	alertStore      *store.AlertStore
 	workerBaseStore *basestore.Store
 
 	// including the DB references for any one off stores that may need to be created.
@@ -38,12 +39,14 @@ func WithBase(insightsDB edb.InsightsDB, primaryDB database.DB, clock func() tim
 	insightStore := store.NewInsightStore(insightsDB)
 	timeSeriesStore := store.NewWithClock(insightsDB, store.NewInsightPermissionStore(primaryDB), clock)
 	dashboardStore := store.NewDashboardStore(insightsDB)
+	alertStore := store.NewAlertStore(insightsDB)
 	workerBaseStore := basestore.NewWithHandle(primaryDB.Handle())
 
 	return &baseInsightResolver{
 		insightStore:    insightStore,
 		timeSeriesStore: timeSeriesStore,
 		dashboardStore:  dashboardStore,
+		alertStore:      alertStore,
 		workerBaseStore: workerBaseStore,
 		insightsDB:      insightsDB,
 		postgresDB:      primaryDB,
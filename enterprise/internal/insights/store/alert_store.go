@@ -0,0 +1,179 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// AlertStore persists the alert rules that are evaluated against insight series.
+type AlertStore struct {
+	*basestore.Store
+	Now func() time.Time
+}
+
+// NewAlertStore returns a new AlertStore backed by the given Postgres db.
+func NewAlertStore(db edb.InsightsDB) *AlertStore {
+	return &AlertStore{Store: basestore.NewWithHandle(db.Handle()), Now: time.Now}
+}
+
+// NewAlertStoreWith returns a new AlertStore backed by the given Postgres db.
+func NewAlertStoreWith(other basestore.ShareableStore) *AlertStore {
+	return &AlertStore{Store: basestore.NewWithHandle(other.Handle()), Now: time.Now}
+}
+
+// With creates a new AlertStore with the given basestore.Shareable store as the underlying basestore.Store.
+// Needed to implement the basestore.Store interface
+func (s *AlertStore) With(other basestore.ShareableStore) *AlertStore {
+	return &AlertStore{Store: s.Store.With(other), Now: s.Now}
+}
+
+func (s *AlertStore) Transact(ctx context.Context) (*AlertStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &AlertStore{Store: txBase, Now: s.Now}, err
+}
+
+// AlertQueryArgs contains query predicates for fetching alerts. Any provided values will be included as query
+// arguments.
+type AlertQueryArgs struct {
+	ID              int
+	InsightViewID   int
+	InsightSeriesID int
+	UserID          int32
+}
+
+// GetAlerts returns the alerts matching args.
+func (s *AlertStore) GetAlerts(ctx context.Context, args AlertQueryArgs) ([]types.SeriesAlert, error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if args.ID > 0 {
+		preds = append(preds, sqlf.Sprintf("a.id = %s", args.ID))
+	}
+	if args.InsightViewID > 0 {
+		preds = append(preds, sqlf.Sprintf("a.insight_view_id = %s", args.InsightViewID))
+	}
+	if args.InsightSeriesID > 0 {
+		preds = append(preds, sqlf.Sprintf("a.insight_series_id = %s", args.InsightSeriesID))
+	}
+	if args.UserID > 0 {
+		preds = append(preds, sqlf.Sprintf("a.user_id = %s", args.UserID))
+	}
+
+	q := sqlf.Sprintf(getAlertsSql, sqlf.Join(preds, "\n AND "))
+	return scanAlerts(s.Query(ctx, q))
+}
+
+// CreateAlert inserts a new alert and returns it as stored.
+func (s *AlertStore) CreateAlert(ctx context.Context, alert types.SeriesAlert) (types.SeriesAlert, error) {
+	id, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(insertAlertSql,
+		alert.InsightViewID,
+		alert.InsightSeriesID,
+		alert.UserID,
+		alert.Kind,
+		alert.Threshold,
+		alert.Channel,
+		alert.WebhookURL,
+		s.Now(),
+	)))
+	if err != nil {
+		return types.SeriesAlert{}, errors.Wrap(err, "CreateAlert")
+	}
+
+	alerts, err := s.GetAlerts(ctx, AlertQueryArgs{ID: id})
+	if err != nil {
+		return types.SeriesAlert{}, err
+	}
+	if len(alerts) != 1 {
+		return types.SeriesAlert{}, errors.Newf("alert %d not found after insert", id)
+	}
+	return alerts[0], nil
+}
+
+// DeleteAlert permanently deletes the alert with the given id.
+func (s *AlertStore) DeleteAlert(ctx context.Context, id int) error {
+	err := s.Exec(ctx, sqlf.Sprintf(deleteAlertSql, id))
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete alert with id: %d", id)
+	}
+	return nil
+}
+
+// SetAlertFiring records the result of evaluating an alert. notified should be true if a notification was sent
+// for this evaluation.
+func (s *AlertStore) SetAlertFiring(ctx context.Context, id int, firing, notified bool) error {
+	notifiedAt := sqlf.Sprintf("last_notified_at")
+	if notified {
+		notifiedAt = sqlf.Sprintf("%s", s.Now())
+	}
+	return s.Exec(ctx, sqlf.Sprintf(setAlertFiringSql, firing, notifiedAt, id))
+}
+
+func scanAlerts(rows *sql.Rows, queryErr error) (_ []types.SeriesAlert, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	results := make([]types.SeriesAlert, 0)
+	for rows.Next() {
+		var temp types.SeriesAlert
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.InsightViewID,
+			&temp.ViewUniqueID,
+			&temp.ViewTitle,
+			&temp.InsightSeriesID,
+			&temp.SeriesID,
+			&temp.Label,
+			&temp.UserID,
+			&temp.Kind,
+			&temp.Threshold,
+			&temp.Channel,
+			&temp.WebhookURL,
+			&temp.Firing,
+			&temp.CreatedAt,
+			&temp.LastNotifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, temp)
+	}
+	return results, nil
+}
+
+const getAlertsSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:GetAlerts
+SELECT a.id, a.insight_view_id, iv.unique_id, COALESCE(iv.title, ''), a.insight_series_id, i.series_id,
+	COALESCE(ivs.label, ''), a.user_id, a.kind, a.threshold, a.channel, a.webhook_url, a.firing,
+	a.created_at, a.last_notified_at
+FROM insight_series_alerts a
+         JOIN insight_view iv ON a.insight_view_id = iv.id
+         JOIN insight_series i ON a.insight_series_id = i.id
+         LEFT JOIN insight_view_series ivs ON ivs.insight_view_id = a.insight_view_id
+    AND ivs.insight_series_id = a.insight_series_id
+WHERE %s
+ORDER BY a.id;
+`
+
+const insertAlertSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:CreateAlert
+INSERT INTO insight_series_alerts (insight_view_id, insight_series_id, user_id, kind, threshold, channel, webhook_url, created_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING id;
+`
+
+const deleteAlertSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:DeleteAlert
+DELETE FROM insight_series_alerts WHERE id = %s;
+`
+
+const setAlertFiringSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:SetAlertFiring
+UPDATE insight_series_alerts SET firing = %s, last_notified_at = %s WHERE id = %s;
+`
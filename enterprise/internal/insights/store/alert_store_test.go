@@ -0,0 +1,128 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestAlertStore(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	now := time.Now().Truncate(time.Microsecond).Round(0).UTC()
+	ctx := context.Background()
+
+	insightStore := NewInsightStore(insightsDB)
+	alertStore := NewAlertStore(insightsDB)
+	alertStore.Now = func() time.Time { return now }
+
+	view, err := insightStore.CreateView(ctx, types.InsightView{
+		Title:            "migration",
+		UniqueID:         "migration-view",
+		PresentationType: types.Line,
+	}, []InsightViewGrant{GlobalGrant()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	series, err := insightStore.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:           "series1",
+		Query:              "query1",
+		CreatedAt:          now,
+		OldestHistoricalAt: now,
+		LastRecordedAt:     now,
+		NextRecordingAfter: now,
+		LastSnapshotAt:     now,
+		NextSnapshotAfter:  now,
+		BackfillQueuedAt:   now,
+		SampleIntervalUnit: string(types.Week),
+		GenerationMethod:   types.Search,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = insightStore.AttachSeriesToView(ctx, series, view, types.InsightViewSeriesMetadata{Label: "old API calls"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	threshold := 100.0
+	created, err := alertStore.CreateAlert(ctx, types.SeriesAlert{
+		InsightViewID:   view.ID,
+		InsightSeriesID: series.ID,
+		UserID:          1,
+		Kind:            types.AlertAbove,
+		Threshold:       &threshold,
+		Channel:         types.AlertEmail,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.ViewUniqueID != "migration-view" || created.ViewTitle != "migration" || created.SeriesID != "series1" || created.Label != "old API calls" {
+		t.Fatalf("unexpected alert view and series: %+v", created)
+	}
+	if created.Threshold == nil || *created.Threshold != threshold || created.Firing || created.LastNotifiedAt != nil {
+		t.Fatalf("unexpected alert state: %+v", created)
+	}
+
+	t.Run("filter by series", func(t *testing.T) {
+		got, err := alertStore.GetAlerts(ctx, AlertQueryArgs{InsightSeriesID: series.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || got[0].ID != created.ID {
+			t.Fatalf("unexpected alerts: %+v", got)
+		}
+
+		got, err = alertStore.GetAlerts(ctx, AlertQueryArgs{InsightSeriesID: series.ID, UserID: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 0 {
+			t.Fatalf("expected no alerts for user 2, got %+v", got)
+		}
+	})
+
+	t.Run("set firing", func(t *testing.T) {
+		if err := alertStore.SetAlertFiring(ctx, created.ID, true, true); err != nil {
+			t.Fatal(err)
+		}
+		got, err := alertStore.GetAlerts(ctx, AlertQueryArgs{ID: created.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got[0].Firing || got[0].LastNotifiedAt == nil || !got[0].LastNotifiedAt.Equal(now) {
+			t.Fatalf("unexpected alert state: %+v", got[0])
+		}
+
+		// Clearing the firing state keeps the last notification time.
+		if err := alertStore.SetAlertFiring(ctx, created.ID, false, false); err != nil {
+			t.Fatal(err)
+		}
+		got, err = alertStore.GetAlerts(ctx, AlertQueryArgs{ID: created.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got[0].Firing || got[0].LastNotifiedAt == nil {
+			t.Fatalf("unexpected alert state: %+v", got[0])
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := alertStore.DeleteAlert(ctx, created.ID); err != nil {
+			t.Fatal(err)
+		}
+		got, err := alertStore.GetAlerts(ctx, AlertQueryArgs{ID: created.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 0 {
+			t.Fatalf("expected alert to be deleted, got %+v", got)
+		}
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -153,6 +155,167 @@ func (s *Store) SeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]Seri
 	return points, err
 }
 
+// RepoSeriesPoint is the value of a series in a single repository at a point in time.
+type RepoSeriesPoint struct {
+	// Time (always UTC).
+	SeriesID string
+	Time     time.Time
+	RepoID   api.RepoID
+	RepoName string
+	Value    float64
+	Capture  *string
+}
+
+// ExportSeriesPoints calls fn with every data point matching opts broken down per repository, ordered by time. The
+// points are filtered with the same repository permissions as SeriesPoints. Limit is ignored.
+func (s *Store) ExportSeriesPoints(ctx context.Context, opts SeriesPointsOpts, fn func(RepoSeriesPoint) error) error {
+	// 🚨 SECURITY: See SeriesPoints for the details of this permission enforcement. Exported points must never include
+	// repositories the current user cannot see. 🚨
+	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
+	if err != nil {
+		return err
+	}
+	opts.Excluded = append(opts.Excluded, denylist...)
+
+	q := sqlf.Sprintf(exportSeriesPointsSql, sqlf.Join(seriesPointsPredicates(opts), "\n AND "))
+	return s.query(ctx, q, func(sc scanner) error {
+		var point RepoSeriesPoint
+		if err := sc.Scan(
+			&point.SeriesID,
+			&point.Time,
+			&point.RepoID,
+			&point.RepoName,
+			&point.Value,
+			&point.Capture,
+		); err != nil {
+			return err
+		}
+		return fn(point)
+	})
+}
+
+// As in fullVectorSeriesAggregation, the maximum of duplicate points recorded for a repository at a given time is used.
+const exportSeriesPointsSql = `
+-- source: enterprise/internal/insights/store/store.go:ExportSeriesPoints
+SELECT sp.series_id, sp.time, sp.repo_id, rn.name, MAX(sp.value) AS value, sp.capture
+FROM (  select * from series_points
+		union
+		select * from series_points_snapshots
+) AS sp
+JOIN repo_names rn ON sp.repo_name_id = rn.id
+WHERE %s
+GROUP BY sp.series_id, sp.time, sp.repo_id, rn.name, sp.capture
+ORDER BY sp.series_id, sp.time, rn.name, sp.capture
+`
+
+// RepoSeriesDelta is the change of the value of a series in a single repository between two points in time.
+type RepoSeriesDelta struct {
+	RepoID   api.RepoID
+	RepoName string
+	Capture  *string
+
+	// FromValue and ToValue are 0 if the repository has no value at the respective point in time.
+	FromValue float64
+	ToValue   float64
+}
+
+func (d RepoSeriesDelta) Delta() float64 {
+	return d.ToValue - d.FromValue
+}
+
+// RepoSeriesDiff is the per repository difference between two points of a series.
+type RepoSeriesDiff struct {
+	// From and To are the times of the points that were compared, which are the latest points recorded at or before
+	// the requested times. They are nil if there is no such point.
+	From *time.Time
+	To   *time.Time
+
+	// Repos are ordered by decreasing absolute delta, then by repository name.
+	Repos []RepoSeriesDelta
+}
+
+// RepoSeriesDiff returns the contribution of every repository to the points of a series at from and to, where a
+// point is the latest one recorded at or before the given time. The points are filtered with the same repository
+// permissions as SeriesPoints. Limit, From and To are ignored.
+func (s *Store) RepoSeriesDiff(ctx context.Context, opts SeriesPointsOpts, from, to time.Time) (RepoSeriesDiff, error) {
+	// 🚨 SECURITY: See SeriesPoints for the details of this permission enforcement. The difference must never include
+	// repositories the current user cannot see. 🚨
+	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
+	if err != nil {
+		return RepoSeriesDiff{}, err
+	}
+	opts.Excluded = append(opts.Excluded, denylist...)
+	opts.From, opts.To = nil, nil
+
+	q := sqlf.Sprintf(repoSeriesDiffSql, sqlf.Join(seriesPointsPredicates(opts), "\n AND "), from, to)
+
+	var diff RepoSeriesDiff
+	err = s.query(ctx, q, func(sc scanner) error {
+		var delta RepoSeriesDelta
+		var repoID *api.RepoID
+		var repoName *string
+		if err := sc.Scan(
+			&diff.From,
+			&diff.To,
+			&repoID,
+			&repoName,
+			&delta.Capture,
+			&delta.FromValue,
+			&delta.ToValue,
+		); err != nil {
+			return err
+		}
+		// Without any point at from or to the query returns a single row of nulls.
+		if repoID == nil {
+			return nil
+		}
+		delta.RepoID, delta.RepoName = *repoID, *repoName
+		diff.Repos = append(diff.Repos, delta)
+		return nil
+	})
+	if err != nil {
+		return RepoSeriesDiff{}, err
+	}
+
+	// The rows are ordered by repository name, so the order of repositories with equal deltas is stable.
+	sort.SliceStable(diff.Repos, func(i, j int) bool {
+		return math.Abs(diff.Repos[i].Delta()) > math.Abs(diff.Repos[j].Delta())
+	})
+	return diff, nil
+}
+
+// As in fullVectorSeriesAggregation, the maximum of duplicate points recorded for a repository at a given time is used.
+const repoSeriesDiffSql = `
+-- source: enterprise/internal/insights/store/store.go:RepoSeriesDiff
+WITH points AS (
+	SELECT sp.time, sp.repo_id, rn.name, sp.capture, MAX(sp.value) AS value
+	FROM (  select * from series_points
+			union
+			select * from series_points_snapshots
+	) AS sp
+	JOIN repo_names rn ON sp.repo_name_id = rn.id
+	WHERE %s
+	GROUP BY sp.time, sp.repo_id, rn.name, sp.capture
+),
+times AS (
+	SELECT
+		(SELECT MAX(time) FROM points WHERE time <= %s) AS from_time,
+		(SELECT MAX(time) FROM points WHERE time <= %s) AS to_time
+)
+SELECT
+	times.from_time,
+	times.to_time,
+	p.repo_id,
+	p.name,
+	p.capture,
+	COALESCE(MAX(p.value) FILTER (WHERE p.time = times.from_time), 0) AS from_value,
+	COALESCE(MAX(p.value) FILTER (WHERE p.time = times.to_time), 0) AS to_value
+FROM times
+LEFT JOIN points p ON p.time = times.from_time OR p.time = times.to_time
+GROUP BY times.from_time, times.to_time, p.repo_id, p.name, p.capture
+ORDER BY p.name, p.capture
+`
+
 // Delete will delete the time series data for a particular series_id. This will hard (permanently) delete the data.
 func (s *Store) Delete(ctx context.Context, seriesId string) (err error) {
 	tx, err := s.Transact(ctx)
@@ -215,6 +378,18 @@ ORDER BY sub.series_id, sub.interval_time ASC
 // 3. Searches may not complete at the same exact time, so even in a perfect world if the interval
 //    should be 12h it may be off by a minute or so.
 func seriesPointsQuery(opts SeriesPointsOpts) *sqlf.Query {
+	limitClause := ""
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+	return sqlf.Sprintf(
+		fullVectorSeriesAggregation+limitClause,
+		sqlf.Join(seriesPointsPredicates(opts), "\n AND "),
+	)
+}
+
+// seriesPointsPredicates returns the conditions on series points (and the repo_names table as rn) described by opts.
+func seriesPointsPredicates(opts SeriesPointsOpts) []*sqlf.Query {
 	preds := []*sqlf.Query{}
 
 	if opts.SeriesID != nil {
@@ -229,10 +404,6 @@ func seriesPointsQuery(opts SeriesPointsOpts) *sqlf.Query {
 	if opts.To != nil {
 		preds = append(preds, sqlf.Sprintf("time <= %s", *opts.To))
 	}
-	limitClause := ""
-	if opts.Limit > 0 {
-		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
-	}
 	if len(opts.Included) > 0 {
 		s := fmt.Sprintf("repo_id = any(%v)", values(opts.Included))
 		preds = append(preds, sqlf.Sprintf(s))
@@ -261,10 +432,7 @@ func seriesPointsQuery(opts SeriesPointsOpts) *sqlf.Query {
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
-	return sqlf.Sprintf(
-		fullVectorSeriesAggregation+limitClause,
-		sqlf.Join(preds, "\n AND "),
-	)
+	return preds
 }
 
 //values constructs a SQL values statement out of an array of repository ids
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/hexops/autogold"
 	"github.com/keegancsmith/sqlf"
 	"github.com/sourcegraph/log/logtest"
@@ -531,6 +532,173 @@ func TestDeleteSnapshots(t *testing.T) {
 	autogold.Equal(t, points, autogold.ExportedOnly())
 }
 
+func TestExportSeriesPoints(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	clock := timeutil.Now
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	permStore := NewInsightPermissionStore(postgres)
+	store := NewWithClock(insightsDB, permStore, clock)
+
+	optionalString := func(v string) *string { return &v }
+	optionalRepoID := func(v api.RepoID) *api.RepoID { return &v }
+
+	current := time.Date(2021, time.September, 10, 10, 0, 0, 0, time.UTC)
+	seriesID := "one"
+	for _, record := range []RecordSeriesPointArgs{
+		{
+			SeriesID:    seriesID,
+			Point:       SeriesPoint{Time: current, Value: 1},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(3),
+			PersistMode: RecordMode,
+		},
+		{
+			SeriesID:    seriesID,
+			Point:       SeriesPoint{Time: current, Value: 2, Capture: optionalString("1.18")},
+			RepoName:    optionalString("repo2"),
+			RepoID:      optionalRepoID(4),
+			PersistMode: RecordMode,
+		},
+		{
+			SeriesID:    seriesID,
+			Point:       SeriesPoint{Time: current.Add(time.Hour), Value: 5},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(3),
+			PersistMode: SnapshotMode,
+		},
+		{
+			SeriesID:    "two",
+			Point:       SeriesPoint{Time: current, Value: 7},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(3),
+			PersistMode: RecordMode,
+		},
+	} {
+		if err := store.RecordSeriesPoint(ctx, record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	export := func(opts SeriesPointsOpts) []RepoSeriesPoint {
+		var points []RepoSeriesPoint
+		err := store.ExportSeriesPoints(ctx, opts, func(point RepoSeriesPoint) error {
+			points = append(points, point)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return points
+	}
+
+	t.Run("all points of a series", func(t *testing.T) {
+		want := []RepoSeriesPoint{
+			{SeriesID: seriesID, Time: current, RepoID: 3, RepoName: "repo1", Value: 1},
+			{SeriesID: seriesID, Time: current, RepoID: 4, RepoName: "repo2", Value: 2, Capture: optionalString("1.18")},
+			{SeriesID: seriesID, Time: current.Add(time.Hour), RepoID: 3, RepoName: "repo1", Value: 5},
+		}
+		got := export(SeriesPointsOpts{SeriesID: &seriesID})
+		if diff := cmp.Diff(want, got, cmpopts.EquateApproxTime(0)); diff != "" {
+			t.Errorf("unexpected points (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("repository filters", func(t *testing.T) {
+		want := []RepoSeriesPoint{
+			{SeriesID: seriesID, Time: current, RepoID: 4, RepoName: "repo2", Value: 2, Capture: optionalString("1.18")},
+		}
+		got := export(SeriesPointsOpts{SeriesID: &seriesID, ExcludeRepoRegex: []string{"repo1"}})
+		if diff := cmp.Diff(want, got, cmpopts.EquateApproxTime(0)); diff != "" {
+			t.Errorf("unexpected points (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestRepoSeriesDiff(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	clock := timeutil.Now
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	permStore := NewInsightPermissionStore(postgres)
+	store := NewWithClock(insightsDB, permStore, clock)
+
+	optionalString := func(v string) *string { return &v }
+	optionalRepoID := func(v api.RepoID) *api.RepoID { return &v }
+
+	first := time.Date(2021, time.September, 1, 0, 0, 0, 0, time.UTC)
+	second := time.Date(2021, time.September, 8, 0, 0, 0, 0, time.UTC)
+	seriesID := "one"
+	for _, record := range []RecordSeriesPointArgs{
+		{SeriesID: seriesID, Point: SeriesPoint{Time: first, Value: 10}, RepoName: optionalString("repo1"), RepoID: optionalRepoID(1), PersistMode: RecordMode},
+		{SeriesID: seriesID, Point: SeriesPoint{Time: first, Value: 4}, RepoName: optionalString("repo2"), RepoID: optionalRepoID(2), PersistMode: RecordMode},
+		{SeriesID: seriesID, Point: SeriesPoint{Time: first, Value: 1}, RepoName: optionalString("repo3"), RepoID: optionalRepoID(3), PersistMode: RecordMode},
+		{SeriesID: seriesID, Point: SeriesPoint{Time: second, Value: 3}, RepoName: optionalString("repo1"), RepoID: optionalRepoID(1), PersistMode: RecordMode},
+		{SeriesID: seriesID, Point: SeriesPoint{Time: second, Value: 4}, RepoName: optionalString("repo2"), RepoID: optionalRepoID(2), PersistMode: RecordMode},
+		{SeriesID: seriesID, Point: SeriesPoint{Time: second, Value: 2}, RepoName: optionalString("repo4"), RepoID: optionalRepoID(4), PersistMode: RecordMode},
+		{SeriesID: "two", Point: SeriesPoint{Time: second, Value: 100}, RepoName: optionalString("repo1"), RepoID: optionalRepoID(1), PersistMode: RecordMode},
+	} {
+		if err := store.RecordSeriesPoint(ctx, record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("between two points", func(t *testing.T) {
+		// The requested times are after the recorded points, which are the latest ones at or before them.
+		got, err := store.RepoSeriesDiff(ctx, SeriesPointsOpts{SeriesID: &seriesID}, first.Add(time.Hour), second.Add(time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := RepoSeriesDiff{
+			From: &first,
+			To:   &second,
+			Repos: []RepoSeriesDelta{
+				{RepoID: 1, RepoName: "repo1", FromValue: 10, ToValue: 3},
+				{RepoID: 4, RepoName: "repo4", FromValue: 0, ToValue: 2},
+				{RepoID: 3, RepoName: "repo3", FromValue: 1, ToValue: 0},
+				{RepoID: 2, RepoName: "repo2", FromValue: 4, ToValue: 4},
+			},
+		}
+		if diff := cmp.Diff(want, got, cmpopts.EquateApproxTime(0)); diff != "" {
+			t.Errorf("unexpected diff (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("no point before from", func(t *testing.T) {
+		got, err := store.RepoSeriesDiff(ctx, SeriesPointsOpts{SeriesID: &seriesID, IncludeRepoRegex: []string{"repo4"}}, first.Add(-time.Hour), second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := RepoSeriesDiff{
+			To:    &second,
+			Repos: []RepoSeriesDelta{{RepoID: 4, RepoName: "repo4", FromValue: 0, ToValue: 2}},
+		}
+		if diff := cmp.Diff(want, got, cmpopts.EquateApproxTime(0)); diff != "" {
+			t.Errorf("unexpected diff (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("no points", func(t *testing.T) {
+		got, err := store.RepoSeriesDiff(ctx, SeriesPointsOpts{SeriesID: &seriesID}, first.Add(-2*time.Hour), first.Add(-time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(RepoSeriesDiff{}, got); diff != "" {
+			t.Errorf("unexpected diff (-want +got):\n%s", diff)
+		}
+	})
+}
+
 func TestValues(t *testing.T) {
 	ids := []api.RepoID{1, 2, 3, 4, 5, 6}
 	got := values(ids)
@@ -198,3 +198,40 @@ type SeriesSortOptions struct {
 	Mode      SeriesSortMode
 	Direction SeriesSortDirection
 }
+
+// AlertKind is the condition that triggers an alert on a series. This is effectively an enum of values.
+type AlertKind string
+
+const (
+	AlertAbove           AlertKind = "ABOVE"             // The latest value is above the threshold.
+	AlertBelow           AlertKind = "BELOW"             // The latest value is below the threshold.
+	AlertIncreasePercent AlertKind = "INCREASE_PERCENT"  // The latest value increased by at least threshold percent week-over-week.
+	AlertNewCaptureValue AlertKind = "NEW_CAPTURE_VALUE" // The latest point contains a capture group value never seen before.
+)
+
+// AlertChannel is how an alert notifies when it triggers.
+type AlertChannel string
+
+const (
+	AlertEmail   AlertChannel = "EMAIL"
+	AlertWebhook AlertChannel = "WEBHOOK"
+)
+
+// SeriesAlert is a rule evaluated against a series each time a new point is recorded.
+type SeriesAlert struct {
+	ID              int
+	InsightViewID   int
+	ViewUniqueID    string
+	ViewTitle       string
+	InsightSeriesID int
+	SeriesID        string
+	Label           string
+	UserID          int32
+	Kind            AlertKind
+	Threshold       *float64
+	Channel         AlertChannel
+	WebhookURL      *string
+	Firing          bool
+	CreatedAt       time.Time
+	LastNotifiedAt  *time.Time
+}
@@ -0,0 +1,286 @@
+// Package backend implements the services notebook blocks are executed
+// against, for use by the notebooks.Executor.
+package backend
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/compute"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/symbols"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// SettingsFunc returns the settings of the current actor, which are used to
+// run the searches of query and compute blocks.
+type SettingsFunc func(ctx context.Context) (*schema.Settings, error)
+
+// blockBackend runs notebook blocks against search, compute, gitserver and
+// symbols on behalf of the current actor.
+type blockBackend struct {
+	logger          log.Logger
+	db              database.DB
+	settings        SettingsFunc
+	searchClient    client.SearchClient
+	gitserverClient *gitserver.ClientImplementor
+}
+
+var _ notebooks.BlockBackend = &blockBackend{}
+
+func New(logger log.Logger, db database.DB, settings SettingsFunc) notebooks.BlockBackend {
+	logger = logger.Scoped("notebookBlockBackend", "executes notebook blocks")
+	return &blockBackend{
+		logger:          logger,
+		db:              db,
+		settings:        settings,
+		searchClient:    client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs()),
+		gitserverClient: gitserver.NewClient(db),
+	}
+}
+
+func (b *blockBackend) Search(ctx context.Context, query string, limit int) (_ []notebooks.BlockMatch, limitHit bool, _ error) {
+	// Query blocks are executed with the same defaults as the web app.
+	patternType := "literal"
+	return b.search(ctx, "V2", patternType, query, limit, func(_ context.Context, match result.Match) ([]notebooks.BlockMatch, error) {
+		return []notebooks.BlockMatch{toBlockMatch(match)}, nil
+	})
+}
+
+func (b *blockBackend) Compute(ctx context.Context, query string, limit int) (_ []notebooks.BlockMatch, limitHit bool, _ error) {
+	computeQuery, err := compute.Parse(query)
+	if err != nil {
+		return nil, false, err
+	}
+	if _, ok := computeQuery.Command.(*compute.Count); ok {
+		return nil, false, errors.New("aggregation commands are only supported by the streaming compute endpoint")
+	}
+	searchQuery, err := computeQuery.ToSearchQuery()
+	if err != nil {
+		return nil, false, err
+	}
+
+	patternType := "regexp"
+	return b.search(ctx, "", patternType, searchQuery, limit, func(ctx context.Context, match result.Match) ([]notebooks.BlockMatch, error) {
+		results, err := toComputeResults(ctx, b.db, computeQuery.Command, match)
+		if err != nil {
+			return nil, err
+		}
+		matches := make([]notebooks.BlockMatch, 0, len(results))
+		for _, r := range results {
+			if r == nil {
+				// Replace has no result for unchanged files and non-file matches.
+				continue
+			}
+			matches = append(matches, toComputeBlockMatch(r))
+		}
+		return matches, nil
+	})
+}
+
+// search runs a search and converts up to limit of its results to block
+// matches. The search is canceled as soon as enough matches were found.
+func (b *blockBackend) search(
+	ctx context.Context,
+	version string,
+	patternType string,
+	query string,
+	limit int,
+	convert func(context.Context, result.Match) ([]notebooks.BlockMatch, error),
+) (_ []notebooks.BlockMatch, limitHit bool, _ error) {
+	settings, err := b.settings(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	inputs, err := b.searchClient.Plan(ctx, version, &patternType, query, search.Streaming, settings, envvar.SourcegraphDotComMode())
+	if err != nil {
+		return nil, false, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu         sync.Mutex
+		matches    []notebooks.BlockMatch
+		convertErr error
+	)
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if event.Stats.IsLimitHit {
+			limitHit = true
+		}
+		if convertErr != nil {
+			return
+		}
+		for _, match := range event.Results {
+			if len(matches) >= limit {
+				limitHit = true
+				cancel()
+				return
+			}
+			ms, err := convert(ctx, match)
+			if err != nil {
+				convertErr = err
+				cancel()
+				return
+			}
+			matches = append(matches, ms...)
+		}
+	})
+
+	_, err = b.searchClient.Execute(ctx, stream, inputs)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if convertErr != nil {
+		return nil, false, convertErr
+	}
+	if err != nil && !(len(matches) >= limit && errors.Is(err, context.Canceled)) {
+		return nil, false, err
+	}
+	if len(matches) > limit {
+		matches, limitHit = matches[:limit], true
+	}
+	return matches, limitHit, nil
+}
+
+// toBlockMatch converts a search result to the output of a query block.
+func toBlockMatch(match result.Match) notebooks.BlockMatch {
+	switch m := match.(type) {
+	case *result.FileMatch:
+		blockMatch := notebooks.BlockMatch{
+			Label: string(m.Repo.Name) + " › " + m.Path,
+			URL:   m.File.URL().String(),
+		}
+		if len(m.ChunkMatches) > 0 {
+			blockMatch.StartLine = m.ChunkMatches[0].ContentStart.Line + 1
+		}
+		var content strings.Builder
+		for _, chunk := range m.ChunkMatches {
+			content.WriteString(chunk.Content)
+			if !strings.HasSuffix(chunk.Content, "\n") {
+				content.WriteString("\n")
+			}
+		}
+		for _, symbol := range m.Symbols {
+			content.WriteString(symbol.Symbol.Name + "\n")
+		}
+		blockMatch.Content = content.String()
+		return blockMatch
+	case *result.RepoMatch:
+		return notebooks.BlockMatch{
+			Label: string(m.Name),
+			URL:   m.URL().String(),
+		}
+	case *result.CommitMatch:
+		blockMatch := notebooks.BlockMatch{
+			Label: string(m.Repo.Name) + " › " + m.Commit.Author.Name + ": " + m.Commit.Message.Subject(),
+			URL:   m.URL().String(),
+		}
+		if m.DiffPreview != nil {
+			blockMatch.Content = m.DiffPreview.Content
+		} else if m.MessagePreview != nil {
+			blockMatch.Content = m.MessagePreview.Content
+		}
+		return blockMatch
+	default:
+		return notebooks.BlockMatch{Label: string(match.RepoName().Name)}
+	}
+}
+
+// toComputeResults runs a compute command on a search result. Commit diffs
+// are split into one result per file.
+func toComputeResults(ctx context.Context, db database.DB, cmd compute.Command, match result.Match) (out []compute.Result, _ error) {
+	if v, ok := match.(*result.CommitMatch); ok && v.DiffPreview != nil {
+		for _, diffMatch := range v.CommitToDiffMatches() {
+			result, err := cmd.Run(ctx, db, diffMatch)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, result)
+		}
+		return out, nil
+	}
+
+	result, err := cmd.Run(ctx, db, match)
+	if err != nil {
+		return nil, err
+	}
+	return []compute.Result{result}, nil
+}
+
+// toComputeBlockMatch converts a compute result to the output of a compute
+// block.
+func toComputeBlockMatch(r compute.Result) notebooks.BlockMatch {
+	switch v := r.(type) {
+	case *compute.Text:
+		return notebooks.BlockMatch{Content: v.Value}
+	case *compute.TextExtra:
+		return notebooks.BlockMatch{Label: v.Repository, Content: v.Value}
+	case *compute.FileDiff:
+		return notebooks.BlockMatch{Label: v.Repository + " › " + v.Path, Content: v.Value}
+	case *compute.MatchContext:
+		var content strings.Builder
+		for _, m := range v.Matches {
+			content.WriteString(m.Value + "\n")
+		}
+		return notebooks.BlockMatch{Label: v.Repository + " › " + v.Path, Content: content.String()}
+	default:
+		return notebooks.BlockMatch{}
+	}
+}
+
+// checkRepoAccess returns an error if repo is not visible to the actor in
+// ctx. The repo store applies repository permissions, so a repo the actor
+// cannot access is reported as not found.
+func (b *blockBackend) checkRepoAccess(ctx context.Context, repo api.RepoName) error {
+	_, err := b.db.Repos().GetByName(ctx, repo)
+	return err
+}
+
+func (b *blockBackend) ResolveRevision(ctx context.Context, repo api.RepoName, revision string) (api.CommitID, error) {
+	if err := b.checkRepoAccess(ctx, repo); err != nil {
+		return "", err
+	}
+	return b.gitserverClient.ResolveRevision(ctx, repo, revision, gitserver.ResolveRevisionOptions{})
+}
+
+func (b *blockBackend) ReadFile(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) ([]byte, error) {
+	if err := b.checkRepoAccess(ctx, repo); err != nil {
+		return nil, err
+	}
+	return b.gitserverClient.ReadFile(ctx, repo, commit, path, authz.DefaultSubRepoPermsChecker)
+}
+
+func (b *blockBackend) Symbols(ctx context.Context, repo api.RepoName, commit api.CommitID, path, name string) (result.Symbols, error) {
+	if err := b.checkRepoAccess(ctx, repo); err != nil {
+		return nil, err
+	}
+	return symbols.DefaultClient.Search(ctx, search.SymbolsParameters{
+		Repo:            repo,
+		CommitID:        commit,
+		Query:           "^" + regexp.QuoteMeta(name) + "$",
+		IsRegExp:        true,
+		IsCaseSensitive: true,
+		IncludePatterns: []string{"^" + regexp.QuoteMeta(path) + "$"},
+		First:           100,
+	})
+}
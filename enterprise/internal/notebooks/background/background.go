@@ -0,0 +1,28 @@
+package background
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+func NewBackgroundJobs(logger log.Logger, db database.DB) []goroutine.BackgroundRoutine {
+	logger = logger.Scoped("BackgroundJobs", "notebook snapshots background jobs")
+
+	store := notebooks.Notebooks(db)
+	metrics := newMetricsForSnapshotJobs(logger)
+
+	// Create a new context. Each background routine will wrap this with
+	// a cancellable context that is canceled when Stop() is called.
+	ctx := context.Background()
+	return []goroutine.BackgroundRoutine{
+		newSnapshotJobEnqueuer(ctx, store),
+		newSnapshotDeleter(ctx, store),
+		newSnapshotRunner(ctx, logger, db, metrics),
+		newSnapshotJobResetter(ctx, store, metrics),
+	}
+}
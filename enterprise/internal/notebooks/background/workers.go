@@ -0,0 +1,137 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/viewersettings"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	snapshotRetentionInDays int = 90
+)
+
+func newSnapshotRunner(ctx context.Context, logger log.Logger, db database.DB, metrics snapshotMetrics) *workerutil.Worker {
+	options := workerutil.WorkerOptions{
+		Name:                 "notebooks_snapshot_jobs_worker",
+		NumHandlers:          2,
+		Interval:             5 * time.Second,
+		HeartbeatInterval:    15 * time.Second,
+		Metrics:              metrics.workerMetrics,
+		MaximumRuntimePerJob: 5 * time.Minute,
+	}
+	handler := &snapshotRunner{
+		db: db,
+		// The worker has no access to the settings of users, so they are
+		// queried from the frontend like code monitors do.
+		backend: backend.New(logger, db, viewersettings.Settings),
+	}
+	return dbworker.NewWorker(ctx, createDBWorkerStoreForSnapshotJobs(notebooks.Notebooks(db)), handler, options)
+}
+
+func newSnapshotJobEnqueuer(ctx context.Context, store notebooks.NotebooksStore) goroutine.BackgroundRoutine {
+	enqueueDue := goroutine.NewHandlerWithErrorMessage(
+		"notebooks_snapshot_job_enqueuer",
+		func(ctx context.Context) error {
+			_, err := store.EnqueueNotebookSnapshotJobs(ctx)
+			return err
+		})
+	return goroutine.NewPeriodicGoroutine(ctx, 1*time.Minute, enqueueDue)
+}
+
+func newSnapshotJobResetter(_ context.Context, store notebooks.NotebooksStore, metrics snapshotMetrics) *dbworker.Resetter {
+	workerStore := createDBWorkerStoreForSnapshotJobs(store)
+
+	options := dbworker.ResetterOptions{
+		Name:     "notebooks_snapshot_jobs_worker_resetter",
+		Interval: 1 * time.Minute,
+		Metrics: dbworker.ResetterMetrics{
+			Errors:              metrics.errors,
+			RecordResetFailures: metrics.resetFailures,
+			RecordResets:        metrics.resets,
+		},
+	}
+	return dbworker.NewResetter(workerStore, options)
+}
+
+func newSnapshotDeleter(ctx context.Context, store notebooks.NotebooksStore) goroutine.BackgroundRoutine {
+	deleteSnapshots := goroutine.NewHandlerWithErrorMessage(
+		"notebooks_snapshot_deleter",
+		func(ctx context.Context) error {
+			return store.DeleteOldNotebookSnapshots(ctx, snapshotRetentionInDays)
+		})
+	return goroutine.NewPeriodicGoroutine(ctx, 60*time.Minute, deleteSnapshots)
+}
+
+func createDBWorkerStoreForSnapshotJobs(s basestore.ShareableStore) dbworkerstore.Store {
+	return dbworkerstore.New(s.Handle(), dbworkerstore.Options{
+		Name:              "notebooks_snapshot_jobs_worker_store",
+		TableName:         "notebook_snapshot_jobs",
+		ColumnExpressions: notebooks.NotebookSnapshotJobColumns,
+		Scan:              notebooks.ScanNotebookSnapshotJobRecord,
+		StalledMaxAge:     60 * time.Second,
+		RetryAfter:        time.Minute,
+		MaxNumRetries:     3,
+		OrderByExpression: sqlf.Sprintf("id"),
+	})
+}
+
+type snapshotRunner struct {
+	db      database.DB
+	backend notebooks.BlockBackend
+}
+
+func (r *snapshotRunner) Handle(ctx context.Context, logger log.Logger, record workerutil.Record) (err error) {
+	defer func() {
+		if err != nil {
+			logger.Error("snapshotRunner.Handle", log.Error(err))
+		}
+	}()
+
+	job, ok := record.(*notebooks.NotebookSnapshotJob)
+	if !ok {
+		return errors.Errorf("unexpected record type %T", record)
+	}
+
+	store := notebooks.Notebooks(r.db)
+	schedule, err := store.GetNotebookSnapshotScheduleForJob(ctx, job.ID)
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	}
+
+	// SECURITY: set the actor to the user that scheduled the snapshots. The
+	// notebook is only executed if they can still access it, and its blocks
+	// only see the repositories and files they can see.
+	ctx = actor.WithActor(ctx, actor.FromUser(schedule.UserID))
+	ctx = featureflag.WithFlags(ctx, r.db.FeatureFlags())
+
+	notebook, err := store.GetNotebook(ctx, schedule.NotebookID)
+	if err != nil {
+		if errors.Is(err, notebooks.ErrNotebookNotFound) {
+			return errcode.MakeNonRetryable(err)
+		}
+		return err
+	}
+
+	executed := notebooks.NewExecutor(r.backend).Execute(ctx, notebook)
+	snapshot := notebooks.NewNotebookSnapshot(executed)
+	snapshot.UserID = schedule.UserID
+	_, err = store.CreateNotebookSnapshot(ctx, snapshot)
+	return err
+}
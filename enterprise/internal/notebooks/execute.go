@@ -0,0 +1,334 @@
+package notebooks
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/group"
+)
+
+// MaxBlockMatches is the maximum number of matches kept for a query or compute
+// block when a notebook is executed.
+const MaxBlockMatches = 50
+
+// maxConcurrentBlocks is the maximum number of blocks of a notebook that are
+// executed at the same time.
+const maxConcurrentBlocks = 4
+
+// BlockMatch is a single piece of output of an executed block, such as a
+// search result of a query block or the lines of a file block.
+type BlockMatch struct {
+	// Label describes the match, e.g. the repository and path of a file.
+	Label string `json:"label"`
+	// URL is the path of the match on Sourcegraph, relative to the external
+	// URL. It is empty if the match has no page of its own.
+	URL string `json:"url,omitempty"`
+	// Content is the text of the match, e.g. the matched lines of a file.
+	Content string `json:"content"`
+	// StartLine is the 1-based line of the first line of Content, or zero
+	// if Content isn't part of a file.
+	StartLine int `json:"startLine,omitempty"`
+}
+
+// ExecutedBlock is a notebook block together with the output of running it.
+type ExecutedBlock struct {
+	NotebookBlock
+
+	// Matches holds the output of the block. Markdown blocks have no output,
+	// file and symbol blocks have a single match.
+	Matches []BlockMatch
+	// LimitHit is true if the block had more matches than MaxBlockMatches.
+	LimitHit bool
+	// Error is set if the block could not be executed. A failing block does
+	// not fail the execution of the notebook.
+	Error string
+}
+
+// ExecutedNotebook is a notebook in which every block has been executed.
+type ExecutedNotebook struct {
+	*Notebook
+
+	Blocks     []*ExecutedBlock
+	ExecutedAt time.Time
+}
+
+// BlockBackend is used by the Executor to run the blocks of a notebook
+// against the services they depend on.
+type BlockBackend interface {
+	// Search runs a search query and returns up to limit of its matches.
+	Search(ctx context.Context, query string, limit int) (_ []BlockMatch, limitHit bool, _ error)
+	// Compute runs a compute query and returns up to limit of its results.
+	Compute(ctx context.Context, query string, limit int) (_ []BlockMatch, limitHit bool, _ error)
+	// ResolveRevision resolves a revision of a repository to a commit. An
+	// empty revision resolves to the default branch.
+	ResolveRevision(ctx context.Context, repo api.RepoName, revision string) (api.CommitID, error)
+	// ReadFile returns the contents of a file at a commit.
+	ReadFile(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) ([]byte, error)
+	// Symbols returns the symbols named name in a file at a commit.
+	Symbols(ctx context.Context, repo api.RepoName, commit api.CommitID, path, name string) (result.Symbols, error)
+}
+
+// Executor runs the blocks of notebooks on the server.
+type Executor struct {
+	backend BlockBackend
+	now     func() time.Time
+}
+
+func NewExecutor(backend BlockBackend) *Executor {
+	return &Executor{backend: backend, now: time.Now}
+}
+
+// Execute runs every block of the notebook. Blocks are executed concurrently,
+// and the returned blocks are in the same order as the blocks of the notebook.
+func (e *Executor) Execute(ctx context.Context, notebook *Notebook) *ExecutedNotebook {
+	blocks := make([]*ExecutedBlock, len(notebook.Blocks))
+	g := group.New().WithMaxConcurrency(maxConcurrentBlocks)
+	for i, block := range notebook.Blocks {
+		i, block := i, block
+		g.Go(func() {
+			blocks[i] = e.executeBlock(ctx, block)
+		})
+	}
+	g.Wait()
+
+	return &ExecutedNotebook{
+		Notebook:   notebook,
+		Blocks:     blocks,
+		ExecutedAt: e.now(),
+	}
+}
+
+func (e *Executor) executeBlock(ctx context.Context, block NotebookBlock) *ExecutedBlock {
+	executed := &ExecutedBlock{NotebookBlock: block}
+
+	var err error
+	switch block.Type {
+	case NotebookMarkdownBlockType:
+	case NotebookQueryBlockType:
+		executed.Matches, executed.LimitHit, err = e.backend.Search(ctx, block.QueryInput.Text, MaxBlockMatches)
+	case NotebookComputeBlockType:
+		executed.Matches, executed.LimitHit, err = e.executeComputeBlock(ctx, block.ComputeInput)
+	case NotebookFileBlockType:
+		var match *BlockMatch
+		match, err = e.executeFileBlock(ctx, block.FileInput)
+		if match != nil {
+			executed.Matches = []BlockMatch{*match}
+		}
+	case NotebookSymbolBlockType:
+		var match *BlockMatch
+		match, err = e.executeSymbolBlock(ctx, block.SymbolInput)
+		if match != nil {
+			executed.Matches = []BlockMatch{*match}
+		}
+	default:
+		err = errors.Errorf("invalid block type: %s", string(block.Type))
+	}
+	if err != nil {
+		executed.Error = err.Error()
+	}
+	return executed
+}
+
+// computeInput is the input of compute blocks as it is stored by the web app.
+type computeInput struct {
+	ComputeQueries []string `json:"computeQueries"`
+}
+
+// computeQueries returns the queries of a compute block. Inputs that are not
+// JSON are treated as a single query.
+func computeQueries(value string) []string {
+	var input computeInput
+	if err := json.Unmarshal([]byte(value), &input); err != nil {
+		return []string{value}
+	}
+	return input.ComputeQueries
+}
+
+func (e *Executor) executeComputeBlock(ctx context.Context, input *NotebookComputeBlockInput) (matches []BlockMatch, limitHit bool, _ error) {
+	for _, query := range computeQueries(input.Value) {
+		if strings.TrimSpace(query) == "" {
+			continue
+		}
+		if len(matches) >= MaxBlockMatches {
+			return matches, true, nil
+		}
+		ms, queryLimitHit, err := e.backend.Compute(ctx, query, MaxBlockMatches-len(matches))
+		if err != nil {
+			return nil, false, err
+		}
+		matches = append(matches, ms...)
+		limitHit = limitHit || queryLimitHit
+	}
+	return matches, limitHit, nil
+}
+
+func (e *Executor) executeFileBlock(ctx context.Context, input *NotebookFileBlockInput) (*BlockMatch, error) {
+	commit, content, err := e.readFile(ctx, input.RepositoryName, input.Revision, input.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := splitLines(string(content))
+	start, end := 0, len(lines)
+	urlStartLine, urlEndLine := 0, 0
+	if input.LineRange != nil {
+		// Line ranges are 0-based, with an exclusive end line.
+		start, end = clampLineRange(int(input.LineRange.StartLine), int(input.LineRange.EndLine), len(lines))
+		urlStartLine, urlEndLine = start+1, end
+	}
+
+	return &BlockMatch{
+		Label:     fileLabel(input.RepositoryName, input.Revision, input.FilePath),
+		URL:       blobURL(input.RepositoryName, revisionOrCommit(input.Revision, commit), input.FilePath, urlStartLine, urlEndLine),
+		Content:   strings.Join(lines[start:end], ""),
+		StartLine: start + 1,
+	}, nil
+}
+
+func (e *Executor) executeSymbolBlock(ctx context.Context, input *NotebookSymbolBlockInput) (*BlockMatch, error) {
+	commit, content, err := e.readFile(ctx, input.RepositoryName, input.Revision, input.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols, err := e.backend.Symbols(ctx, api.RepoName(input.RepositoryName), commit, input.FilePath, input.SymbolName)
+	if err != nil {
+		return nil, err
+	}
+	symbol, ok := findSymbol(symbols, input)
+	if !ok {
+		return nil, errors.Errorf("symbol %q not found in %s", input.SymbolName, input.FilePath)
+	}
+
+	lines := splitLines(string(content))
+	// Symbol lines are 1-based.
+	start, end := clampLineRange(symbol.Line-1-int(input.LineContext), symbol.Line+int(input.LineContext), len(lines))
+
+	return &BlockMatch{
+		Label:     fileLabel(input.RepositoryName, input.Revision, input.FilePath) + " › " + symbolLabel(input.SymbolContainerName, input.SymbolName),
+		URL:       blobURL(input.RepositoryName, revisionOrCommit(input.Revision, commit), input.FilePath, symbol.Line, symbol.Line),
+		Content:   strings.Join(lines[start:end], ""),
+		StartLine: start + 1,
+	}, nil
+}
+
+func (e *Executor) readFile(ctx context.Context, repositoryName string, revision *string, path string) (api.CommitID, []byte, error) {
+	repo := api.RepoName(repositoryName)
+	rev := ""
+	if revision != nil {
+		rev = *revision
+	}
+
+	commit, err := e.backend.ResolveRevision(ctx, repo, rev)
+	if err != nil {
+		return "", nil, err
+	}
+	content, err := e.backend.ReadFile(ctx, repo, commit, path)
+	if err != nil {
+		return "", nil, err
+	}
+	return commit, content, nil
+}
+
+// findSymbol returns the symbol matching the name, container name and kind of
+// a symbol block. If no symbol matches exactly, the first symbol with a
+// matching name is returned.
+func findSymbol(symbols result.Symbols, input *NotebookSymbolBlockInput) (result.Symbol, bool) {
+	var candidates []result.Symbol
+	for _, s := range symbols {
+		if s.Name == input.SymbolName {
+			candidates = append(candidates, s)
+		}
+	}
+	for _, s := range candidates {
+		if s.Parent == input.SymbolContainerName && symbolKind(s) == input.SymbolKind {
+			return s, true
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates[0], true
+	}
+	return result.Symbol{}, false
+}
+
+// symbolKind returns the kind of a symbol in the form of the GraphQL
+// SymbolKind enum, which is what symbol blocks store.
+func symbolKind(s result.Symbol) string {
+	kind := s.LSPKind()
+	if kind == 0 {
+		return "UNKNOWN"
+	}
+	return strings.ToUpper(kind.String())
+}
+
+// splitLines splits content into lines, keeping the line endings.
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// clampLineRange limits the 0-based, end-exclusive line range [start, end) to
+// the lines of a file with lineCount lines.
+func clampLineRange(start, end, lineCount int) (int, int) {
+	if start < 0 {
+		start = 0
+	}
+	if end > lineCount {
+		end = lineCount
+	}
+	if start > end {
+		start = end
+	}
+	return start, end
+}
+
+func revisionOrCommit(revision *string, commit api.CommitID) string {
+	if revision != nil && *revision != "" {
+		return *revision
+	}
+	return string(commit)
+}
+
+func fileLabel(repositoryName string, revision *string, path string) string {
+	if revision != nil && *revision != "" {
+		return repositoryName + "@" + *revision + " › " + path
+	}
+	return repositoryName + " › " + path
+}
+
+func symbolLabel(containerName, name string) string {
+	if containerName != "" {
+		return containerName + "." + name
+	}
+	return name
+}
+
+// blobURL returns the URL of the 1-based, inclusive line range of a file.
+func blobURL(repositoryName, revision, path string, startLine, endLine int) string {
+	var b strings.Builder
+	b.WriteString("/" + repositoryName)
+	if revision != "" {
+		b.WriteString("@" + revision)
+	}
+	b.WriteString("/-/blob/" + path)
+	if startLine > 0 && endLine >= startLine {
+		if startLine == endLine {
+			b.WriteString("?L" + strconv.Itoa(startLine))
+		} else {
+			b.WriteString("?L" + strconv.Itoa(startLine) + "-" + strconv.Itoa(endLine))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,144 @@
+package notebooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type fakeBlockBackend struct {
+	search  func(query string, limit int) ([]BlockMatch, bool, error)
+	compute func(query string, limit int) ([]BlockMatch, bool, error)
+	files   map[string]string
+	symbols result.Symbols
+}
+
+func (b *fakeBlockBackend) Search(_ context.Context, query string, limit int) ([]BlockMatch, bool, error) {
+	return b.search(query, limit)
+}
+
+func (b *fakeBlockBackend) Compute(_ context.Context, query string, limit int) ([]BlockMatch, bool, error) {
+	return b.compute(query, limit)
+}
+
+func (b *fakeBlockBackend) ResolveRevision(_ context.Context, repo api.RepoName, revision string) (api.CommitID, error) {
+	if revision == "missing" {
+		return "", errors.Errorf("revision not found: %s@%s", repo, revision)
+	}
+	return "deadbeef", nil
+}
+
+func (b *fakeBlockBackend) ReadFile(_ context.Context, repo api.RepoName, commit api.CommitID, path string) ([]byte, error) {
+	content, ok := b.files[string(repo)+"/"+path]
+	if !ok {
+		return nil, errors.Errorf("file not found: %s", path)
+	}
+	return []byte(content), nil
+}
+
+func (b *fakeBlockBackend) Symbols(_ context.Context, repo api.RepoName, commit api.CommitID, path, name string) (result.Symbols, error) {
+	return b.symbols, nil
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestExecute(t *testing.T) {
+	backend := &fakeBlockBackend{
+		search: func(query string, limit int) ([]BlockMatch, bool, error) {
+			if query == "error" {
+				return nil, false, errors.New("search failed")
+			}
+			return []BlockMatch{{Label: "github.com/a/b › main.go", URL: "/github.com/a/b/-/blob/main.go", Content: "func main() {}\n", StartLine: 3}}, true, nil
+		},
+		compute: func(query string, limit int) ([]BlockMatch, bool, error) {
+			return []BlockMatch{{Label: "github.com/a/b", Content: query}}, false, nil
+		},
+		files: map[string]string{
+			"github.com/a/b/main.go": "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(greeting())\n}\n\nfunc greeting() string {\n\treturn \"hello\"\n}\n",
+		},
+		symbols: result.Symbols{
+			{Name: "greeting", Path: "main.go", Line: 9, Kind: "function"},
+		},
+	}
+
+	notebook := &Notebook{
+		Title: "Notebook",
+		Blocks: NotebookBlocks{
+			{ID: "1", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "# Title"}},
+			{ID: "2", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "fmt.Println"}},
+			{ID: "3", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "error"}},
+			{ID: "4", Type: NotebookFileBlockType, FileInput: &NotebookFileBlockInput{RepositoryName: "github.com/a/b", FilePath: "main.go", LineRange: &LineRange{StartLine: 4, EndLine: 7}}},
+			{ID: "5", Type: NotebookFileBlockType, FileInput: &NotebookFileBlockInput{RepositoryName: "github.com/a/b", FilePath: "main.go", Revision: strPtr("missing")}},
+			{ID: "6", Type: NotebookSymbolBlockType, SymbolInput: &NotebookSymbolBlockInput{RepositoryName: "github.com/a/b", FilePath: "main.go", Revision: strPtr("main"), LineContext: 1, SymbolName: "greeting", SymbolKind: "FUNCTION"}},
+			{ID: "7", Type: NotebookComputeBlockType, ComputeInput: &NotebookComputeBlockInput{Value: `{"computeQueries":["content:output(a -> b)", ""],"experimentalOptions":{}}`}},
+		},
+	}
+
+	executedAt := time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+	executor := NewExecutor(backend)
+	executor.now = func() time.Time { return executedAt }
+
+	got := executor.Execute(context.Background(), notebook)
+
+	want := &ExecutedNotebook{
+		Notebook:   notebook,
+		ExecutedAt: executedAt,
+		Blocks: []*ExecutedBlock{
+			{NotebookBlock: notebook.Blocks[0]},
+			{
+				NotebookBlock: notebook.Blocks[1],
+				Matches:       []BlockMatch{{Label: "github.com/a/b › main.go", URL: "/github.com/a/b/-/blob/main.go", Content: "func main() {}\n", StartLine: 3}},
+				LimitHit:      true,
+			},
+			{NotebookBlock: notebook.Blocks[2], Error: "search failed"},
+			{
+				NotebookBlock: notebook.Blocks[3],
+				Matches: []BlockMatch{{
+					Label:     "github.com/a/b › main.go",
+					URL:       "/github.com/a/b@deadbeef/-/blob/main.go?L5-7",
+					Content:   "func main() {\n\tfmt.Println(greeting())\n}\n",
+					StartLine: 5,
+				}},
+			},
+			{NotebookBlock: notebook.Blocks[4], Error: "revision not found: github.com/a/b@missing"},
+			{
+				NotebookBlock: notebook.Blocks[5],
+				Matches: []BlockMatch{{
+					Label:     "github.com/a/b@main › main.go › greeting",
+					URL:       "/github.com/a/b@main/-/blob/main.go?L9",
+					Content:   "\nfunc greeting() string {\n\treturn \"hello\"\n",
+					StartLine: 8,
+				}},
+			},
+			{
+				NotebookBlock: notebook.Blocks[6],
+				Matches:       []BlockMatch{{Label: "github.com/a/b", Content: "content:output(a -> b)"}},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected executed notebook (-want +got):\n%s", diff)
+	}
+}
+
+func TestComputeQueries(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{value: `{"computeQueries":["a","b"],"experimentalOptions":{}}`, want: []string{"a", "b"}},
+		{value: `content:output(a -> b)`, want: []string{"content:output(a -> b)"}},
+	}
+
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, computeQueries(tt.value)); diff != "" {
+			t.Fatalf("unexpected compute queries for %q (-want +got):\n%s", tt.value, diff)
+		}
+	}
+}
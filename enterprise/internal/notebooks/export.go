@@ -0,0 +1,174 @@
+package notebooks
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/url"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	gfm "github.com/shurcooL/github_flavored_markdown"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatMarkdown ExportFormat = "MARKDOWN"
+	ExportFormatHTML     ExportFormat = "HTML"
+)
+
+// Export renders an executed notebook as a self-contained document in the
+// given format. Links to matches are made absolute with externalURL.
+func Export(notebook *ExecutedNotebook, format ExportFormat, externalURL *url.URL) (string, error) {
+	switch format {
+	case ExportFormatMarkdown:
+		return ExportMarkdown(notebook, externalURL), nil
+	case ExportFormatHTML:
+		return ExportHTML(notebook, externalURL)
+	default:
+		return "", errors.Errorf("unsupported notebook export format: %s", format)
+	}
+}
+
+// ExportMarkdown renders an executed notebook as Markdown. Markdown blocks are
+// included as they are, and the other blocks are followed by their output.
+func ExportMarkdown(notebook *ExecutedNotebook, externalURL *url.URL) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", notebook.Title)
+	fmt.Fprintf(&b, "_Executed on %s._\n", notebook.ExecutedAt.UTC().Format("2006-01-02 15:04 MST"))
+
+	for _, block := range notebook.Blocks {
+		b.WriteString("\n")
+		writeMarkdownBlock(&b, block, externalURL)
+	}
+	return b.String()
+}
+
+func writeMarkdownBlock(b *strings.Builder, block *ExecutedBlock, externalURL *url.URL) {
+	// paragraph separates the parts of the block with blank lines.
+	started := false
+	paragraph := func() {
+		if started {
+			b.WriteString("\n")
+		}
+		started = true
+	}
+
+	switch block.Type {
+	case NotebookMarkdownBlockType:
+		b.WriteString(strings.TrimRight(block.MarkdownInput.Text, "\n") + "\n")
+		return
+	case NotebookQueryBlockType:
+		paragraph()
+		writeCodeBlock(b, "sourcegraph", block.QueryInput.Text)
+	case NotebookComputeBlockType:
+		paragraph()
+		writeCodeBlock(b, "sourcegraph-compute", strings.Join(computeQueries(block.ComputeInput.Value), "\n"))
+	}
+
+	if block.Error != "" {
+		paragraph()
+		fmt.Fprintf(b, "> **Error:** %s\n", strings.ReplaceAll(block.Error, "\n", " "))
+		return
+	}
+
+	if len(block.Matches) == 0 {
+		paragraph()
+		b.WriteString("_No results._\n")
+		return
+	}
+
+	for _, match := range block.Matches {
+		paragraph()
+		if match.URL != "" {
+			fmt.Fprintf(b, "**[%s](%s)**\n\n", escapeMarkdown(match.Label), absoluteURL(externalURL, match.URL))
+		} else if match.Label != "" {
+			fmt.Fprintf(b, "**%s**\n\n", escapeMarkdown(match.Label))
+		}
+		writeCodeBlock(b, "", match.Content)
+	}
+
+	if block.LimitHit {
+		paragraph()
+		fmt.Fprintf(b, "_Only the first %d results are shown._\n", len(block.Matches))
+	}
+}
+
+// writeCodeBlock writes a fenced code block. The fence is longer than any run
+// of backticks in content, so that content can't end the code block.
+func writeCodeBlock(b *strings.Builder, info, content string) {
+	fence := "```"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+	b.WriteString(fence + info + "\n")
+	b.WriteString(content)
+	if !strings.HasSuffix(content, "\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString(fence + "\n")
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`*`, `\*`,
+	`_`, `\_`,
+	"`", "\\`",
+	`<`, `\<`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+func absoluteURL(externalURL *url.URL, path string) string {
+	if externalURL == nil {
+		return path
+	}
+	return strings.TrimSuffix(externalURL.String(), "/") + path
+}
+
+var htmlExportTemplate = template.Must(template.New("notebook").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { max-width: 60rem; margin: 2rem auto; padding: 0 1rem; font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; line-height: 1.5; color: #14171f; }
+pre { overflow-x: auto; padding: 0.75rem; background: #f9fafb; border: 1px solid #dbe2f0; border-radius: 3px; }
+code { font-family: SFMono-Regular, Consolas, Menlo, monospace; font-size: 0.875rem; }
+blockquote { margin: 0; padding-left: 1rem; border-left: 3px solid #dbe2f0; color: #5e6e8c; }
+a { color: #0b70db; }
+</style>
+</head>
+<body>
+{{.Body}}
+</body>
+</html>
+`))
+
+// ExportHTML renders an executed notebook as a standalone HTML page. The page
+// is rendered from the Markdown export and sanitized, so it is safe to view
+// even though notebooks contain user-provided Markdown.
+func ExportHTML(notebook *ExecutedNotebook, externalURL *url.URL) (string, error) {
+	unsafeHTML := gfm.Markdown([]byte(ExportMarkdown(notebook, externalURL)))
+	body := bluemonday.UGCPolicy().SanitizeBytes(unsafeHTML)
+
+	var buf bytes.Buffer
+	err := htmlExportTemplate.Execute(&buf, struct {
+		Title string
+		Body  template.HTML
+	}{
+		Title: notebook.Title,
+		Body:  template.HTML(body),
+	})
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
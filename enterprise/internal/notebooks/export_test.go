@@ -0,0 +1,70 @@
+package notebooks
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold"
+)
+
+func testExecutedNotebook() *ExecutedNotebook {
+	notebook := &Notebook{
+		Title: "Incident 42",
+		Blocks: NotebookBlocks{
+			{ID: "1", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "## Summary\n\nCalls to `greeting` panicked.<script>alert(1)</script>\n"}},
+			{ID: "2", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "greeting() lang:go"}},
+			{ID: "3", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "error"}},
+			{ID: "4", Type: NotebookFileBlockType, FileInput: &NotebookFileBlockInput{RepositoryName: "github.com/a/b", FilePath: "README.md"}},
+			{ID: "5", Type: NotebookComputeBlockType, ComputeInput: &NotebookComputeBlockInput{Value: `{"computeQueries":["content:output(a -> b)"],"experimentalOptions":{}}`}},
+		},
+	}
+
+	return &ExecutedNotebook{
+		Notebook:   notebook,
+		ExecutedAt: time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC),
+		Blocks: []*ExecutedBlock{
+			{NotebookBlock: notebook.Blocks[0]},
+			{
+				NotebookBlock: notebook.Blocks[1],
+				Matches:       []BlockMatch{{Label: "github.com/a/b › main_test.go", URL: "/github.com/a/b/-/blob/main_test.go", Content: "\tgot := greeting()\n", StartLine: 7}},
+				LimitHit:      true,
+			},
+			{NotebookBlock: notebook.Blocks[2], Error: "search failed"},
+			{
+				NotebookBlock: notebook.Blocks[3],
+				Matches:       []BlockMatch{{Label: "github.com/a/b › README.md", URL: "/github.com/a/b@deadbeef/-/blob/README.md", Content: "Run:\n\n```\ngo run .\n```\n", StartLine: 1}},
+			},
+			{NotebookBlock: notebook.Blocks[4]},
+		},
+	}
+}
+
+func TestExportMarkdown(t *testing.T) {
+	externalURL, _ := url.Parse("https://sourcegraph.example.com")
+	got := ExportMarkdown(testExecutedNotebook(), externalURL)
+	autogold.Want("exports markdown", "# Incident 42\n\n_Executed on 2022-07-01 12:00 UTC._\n\n## Summary\n\nCalls to `greeting` panicked.<script>alert(1)</script>\n\n```sourcegraph\ngreeting() lang:go\n```\n\n**[github.com/a/b › main\\_test.go](https://sourcegraph.example.com/github.com/a/b/-/blob/main_test.go)**\n\n```\n\tgot := greeting()\n```\n\n_Only the first 1 results are shown._\n\n```sourcegraph\nerror\n```\n\n> **Error:** search failed\n\n**[github.com/a/b › README.md](https://sourcegraph.example.com/github.com/a/b@deadbeef/-/blob/README.md)**\n\n````\nRun:\n\n```\ngo run .\n```\n````\n\n```sourcegraph-compute\ncontent:output(a -> b)\n```\n\n_No results._\n").Equal(t, got)
+}
+
+func TestExportHTML(t *testing.T) {
+	externalURL, _ := url.Parse("https://sourcegraph.example.com")
+	got, err := ExportHTML(testExecutedNotebook(), externalURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"<title>Incident 42</title>",
+		`href="https://sourcegraph.example.com/github.com/a/b/-/blob/main_test.go"`,
+		"search failed",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected exported HTML to contain %q", want)
+		}
+	}
+	// User-provided Markdown must be sanitized.
+	if strings.Contains(got, "<script>") {
+		t.Errorf("expected exported HTML to be sanitized, got %s", got)
+	}
+}
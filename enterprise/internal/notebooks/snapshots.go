@@ -0,0 +1,82 @@
+package notebooks
+
+// NotebookBlockSnapshotStatus describes how the results of a block changed
+// between two snapshots of a notebook.
+type NotebookBlockSnapshotStatus string
+
+const (
+	// NotebookBlockSnapshotAdded is the status of blocks that are not part of
+	// the previous snapshot, e.g. because they were added to the notebook.
+	NotebookBlockSnapshotAdded     NotebookBlockSnapshotStatus = "ADDED"
+	NotebookBlockSnapshotChanged   NotebookBlockSnapshotStatus = "CHANGED"
+	NotebookBlockSnapshotUnchanged NotebookBlockSnapshotStatus = "UNCHANGED"
+)
+
+// NewNotebookSnapshot returns a snapshot of the results of the executable
+// blocks of an executed notebook. Markdown blocks have no results and are not
+// part of snapshots.
+func NewNotebookSnapshot(executed *ExecutedNotebook) *NotebookSnapshot {
+	blocks := make(NotebookBlockSnapshots, 0, len(executed.Blocks))
+	for _, block := range executed.Blocks {
+		if block.Type == NotebookMarkdownBlockType {
+			continue
+		}
+		matches := block.Matches
+		if matches == nil {
+			matches = []BlockMatch{}
+		}
+		blocks = append(blocks, NotebookBlockSnapshot{
+			ID:       block.ID,
+			Type:     block.Type,
+			Matches:  matches,
+			LimitHit: block.LimitHit,
+			Error:    block.Error,
+		})
+	}
+
+	return &NotebookSnapshot{
+		NotebookID: executed.ID,
+		Blocks:     blocks,
+		CreatedAt:  executed.ExecutedAt,
+	}
+}
+
+// DiffNotebookSnapshots returns the status of every block of current compared
+// to the same block in previous, keyed by block ID. previous is nil for the
+// first snapshot of a notebook, in which case every block is added.
+func DiffNotebookSnapshots(previous, current *NotebookSnapshot) map[string]NotebookBlockSnapshotStatus {
+	previousBlocks := map[string]NotebookBlockSnapshot{}
+	if previous != nil {
+		for _, block := range previous.Blocks {
+			previousBlocks[block.ID] = block
+		}
+	}
+
+	statuses := make(map[string]NotebookBlockSnapshotStatus, len(current.Blocks))
+	for _, block := range current.Blocks {
+		previousBlock, ok := previousBlocks[block.ID]
+		switch {
+		case !ok:
+			statuses[block.ID] = NotebookBlockSnapshotAdded
+		case block.equalResults(previousBlock):
+			statuses[block.ID] = NotebookBlockSnapshotUnchanged
+		default:
+			statuses[block.ID] = NotebookBlockSnapshotChanged
+		}
+	}
+	return statuses
+}
+
+// equalResults returns true if both snapshots of a block hold the same
+// results.
+func (b NotebookBlockSnapshot) equalResults(other NotebookBlockSnapshot) bool {
+	if b.Type != other.Type || b.LimitHit != other.LimitHit || b.Error != other.Error || len(b.Matches) != len(other.Matches) {
+		return false
+	}
+	for i := range b.Matches {
+		if b.Matches[i] != other.Matches[i] {
+			return false
+		}
+	}
+	return true
+}
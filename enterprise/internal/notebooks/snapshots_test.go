@@ -0,0 +1,86 @@
+package notebooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewNotebookSnapshot(t *testing.T) {
+	executedAt := time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+	notebook := &Notebook{
+		ID: 1,
+		Blocks: NotebookBlocks{
+			{ID: "1", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "# Title"}},
+			{ID: "2", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "error"}},
+			{ID: "3", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "fmt.Println"}},
+		},
+	}
+	executed := &ExecutedNotebook{
+		Notebook:   notebook,
+		ExecutedAt: executedAt,
+		Blocks: []*ExecutedBlock{
+			{NotebookBlock: notebook.Blocks[0]},
+			{NotebookBlock: notebook.Blocks[1], Error: "search failed"},
+			{NotebookBlock: notebook.Blocks[2], Matches: []BlockMatch{{Label: "a › main.go", Content: "fmt.Println()\n"}}, LimitHit: true},
+		},
+	}
+
+	want := &NotebookSnapshot{
+		NotebookID: 1,
+		CreatedAt:  executedAt,
+		Blocks: NotebookBlockSnapshots{
+			{ID: "2", Type: NotebookQueryBlockType, Matches: []BlockMatch{}, Error: "search failed"},
+			{ID: "3", Type: NotebookQueryBlockType, Matches: []BlockMatch{{Label: "a › main.go", Content: "fmt.Println()\n"}}, LimitHit: true},
+		},
+	}
+	if diff := cmp.Diff(want, NewNotebookSnapshot(executed)); diff != "" {
+		t.Fatalf("unexpected snapshot (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiffNotebookSnapshots(t *testing.T) {
+	match := func(content string) []BlockMatch {
+		return []BlockMatch{{Label: "a › main.go", URL: "/a/-/blob/main.go", Content: content}}
+	}
+
+	previous := &NotebookSnapshot{Blocks: NotebookBlockSnapshots{
+		{ID: "unchanged", Type: NotebookQueryBlockType, Matches: match("a")},
+		{ID: "changed-content", Type: NotebookQueryBlockType, Matches: match("a")},
+		{ID: "changed-count", Type: NotebookQueryBlockType, Matches: match("a")},
+		{ID: "changed-error", Type: NotebookFileBlockType, Matches: match("a")},
+		{ID: "removed", Type: NotebookQueryBlockType, Matches: match("a")},
+	}}
+	current := &NotebookSnapshot{Blocks: NotebookBlockSnapshots{
+		{ID: "unchanged", Type: NotebookQueryBlockType, Matches: match("a")},
+		{ID: "changed-content", Type: NotebookQueryBlockType, Matches: match("b")},
+		{ID: "changed-count", Type: NotebookQueryBlockType, Matches: append(match("a"), match("b")...)},
+		{ID: "changed-error", Type: NotebookFileBlockType, Matches: []BlockMatch{}, Error: "file not found"},
+		{ID: "added", Type: NotebookSymbolBlockType, Matches: match("a")},
+	}}
+
+	want := map[string]NotebookBlockSnapshotStatus{
+		"unchanged":       NotebookBlockSnapshotUnchanged,
+		"changed-content": NotebookBlockSnapshotChanged,
+		"changed-count":   NotebookBlockSnapshotChanged,
+		"changed-error":   NotebookBlockSnapshotChanged,
+		"added":           NotebookBlockSnapshotAdded,
+	}
+	if diff := cmp.Diff(want, DiffNotebookSnapshots(previous, current)); diff != "" {
+		t.Fatalf("unexpected statuses (-want +got):\n%s", diff)
+	}
+
+	t.Run("first snapshot", func(t *testing.T) {
+		want := map[string]NotebookBlockSnapshotStatus{
+			"unchanged":       NotebookBlockSnapshotAdded,
+			"changed-content": NotebookBlockSnapshotAdded,
+			"changed-count":   NotebookBlockSnapshotAdded,
+			"changed-error":   NotebookBlockSnapshotAdded,
+			"added":           NotebookBlockSnapshotAdded,
+		}
+		if diff := cmp.Diff(want, DiffNotebookSnapshots(nil, current)); diff != "" {
+			t.Fatalf("unexpected statuses (-want +got):\n%s", diff)
+		}
+	})
+}
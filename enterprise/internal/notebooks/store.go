@@ -15,11 +15,13 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 var ErrNotebookNotFound = errors.New("notebook not found")
 var ErrNotebookStarNotFound = errors.New("notebook star not found")
+var ErrNotebookSnapshotScheduleNotFound = errors.New("notebook snapshot schedule not found")
 
 type NotebooksOrderByOption uint8
 
@@ -40,6 +42,11 @@ type ListNotebookStarsPageOptions struct {
 	After int64
 }
 
+type ListNotebookSnapshotsPageOptions struct {
+	First int32
+	After int64
+}
+
 type ListNotebooksOptions struct {
 	Query             string
 	CreatorUserID     int32
@@ -62,6 +69,18 @@ func (blocks *NotebookBlocks) Scan(value any) error {
 	return json.Unmarshal(b, &blocks)
 }
 
+func (blocks NotebookBlockSnapshots) Value() (driver.Value, error) {
+	return json.Marshal(blocks)
+}
+
+func (blocks *NotebookBlockSnapshots) Scan(value any) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &blocks)
+}
+
 func Notebooks(db database.DB) NotebooksStore {
 	store := basestore.NewWithHandle(db.Handle())
 	return &notebooksStore{store}
@@ -81,6 +100,16 @@ type NotebooksStore interface {
 	DeleteNotebookStar(ctx context.Context, notebookID int64, userID int32) error
 	ListNotebookStars(ctx context.Context, pageOpts ListNotebookStarsPageOptions, notebookID int64) ([]*NotebookStar, error)
 	CountNotebookStars(ctx context.Context, notebookID int64) (int64, error)
+
+	GetNotebookSnapshotSchedule(ctx context.Context, notebookID int64) (*NotebookSnapshotSchedule, error)
+	GetNotebookSnapshotScheduleForJob(ctx context.Context, jobID int32) (*NotebookSnapshotSchedule, error)
+	UpsertNotebookSnapshotSchedule(ctx context.Context, notebookID int64, userID int32, interval NotebookSnapshotInterval) (*NotebookSnapshotSchedule, error)
+	DeleteNotebookSnapshotSchedule(ctx context.Context, notebookID int64) error
+	EnqueueNotebookSnapshotJobs(ctx context.Context) ([]*NotebookSnapshotJob, error)
+	CreateNotebookSnapshot(ctx context.Context, snapshot *NotebookSnapshot) (*NotebookSnapshot, error)
+	ListNotebookSnapshots(ctx context.Context, pageOpts ListNotebookSnapshotsPageOptions, notebookID int64, userID int32) ([]*NotebookSnapshot, error)
+	CountNotebookSnapshots(ctx context.Context, notebookID int64, userID int32) (int64, error)
+	DeleteOldNotebookSnapshots(ctx context.Context, retentionInDays int) error
 }
 
 type notebooksStore struct {
@@ -480,6 +509,298 @@ func (s *notebooksStore) CountNotebookStars(ctx context.Context, notebookID int6
 	return count, nil
 }
 
+var notebookSnapshotScheduleColumns = []*sqlf.Query{
+	sqlf.Sprintf("notebook_snapshot_schedules.id"),
+	sqlf.Sprintf("notebook_snapshot_schedules.notebook_id"),
+	sqlf.Sprintf("notebook_snapshot_schedules.user_id"),
+	sqlf.Sprintf("notebook_snapshot_schedules.snapshot_interval"),
+	sqlf.Sprintf("notebook_snapshot_schedules.next_run_at"),
+	sqlf.Sprintf("notebook_snapshot_schedules.created_at"),
+	sqlf.Sprintf("notebook_snapshot_schedules.updated_at"),
+}
+
+func scanNotebookSnapshotSchedule(scanner dbutil.Scanner) (*NotebookSnapshotSchedule, error) {
+	schedule := &NotebookSnapshotSchedule{}
+	err := scanner.Scan(
+		&schedule.ID,
+		&schedule.NotebookID,
+		&schedule.UserID,
+		&schedule.Interval,
+		&schedule.NextRunAt,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotebookSnapshotScheduleNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+const getNotebookSnapshotScheduleFmtStr = `
+SELECT %s
+FROM notebook_snapshot_schedules
+WHERE notebook_id = %d
+`
+
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook.
+func (s *notebooksStore) GetNotebookSnapshotSchedule(ctx context.Context, notebookID int64) (*NotebookSnapshotSchedule, error) {
+	row := s.QueryRow(ctx, sqlf.Sprintf(getNotebookSnapshotScheduleFmtStr, sqlf.Join(notebookSnapshotScheduleColumns, ","), notebookID))
+	return scanNotebookSnapshotSchedule(row)
+}
+
+const getNotebookSnapshotScheduleForJobFmtStr = `
+SELECT %s
+FROM notebook_snapshot_schedules
+INNER JOIN notebook_snapshot_jobs ON notebook_snapshot_jobs.schedule_id = notebook_snapshot_schedules.id
+WHERE notebook_snapshot_jobs.id = %d
+`
+
+func (s *notebooksStore) GetNotebookSnapshotScheduleForJob(ctx context.Context, jobID int32) (*NotebookSnapshotSchedule, error) {
+	row := s.QueryRow(ctx, sqlf.Sprintf(getNotebookSnapshotScheduleForJobFmtStr, sqlf.Join(notebookSnapshotScheduleColumns, ","), jobID))
+	return scanNotebookSnapshotSchedule(row)
+}
+
+const upsertNotebookSnapshotScheduleFmtStr = `
+INSERT INTO notebook_snapshot_schedules (notebook_id, user_id, snapshot_interval)
+VALUES (%d, %d, %s)
+ON CONFLICT (notebook_id) DO UPDATE
+SET
+	user_id = EXCLUDED.user_id,
+	snapshot_interval = EXCLUDED.snapshot_interval,
+	updated_at = now()
+RETURNING %s
+`
+
+// UpsertNotebookSnapshotSchedule puts the notebook on a snapshot schedule, or
+// updates its existing schedule. The first snapshot of a new schedule is
+// recorded right away.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has permission to update the notebook.
+func (s *notebooksStore) UpsertNotebookSnapshotSchedule(ctx context.Context, notebookID int64, userID int32, interval NotebookSnapshotInterval) (*NotebookSnapshotSchedule, error) {
+	row := s.QueryRow(ctx, sqlf.Sprintf(upsertNotebookSnapshotScheduleFmtStr, notebookID, userID, interval, sqlf.Join(notebookSnapshotScheduleColumns, ",")))
+	return scanNotebookSnapshotSchedule(row)
+}
+
+const deleteNotebookSnapshotScheduleFmtStr = `DELETE FROM notebook_snapshot_schedules WHERE notebook_id = %d`
+
+// DeleteNotebookSnapshotSchedule takes the notebook off its snapshot schedule.
+// Snapshots that were already recorded are kept.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has permission to update the notebook.
+func (s *notebooksStore) DeleteNotebookSnapshotSchedule(ctx context.Context, notebookID int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteNotebookSnapshotScheduleFmtStr, notebookID))
+}
+
+var NotebookSnapshotJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("notebook_snapshot_jobs.id"),
+	sqlf.Sprintf("notebook_snapshot_jobs.schedule_id"),
+	sqlf.Sprintf("notebook_snapshot_jobs.state"),
+	sqlf.Sprintf("notebook_snapshot_jobs.failure_message"),
+	sqlf.Sprintf("notebook_snapshot_jobs.started_at"),
+	sqlf.Sprintf("notebook_snapshot_jobs.finished_at"),
+	sqlf.Sprintf("notebook_snapshot_jobs.process_after"),
+	sqlf.Sprintf("notebook_snapshot_jobs.num_resets"),
+	sqlf.Sprintf("notebook_snapshot_jobs.num_failures"),
+}
+
+func scanNotebookSnapshotJob(scanner dbutil.Scanner) (*NotebookSnapshotJob, error) {
+	job := &NotebookSnapshotJob{}
+	err := scanner.Scan(
+		&job.ID,
+		&job.ScheduleID,
+		&job.State,
+		&job.FailureMessage,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.ProcessAfter,
+		&job.NumResets,
+		&job.NumFailures,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func scanNotebookSnapshotJobs(rows *sql.Rows) ([]*NotebookSnapshotJob, error) {
+	var jobs []*NotebookSnapshotJob
+	for rows.Next() {
+		job, err := scanNotebookSnapshotJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// ScanNotebookSnapshotJobRecord scans a notebook snapshot job for the
+// dbworker store.
+func ScanNotebookSnapshotJobRecord(rows *sql.Rows, err error) (workerutil.Record, bool, error) {
+	if err != nil {
+		return nil, false, err
+	}
+	jobs, err := scanNotebookSnapshotJobs(rows)
+	if err != nil || len(jobs) == 0 {
+		return &NotebookSnapshotJob{}, false, err
+	}
+	return jobs[0], true, nil
+}
+
+const enqueueNotebookSnapshotJobsFmtStr = `
+WITH due AS (
+	SELECT id
+	FROM notebook_snapshot_schedules
+	WHERE
+		next_run_at <= clock_timestamp()
+		AND NOT EXISTS (
+			SELECT 1 FROM notebook_snapshot_jobs
+			WHERE
+				notebook_snapshot_jobs.schedule_id = notebook_snapshot_schedules.id
+				AND notebook_snapshot_jobs.state IN ('queued', 'processing')
+		)
+	FOR UPDATE SKIP LOCKED
+),
+scheduled AS (
+	UPDATE notebook_snapshot_schedules
+	SET next_run_at = clock_timestamp() + (CASE snapshot_interval WHEN %s THEN %s ELSE %s END * interval '1 second')
+	FROM due
+	WHERE notebook_snapshot_schedules.id = due.id
+	RETURNING notebook_snapshot_schedules.id
+)
+INSERT INTO notebook_snapshot_jobs (schedule_id)
+SELECT id FROM scheduled ORDER BY id
+RETURNING %s
+`
+
+// EnqueueNotebookSnapshotJobs enqueues a snapshot job for every schedule that
+// is due, and moves the next run of those schedules forward by their interval.
+func (s *notebooksStore) EnqueueNotebookSnapshotJobs(ctx context.Context) ([]*NotebookSnapshotJob, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(
+		enqueueNotebookSnapshotJobsFmtStr,
+		NotebookSnapshotIntervalWeekly,
+		NotebookSnapshotIntervalWeekly.Duration().Seconds(),
+		NotebookSnapshotIntervalDaily.Duration().Seconds(),
+		sqlf.Join(NotebookSnapshotJobColumns, ","),
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanNotebookSnapshotJobs(rows)
+}
+
+var notebookSnapshotColumns = []*sqlf.Query{
+	sqlf.Sprintf("notebook_snapshots.id"),
+	sqlf.Sprintf("notebook_snapshots.notebook_id"),
+	sqlf.Sprintf("notebook_snapshots.user_id"),
+	sqlf.Sprintf("notebook_snapshots.blocks"),
+	sqlf.Sprintf("notebook_snapshots.created_at"),
+}
+
+func scanNotebookSnapshot(scanner dbutil.Scanner) (*NotebookSnapshot, error) {
+	snapshot := &NotebookSnapshot{}
+	err := scanner.Scan(&snapshot.ID, &snapshot.NotebookID, &snapshot.UserID, &snapshot.Blocks, &snapshot.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+const insertNotebookSnapshotFmtStr = `
+INSERT INTO notebook_snapshots (notebook_id, user_id, blocks, created_at) VALUES (%d, %d, %s, %s)
+RETURNING %s
+`
+
+func (s *notebooksStore) CreateNotebookSnapshot(ctx context.Context, snapshot *NotebookSnapshot) (*NotebookSnapshot, error) {
+	row := s.QueryRow(ctx, sqlf.Sprintf(
+		insertNotebookSnapshotFmtStr,
+		snapshot.NotebookID,
+		snapshot.UserID,
+		snapshot.Blocks,
+		snapshot.CreatedAt,
+		sqlf.Join(notebookSnapshotColumns, ","),
+	))
+	return scanNotebookSnapshot(row)
+}
+
+const listNotebookSnapshotsFmtStr = `
+SELECT %s
+FROM notebook_snapshots
+WHERE notebook_id = %d AND user_id = %d
+ORDER BY created_at DESC, id DESC
+LIMIT %d
+OFFSET %d
+`
+
+// ListNotebookSnapshots lists the snapshots of a notebook that were recorded
+// as the given user, starting with the most recent one.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook,
+// and that userID is the actor's user ID.
+func (s *notebooksStore) ListNotebookSnapshots(ctx context.Context, pageOpts ListNotebookSnapshotsPageOptions, notebookID int64, userID int32) ([]*NotebookSnapshot, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(
+		listNotebookSnapshotsFmtStr,
+		sqlf.Join(notebookSnapshotColumns, ","),
+		notebookID,
+		userID,
+		pageOpts.First,
+		pageOpts.After,
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []*NotebookSnapshot
+	for rows.Next() {
+		snapshot, err := scanNotebookSnapshot(rows)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, rows.Err()
+}
+
+const countNotebookSnapshotsFmtStr = `SELECT COUNT(*) FROM notebook_snapshots WHERE notebook_id = %d AND user_id = %d`
+
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook,
+// and that userID is the actor's user ID.
+func (s *notebooksStore) CountNotebookSnapshots(ctx context.Context, notebookID int64, userID int32) (int64, error) {
+	var count int64
+	err := s.QueryRow(ctx, sqlf.Sprintf(countNotebookSnapshotsFmtStr, notebookID, userID)).Scan(&count)
+	if err != nil {
+		return -1, err
+	}
+	return count, nil
+}
+
+const deleteOldNotebookSnapshotsFmtStr = `
+WITH latest AS (
+	SELECT DISTINCT ON (notebook_id, user_id) id
+	FROM notebook_snapshots
+	ORDER BY notebook_id, user_id, created_at DESC, id DESC
+),
+deleted_snapshots AS (
+	DELETE FROM notebook_snapshots
+	WHERE
+		created_at < (now() - (%s * '1 day'::interval))
+		AND id NOT IN (SELECT id FROM latest)
+)
+DELETE FROM notebook_snapshot_jobs
+WHERE finished_at < (now() - (%s * '1 day'::interval))
+`
+
+// DeleteOldNotebookSnapshots deletes snapshots and finished snapshot jobs that
+// are older than the retention period. The latest snapshot of every notebook
+// and user is kept, so that the next snapshot can be compared to it.
+func (s *notebooksStore) DeleteOldNotebookSnapshots(ctx context.Context, retentionInDays int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteOldNotebookSnapshotsFmtStr, retentionInDays, retentionInDays))
+}
+
 func nullInt32Column(n int32) *int32 {
 	if n == 0 {
 		return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/log/logtest"
 
@@ -682,3 +683,109 @@ func TestCreatingAndDeletingNotebookStars(t *testing.T) {
 		t.Errorf("expected non-nil error, got nil")
 	}
 }
+
+func TestNotebookSnapshots(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	internalCtx := actor.WithInternalActor(context.Background())
+	u := db.Users()
+	n := Notebooks(db)
+
+	user, err := u.Create(internalCtx, database.NewUser{Username: "u1", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	createdNotebooks, err := createNotebooks(internalCtx, n, []*Notebook{
+		notebookByUser(&Notebook{Title: "Notebook", Blocks: NotebookBlocks{}, Public: true}, user.ID),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	notebook := createdNotebooks[0]
+
+	_, err = n.GetNotebookSnapshotSchedule(internalCtx, notebook.ID)
+	if !errors.Is(err, ErrNotebookSnapshotScheduleNotFound) {
+		t.Fatalf("expected ErrNotebookSnapshotScheduleNotFound, got %+v", err)
+	}
+
+	schedule, err := n.UpsertNotebookSnapshotSchedule(internalCtx, notebook.ID, user.ID, NotebookSnapshotIntervalDaily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Updating the schedule keeps a single schedule per notebook.
+	updatedSchedule, err := n.UpsertNotebookSnapshotSchedule(internalCtx, notebook.ID, user.ID, NotebookSnapshotIntervalWeekly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updatedSchedule.ID != schedule.ID || updatedSchedule.Interval != NotebookSnapshotIntervalWeekly {
+		t.Fatalf("unexpected updated schedule %+v", updatedSchedule)
+	}
+
+	// New schedules are due right away.
+	jobs, err := n.EnqueueNotebookSnapshotJobs(internalCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].ScheduleID != schedule.ID {
+		t.Fatalf("expected a single job for schedule %d, got %+v", schedule.ID, jobs)
+	}
+	// The next run was moved forward, so nothing is enqueued until then.
+	jobs2, err := n.EnqueueNotebookSnapshotJobs(internalCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs2) != 0 {
+		t.Fatalf("expected no jobs, got %+v", jobs2)
+	}
+
+	jobSchedule, err := n.GetNotebookSnapshotScheduleForJob(internalCtx, jobs[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobSchedule.NotebookID != notebook.ID || jobSchedule.UserID != user.ID {
+		t.Fatalf("unexpected schedule for job %+v", jobSchedule)
+	}
+
+	for _, content := range []string{"a", "b"} {
+		_, err := n.CreateNotebookSnapshot(internalCtx, &NotebookSnapshot{
+			NotebookID: notebook.ID,
+			UserID:     user.ID,
+			Blocks: NotebookBlockSnapshots{
+				{ID: "1", Type: NotebookQueryBlockType, Matches: []BlockMatch{{Label: "repo", Content: content}}},
+			},
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshots, err := n.ListNotebookSnapshots(internalCtx, ListNotebookSnapshotsPageOptions{First: 10}, notebook.ID, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(snapshots))
+	}
+	if got := snapshots[0].Blocks[0].Matches[0].Content; got != "b" {
+		t.Fatalf("expected the most recent snapshot first, got content %q", got)
+	}
+	count, err := n.CountNotebookSnapshots(internalCtx, notebook.ID, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", count)
+	}
+
+	err = n.DeleteNotebookSnapshotSchedule(internalCtx, notebook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = n.GetNotebookSnapshotSchedule(internalCtx, notebook.ID)
+	if !errors.Is(err, ErrNotebookSnapshotScheduleNotFound) {
+		t.Fatalf("expected ErrNotebookSnapshotScheduleNotFound, got %+v", err)
+	}
+}
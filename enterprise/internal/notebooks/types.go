@@ -81,3 +81,77 @@ type NotebookStar struct {
 	UserID     int32
 	CreatedAt  time.Time
 }
+
+// NotebookSnapshotInterval is how often the snapshots of a notebook are
+// recorded.
+type NotebookSnapshotInterval string
+
+const (
+	NotebookSnapshotIntervalDaily  NotebookSnapshotInterval = "DAILY"
+	NotebookSnapshotIntervalWeekly NotebookSnapshotInterval = "WEEKLY"
+)
+
+// Duration returns the time between two snapshots.
+func (i NotebookSnapshotInterval) Duration() time.Duration {
+	if i == NotebookSnapshotIntervalWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// NotebookSnapshotSchedule is the schedule on which the snapshots of a
+// notebook are recorded.
+type NotebookSnapshotSchedule struct {
+	ID         int64
+	NotebookID int64
+	UserID     int32 // the user who scheduled the snapshots, the notebook is executed as this user
+	Interval   NotebookSnapshotInterval
+	NextRunAt  time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NotebookBlockSnapshot holds the results of a block at the time of a
+// snapshot.
+type NotebookBlockSnapshot struct {
+	ID       string            `json:"id"`
+	Type     NotebookBlockType `json:"type"`
+	Matches  []BlockMatch      `json:"matches"`
+	LimitHit bool              `json:"limitHit"`
+	Error    string            `json:"error,omitempty"`
+}
+
+type NotebookBlockSnapshots []NotebookBlockSnapshot
+
+// NotebookSnapshot holds the results of the executable blocks of a notebook at
+// a point in time.
+type NotebookSnapshot struct {
+	ID         int64
+	NotebookID int64
+	// UserID is the user the notebook was executed as. Snapshots can contain
+	// results from repositories only that user can access, so they are only
+	// visible to them.
+	UserID    int32
+	Blocks    NotebookBlockSnapshots
+	CreatedAt time.Time
+}
+
+// NotebookSnapshotJob is a job that records a snapshot of a notebook on a
+// snapshot schedule.
+type NotebookSnapshotJob struct {
+	ID         int32
+	ScheduleID int64
+
+	// Fields demanded for any dbworker.
+	State          string
+	FailureMessage *string
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	ProcessAfter   *time.Time
+	NumResets      int32
+	NumFailures    int32
+}
+
+func (j *NotebookSnapshotJob) RecordID() int {
+	return int(j.ID)
+}
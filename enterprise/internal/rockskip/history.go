@@ -0,0 +1,383 @@
+package rockskip
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/keegancsmith/sqlf"
+	pg "github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// commitChain is the first-parent history of an indexed commit, oldest commit first. Rockskip indexes commits along
+// their first-parent history, so every commit of the chain is indexed and the ancestor of every commit is also on the
+// chain.
+type commitChain struct {
+	hashes    []string
+	ids       []CommitId
+	positions map[CommitId]int
+	ancestors map[CommitId]CommitId
+}
+
+// getCommitChain returns the chain of the commits with the given hashes, which must be the first-parent history of an
+// indexed commit as returned by Git.RevListEach (newest commit first).
+func getCommitChain(ctx context.Context, db dbutil.DB, repoId int, hashes []string) (*commitChain, error) {
+	hashToCommit := make(map[string]CommitId, len(hashes))
+	ancestors := make(map[CommitId]CommitId, len(hashes))
+
+	for _, chunk := range chunksOf(hashes, 1000) {
+		rows, err := db.QueryContext(ctx, `
+			SELECT id, commit_id, ancestor
+			FROM rockskip_ancestry
+			WHERE repo_id = $1 AND commit_id = ANY($2)
+		`, repoId, pg.Array(chunk))
+		if err != nil {
+			return nil, errors.Newf("getCommitChain: %s", err)
+		}
+		for rows.Next() {
+			var id, ancestor CommitId
+			var hash string
+			if err := rows.Scan(&id, &hash, &ancestor); err != nil {
+				return nil, errors.Newf("getCommitChain: %s", err)
+			}
+			hashToCommit[hash] = id
+			ancestors[id] = ancestor
+		}
+		err = rows.Close()
+		if err != nil {
+			return nil, errors.Newf("getCommitChain: %s", err)
+		}
+	}
+
+	chain := &commitChain{
+		hashes:    make([]string, 0, len(hashes)),
+		ids:       make([]CommitId, 0, len(hashes)),
+		positions: make(map[CommitId]int, len(hashes)),
+		ancestors: ancestors,
+	}
+	for i := len(hashes) - 1; i >= 0; i-- {
+		id, ok := hashToCommit[hashes[i]]
+		if !ok {
+			return nil, errors.Newf("commit %s is not indexed", hashes[i])
+		}
+		chain.positions[id] = len(chain.ids)
+		chain.hashes = append(chain.hashes, hashes[i])
+		chain.ids = append(chain.ids, id)
+	}
+
+	return chain, nil
+}
+
+// hops returns the hops of the commit at the given position, without the null commit. It is equivalent to getHops
+// but does not query the database.
+func (c *commitChain) hops(position int) []CommitId {
+	hops := []CommitId{}
+	for current := c.ids[position]; current != NULL; current = c.ancestors[current] {
+		hops = append(hops, current)
+	}
+	return hops
+}
+
+// alive returns true if a symbol with the given added and deleted hops exists at the commit at the given position.
+func (c *commitChain) alive(position int, added, deleted []CommitId) bool {
+	hops := c.hops(position)
+	return intersects(hops, added) && !intersects(hops, deleted)
+}
+
+// deletion returns the position of the commit that deleted a symbol that was introduced at the given position, or -1
+// if the symbol still exists at the newest commit of the chain.
+//
+// A symbol that is deleted and inserted again gets a new row, so each row exists on a single contiguous range of the
+// chain. The deletion commit is not always recorded in the deleted hops of the row, so it is found with a binary
+// search over that range.
+func (c *commitChain) deletion(introduced int, added, deleted []CommitId) int {
+	newest := len(c.ids) - 1
+	if c.alive(newest, added, deleted) {
+		return -1
+	}
+
+	// Invariant: the symbol exists at lo and does not exist at hi.
+	lo, hi := introduced, newest
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if c.alive(mid, added, deleted) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+func intersects(a, b []CommitId) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// historicalSymbol is a symbol row along with the positions of the commits that introduced and deleted it.
+type historicalSymbol struct {
+	path       string
+	name       string
+	introduced int
+	deleted    int
+}
+
+// lastSeen returns the position of the newest commit at which the symbol exists.
+func (s historicalSymbol) lastSeen(chain *commitChain) int {
+	if s.deleted == -1 {
+		return len(chain.ids) - 1
+	}
+	return s.deleted - 1
+}
+
+const (
+	// historyMaxCommits is the number of commits of the first-parent history that a symbol history search covers.
+	// Symbols introduced before are not returned.
+	historyMaxCommits = 100_000
+
+	// historyPageSize is the number of commits of the chain whose introduced symbols are queried at once.
+	historyPageSize = 1000
+)
+
+// querySymbolHistory returns the symbols that match the search args and that were introduced at some point of the
+// first-parent history of the given commit, including those that have since been deleted. Each symbol carries the
+// commit that introduced it and, if it was deleted, the commit that deleted it. Deleted symbols are located in the
+// newest commit that contains them.
+//
+// Only the newest historyMaxCommits commits of the history are searched, and the oldest symbols are returned first.
+func (s *Service) querySymbolHistory(ctx context.Context, args search.SymbolsParameters, repoId int, threadStatus *ThreadStatus) (result.Symbols, error) {
+	db := database.NewDB(s.logger, s.db)
+
+	threadStatus.Tasklog.Start("RevList")
+	hashes := []string{}
+	err := s.git.RevListEach(string(args.Repo), db, string(args.CommitID), func(commitHash string) (shouldContinue bool, err error) {
+		hashes = append(hashes, commitHash)
+		return len(hashes) < historyMaxCommits, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "RevList")
+	}
+
+	threadStatus.Tasklog.Start("get commit chain")
+	chain, err := getCommitChain(ctx, s.db, repoId, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := DEFAULT_LIMIT
+	if args.First > 0 {
+		limit = args.First
+	}
+
+	// Page through the chain oldest commits first, so that each query only binds a page of commits.
+	historicalSymbols := []historicalSymbol{}
+	var q *sqlf.Query
+	var duration time.Duration
+	for _, page := range chunksOf(chain.ids, historyPageSize) {
+		if len(historicalSymbols) >= limit {
+			break
+		}
+
+		threadStatus.Tasklog.Start("run query")
+		q = historyQuery(args, repoId, page, limit-len(historicalSymbols))
+		start := time.Now()
+		pageSymbols, err := queryHistoricalSymbols(ctx, s.db, q, chain, threadStatus)
+		duration += time.Since(start)
+		if err != nil {
+			return nil, err
+		}
+		historicalSymbols = append(historicalSymbols, pageSymbols...)
+	}
+
+	// Make the results deterministic, oldest symbols first.
+	sort.Slice(historicalSymbols, func(i, j int) bool {
+		a, b := historicalSymbols[i], historicalSymbols[j]
+		if a.introduced != b.introduced {
+			return a.introduced < b.introduced
+		}
+		if a.path != b.path {
+			return a.path < b.path
+		}
+		return a.name < b.name
+	})
+
+	// Parse each file at the newest commit that contains its symbols to find their locations.
+	symbols := make([]result.Symbol, len(historicalSymbols))
+	lastSeenToPathToIndexes := map[int]map[string][]int{}
+	for i, historical := range historicalSymbols {
+		lastSeen := historical.lastSeen(chain)
+		symbols[i] = result.Symbol{
+			Name: historical.name,
+			Path: historical.path,
+			History: &result.SymbolHistory{
+				Introduced: api.CommitID(chain.hashes[historical.introduced]),
+				LastSeen:   api.CommitID(chain.hashes[lastSeen]),
+			},
+		}
+		if historical.deleted != -1 {
+			symbols[i].History.Deleted = api.CommitID(chain.hashes[historical.deleted])
+		}
+
+		if lastSeenToPathToIndexes[lastSeen] == nil {
+			lastSeenToPathToIndexes[lastSeen] = map[string][]int{}
+		}
+		lastSeenToPathToIndexes[lastSeen][historical.path] = append(lastSeenToPathToIndexes[lastSeen][historical.path], i)
+	}
+
+	parser, err := s.createParser()
+	if err != nil {
+		return nil, errors.Wrap(err, "create parser")
+	}
+	defer parser.Close()
+
+	for lastSeen, pathToIndexes := range lastSeenToPathToIndexes {
+		paths := make([]string, 0, len(pathToIndexes))
+		for path := range pathToIndexes {
+			paths = append(paths, path)
+		}
+
+		threadStatus.Tasklog.Start("ArchiveEach")
+		err = s.git.ArchiveEach(string(args.Repo), chain.hashes[lastSeen], paths, func(path string, contents []byte) error {
+			defer threadStatus.Tasklog.Continue("ArchiveEach")
+
+			threadStatus.Tasklog.Start("parse")
+			entries, err := parser.Parse(path, contents)
+			if err != nil {
+				return err
+			}
+
+			lines := strings.Split(string(contents), "\n")
+
+			for _, i := range pathToIndexes[path] {
+				symbol := &symbols[i]
+				found := false
+				for _, entry := range entries {
+					if entry.Name != symbol.Name {
+						continue
+					}
+					if entry.Line < 1 || entry.Line > len(lines) {
+						log15.Warn("ctags returned an invalid line number", "path", path, "line", entry.Line, "len(lines)", len(lines), "symbol", entry.Name)
+						continue
+					}
+
+					character := strings.Index(lines[entry.Line-1], entry.Name)
+					if character == -1 {
+						// Could not find the symbol in the line. ctags doesn't always return the right line.
+						character = 0
+					}
+
+					symbol.Line = entry.Line - 1
+					symbol.Character = character
+					symbol.Kind = entry.Kind
+					symbol.Parent = entry.Parent
+					found = true
+					break
+				}
+				if !found {
+					// The symbol is still returned for its history, located at the start of the file.
+					log15.Warn("Could not find symbol in the commit where it was last seen", "repo", args.Repo, "commit", chain.hashes[lastSeen], "path", path, "symbol", symbol.Name)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if s.logQueries && q != nil {
+		err = logQuery(ctx, db, args, q, duration, len(symbols))
+		if err != nil {
+			return nil, errors.Wrap(err, "logQuery")
+		}
+	}
+
+	return symbols, nil
+}
+
+// historyQuery returns the query for at most limit symbols that match the search args and that were introduced at one
+// of the given commits. Symbols introduced on other branches are never visible on the chain, so none of their added
+// hops are on the chain.
+func historyQuery(args search.SymbolsParameters, repoId int, commits []CommitId, limit int) *sqlf.Query {
+	return sqlf.Sprintf(`
+		SELECT path, name, added, deleted
+		FROM rockskip_symbols
+		WHERE
+			%s && singleton_integer(repo_id)
+			AND %s && added
+			AND added[1] = ANY(%s)
+			AND %s
+		LIMIT %s;`,
+		pg.Array([]int{repoId}),
+		pg.Array(commits),
+		pg.Array(commits),
+		convertSearchArgsToSqlQuery(args),
+		limit,
+	)
+}
+
+// queryHistoricalSymbols runs a query returned by historyQuery and finds the commits that deleted the symbols.
+func queryHistoricalSymbols(ctx context.Context, db dbutil.DB, q *sqlf.Query, chain *commitChain, threadStatus *ThreadStatus) ([]historicalSymbol, error) {
+	rows, err := db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, errors.Wrap(err, "Search")
+	}
+	defer rows.Close()
+
+	threadStatus.Tasklog.Start("find deletions")
+	historicalSymbols := []historicalSymbol{}
+	for rows.Next() {
+		var path, name string
+		var added64, deleted64 []int64
+		if err := rows.Scan(&path, &name, pg.Array(&added64), pg.Array(&deleted64)); err != nil {
+			return nil, errors.Wrap(err, "Search: Scan")
+		}
+		added, deleted := toCommitIds(added64), toCommitIds(deleted64)
+
+		if len(added) == 0 {
+			continue
+		}
+
+		// The first added hop is the commit that inserted the symbol.
+		introduced, ok := chain.positions[added[0]]
+		if !ok || !chain.alive(introduced, added, deleted) {
+			continue
+		}
+
+		historicalSymbols = append(historicalSymbols, historicalSymbol{
+			path:       path,
+			name:       name,
+			introduced: introduced,
+			deleted:    chain.deletion(introduced, added, deleted),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "Search: Next")
+	}
+
+	return historicalSymbols, nil
+}
+
+func toCommitIds(xs []int64) []CommitId {
+	ids := make([]CommitId, 0, len(xs))
+	for _, x := range xs {
+		ids = append(ids, CommitId(x))
+	}
+	return ids
+}
@@ -0,0 +1,166 @@
+package rockskip
+
+import (
+	"fmt"
+	"testing"
+)
+
+// symbolRow is the added and deleted hops of a row of rockskip_symbols.
+type symbolRow struct {
+	added   []CommitId
+	deleted []CommitId
+}
+
+// simulateIndex mimics how Service.Index maintains the ancestry of a linear history of n commits, with the commit at
+// height h having ID h, and the rows of a symbol that is inserted at the heights in insertions and deleted at the
+// heights in deletions.
+func simulateIndex(n int, insertions, deletions []int) (chain *commitChain, rows []*symbolRow) {
+	chain = &commitChain{positions: map[CommitId]int{}, ancestors: map[CommitId]CommitId{}}
+
+	for height := 1; height <= n; height++ {
+		hops := []CommitId{}
+		if height > 1 {
+			hops = chain.hops(height - 2)
+		}
+		hops = append(hops, NULL)
+
+		r := ruler(height)
+		chain.ancestors[height] = hops[r]
+		chain.positions[height] = len(chain.ids)
+		chain.ids = append(chain.ids, height)
+		chain.hashes = append(chain.hashes, fmt.Sprint(height))
+
+		// AppendHop
+		for _, row := range rows {
+			if intersects(hops[0:r], row.added) && !intersects(hops[0:r], row.deleted) {
+				row.added = append(row.added, height)
+			} else if intersects(hops[0:r], row.deleted) && !intersects(hops[0:r], row.added) {
+				row.deleted = append(row.deleted, height)
+			}
+		}
+
+		// GetSymbol and UpdateSymbolHops
+		if contains(deletions, height) {
+			for _, row := range rows {
+				if intersects(hops, row.added) && !intersects(hops, row.deleted) {
+					row.deleted = append(row.deleted, height)
+				}
+			}
+		}
+
+		// InsertSymbol always inserts a new row, so a symbol that is deleted and inserted again has one row per
+		// insertion.
+		if contains(insertions, height) {
+			rows = append(rows, &symbolRow{added: []CommitId{height}})
+		}
+
+		// DeleteRedundant
+		for _, row := range rows {
+			if intersects([]CommitId{height}, row.added) && intersects([]CommitId{height}, row.deleted) {
+				row.added = remove(row.added, height)
+				row.deleted = remove(row.deleted, height)
+			}
+		}
+	}
+
+	return chain, rows
+}
+
+func contains(xs []int, x int) bool {
+	for _, y := range xs {
+		if y == x {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(xs []CommitId, x CommitId) []CommitId {
+	result := []CommitId{}
+	for _, y := range xs {
+		if y != x {
+			result = append(result, y)
+		}
+	}
+	return result
+}
+
+func TestCommitChainDeletion(t *testing.T) {
+	n := 37
+
+	for introducedAt := 1; introducedAt <= n; introducedAt++ {
+		// 0 stands for never.
+		deletions := []int{0}
+		for deletedAt := introducedAt + 1; deletedAt <= n; deletedAt++ {
+			deletions = append(deletions, deletedAt)
+		}
+
+		for _, deletedAt := range deletions {
+			chain, rows := simulateIndex(n, []int{introducedAt}, []int{deletedAt})
+			added, deleted := rows[0].added, rows[0].deleted
+			name := fmt.Sprintf("introduced at %d, deleted at %d", introducedAt, deletedAt)
+
+			if added[0] != introducedAt {
+				t.Fatalf("%s: expected the first added hop to be %d, got %v", name, introducedAt, added)
+			}
+
+			for position := range chain.ids {
+				height := position + 1
+				want := height >= introducedAt && (deletedAt == 0 || height < deletedAt)
+				if got := chain.alive(position, added, deleted); got != want {
+					t.Fatalf("%s: expected alive at %d to be %v, got %v (added %v, deleted %v)", name, height, want, got, added, deleted)
+				}
+			}
+
+			want := -1
+			if deletedAt != 0 {
+				want = deletedAt - 1
+			}
+			if got := chain.deletion(introducedAt-1, added, deleted); got != want {
+				t.Fatalf("%s: expected deletion at position %d, got %d", name, want, got)
+			}
+		}
+	}
+}
+
+func TestCommitChainDeletionReinserted(t *testing.T) {
+	n := 37
+
+	// The symbol exists from height 3 to 9, from 12 to 19, and from 21 to the newest commit.
+	insertions := []int{3, 12, 21}
+	deletions := []int{10, 20}
+
+	chain, rows := simulateIndex(n, insertions, deletions)
+	if len(rows) != len(insertions) {
+		t.Fatalf("expected %d rows, got %d", len(insertions), len(rows))
+	}
+
+	wantDeletions := []int{10 - 1, 20 - 1, -1}
+	for i, row := range rows {
+		introduced := chain.positions[row.added[0]]
+		if want := insertions[i] - 1; introduced != want {
+			t.Fatalf("row %d: expected introduction at position %d, got %d", i, want, introduced)
+		}
+		if got := chain.deletion(introduced, row.added, row.deleted); got != wantDeletions[i] {
+			t.Fatalf("row %d: expected deletion at position %d, got %d (added %v, deleted %v)", i, wantDeletions[i], got, row.added, row.deleted)
+		}
+	}
+
+	// Every commit contains the symbol through at most one row.
+	for position := range chain.ids {
+		alive := 0
+		for _, row := range rows {
+			if chain.alive(position, row.added, row.deleted) {
+				alive++
+			}
+		}
+		height := position + 1
+		want := 0
+		if (height >= 3 && height < 10) || (height >= 12 && height < 20) || height >= 21 {
+			want = 1
+		}
+		if alive != want {
+			t.Fatalf("expected %d alive rows at %d, got %d", want, height, alive)
+		}
+	}
+}
@@ -106,6 +106,14 @@ func (s *Service) Search(ctx context.Context, args search.SymbolsParameters) (_
 	}
 
 	// Finally search.
+	if args.History {
+		symbols, err := s.querySymbolHistory(ctx, args, repoId, threadStatus)
+		if err != nil {
+			return nil, errors.Wrap(err, "querySymbolHistory")
+		}
+		return symbols, nil
+	}
+
 	symbols, err := s.querySymbols(ctx, args, repoId, commit, threadStatus)
 	if err != nil {
 		return nil, errors.Wrap(err, "querySymbols")
@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -167,9 +168,10 @@ func TestIndex(t *testing.T) {
 		}
 	}
 
-	commit := func(message string) {
+	commit := func(message string) string {
 		gitRun("commit", "--allow-empty", "-m", message)
 		verifyBlobs()
+		return getHead()
 	}
 
 	add("a.txt", "sym1\n")
@@ -179,15 +181,40 @@ func TestIndex(t *testing.T) {
 	commit("add another file with 1 symbol")
 
 	add("c.txt", "sym1\nsym2")
-	commit("add another file with 2 symbols")
+	addedToC := commit("add another file with 2 symbols")
 
 	add("a.txt", "sym1\nsym2")
-	commit("add a symbol to a.txt")
+	addedToA := commit("add a symbol to a.txt")
 
-	commit("empty")
+	lastSeenInA := commit("empty")
 
 	rm("a.txt")
-	commit("rm a.txt")
+	deletedFromA := commit("rm a.txt")
+
+	head := commit("empty")
+
+	// Both sym2 symbols are in the history, including the deleted one.
+	args := search.SymbolsParameters{Repo: "somerepo", CommitID: api.CommitID(head), Query: "^sym2$", IsRegExp: true, IsCaseSensitive: true, History: true}
+	symbols, err := service.Search(context.Background(), args)
+	fatalIfError(err, "Search")
+
+	want := result.Symbols{
+		{
+			Name:    "sym2",
+			Path:    "c.txt",
+			Line:    1,
+			History: &result.SymbolHistory{Introduced: api.CommitID(addedToC), LastSeen: api.CommitID(head)},
+		},
+		{
+			Name:    "sym2",
+			Path:    "a.txt",
+			Line:    1,
+			History: &result.SymbolHistory{Introduced: api.CommitID(addedToA), Deleted: api.CommitID(deletedFromA), LastSeen: api.CommitID(lastSeenInA)},
+		},
+	}
+	if diff := cmp.Diff(want, symbols); diff != "" {
+		t.Fatalf("unexpected symbol history (-want +got):\n%s", diff)
+	}
 }
 
 type SubprocessGit struct {
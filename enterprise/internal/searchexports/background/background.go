@@ -0,0 +1,31 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchexports"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+)
+
+// NewBackgroundJobs returns the routines that run search exports and delete
+// them once they are older than retention.
+func NewBackgroundJobs(logger log.Logger, db database.DB, uploadStore uploadstore.Store, retention time.Duration) []goroutine.BackgroundRoutine {
+	logger = logger.Scoped("BackgroundJobs", "search exports background jobs")
+
+	store := searchexports.Exports(db)
+	metrics := newMetricsForExportJobs(logger)
+
+	// Create a new context. Each background routine will wrap this with
+	// a cancellable context that is canceled when Stop() is called.
+	ctx := context.Background()
+	return []goroutine.BackgroundRoutine{
+		newExportRunner(ctx, logger, db, uploadStore, metrics),
+		newExportJobResetter(ctx, store, metrics),
+		newExportDeleter(ctx, logger, store, uploadStore, retention),
+	}
+}
@@ -0,0 +1,162 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchexports"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/viewersettings"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/search/run"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func newExportRunner(ctx context.Context, logger log.Logger, db database.DB, uploadStore uploadstore.Store, metrics exportMetrics) *workerutil.Worker {
+	options := workerutil.WorkerOptions{
+		Name:              "search_export_jobs_worker",
+		NumHandlers:       2,
+		Interval:          5 * time.Second,
+		HeartbeatInterval: 15 * time.Second,
+		Metrics:           metrics.workerMetrics,
+		// Exports of large result sets run for a long time, since they are
+		// not limited to the results shown to interactive searches.
+		MaximumRuntimePerJob: time.Hour,
+	}
+	handler := &exportRunner{
+		db:          db,
+		uploadStore: uploadStore,
+		// Exports search with the settings of the user that requested
+		// them, such as their search defaults, as the search page does.
+		exporter: searchexports.NewExporter(logger, db, viewersettings.Settings),
+	}
+	return dbworker.NewWorker(ctx, createDBWorkerStoreForExportJobs(searchexports.Exports(db)), handler, options)
+}
+
+func newExportJobResetter(_ context.Context, store searchexports.ExportsStore, metrics exportMetrics) *dbworker.Resetter {
+	workerStore := createDBWorkerStoreForExportJobs(store)
+
+	options := dbworker.ResetterOptions{
+		Name:     "search_export_jobs_worker_resetter",
+		Interval: 1 * time.Minute,
+		Metrics: dbworker.ResetterMetrics{
+			Errors:              metrics.errors,
+			RecordResetFailures: metrics.resetFailures,
+			RecordResets:        metrics.resets,
+		},
+	}
+	return dbworker.NewResetter(workerStore, options)
+}
+
+func newExportDeleter(ctx context.Context, logger log.Logger, store searchexports.ExportsStore, uploadStore uploadstore.Store, retention time.Duration) goroutine.BackgroundRoutine {
+	deleteExports := goroutine.NewHandlerWithErrorMessage(
+		"search_exports_deleter",
+		func(ctx context.Context) error {
+			keys, err := store.DeleteOldExportJobs(ctx, retention)
+			if err != nil {
+				return err
+			}
+			for _, key := range keys {
+				// The files also expire in the upload store, so a file that
+				// cannot be deleted now is deleted eventually.
+				if err := uploadStore.Delete(ctx, key); err != nil {
+					logger.Warn("failed to delete search export", log.String("key", key), log.Error(err))
+				}
+			}
+			return nil
+		})
+	return goroutine.NewPeriodicGoroutine(ctx, 60*time.Minute, deleteExports)
+}
+
+func createDBWorkerStoreForExportJobs(s basestore.ShareableStore) dbworkerstore.Store {
+	return dbworkerstore.New(s.Handle(), dbworkerstore.Options{
+		Name:              "search_export_jobs_worker_store",
+		TableName:         "search_export_jobs",
+		ColumnExpressions: searchexports.ExportJobColumns,
+		Scan:              searchexports.ScanExportJobRecord,
+		StalledMaxAge:     60 * time.Second,
+		RetryAfter:        time.Minute,
+		MaxNumRetries:     3,
+		OrderByExpression: sqlf.Sprintf("id"),
+	})
+}
+
+type exportRunner struct {
+	db          database.DB
+	uploadStore uploadstore.Store
+	exporter    *searchexports.Exporter
+}
+
+func (r *exportRunner) Handle(ctx context.Context, logger log.Logger, record workerutil.Record) (err error) {
+	defer func() {
+		if err != nil {
+			logger.Error("exportRunner.Handle", log.Error(err))
+		}
+	}()
+
+	job, ok := record.(*searchexports.ExportJob)
+	if !ok {
+		return errors.Errorf("unexpected record type %T", record)
+	}
+
+	// SECURITY: set the actor to the user that requested the export, so that
+	// the export only contains the repositories and files they can see.
+	ctx = actor.WithActor(ctx, actor.FromUser(job.UserID))
+	ctx = featureflag.WithFlags(ctx, r.db.FeatureFlags())
+
+	store := searchexports.Exports(r.db)
+	progress := func(manifest searchexports.Manifest) {
+		if err := store.UpdateExportManifest(ctx, job.ID, manifest); err != nil {
+			logger.Warn("failed to update search export progress", log.Error(err))
+		}
+	}
+
+	// The results are uploaded while the search runs, so that they are never
+	// held in memory or on disk.
+	pr, pw := io.Pipe()
+	type exportResult struct {
+		manifest searchexports.Manifest
+		err      error
+	}
+	exported := make(chan exportResult, 1)
+	go func() {
+		manifest, err := r.exporter.Export(ctx, job, pw, progress)
+		pw.CloseWithError(err)
+		exported <- exportResult{manifest: manifest, err: err}
+	}()
+
+	key := fmt.Sprintf("search-exports/%d.%s", job.ID, job.Format)
+	size, uploadErr := r.uploadStore.Upload(ctx, key, pr)
+	// Unblock the export if the upload stopped reading early.
+	pr.CloseWithError(uploadErr)
+
+	result := <-exported
+	if result.err != nil {
+		var queryErr *run.QueryError
+		if errors.As(result.err, &queryErr) {
+			return errcode.MakeNonRetryable(result.err)
+		}
+		return result.err
+	}
+	if uploadErr != nil {
+		return uploadErr
+	}
+
+	manifest := result.manifest
+	manifest.SizeBytes = size
+	return store.MarkExportUploaded(ctx, job.ID, key, manifest)
+}
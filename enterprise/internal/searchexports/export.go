@@ -0,0 +1,240 @@
+package searchexports
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/run"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// maxExportResults is the result limit of exports whose query has no count:
+// filter. It is the limit count:all stands for.
+const maxExportResults = 99999999
+
+// progressInterval is how often the manifest of a running export is updated.
+const progressInterval = 5 * time.Second
+
+// SettingsFunc returns the settings of the current actor, which the search of
+// an export is run with.
+type SettingsFunc func(ctx context.Context) (*schema.Settings, error)
+
+// Exporter runs the searches of exports.
+type Exporter struct {
+	db           database.DB
+	settings     SettingsFunc
+	searchClient client.SearchClient
+}
+
+func NewExporter(logger log.Logger, db database.DB, settings SettingsFunc) *Exporter {
+	logger = logger.Scoped("searchExporter", "runs the searches of search exports")
+	return &Exporter{
+		db:           db,
+		settings:     settings,
+		searchClient: client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs()),
+	}
+}
+
+// Plan parses the query of job. Unlike interactive searches, the number of
+// results is not limited unless the query has a count: filter.
+func (e *Exporter) Plan(ctx context.Context, job *ExportJob) (*run.SearchInputs, error) {
+	settings, err := e.settings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	patternType := job.PatternType
+	inputs, err := e.searchClient.Plan(ctx, "V3", &patternType, job.Query, search.Streaming, settings, envvar.SourcegraphDotComMode())
+	if err != nil {
+		return nil, err
+	}
+	inputs.Plan = withoutResultLimit(inputs.Plan)
+	inputs.Query = inputs.Plan.ToQ()
+	return inputs, nil
+}
+
+// withoutResultLimit adds the maximum count: filter to every basic query of
+// plan that does not have one. Like any query with a count: filter, it is then
+// run with the maximum timeout.
+func withoutResultLimit(plan query.Plan) query.Plan {
+	mapped := make(query.Plan, 0, len(plan))
+	for _, basic := range plan {
+		if basic.Parameters.Count() == nil {
+			parameters := append(basic.Parameters[:len(basic.Parameters):len(basic.Parameters)], query.Parameter{
+				Field: query.FieldCount,
+				Value: strconv.Itoa(maxExportResults),
+			})
+			basic = basic.MapParameters(parameters)
+		}
+		mapped = append(mapped, basic)
+	}
+	return mapped
+}
+
+// Export runs the search of job as the current actor and writes its results
+// to w. progress is called periodically with the manifest of the rows
+// written so far.
+func (e *Exporter) Export(ctx context.Context, job *ExportJob, w io.Writer, progress func(Manifest)) (Manifest, error) {
+	inputs, err := e.Plan(ctx, job)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	rw, err := newRowWriter(job.Format, w)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		manifest Manifest
+		stats    streaming.Stats
+		writeErr error
+	)
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		stats.Update(&event.Stats)
+		if writeErr != nil {
+			return
+		}
+		for _, match := range event.Results {
+			for _, row := range rowsFromMatch(match) {
+				if err := rw.WriteRow(row); err != nil {
+					writeErr = err
+					cancel()
+					return
+				}
+				manifest.ResultCount++
+			}
+		}
+	})
+
+	// The progress of the export is reported until it finished, and never
+	// after Export returned.
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	defer func() {
+		close(done)
+		wg.Wait()
+	}()
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				mu.Lock()
+				m := manifest
+				mu.Unlock()
+				progress(m)
+			}
+		}
+	}()
+
+	alert, err := e.searchClient.Execute(ctx, stream, inputs)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if writeErr != nil {
+		return Manifest{}, writeErr
+	}
+	if err != nil {
+		return Manifest{}, err
+	}
+	if err := rw.Flush(); err != nil {
+		return Manifest{}, err
+	}
+
+	manifest.LimitHit = stats.IsLimitHit
+	manifest.Incomplete = isIncomplete(&stats)
+	if alert != nil {
+		manifest.Alert = alert.Title
+	}
+	manifest.Skipped, err = e.skippedRepositories(ctx, &stats.Status)
+	if err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
+// isIncomplete returns true if stats report repositories that were not
+// searched, or not searched to the end, for other reasons than a result limit.
+// Exports run with the maximum search timeout, so large exports may time out
+// in some repositories.
+func isIncomplete(stats *streaming.Stats) bool {
+	return stats.Status.Any(search.RepoStatusCloning | search.RepoStatusMissing | search.RepoStatusTimedout)
+}
+
+var skippedReasons = []struct {
+	status search.RepoStatus
+	reason string
+}{
+	{search.RepoStatusCloning, "cloning"},
+	{search.RepoStatusMissing, "missing"},
+	{search.RepoStatusTimedout, "timedout"},
+	{search.RepoStatusLimitHit, "limithit"},
+}
+
+// skippedRepositories returns the repositories whose results are missing or
+// incomplete according to statuses, ordered by name.
+func (e *Exporter) skippedRepositories(ctx context.Context, statuses *search.RepoStatusMap) ([]SkippedRepository, error) {
+	byID := map[api.RepoID]search.RepoStatus{}
+	var ids []api.RepoID
+	statuses.Iterate(func(id api.RepoID, status search.RepoStatus) {
+		if status != 0 {
+			byID[id] = status
+			ids = append(ids, id)
+		}
+	})
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	repos, err := e.db.Repos().GetReposSetByIDs(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	var skipped []SkippedRepository
+	for id, status := range byID {
+		repo, ok := repos[id]
+		if !ok {
+			// The repository was deleted since it was searched.
+			continue
+		}
+		for _, r := range skippedReasons {
+			if status&r.status != 0 {
+				skipped = append(skipped, SkippedRepository{Name: string(repo.Name), Reason: r.reason})
+			}
+		}
+	}
+	sort.Slice(skipped, func(i, j int) bool {
+		if skipped[i].Name != skipped[j].Name {
+			return skipped[i].Name < skipped[j].Name
+		}
+		return skipped[i].Reason < skipped[j].Reason
+	})
+	return skipped, nil
+}
@@ -0,0 +1,60 @@
+package searchexports
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+func TestWithoutResultLimit(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "foo", want: `(and "count:99999999" "foo")`},
+		{query: "foo count:10", want: `(and "count:10" "foo")`},
+		{query: "foo count:all", want: `(and "count:99999999" "foo")`},
+		{query: "(foo count:10) or bar", want: `(or (and "count:10" "foo") (and "count:99999999" "bar"))`},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			plan, err := query.Pipeline(query.Init(test.query, query.SearchTypeLiteral))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := withoutResultLimit(plan).ToQ().String(); got != test.want {
+				t.Fatalf("unexpected query, want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestIsIncomplete(t *testing.T) {
+	newStats := func(status search.RepoStatus) *streaming.Stats {
+		var stats streaming.Stats
+		stats.Status.Update(1, status)
+		return &stats
+	}
+
+	tests := []struct {
+		name  string
+		stats *streaming.Stats
+		want  bool
+	}{
+		{name: "complete", stats: &streaming.Stats{}, want: false},
+		{name: "limit hit", stats: &streaming.Stats{IsLimitHit: true}, want: false},
+		{name: "repo limit hit", stats: newStats(search.RepoStatusLimitHit), want: false},
+		{name: "timed out", stats: newStats(search.RepoStatusTimedout), want: true},
+		{name: "cloning", stats: newStats(search.RepoStatusCloning), want: true},
+		{name: "missing", stats: newStats(search.RepoStatusMissing), want: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isIncomplete(test.stats); got != test.want {
+				t.Errorf("unexpected result, want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
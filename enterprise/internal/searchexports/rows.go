@@ -0,0 +1,143 @@
+package searchexports
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// Row is a line of an exported file. A search result becomes one or more rows,
+// e.g. a file match becomes a row per matched line.
+type Row struct {
+	// Type is one of content, path, symbol, repo, commit or owner.
+	Type       string `json:"type"`
+	Repository string `json:"repository"`
+	Commit     string `json:"commit,omitempty"`
+	Path       string `json:"path,omitempty"`
+
+	// Line is the 1-based number of the matched line, or 0 if the row is not
+	// about a line.
+	Line int `json:"line,omitempty"`
+
+	// Preview is the matched line, symbol name, commit subject or owner
+	// handle.
+	Preview string `json:"preview,omitempty"`
+	URL     string `json:"url"`
+}
+
+var csvHeader = []string{"type", "repository", "commit", "path", "line", "preview", "url"}
+
+// rowsFromMatch returns the rows of a search result.
+func rowsFromMatch(match result.Match) []Row {
+	switch m := match.(type) {
+	case *result.FileMatch:
+		file := Row{
+			Repository: string(m.Repo.Name),
+			Commit:     string(m.CommitID),
+			Path:       m.Path,
+			URL:        m.File.URL().String(),
+		}
+
+		var rows []Row
+		for _, line := range m.ChunkMatches.AsLineMatches() {
+			row := file
+			row.Type = "content"
+			row.Line = int(line.LineNumber) + 1
+			row.Preview = line.Preview
+			rows = append(rows, row)
+		}
+		for _, symbol := range m.Symbols {
+			row := file
+			row.Type = "symbol"
+			row.Line = symbol.Symbol.Line
+			row.Preview = symbol.Symbol.Name
+			row.URL = symbol.URL().String()
+			rows = append(rows, row)
+		}
+		if len(rows) == 0 {
+			file.Type = "path"
+			rows = append(rows, file)
+		}
+		return rows
+
+	case *result.RepoMatch:
+		return []Row{{
+			Type:       "repo",
+			Repository: string(m.Name),
+			URL:        m.URL().String(),
+		}}
+
+	case *result.CommitMatch:
+		return []Row{{
+			Type:       "commit",
+			Repository: string(m.Repo.Name),
+			Commit:     string(m.Commit.ID),
+			Preview:    m.Commit.Message.Subject(),
+			URL:        m.URL().String(),
+		}}
+
+	case *result.OwnerMatch:
+		return []Row{{
+			Type:       "owner",
+			Repository: string(m.Repo.Name),
+			Commit:     string(m.CommitID),
+			Preview:    m.Handle,
+		}}
+	}
+	return nil
+}
+
+// rowWriter writes the rows of an export in its format.
+type rowWriter interface {
+	WriteRow(Row) error
+
+	// Flush writes any buffered rows.
+	Flush() error
+}
+
+func newRowWriter(format Format, w io.Writer) (rowWriter, error) {
+	if format == FormatCSV {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return nil, err
+		}
+		return &csvRowWriter{w: cw}, nil
+	}
+	bw := bufio.NewWriter(w)
+	return &jsonlRowWriter{w: bw, enc: json.NewEncoder(bw)}, nil
+}
+
+type csvRowWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvRowWriter) WriteRow(row Row) error {
+	line := ""
+	if row.Line > 0 {
+		line = strconv.Itoa(row.Line)
+	}
+	return w.w.Write([]string{row.Type, row.Repository, row.Commit, row.Path, line, row.Preview, row.URL})
+}
+
+func (w *csvRowWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type jsonlRowWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func (w *jsonlRowWriter) WriteRow(row Row) error {
+	// Encode terminates every row with a newline.
+	return w.enc.Encode(row)
+}
+
+func (w *jsonlRowWriter) Flush() error {
+	return w.w.Flush()
+}
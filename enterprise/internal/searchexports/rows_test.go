@@ -0,0 +1,126 @@
+package searchexports
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRowsFromMatch(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	file := result.File{Repo: repo, CommitID: "deadbeef", Path: "README.md"}
+
+	tests := []struct {
+		name  string
+		match result.Match
+		want  []Row
+	}{
+		{
+			name: "content",
+			match: &result.FileMatch{
+				File: file,
+				ChunkMatches: result.ChunkMatches{{
+					Content:      "hello\nworld",
+					ContentStart: result.Location{Line: 2},
+					Ranges: result.Ranges{
+						{Start: result.Location{Line: 2, Column: 0}, End: result.Location{Line: 2, Column: 5}},
+						{Start: result.Location{Line: 3, Column: 0}, End: result.Location{Line: 3, Column: 5}},
+					},
+				}},
+			},
+			want: []Row{
+				{Type: "content", Repository: string(repo.Name), Commit: "deadbeef", Path: "README.md", Line: 3, Preview: "hello", URL: "/github.com/sourcegraph/sourcegraph/-/blob/README.md"},
+				{Type: "content", Repository: string(repo.Name), Commit: "deadbeef", Path: "README.md", Line: 4, Preview: "world", URL: "/github.com/sourcegraph/sourcegraph/-/blob/README.md"},
+			},
+		},
+		{
+			name:  "path",
+			match: &result.FileMatch{File: file},
+			want: []Row{
+				{Type: "path", Repository: string(repo.Name), Commit: "deadbeef", Path: "README.md", URL: "/github.com/sourcegraph/sourcegraph/-/blob/README.md"},
+			},
+		},
+		{
+			name:  "repo",
+			match: &result.RepoMatch{ID: repo.ID, Name: repo.Name},
+			want: []Row{
+				{Type: "repo", Repository: string(repo.Name), URL: "/github.com/sourcegraph/sourcegraph"},
+			},
+		},
+		{
+			name: "commit",
+			match: &result.CommitMatch{
+				Repo:   repo,
+				Commit: gitdomain.Commit{ID: "deadbeef", Message: "Fix typo\n\nIn the README."},
+			},
+			want: []Row{
+				{Type: "commit", Repository: string(repo.Name), Commit: "deadbeef", Preview: "Fix typo", URL: "/github.com/sourcegraph/sourcegraph/-/commit/deadbeef"},
+			},
+		},
+		{
+			name:  "owner",
+			match: &result.OwnerMatch{Handle: "@sourcegraph/search", Repo: repo, CommitID: "deadbeef"},
+			want: []Row{
+				{Type: "owner", Repository: string(repo.Name), Commit: "deadbeef", Preview: "@sourcegraph/search"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, rowsFromMatch(test.match)); diff != "" {
+				t.Fatalf("unexpected rows (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRowWriter(t *testing.T) {
+	rows := []Row{
+		{Type: "content", Repository: "r", Commit: "c", Path: "a.go", Line: 3, Preview: `fmt.Println("a, b")`, URL: "/r@c/-/blob/a.go"},
+		{Type: "repo", Repository: "r", URL: "/r"},
+	}
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{
+			format: FormatCSV,
+			want: `type,repository,commit,path,line,preview,url
+content,r,c,a.go,3,"fmt.Println(""a, b"")",/r@c/-/blob/a.go
+repo,r,,,,,/r
+`,
+		},
+		{
+			format: FormatJSONL,
+			want: `{"type":"content","repository":"r","commit":"c","path":"a.go","line":3,"preview":"fmt.Println(\"a, b\")","url":"/r@c/-/blob/a.go"}
+{"type":"repo","repository":"r","url":"/r"}
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(string(test.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := newRowWriter(test.format, &buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, row := range rows {
+				if err := w.WriteRow(row); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, buf.String()); diff != "" {
+				t.Fatalf("unexpected output (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
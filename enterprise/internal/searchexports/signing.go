@@ -0,0 +1,60 @@
+package searchexports
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	ErrDownloadURLExpired = errors.New("download URL expired")
+	ErrInvalidSignature   = errors.New("invalid download URL signature")
+)
+
+// DownloadURL returns a URL the file of an export can be downloaded from
+// until expiresAt, without further authentication. baseURL is the external
+// URL of the instance.
+func DownloadURL(baseURL string, job *ExportJob, expiresAt time.Time) string {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	q := url.Values{}
+	q.Set("expires", expires)
+	q.Set("signature", sign(job, expires))
+	return fmt.Sprintf("%s/.api/search/exports/%d/download?%s", baseURL, job.ID, q.Encode())
+}
+
+// VerifyDownload checks that the expires and signature query parameters of a
+// download URL were created by DownloadURL for job and have not expired.
+func VerifyDownload(job *ExportJob, expires, signature string, now time.Time) error {
+	given, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(given, mac(job, expires)) {
+		return ErrInvalidSignature
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if now.Unix() > expiresAt {
+		return ErrDownloadURLExpired
+	}
+	return nil
+}
+
+func sign(job *ExportJob, expires string) string {
+	return hex.EncodeToString(mac(job, expires))
+}
+
+// mac authenticates the ID of the export and the expiry of the URL with the
+// download key of the export, so that the URL of one export cannot be used to
+// download another one.
+func mac(job *ExportJob, expires string) []byte {
+	h := hmac.New(sha256.New, job.DownloadKey)
+	fmt.Fprintf(h, "%d:%s", job.ID, expires)
+	return h.Sum(nil)
+}
@@ -0,0 +1,49 @@
+package searchexports
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestDownloadURL(t *testing.T) {
+	now := time.Date(2022, 7, 20, 12, 0, 0, 0, time.UTC)
+	job := &ExportJob{ID: 42, DownloadKey: []byte("secret")}
+	other := &ExportJob{ID: 43, DownloadKey: []byte("secret")}
+	rotated := &ExportJob{ID: 42, DownloadKey: []byte("other secret")}
+
+	u, err := url.Parse(DownloadURL("https://sourcegraph.example.com", job, now.Add(time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "/.api/search/exports/42/download"; u.Path != want {
+		t.Fatalf("unexpected path, want %q, got %q", want, u.Path)
+	}
+	expires, signature := u.Query().Get("expires"), u.Query().Get("signature")
+
+	tests := []struct {
+		name      string
+		job       *ExportJob
+		expires   string
+		signature string
+		now       time.Time
+		want      error
+	}{
+		{name: "valid", job: job, expires: expires, signature: signature, now: now},
+		{name: "expired", job: job, expires: expires, signature: signature, now: now.Add(2 * time.Hour), want: ErrDownloadURLExpired},
+		{name: "other export", job: other, expires: expires, signature: signature, now: now, want: ErrInvalidSignature},
+		{name: "other key", job: rotated, expires: expires, signature: signature, now: now, want: ErrInvalidSignature},
+		{name: "extended expiry", job: job, expires: "9999999999", signature: signature, now: now, want: ErrInvalidSignature},
+		{name: "malformed signature", job: job, expires: expires, signature: "xyz", now: now, want: ErrInvalidSignature},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyDownload(test.job, test.expires, test.signature, test.now)
+			if !errors.Is(err, test.want) {
+				t.Fatalf("unexpected error, want %v, got %v", test.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package searchexports
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var ErrExportJobNotFound = errors.New("search export not found")
+
+// downloadKeySize is the size in bytes of the secret download URLs are signed
+// with.
+const downloadKeySize = 32
+
+func Exports(db database.DB) ExportsStore {
+	store := basestore.NewWithHandle(db.Handle())
+	return &exportsStore{store}
+}
+
+type ExportsStore interface {
+	basestore.ShareableStore
+	CreateExportJob(ctx context.Context, job *ExportJob) (*ExportJob, error)
+	GetExportJob(ctx context.Context, id int32) (*ExportJob, error)
+	ListExportJobs(ctx context.Context, userID int32) ([]*ExportJob, error)
+	UpdateExportManifest(ctx context.Context, id int32, manifest Manifest) error
+	MarkExportUploaded(ctx context.Context, id int32, objectKey string, manifest Manifest) error
+	DeleteOldExportJobs(ctx context.Context, retention time.Duration) (objectKeys []string, _ error)
+}
+
+type exportsStore struct {
+	*basestore.Store
+}
+
+var ExportJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("search_export_jobs.id"),
+	sqlf.Sprintf("search_export_jobs.user_id"),
+	sqlf.Sprintf("search_export_jobs.query"),
+	sqlf.Sprintf("search_export_jobs.pattern_type"),
+	sqlf.Sprintf("search_export_jobs.format"),
+	sqlf.Sprintf("search_export_jobs.object_key"),
+	sqlf.Sprintf("search_export_jobs.download_key"),
+	sqlf.Sprintf("search_export_jobs.manifest"),
+	sqlf.Sprintf("search_export_jobs.created_at"),
+	sqlf.Sprintf("search_export_jobs.state"),
+	sqlf.Sprintf("search_export_jobs.failure_message"),
+	sqlf.Sprintf("search_export_jobs.started_at"),
+	sqlf.Sprintf("search_export_jobs.finished_at"),
+	sqlf.Sprintf("search_export_jobs.process_after"),
+	sqlf.Sprintf("search_export_jobs.num_resets"),
+	sqlf.Sprintf("search_export_jobs.num_failures"),
+}
+
+func scanExportJob(scanner dbutil.Scanner) (*ExportJob, error) {
+	job := &ExportJob{}
+	err := scanner.Scan(
+		&job.ID,
+		&job.UserID,
+		&job.Query,
+		&job.PatternType,
+		&job.Format,
+		&dbutil.NullString{S: &job.ObjectKey},
+		&job.DownloadKey,
+		&job.Manifest,
+		&job.CreatedAt,
+		&job.State,
+		&job.FailureMessage,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.ProcessAfter,
+		&job.NumResets,
+		&job.NumFailures,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func scanExportJobs(rows *sql.Rows) ([]*ExportJob, error) {
+	var jobs []*ExportJob
+	for rows.Next() {
+		job, err := scanExportJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// ScanExportJobRecord scans a search export job for the dbworker store.
+func ScanExportJobRecord(rows *sql.Rows, err error) (workerutil.Record, bool, error) {
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	jobs, err := scanExportJobs(rows)
+	if err != nil || len(jobs) == 0 {
+		return &ExportJob{}, false, err
+	}
+	return jobs[0], true, nil
+}
+
+const insertExportJobFmtStr = `
+INSERT INTO search_export_jobs (user_id, query, pattern_type, format, download_key)
+VALUES (%d, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// CreateExportJob queues an export of the results of a search. The download
+// key of the export is generated.
+//
+// 🚨 SECURITY: The caller must ensure that job.UserID is the current actor.
+func (s *exportsStore) CreateExportJob(ctx context.Context, job *ExportJob) (*ExportJob, error) {
+	downloadKey := make([]byte, downloadKeySize)
+	if _, err := rand.Read(downloadKey); err != nil {
+		return nil, err
+	}
+
+	row := s.QueryRow(ctx, sqlf.Sprintf(
+		insertExportJobFmtStr,
+		job.UserID,
+		job.Query,
+		job.PatternType,
+		job.Format,
+		downloadKey,
+		sqlf.Join(ExportJobColumns, ","),
+	))
+	return scanExportJob(row)
+}
+
+const getExportJobFmtStr = `
+SELECT %s
+FROM search_export_jobs
+WHERE id = %d
+`
+
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access
+// the export.
+func (s *exportsStore) GetExportJob(ctx context.Context, id int32) (*ExportJob, error) {
+	row := s.QueryRow(ctx, sqlf.Sprintf(getExportJobFmtStr, sqlf.Join(ExportJobColumns, ","), id))
+	job, err := scanExportJob(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrExportJobNotFound
+	}
+	return job, err
+}
+
+const listExportJobsFmtStr = `
+SELECT %s
+FROM search_export_jobs
+WHERE user_id = %d
+ORDER BY created_at DESC, id DESC
+`
+
+// ListExportJobs lists the exports of a user, starting with the most recent
+// one.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is the user or a site
+// admin.
+func (s *exportsStore) ListExportJobs(ctx context.Context, userID int32) ([]*ExportJob, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listExportJobsFmtStr, sqlf.Join(ExportJobColumns, ","), userID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanExportJobs(rows)
+}
+
+const updateExportManifestFmtStr = `
+UPDATE search_export_jobs
+SET manifest = %s
+WHERE id = %d
+`
+
+// UpdateExportManifest records the progress of a running export.
+func (s *exportsStore) UpdateExportManifest(ctx context.Context, id int32, manifest Manifest) error {
+	return s.Exec(ctx, sqlf.Sprintf(updateExportManifestFmtStr, manifest, id))
+}
+
+const markExportUploadedFmtStr = `
+UPDATE search_export_jobs
+SET object_key = %s, manifest = %s
+WHERE id = %d
+`
+
+// MarkExportUploaded records the key of the exported file and the final
+// manifest of an export.
+func (s *exportsStore) MarkExportUploaded(ctx context.Context, id int32, objectKey string, manifest Manifest) error {
+	return s.Exec(ctx, sqlf.Sprintf(markExportUploadedFmtStr, objectKey, manifest, id))
+}
+
+const deleteOldExportJobsFmtStr = `
+DELETE FROM search_export_jobs
+WHERE finished_at < (now() - (%s * '1 second'::interval))
+RETURNING object_key
+`
+
+// DeleteOldExportJobs deletes the exports that finished longer than retention
+// ago. It returns the keys of their files, which the caller must delete from
+// the upload store.
+func (s *exportsStore) DeleteOldExportJobs(ctx context.Context, retention time.Duration) ([]string, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(deleteOldExportJobsFmtStr, retention.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key sql.NullString
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		if key.Valid {
+			keys = append(keys, key.String)
+		}
+	}
+	return keys, rows.Err()
+}
@@ -0,0 +1,134 @@
+package searchexports
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestExportsStore(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := actor.WithInternalActor(context.Background())
+	s := Exports(db)
+
+	user1, err := db.Users().Create(ctx, database.NewUser{Username: "u1", Password: "p"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user2, err := db.Users().Create(ctx, database.NewUser{Username: "u2", Password: "p"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	create := func(userID int32, query string) *ExportJob {
+		t.Helper()
+		job, err := s.CreateExportJob(ctx, &ExportJob{UserID: userID, Query: query, PatternType: "standard", Format: FormatCSV})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return job
+	}
+	job1 := create(user1.ID, "a")
+	job2 := create(user1.ID, "b")
+	job3 := create(user2.ID, "c")
+
+	t.Run("create", func(t *testing.T) {
+		if job1.State != "queued" {
+			t.Fatalf("unexpected state %q", job1.State)
+		}
+		if len(job1.DownloadKey) != downloadKeySize {
+			t.Fatalf("unexpected download key size %d", len(job1.DownloadKey))
+		}
+		if string(job1.DownloadKey) == string(job2.DownloadKey) {
+			t.Fatal("expected exports to have different download keys")
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		got, err := s.GetExportJob(ctx, job1.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(job1, got); diff != "" {
+			t.Fatalf("unexpected export (-want +got):\n%s", diff)
+		}
+
+		if _, err := s.GetExportJob(ctx, 1337); !errors.Is(err, ErrExportJobNotFound) {
+			t.Fatalf("expected ErrExportJobNotFound, got %v", err)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		jobs, err := s.ListExportJobs(ctx, user1.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []int32
+		for _, job := range jobs {
+			ids = append(ids, job.ID)
+		}
+		if diff := cmp.Diff([]int32{job2.ID, job1.ID}, ids); diff != "" {
+			t.Fatalf("unexpected exports (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("progress and upload", func(t *testing.T) {
+		if err := s.UpdateExportManifest(ctx, job1.ID, Manifest{ResultCount: 10}); err != nil {
+			t.Fatal(err)
+		}
+		manifest := Manifest{
+			ResultCount: 20,
+			SizeBytes:   1024,
+			Skipped:     []SkippedRepository{{Name: "github.com/sourcegraph/sourcegraph", Reason: "timedout"}},
+		}
+		if err := s.MarkExportUploaded(ctx, job1.ID, "search-exports/1.csv", manifest); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := s.GetExportJob(ctx, job1.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ObjectKey != "search-exports/1.csv" {
+			t.Fatalf("unexpected object key %q", got.ObjectKey)
+		}
+		if diff := cmp.Diff(manifest, got.Manifest); diff != "" {
+			t.Fatalf("unexpected manifest (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("delete old", func(t *testing.T) {
+		if err := basestore.NewWithHandle(s.Handle()).Exec(ctx, sqlf.Sprintf(
+			"UPDATE search_export_jobs SET state = 'completed', finished_at = %s WHERE id IN (%s, %s)",
+			time.Now().Add(-48*time.Hour), job1.ID, job3.ID,
+		)); err != nil {
+			t.Fatal(err)
+		}
+
+		keys, err := s.DeleteOldExportJobs(ctx, 24*time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"search-exports/1.csv"}, keys); diff != "" {
+			t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+		}
+		if _, err := s.GetExportJob(ctx, job3.ID); !errors.Is(err, ErrExportJobNotFound) {
+			t.Fatalf("expected export to be deleted, got %v", err)
+		}
+		if _, err := s.GetExportJob(ctx, job2.ID); err != nil {
+			t.Fatalf("expected export to be kept, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+// Package searchexports exports the complete results of a search to a CSV or
+// JSONL file in the upload store. Exports are queued as jobs, run by a worker
+// and downloaded through signed URLs.
+package searchexports
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Format is the file format of an export.
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// ParseFormat returns the format named s.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatCSV, FormatJSONL:
+		return f, nil
+	}
+	return "", errors.Errorf("unsupported export format %q, must be csv or jsonl", s)
+}
+
+// ContentType returns the MIME type of files in the format.
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// ExportJob is a search whose results are exported to a file.
+type ExportJob struct {
+	ID          int32
+	UserID      int32
+	Query       string
+	PatternType string
+	Format      Format
+
+	// ObjectKey is the key of the exported file in the upload store. It is
+	// empty until the export completed.
+	ObjectKey string
+
+	// DownloadKey is the secret download URLs of the export are signed with.
+	DownloadKey []byte
+
+	Manifest  Manifest
+	CreatedAt time.Time
+
+	// Fields demanded for any dbworker.
+	State          string
+	FailureMessage *string
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	ProcessAfter   *time.Time
+	NumResets      int32
+	NumFailures    int32
+}
+
+func (j *ExportJob) RecordID() int {
+	return int(j.ID)
+}
+
+// FileName returns the name the exported file is downloaded as.
+func (j *ExportJob) FileName() string {
+	return "search-export-" + strconv.Itoa(int(j.ID)) + "." + string(j.Format)
+}
+
+// Manifest describes the progress of an export and how complete its results
+// are. It is updated while the export runs.
+type Manifest struct {
+	// ResultCount is the number of rows written so far.
+	ResultCount int `json:"resultCount"`
+
+	// SizeBytes is the size of the exported file, set once the export
+	// completed.
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+
+	// LimitHit is true if the search stopped before it found all results,
+	// e.g. because the query has a count: filter.
+	LimitHit bool `json:"limitHit"`
+
+	// Incomplete is true if some repositories could not be searched, e.g.
+	// because the search timed out before reaching them. Their results are
+	// missing from the file, unlike those of a result limit that was hit.
+	Incomplete bool `json:"incomplete"`
+
+	// Skipped are the repositories whose results are missing or incomplete.
+	Skipped []SkippedRepository `json:"skipped,omitempty"`
+
+	// Alert is the title of the alert returned by the search, if any.
+	Alert string `json:"alert,omitempty"`
+}
+
+// SkippedRepository is a repository that was not searched completely.
+type SkippedRepository struct {
+	Name string `json:"name"`
+
+	// Reason is one of cloning, missing, timedout or limithit.
+	Reason string `json:"reason"`
+}
+
+func (m Manifest) Value() (driver.Value, error) {
+	return json.Marshal(m)
+}
+
+func (m *Manifest) Scan(value any) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, m)
+}
@@ -0,0 +1,105 @@
+// Package viewersettings fetches the final settings of the current actor
+// from the frontend's internal GraphQL API.
+package viewersettings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/opentracing/opentracing-go"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const gqlSettingsQuery = `query ViewerSettings{
+	viewerSettings {
+		final
+	}
+}`
+
+type gqlSettingsResponse struct {
+	Data struct {
+		ViewerSettings struct {
+			Final string `json:"final"`
+		} `json:"viewerSettings"`
+	} `json:"data"`
+	Errors []gqlerrors.FormattedError
+}
+
+// Settings queries the frontend for the computed settings of the actor in
+// ctx. It is used by background workers that run searches on behalf of a
+// user, but have no direct access to the settings cascade.
+func Settings(ctx context.Context) (_ *schema.Settings, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "viewersettings.Settings")
+	defer func() {
+		span.LogFields(otlog.Error(err))
+		span.Finish()
+	}()
+
+	reqBody, err := json.Marshal(map[string]any{"query": gqlSettingsQuery})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal request body")
+	}
+
+	url, err := gqlURL("ViewerSettings")
+	if err != nil {
+		return nil, errors.Wrap(err, "construct frontend URL")
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, errors.Wrap(err, "construct request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if span != nil {
+		carrier := opentracing.HTTPHeadersCarrier(req.Header)
+		span.Tracer().Inject(
+			span.Context(),
+			opentracing.HTTPHeaders,
+			carrier,
+		)
+	}
+
+	resp, err := httpcli.InternalDoer.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	var res gqlSettingsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, errors.Wrap(err, "decode response")
+	}
+
+	if len(res.Errors) > 0 {
+		var combined error
+		for _, err := range res.Errors {
+			combined = errors.Append(combined, err)
+		}
+		return nil, combined
+	}
+
+	var unmarshaledSettings schema.Settings
+	if err := json.Unmarshal([]byte(res.Data.ViewerSettings.Final), &unmarshaledSettings); err != nil {
+		return nil, err
+	}
+	return &unmarshaledSettings, nil
+}
+
+func gqlURL(queryName string) (string, error) {
+	u, err := url.Parse(internalapi.Client.URL)
+	if err != nil {
+		return "", err
+	}
+	u.Path = "/.internal/graphql"
+	u.RawQuery = queryName
+	return u.String(), nil
+}
@@ -0,0 +1,127 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestDotnetGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "dotnet solution",
+			repositoryContents: map[string]string{
+				"App.sln":                      "",
+				"src/App/App.csproj":           "",
+				"src/App.Core/App.Core.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    "sourcegraph/scip-dotnet",
+							Commands: []string{"dotnet restore App.sln"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-dotnet",
+					IndexerArgs: []string{"scip-dotnet", "index", "App.sln"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "dotnet projects without solution",
+			repositoryContents: map[string]string{
+				"src/App/App.csproj": "",
+				"src/Lib/Lib.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "src/App",
+							Image:    "sourcegraph/scip-dotnet",
+							Commands: []string{"dotnet restore App.csproj"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "src/App",
+					Indexer:     "sourcegraph/scip-dotnet",
+					IndexerArgs: []string{"scip-dotnet", "index", "App.csproj"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "src/Lib",
+							Image:    "sourcegraph/scip-dotnet",
+							Commands: []string{"dotnet restore Lib.csproj"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "src/Lib",
+					Indexer:     "sourcegraph/scip-dotnet",
+					IndexerArgs: []string{"scip-dotnet", "index", "Lib.csproj"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "dotnet solutions in the same directory",
+			repositoryContents: map[string]string{
+				"Foo.Tests.sln":      "",
+				"Foo.sln":            "",
+				"src/Foo/Foo.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    "sourcegraph/scip-dotnet",
+							Commands: []string{"dotnet restore Foo.sln"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-dotnet",
+					IndexerArgs: []string{"scip-dotnet", "index", "Foo.sln"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "dotnet projects in the same directory without solution",
+			repositoryContents: map[string]string{
+				"src/App/App.csproj":       "",
+				"src/App/App.Tests.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "src/App",
+							Image:    "sourcegraph/scip-dotnet",
+							Commands: []string{"dotnet restore App.csproj"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "src/App",
+					Indexer:     "sourcegraph/scip-dotnet",
+					IndexerArgs: []string{"scip-dotnet", "index", "App.csproj"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "c# files without project (no match)",
+			repositoryContents: map[string]string{
+				"Program.cs": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
@@ -0,0 +1,75 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestKotlinGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "gradle kotlin build",
+			repositoryContents: map[string]string{
+				"build.gradle.kts":            "",
+				"core/build.gradle.kts":       "",
+				"core/src/main/kotlin/App.kt": "",
+				"settings.gradle.kts":         "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "multiple gradle kotlin builds",
+			repositoryContents: map[string]string{
+				"server/build.gradle.kts":        "",
+				"client/build.gradle.kts":        "",
+				"client/macros/build.gradle.kts": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "client",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "server",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "gradle kotlin build with lsif-java.json",
+			repositoryContents: map[string]string{
+				"build.gradle.kts": "",
+				"lsif-java.json":   "",
+				"src/main/App.kt":  "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=lsif"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,80 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestPHPGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "php project with composer.json",
+			repositoryContents: map[string]string{
+				"composer.json":   "",
+				"composer.lock":   "",
+				"src/Greeter.php": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    "davidrjenni/lsif-php",
+							Commands: []string{"composer install --no-scripts --no-interaction"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "davidrjenni/lsif-php",
+					IndexerArgs: []string{"lsif-php"},
+					Outfile:     "dump.lsif",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "php projects with multiple composer.json files",
+			repositoryContents: map[string]string{
+				"packages/client/composer.json": "",
+				"packages/server/composer.json": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "packages/client",
+							Image:    "davidrjenni/lsif-php",
+							Commands: []string{"composer install --no-scripts --no-interaction"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "packages/client",
+					Indexer:     "davidrjenni/lsif-php",
+					IndexerArgs: []string{"lsif-php"},
+					Outfile:     "dump.lsif",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "packages/server",
+							Image:    "davidrjenni/lsif-php",
+							Commands: []string{"composer install --no-scripts --no-interaction"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "packages/server",
+					Indexer:     "davidrjenni/lsif-php",
+					IndexerArgs: []string{"lsif-php"},
+					Outfile:     "dump.lsif",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "php files without composer.json (no match)",
+			repositoryContents: map[string]string{
+				"index.php": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
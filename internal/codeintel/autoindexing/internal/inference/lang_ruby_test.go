@@ -0,0 +1,80 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestRubyGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "ruby project with Gemfile",
+			repositoryContents: map[string]string{
+				"Gemfile":      "",
+				"Gemfile.lock": "",
+				"lib/app.rb":   "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    "sourcegraph/scip-ruby",
+							Commands: []string{"bundle install"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-ruby",
+					IndexerArgs: []string{"scip-ruby", "--index-file", "index.scip", "."},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "ruby projects with multiple Gemfiles",
+			repositoryContents: map[string]string{
+				"api/Gemfile": "",
+				"web/Gemfile": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "api",
+							Image:    "sourcegraph/scip-ruby",
+							Commands: []string{"bundle install"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "api",
+					Indexer:     "sourcegraph/scip-ruby",
+					IndexerArgs: []string{"scip-ruby", "--index-file", "index.scip", "."},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "web",
+							Image:    "sourcegraph/scip-ruby",
+							Commands: []string{"bundle install"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "web",
+					Indexer:     "sourcegraph/scip-ruby",
+					IndexerArgs: []string{"scip-ruby", "--index-file", "index.scip", "."},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "ruby files without Gemfile (no match)",
+			repositoryContents: map[string]string{
+				"lib/app.rb": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
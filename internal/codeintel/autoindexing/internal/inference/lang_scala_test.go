@@ -0,0 +1,75 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestScalaGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "sbt build",
+			repositoryContents: map[string]string{
+				"build.sbt":                     "",
+				"core/build.sbt":                "",
+				"core/src/main/scala/App.scala": "",
+				"project/plugins.sbt":           "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=sbt"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "multiple sbt builds",
+			repositoryContents: map[string]string{
+				"server/build.sbt":        "",
+				"client/build.sbt":        "",
+				"client/macros/build.sbt": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "client",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=sbt"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "server",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=sbt"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "sbt build with lsif-java.json",
+			repositoryContents: map[string]string{
+				"build.sbt":          "",
+				"lsif-java.json":     "",
+				"src/main/App.scala": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=lsif"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
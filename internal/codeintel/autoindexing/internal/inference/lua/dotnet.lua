@@ -0,0 +1,88 @@
+local path = require "path"
+local patterns = require "sg.patterns"
+local recognizers = require "sg.recognizers"
+
+local shared = loadfile "shared.lua"()
+
+local indexer = "sourcegraph/scip-dotnet"
+local outfile = "index.scip"
+
+-- Returns whether the solution or project file a should be indexed rather than the
+-- file b of the same directory. The shortest name wins (e.g. Foo.sln over Foo.Tests.sln),
+-- and ties are broken by name so that the choice is deterministic.
+local preferred = function(a, b)
+  if #a ~= #b then
+    return #a < #b
+  end
+
+  return a < b
+end
+
+-- Returns one job per directory of the given solution or project files, as the uploads
+-- of several jobs with the same root and indexer would shadow each other.
+local make_jobs = function(paths)
+  local roots = {}
+  local bases = {}
+  for i = 1, #paths do
+    local root = path.dirname(paths[i])
+    local base = path.basename(paths[i])
+
+    if bases[root] == nil then
+      table.insert(roots, root)
+      bases[root] = base
+    elseif preferred(base, bases[root]) then
+      bases[root] = base
+    end
+  end
+
+  local jobs = {}
+  for i = 1, #roots do
+    local root = roots[i]
+    local base = bases[root]
+
+    table.insert(jobs, {
+      steps = {
+        {
+          root = root,
+          image = indexer,
+          commands = { "dotnet restore " .. base },
+        },
+      },
+      root = root,
+      indexer = indexer,
+      indexer_args = { "scip-dotnet", "index", base },
+      outfile = outfile,
+    })
+  end
+
+  return jobs
+end
+
+local sln_recognizer = recognizers.path_recognizer {
+  patterns = {
+    patterns.path_extension "sln",
+    patterns.path_exclude(shared.exclude_paths),
+  },
+
+  -- Invoked when solution files exist
+  generate = function(_, paths)
+    return make_jobs(paths)
+  end,
+}
+
+local csproj_recognizer = recognizers.path_recognizer {
+  patterns = {
+    patterns.path_extension "csproj",
+    patterns.path_exclude(shared.exclude_paths),
+  },
+
+  -- Invoked when no solution files exist but C# project files exist
+  generate = function(_, paths)
+    return make_jobs(paths)
+  end,
+}
+
+return recognizers.fallback_recognizer {
+  sln_recognizer,
+  csproj_recognizer,
+}
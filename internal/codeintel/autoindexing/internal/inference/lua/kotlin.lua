@@ -0,0 +1,39 @@
+local patterns = require "sg.patterns"
+local recognizers = require "sg.recognizers"
+
+local shared = loadfile "shared.lua"()
+local util = loadfile "util.lua"()
+
+local indexer = "sourcegraph/scip-java"
+local outfile = "index.scip"
+
+return recognizers.path_recognizer {
+  patterns = {
+    patterns.path_basename "build.gradle.kts",
+    patterns.path_literal "lsif-java.json",
+    patterns.path_exclude(shared.exclude_paths),
+  },
+
+  -- Invoked when Gradle Kotlin build files or lsif-java.json exist. Builds nested in the
+  -- directory of another build are indexed as part of the outer build.
+  generate = function(_, paths)
+    -- Repositories with lsif-java.json are indexed by the java recognizer
+    if util.contains(paths, "lsif-java.json") then
+      return {}
+    end
+
+    local jobs = {}
+    local roots = util.outermost_dirnames(paths)
+    for i = 1, #roots do
+      table.insert(jobs, {
+        steps = {},
+        root = roots[i],
+        indexer = indexer,
+        indexer_args = { "scip-java", "index", "--build-tool=gradle" },
+        outfile = outfile,
+      })
+    end
+
+    return jobs
+  end,
+}
@@ -0,0 +1,43 @@
+local path = require "path"
+local patterns = require "sg.patterns"
+local recognizers = require "sg.recognizers"
+
+local shared = loadfile "shared.lua"()
+
+local indexer = "davidrjenni/lsif-php"
+local outfile = "dump.lsif"
+
+local exclude_paths = patterns.path_combine(shared.exclude_paths, {
+  patterns.path_segment "vendor",
+})
+
+return recognizers.path_recognizer {
+  patterns = {
+    patterns.path_basename "composer.json",
+    patterns.path_exclude(exclude_paths),
+  },
+
+  -- Invoked when composer.json files exist
+  generate = function(_, paths)
+    local jobs = {}
+    for i = 1, #paths do
+      local root = path.dirname(paths[i])
+
+      table.insert(jobs, {
+        steps = {
+          {
+            root = root,
+            image = indexer,
+            commands = { "composer install --no-scripts --no-interaction" },
+          },
+        },
+        root = root,
+        indexer = indexer,
+        indexer_args = { "lsif-php" },
+        outfile = outfile,
+      })
+    end
+
+    return jobs
+  end,
+}
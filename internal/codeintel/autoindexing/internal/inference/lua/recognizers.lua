@@ -1,9 +1,14 @@
 local languages = {
   "clang",
+  "dotnet",
   "go",
   "java",
+  "kotlin",
+  "php",
   "python",
+  "ruby",
   "rust",
+  "scala",
   "test",
   "typescript",
 }
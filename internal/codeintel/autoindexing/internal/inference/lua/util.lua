@@ -1,3 +1,5 @@
+local path = require "path"
+
 local contains = function(table, element)
   for i = 1, #table do
     if table[i] == element then
@@ -36,9 +38,40 @@ local with_new_head = function(slice, element)
   return new
 end
 
+-- Returns the directories of the given paths that are not nested in the directory of
+-- another given path, in the order that they first occur.
+local outermost_dirnames = function(paths)
+  local dirs = {}
+  for i = 1, #paths do
+    dirs[path.dirname(paths[i])] = true
+  end
+
+  local outermost = {}
+  local visited = {}
+  for i = 1, #paths do
+    local dir = path.dirname(paths[i])
+
+    local nested = false
+    local ancestors = path.ancestors(dir)
+    for j = 1, #ancestors do
+      if ancestors[j] ~= dir and dirs[ancestors[j]] then
+        nested = true
+      end
+    end
+
+    if visited[dir] == nil and not nested then
+      table.insert(outermost, dir)
+      visited[dir] = true
+    end
+  end
+
+  return outermost
+end
+
 return {
   contains = contains,
   contains_any = contains_any,
+  outermost_dirnames = outermost_dirnames,
   reverse = reverse,
   with_new_head = with_new_head,
 }
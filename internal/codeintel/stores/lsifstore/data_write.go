@@ -55,29 +55,38 @@ func (s *Store) WriteDocuments(ctx context.Context, bundleID int, documents chan
 	}
 
 	inserter := func(inserter *batch.Inserter) error {
-		for v := range documents {
-			data, err := s.serializer.MarshalDocumentData(v.Document)
-			if err != nil {
-				return err
-			}
-
-			if err := inserter.Insert(
-				ctx,
-				v.Path,
-				data.Ranges,
-				data.HoverResults,
-				data.Monikers,
-				data.PackageInformation,
-				data.Diagnostics,
-				len(v.Document.Diagnostics),
-			); err != nil {
-				return err
+		// Document symbols are also flattened into lsif_data_symbols so that they can be
+		// searched by name without decoding every document of the upload.
+		return batch.WithInserter(ctx, tx.Handle(), "lsif_data_symbols", batch.MaxNumPostgresParameters, writeSymbolsColumns, func(symbolInserter *batch.Inserter) error {
+			for v := range documents {
+				data, err := s.serializer.MarshalDocumentData(v.Document)
+				if err != nil {
+					return err
+				}
+
+				if err := inserter.Insert(
+					ctx,
+					v.Path,
+					data.Ranges,
+					data.HoverResults,
+					data.Monikers,
+					data.PackageInformation,
+					data.Diagnostics,
+					len(v.Document.Diagnostics),
+					data.Symbols,
+				); err != nil {
+					return err
+				}
+
+				if err := insertSymbols(ctx, symbolInserter, bundleID, v.Path, nil, v.Document.Symbols); err != nil {
+					return err
+				}
+
+				atomic.AddUint32(&count, 1)
 			}
 
-			atomic.AddUint32(&count, 1)
-		}
-
-		return nil
+			return nil
+		})
 	}
 
 	// Bulk insert all the unique column values into the temporary table
@@ -93,6 +102,7 @@ func (s *Store) WriteDocuments(ctx context.Context, bundleID int, documents chan
 			"packages",
 			"diagnostics",
 			"num_diagnostics",
+			"symbols",
 		},
 		inserter,
 	); err != nil {
@@ -115,17 +125,58 @@ CREATE TEMPORARY TABLE t_lsif_data_documents (
 	monikers bytea,
 	packages bytea,
 	diagnostics bytea,
-	num_diagnostics integer NOT NULL
+	num_diagnostics integer NOT NULL,
+	symbols bytea
 ) ON COMMIT DROP
 `
 
 const writeDocumentsInsertQuery = `
 -- source: internal/codeintel/stores/lsifstore/data_write.go:WriteDocuments
-INSERT INTO lsif_data_documents (dump_id, schema_version, path, ranges, hovers, monikers, packages, diagnostics, num_diagnostics)
-SELECT %s, %s, source.path, source.ranges, source.hovers, source.monikers, source.packages, source.diagnostics, source.num_diagnostics
+INSERT INTO lsif_data_documents (dump_id, schema_version, path, ranges, hovers, monikers, packages, diagnostics, num_diagnostics, symbols)
+SELECT %s, %s, source.path, source.ranges, source.hovers, source.monikers, source.packages, source.diagnostics, source.num_diagnostics, source.symbols
 FROM t_lsif_data_documents source
 `
 
+var writeSymbolsColumns = []string{
+	"dump_id",
+	"path",
+	"name",
+	"kind",
+	"parent",
+	"start_line",
+	"start_character",
+	"end_line",
+	"end_character",
+}
+
+// insertSymbols inserts a row into lsif_data_symbols for each of the given symbols and their
+// descendants. The parent of each row is the name of its enclosing symbol, if any.
+func insertSymbols(ctx context.Context, inserter *batch.Inserter, bundleID int, path string, parent *string, symbols []precise.SymbolData) error {
+	for _, symbol := range symbols {
+		if err := inserter.Insert(
+			ctx,
+			bundleID,
+			path,
+			symbol.Name,
+			int(symbol.Kind),
+			parent,
+			symbol.StartLine,
+			symbol.StartCharacter,
+			symbol.EndLine,
+			symbol.EndCharacter,
+		); err != nil {
+			return err
+		}
+
+		name := symbol.Name
+		if err := insertSymbols(ctx, inserter, bundleID, path, &name, symbol.Children); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // WriteResultChunks is called (transactionally) from the precise-code-intel-worker.
 func (s *Store) WriteResultChunks(ctx context.Context, bundleID int, resultChunks chan precise.IndexedResultChunkData) (count uint32, err error) {
 	ctx, trace, endObservation := s.operations.writeResultChunks.With(ctx, &err, observation.Args{LogFields: []log.Field{
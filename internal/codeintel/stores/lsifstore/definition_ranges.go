@@ -0,0 +1,64 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// DefinitionRanges returns the ranges within a single document that define a non-local symbol, ordered by
+// position. A symbol is non-local when a moniker is attached to it, which excludes local variables and
+// parameters. These ranges approximate the declarations that enclose the other ranges of the document, as
+// indexes do not record the extent of declarations.
+func (s *Store) DefinitionRanges(ctx context.Context, bundleID int, path string) (_ []Range, err error) {
+	ctx, trace, endObservation := s.operations.definitionRanges.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.String("path", path),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.Store.Query(ctx, sqlf.Sprintf(rangesDocumentQuery, bundleID, path)))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	candidates := make([]precise.RangeData, 0, len(documentData.Document.Ranges))
+	for _, r := range documentData.Document.Ranges {
+		if r.DefinitionResultID != "" && len(r.MonikerIDs) > 0 {
+			candidates = append(candidates, r)
+		}
+	}
+	trace.Log(
+		log.Int("numRanges", len(documentData.Document.Ranges)),
+		log.Int("numCandidateRanges", len(candidates)),
+	)
+
+	definitionResultIDs := extractResultIDs(candidates, func(r precise.RangeData) precise.ID { return r.DefinitionResultID })
+	definitionLocations, err := s.locationsWithinFile(ctx, bundleID, definitionResultIDs, path, documentData.Document)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := make([]Range, 0, len(candidates))
+	for _, r := range candidates {
+		// Only keep the ranges that are one of the definitions of their own symbol
+		rn := newRange(r.StartLine, r.StartCharacter, r.EndLine, r.EndCharacter)
+		for _, location := range definitionLocations[r.DefinitionResultID] {
+			if location.Range == rn {
+				ranges = append(ranges, rn)
+				break
+			}
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return compareBundleRanges(ranges[i], ranges[j])
+	})
+	trace.Log(log.Int("numDefinitionRanges", len(ranges)))
+
+	return ranges, nil
+}
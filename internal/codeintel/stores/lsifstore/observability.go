@@ -10,9 +10,11 @@ import (
 type operations struct {
 	bulkMonikerResults     *observation.Operation
 	clear                  *observation.Operation
+	definitionRanges       *observation.Operation
 	definitions            *observation.Operation
 	deleteOldSearchRecords *observation.Operation
 	diagnostics            *observation.Operation
+	documentSymbols        *observation.Operation
 	exists                 *observation.Operation
 	hover                  *observation.Operation
 	implementations        *observation.Operation
@@ -21,7 +23,9 @@ type operations struct {
 	packageInformation     *observation.Operation
 	ranges                 *observation.Operation
 	references             *observation.Operation
+	searchSymbols          *observation.Operation
 	stencil                *observation.Operation
+	typeDefinitions        *observation.Operation
 	writeDefinitions       *observation.Operation
 	writeDocuments         *observation.Operation
 	writeImplementations   *observation.Operation
@@ -61,9 +65,11 @@ func newOperations(observationContext *observation.Context) *operations {
 	return &operations{
 		bulkMonikerResults:     op("BulkMonikerResults"),
 		clear:                  op("Clear"),
+		definitionRanges:       op("DefinitionRanges"),
 		definitions:            op("Definitions"),
 		deleteOldSearchRecords: op("DeleteOldSearchRecords"),
 		diagnostics:            op("Diagnostics"),
+		documentSymbols:        op("DocumentSymbols"),
 		exists:                 op("Exists"),
 		hover:                  op("Hover"),
 		implementations:        op("Implementations"),
@@ -72,7 +78,9 @@ func newOperations(observationContext *observation.Context) *operations {
 		packageInformation:     op("PackageInformation"),
 		ranges:                 op("Ranges"),
 		references:             op("References"),
+		searchSymbols:          op("SearchSymbols"),
 		stencil:                op("Stencil"),
+		typeDefinitions:        op("TypeDefinitions"),
 		writeDefinitions:       op("WriteDefinitions"),
 		writeDocuments:         op("WriteDocuments"),
 		writeImplementations:   op("WriteImplementations"),
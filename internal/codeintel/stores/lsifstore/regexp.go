@@ -0,0 +1,190 @@
+package lsifstore
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxPostgresRepeat is the largest bound of a repetition that Postgres accepts.
+const maxPostgresRepeat = 255
+
+// toPostgresRegexp translates an RE2 pattern, as used by search queries, into an equivalent
+// Postgres advanced regular expression. The syntax of the two differs (e.g. \b is a word
+// boundary in RE2 and a backspace in Postgres), so patterns cannot be passed through as is.
+// Symbol names and paths never contain newlines, so multi-line anchors are treated as text
+// anchors. An error is returned for patterns that cannot be expressed in Postgres.
+func toPostgresRegexp(pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", errors.Wrap(err, "regexp/syntax.Parse")
+	}
+
+	var b strings.Builder
+	if err := writePostgresRegexp(&b, re.Simplify()); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func writePostgresRegexp(b *strings.Builder, re *syntax.Regexp) error {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return errors.Newf("unsupported regular expression %q", re.String())
+
+	case syntax.OpEmptyMatch:
+		b.WriteString("(?:)")
+
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 && unicode.SimpleFold(r) != r {
+				b.WriteString("[")
+				for f := r; ; {
+					writePostgresClassRune(b, f)
+					if f = unicode.SimpleFold(f); f == r {
+						break
+					}
+				}
+				b.WriteString("]")
+				continue
+			}
+			writePostgresRune(b, r)
+		}
+
+	case syntax.OpCharClass:
+		b.WriteString("[")
+		for i := 0; i < len(re.Rune); i += 2 {
+			// Postgres text cannot contain NUL characters, and rejects them in patterns
+			lo, hi := re.Rune[i], re.Rune[i+1]
+			if lo == 0 {
+				if hi == 0 {
+					continue
+				}
+				lo = 1
+			}
+			writePostgresClassRune(b, lo)
+			if hi != lo {
+				b.WriteString("-")
+				writePostgresClassRune(b, hi)
+			}
+		}
+		b.WriteString("]")
+
+	case syntax.OpAnyCharNotNL:
+		b.WriteString(`[^\n]`)
+	case syntax.OpAnyChar:
+		// Postgres matches newlines with . unless newline-sensitive matching is enabled
+		b.WriteString(".")
+	case syntax.OpBeginLine, syntax.OpBeginText:
+		b.WriteString("^")
+	case syntax.OpEndLine, syntax.OpEndText:
+		b.WriteString("$")
+	case syntax.OpWordBoundary:
+		b.WriteString(`\y`)
+	case syntax.OpNoWordBoundary:
+		b.WriteString(`\Y`)
+
+	case syntax.OpCapture:
+		return writePostgresGroup(b, re.Sub[0])
+
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest:
+		if err := writePostgresGroup(b, re.Sub[0]); err != nil {
+			return err
+		}
+		switch re.Op {
+		case syntax.OpStar:
+			b.WriteString("*")
+		case syntax.OpPlus:
+			b.WriteString("+")
+		case syntax.OpQuest:
+			b.WriteString("?")
+		}
+		if re.Flags&syntax.NonGreedy != 0 {
+			b.WriteString("?")
+		}
+
+	case syntax.OpRepeat:
+		if re.Min > maxPostgresRepeat || re.Max > maxPostgresRepeat {
+			return errors.Newf("repetition count of %q is larger than %d", re.String(), maxPostgresRepeat)
+		}
+		if err := writePostgresGroup(b, re.Sub[0]); err != nil {
+			return err
+		}
+		switch {
+		case re.Max == -1:
+			fmt.Fprintf(b, "{%d,}", re.Min)
+		case re.Max == re.Min:
+			fmt.Fprintf(b, "{%d}", re.Min)
+		default:
+			fmt.Fprintf(b, "{%d,%d}", re.Min, re.Max)
+		}
+		if re.Flags&syntax.NonGreedy != 0 {
+			b.WriteString("?")
+		}
+
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := writePostgresRegexp(b, sub); err != nil {
+				return err
+			}
+		}
+
+	case syntax.OpAlternate:
+		b.WriteString("(?:")
+		for i, sub := range re.Sub {
+			if i > 0 {
+				b.WriteString("|")
+			}
+			if err := writePostgresRegexp(b, sub); err != nil {
+				return err
+			}
+		}
+		b.WriteString(")")
+
+	default:
+		return errors.Newf("unsupported regular expression operator %s", re.Op)
+	}
+
+	return nil
+}
+
+// writePostgresGroup writes the given expression as a single atom, so that it can be quantified.
+func writePostgresGroup(b *strings.Builder, re *syntax.Regexp) error {
+	b.WriteString("(?:")
+	if err := writePostgresRegexp(b, re); err != nil {
+		return err
+	}
+	b.WriteString(")")
+	return nil
+}
+
+// writePostgresRune writes the given rune outside of a bracket expression.
+func writePostgresRune(b *strings.Builder, r rune) {
+	switch {
+	case strings.ContainsRune(`\.+*?()|[]{}^$`, r):
+		b.WriteRune('\\')
+		b.WriteRune(r)
+	case r > ' ' && r < 0x7f:
+		b.WriteRune(r)
+	default:
+		writePostgresClassRune(b, r)
+	}
+}
+
+// writePostgresClassRune writes the given rune inside of a bracket expression. Anything but
+// letters and digits is escaped, which Postgres allows inside of brackets in advanced regular
+// expressions.
+func writePostgresClassRune(b *strings.Builder, r rune) {
+	switch {
+	case r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+		b.WriteRune(r)
+	case r <= 0xFFFF:
+		fmt.Fprintf(b, `\u%04x`, r)
+	default:
+		fmt.Fprintf(b, `\U%08x`, r)
+	}
+}
@@ -0,0 +1,38 @@
+package lsifstore
+
+import "testing"
+
+func TestToPostgresRegexp(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		want    string
+	}{
+		{pattern: `Server`, want: `Server`},
+		{pattern: `^Serve$`, want: `^Serve$`},
+		{pattern: `\bServe\b`, want: `\yServe\y`},
+		{pattern: `a.b`, want: `a[^\n]b`},
+		{pattern: `(?s)a.b`, want: `a.b`},
+		{pattern: `\d+`, want: `(?:[0-9])+`},
+		{pattern: `foo|bar`, want: `(?:foo|bar)`},
+		{pattern: `(?P<name>ab)*?`, want: `(?:(?:ab))*?`},
+		{pattern: `\.go$`, want: `\.go$`},
+		{pattern: `(?i)go`, want: `[Gg][Oo]`},
+		{pattern: `[^a]`, want: "[\\u0001-\\u0060b-\\U0010ffff]"},
+		{pattern: `a_b-c`, want: `a_b-c`},
+		{pattern: `\Qa.b\E`, want: `a\.b`},
+	}
+
+	for _, testCase := range testCases {
+		got, err := toPostgresRegexp(testCase.pattern)
+		if err != nil {
+			t.Fatalf("unexpected error translating %q: %s", testCase.pattern, err)
+		}
+		if got != testCase.want {
+			t.Errorf("unexpected translation of %q. want=%q have=%q", testCase.pattern, testCase.want, got)
+		}
+	}
+
+	if _, err := toPostgresRegexp(`(`); err == nil {
+		t.Errorf("expected error translating an invalid pattern")
+	}
+}
@@ -35,6 +35,7 @@ type MarshalledDocumentData struct {
 	Monikers           []byte
 	PackageInformation []byte
 	Diagnostics        []byte
+	Symbols            []byte
 }
 
 // MarshalDocumentData transforms the fields of the given document data payload into a set of
@@ -55,6 +56,9 @@ func (s *Serializer) MarshalDocumentData(document precise.DocumentData) (data Ma
 	if data.Diagnostics, err = s.encode(&document.Diagnostics); err != nil {
 		return MarshalledDocumentData{}, err
 	}
+	if data.Symbols, err = s.encode(&document.Symbols); err != nil {
+		return MarshalledDocumentData{}, err
+	}
 
 	return data, nil
 }
@@ -114,6 +118,9 @@ func (s *Serializer) UnmarshalDocumentData(data MarshalledDocumentData) (documen
 	if err := s.decode(data.Diagnostics, &document.Diagnostics); err != nil {
 		return precise.DocumentData{}, err
 	}
+	if err := s.decode(data.Symbols, &document.Symbols); err != nil {
+		return precise.DocumentData{}, err
+	}
 
 	return document, nil
 }
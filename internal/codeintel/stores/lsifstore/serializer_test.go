@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
 
@@ -56,6 +57,36 @@ func TestDocumentData(t *testing.T) {
 				Version: "v0.0.0-ad3507cbeb18",
 			},
 		},
+		Symbols: []precise.SymbolData{
+			{
+				Name:               "Vertex",
+				Kind:               protocol.Struct,
+				StartLine:          266,
+				StartCharacter:     5,
+				EndLine:            266,
+				EndCharacter:       11,
+				FullStartLine:      266,
+				FullStartCharacter: 0,
+				FullEndLine:        269,
+				FullEndCharacter:   1,
+				Children: []precise.SymbolData{
+					{
+						Name:               "Label",
+						Kind:               protocol.Field,
+						Detail:             "VertexLabel",
+						Tags:               []protocol.SymbolTag{protocol.Deprecated},
+						StartLine:          268,
+						StartCharacter:     1,
+						EndLine:            268,
+						EndCharacter:       6,
+						FullStartLine:      268,
+						FullStartCharacter: 1,
+						FullEndLine:        268,
+						FullEndCharacter:   37,
+					},
+				},
+			},
+		},
 	}
 
 	t.Run("current", func(t *testing.T) {
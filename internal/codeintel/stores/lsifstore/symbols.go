@@ -0,0 +1,142 @@
+package lsifstore
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// DocumentSymbols returns the document symbol trees of the document with the given path. Documents
+// of uploads that were processed before document symbols were stored have no symbols.
+func (s *Store) DocumentSymbols(ctx context.Context, bundleID int, path string) (_ []precise.SymbolData, err error) {
+	ctx, trace, endObservation := s.operations.documentSymbols.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.String("path", path),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	data, exists, err := scanFirstBytes(s.Store.Query(ctx, sqlf.Sprintf(documentSymbolsQuery, bundleID, path)))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	document, err := s.serializer.UnmarshalDocumentData(MarshalledDocumentData{Symbols: data})
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numSymbols", len(document.Symbols)))
+
+	return document.Symbols, nil
+}
+
+const documentSymbolsQuery = `
+-- source: internal/codeintel/stores/lsifstore/symbols.go:DocumentSymbols
+SELECT symbols FROM lsif_data_documents WHERE dump_id = %s AND path = %s LIMIT 1
+`
+
+var scanFirstBytes = basestore.NewFirstScanner(basestore.ScanAny[[]byte])
+
+// SearchSymbols returns the document symbols of the given bundle that match the given options,
+// ordered by path and position. The paths of the returned symbols are relative to the root of
+// the bundle, which is prepended to the stored paths when matching the path patterns.
+func (s *Store) SearchSymbols(ctx context.Context, bundleID int, root string, opts SymbolSearchOptions, limit int) (_ []SymbolMatch, err error) {
+	ctx, trace, endObservation := s.operations.searchSymbols.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.String("root", root),
+		log.String("query", opts.Query),
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	conds, err := makeSymbolSearchConditions(root, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols, err := scanSymbolMatches(s.Store.Query(ctx, sqlf.Sprintf(
+		searchSymbolsQuery,
+		bundleID,
+		conds,
+		limit,
+	)))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numSymbols", len(symbols)))
+
+	return symbols, nil
+}
+
+const searchSymbolsQuery = `
+-- source: internal/codeintel/stores/lsifstore/symbols.go:SearchSymbols
+SELECT dump_id, path, name, kind, parent, start_line, start_character, end_line, end_character
+FROM lsif_data_symbols
+WHERE dump_id = %s AND %s
+ORDER BY path, start_line, start_character
+LIMIT %s
+`
+
+// makeSymbolSearchConditions returns the conjunction of the conditions of the given search options.
+// The patterns of the options are translated into the regular expression syntax of Postgres.
+func makeSymbolSearchConditions(root string, opts SymbolSearchOptions) (*sqlf.Query, error) {
+	operator := "~*"
+	if opts.IsCaseSensitive {
+		operator = "~"
+	}
+
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.Query != "" {
+		pattern, err := toPostgresRegexp(opts.Query)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, sqlf.Sprintf("name "+operator+" %s", pattern))
+	}
+	for _, includePattern := range opts.IncludePatterns {
+		pattern, err := toPostgresRegexp(includePattern)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, sqlf.Sprintf("(%s || path) "+operator+" %s", root, pattern))
+	}
+	if opts.ExcludePattern != "" {
+		pattern, err := toPostgresRegexp(opts.ExcludePattern)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, sqlf.Sprintf("NOT (%s || path) "+operator+" %s", root, pattern))
+	}
+
+	return sqlf.Join(conds, " AND "), nil
+}
+
+var scanSymbolMatches = basestore.NewSliceScanner(scanSymbolMatch)
+
+func scanSymbolMatch(s dbutil.Scanner) (symbol SymbolMatch, _ error) {
+	var kind int
+	var parent sql.NullString
+
+	err := s.Scan(
+		&symbol.DumpID,
+		&symbol.Path,
+		&symbol.Name,
+		&kind,
+		&parent,
+		&symbol.Range.Start.Line,
+		&symbol.Range.Start.Character,
+		&symbol.Range.End.Line,
+		&symbol.Range.End.Character,
+	)
+	symbol.Kind = protocol.SymbolKind(kind)
+	symbol.Parent = parent.String
+
+	return symbol, err
+}
@@ -0,0 +1,129 @@
+package lsifstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestDocumentSymbols(t *testing.T) {
+	store := populateSymbolsTestStore(t)
+
+	symbols, err := store.DocumentSymbols(context.Background(), testBundleID, "protocol/protocol.go")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if diff := cmp.Diff(testSymbols, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	symbols, err = store.DocumentSymbols(context.Background(), testBundleID, "missing.go")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if len(symbols) != 0 {
+		t.Errorf("unexpected symbols for missing document: %v", symbols)
+	}
+}
+
+func TestSearchSymbols(t *testing.T) {
+	store := populateSymbolsTestStore(t)
+
+	vertex := SymbolMatch{
+		DumpID: testBundleID,
+		Path:   "protocol/protocol.go",
+		Name:   "Vertex",
+		Kind:   protocol.Struct,
+		Range:  newRange(10, 5, 10, 11),
+	}
+	vertexLabel := SymbolMatch{
+		DumpID: testBundleID,
+		Path:   "protocol/protocol.go",
+		Name:   "VertexLabel",
+		Kind:   protocol.Field,
+		Parent: "Vertex",
+		Range:  newRange(12, 1, 12, 12),
+	}
+
+	testCases := []struct {
+		root     string
+		opts     SymbolSearchOptions
+		limit    int
+		expected []SymbolMatch
+	}{
+		{"", SymbolSearchOptions{Query: "^vertex"}, 10, []SymbolMatch{vertex, vertexLabel}},
+		{"", SymbolSearchOptions{Query: "^vertex", IsCaseSensitive: true}, 10, nil},
+		{"", SymbolSearchOptions{Query: "Label$"}, 10, []SymbolMatch{vertexLabel}},
+		{"", SymbolSearchOptions{Query: "^Vertex"}, 1, []SymbolMatch{vertex}},
+		{"sub/", SymbolSearchOptions{IncludePatterns: []string{"^sub/protocol/"}}, 10, []SymbolMatch{vertex, vertexLabel}},
+		{"", SymbolSearchOptions{IncludePatterns: []string{"^sub/protocol/"}}, 10, nil},
+		{"", SymbolSearchOptions{ExcludePattern: `protocol\.go$`}, 10, nil},
+	}
+
+	for _, testCase := range testCases {
+		symbols, err := store.SearchSymbols(context.Background(), testBundleID, testCase.root, testCase.opts, testCase.limit)
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if diff := cmp.Diff(testCase.expected, symbols); diff != "" {
+			t.Errorf("unexpected symbols for %+v (-want +got):\n%s", testCase.opts, diff)
+		}
+	}
+}
+
+var testSymbols = []precise.SymbolData{
+	{
+		Name:               "Vertex",
+		Kind:               protocol.Struct,
+		StartLine:          10,
+		StartCharacter:     5,
+		EndLine:            10,
+		EndCharacter:       11,
+		FullStartLine:      10,
+		FullStartCharacter: 0,
+		FullEndLine:        13,
+		FullEndCharacter:   1,
+		Children: []precise.SymbolData{
+			{
+				Name:               "VertexLabel",
+				Kind:               protocol.Field,
+				StartLine:          12,
+				StartCharacter:     1,
+				EndLine:            12,
+				EndCharacter:       12,
+				FullStartLine:      12,
+				FullStartCharacter: 1,
+				FullEndLine:        12,
+				FullEndCharacter:   12,
+			},
+		},
+	},
+}
+
+func populateSymbolsTestStore(t *testing.T) *Store {
+	logger := logtest.Scoped(t)
+	db := stores.NewCodeIntelDB(dbtest.NewDB(logger, t))
+	store := NewStore(db, conf.DefaultClient(), &observation.TestContext)
+
+	documents := make(chan precise.KeyedDocumentData, 1)
+	documents <- precise.KeyedDocumentData{
+		Path:     "protocol/protocol.go",
+		Document: precise.DocumentData{Symbols: testSymbols},
+	}
+	close(documents)
+
+	if _, err := store.WriteDocuments(context.Background(), testBundleID, documents); err != nil {
+		t.Fatalf("unexpected error writing documents: %s", err)
+	}
+
+	return store
+}
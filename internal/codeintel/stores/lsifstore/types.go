@@ -1,6 +1,9 @@
 package lsifstore
 
-import "github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+import (
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
 
 // Location is an LSP-like location scoped to a dump.
 type Location struct {
@@ -37,3 +40,22 @@ type CodeIntelligenceRange struct {
 	Implementations []Location
 	HoverText       string
 }
+
+// SymbolMatch is a document symbol of a dump that matched a symbol search.
+type SymbolMatch struct {
+	DumpID int
+	Path   string
+	Name   string
+	Kind   protocol.SymbolKind
+	Parent string
+	Range  Range
+}
+
+// SymbolSearchOptions configures a symbol search. The query and the path patterns are regular
+// expressions. The path patterns are matched against repository-relative paths.
+type SymbolSearchOptions struct {
+	Query           string
+	IsCaseSensitive bool
+	IncludePatterns []string
+	ExcludePattern  string
+}
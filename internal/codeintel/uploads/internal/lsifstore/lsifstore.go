@@ -3,6 +3,8 @@ package lsifstore
 import (
 	"context"
 
+	codeintellsifstore "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -10,16 +12,19 @@ import (
 
 type LsifStore interface {
 	DeleteLsifDataByUploadIds(ctx context.Context, bundleIDs ...int) (err error)
+	GetExportedSymbols(ctx context.Context, bundleID int) (_ []shared.ExportedSymbol, err error)
 }
 
 type store struct {
 	db         *basestore.Store
+	serializer *codeintellsifstore.Serializer
 	operations *operations
 }
 
 func New(db database.DB, observationContext *observation.Context) LsifStore {
 	return &store{
 		db:         basestore.NewWithHandle(db.Handle()),
+		serializer: codeintellsifstore.NewSerializer(),
 		operations: newOperations(observationContext),
 	}
 }
@@ -0,0 +1,214 @@
+package lsifstore
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	codeintellsifstore "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// GetExportedSymbols returns the symbols of the given bundle that have an export moniker, ordered
+// by moniker scheme and identifier. The location of each symbol is the first of its definitions.
+// Its signature and hover text are read from the document symbol and the range at that location.
+func (s *store) GetExportedSymbols(ctx context.Context, bundleID int) (_ []shared.ExportedSymbol, err error) {
+	ctx, trace, endObservation := s.operations.getExportedSymbols.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	monikerLocations, err := s.scanMonikerLocations(s.db.Query(ctx, sqlf.Sprintf(exportedMonikersQuery, bundleID)))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numMonikers", len(monikerLocations)))
+
+	if len(monikerLocations) == 0 {
+		return nil, nil
+	}
+
+	symbols := make([]shared.ExportedSymbol, 0, len(monikerLocations))
+	definitions := make([]precise.LocationData, 0, len(monikerLocations))
+	pathMap := map[string]struct{}{}
+	for _, moniker := range monikerLocations {
+		if len(moniker.Locations) == 0 {
+			continue
+		}
+
+		definition := firstLocation(moniker.Locations)
+		symbols = append(symbols, shared.ExportedSymbol{
+			Scheme:     moniker.Scheme,
+			Identifier: moniker.Identifier,
+			Path:       definition.URI,
+			Line:       definition.StartLine,
+			Character:  definition.StartCharacter,
+		})
+		definitions = append(definitions, definition)
+		pathMap[definition.URI] = struct{}{}
+	}
+
+	paths := make([]string, 0, len(pathMap))
+	for path := range pathMap {
+		paths = append(paths, path)
+	}
+
+	documents, err := s.scanDocuments(s.db.Query(ctx, sqlf.Sprintf(exportedSymbolDocumentsQuery, bundleID, pq.Array(paths))))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numDocuments", len(documents)))
+
+	for i, definition := range definitions {
+		document, ok := documents[definition.URI]
+		if !ok {
+			continue
+		}
+
+		symbols[i].HoverText = hoverTextAt(document, definition)
+		symbols[i].Signature = signatureAt(document.Symbols, definition)
+	}
+
+	sort.Slice(symbols, func(i, j int) bool {
+		if symbols[i].Scheme != symbols[j].Scheme {
+			return symbols[i].Scheme < symbols[j].Scheme
+		}
+		return symbols[i].Identifier < symbols[j].Identifier
+	})
+
+	return symbols, nil
+}
+
+const exportedMonikersQuery = `
+-- source: internal/codeintel/uploads/internal/lsifstore/lsifstore_exports.go:GetExportedSymbols
+SELECT scheme, identifier, data FROM lsif_data_definitions WHERE dump_id = %s
+`
+
+const exportedSymbolDocumentsQuery = `
+-- source: internal/codeintel/uploads/internal/lsifstore/lsifstore_exports.go:GetExportedSymbols
+SELECT path, data, ranges, hovers, symbols FROM lsif_data_documents WHERE dump_id = %s AND path = ANY(%s)
+`
+
+// scanMonikerLocations reads moniker locations values from the given row object.
+func (s *store) scanMonikerLocations(rows *sql.Rows, queryErr error) (_ []precise.MonikerLocations, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var values []precise.MonikerLocations
+	for rows.Next() {
+		var rawData []byte
+		var record precise.MonikerLocations
+		if err := rows.Scan(&record.Scheme, &record.Identifier, &rawData); err != nil {
+			return nil, err
+		}
+
+		locations, err := s.serializer.UnmarshalLocations(rawData)
+		if err != nil {
+			return nil, err
+		}
+		record.Locations = locations
+
+		values = append(values, record)
+	}
+
+	return values, nil
+}
+
+// scanDocuments reads document data from the given row object and returns a map from document
+// paths to the decoded ranges, hover texts and document symbols of each document.
+func (s *store) scanDocuments(rows *sql.Rows, queryErr error) (_ map[string]precise.DocumentData, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	documents := map[string]precise.DocumentData{}
+	for rows.Next() {
+		var path string
+		var rawData []byte
+		var encoded codeintellsifstore.MarshalledDocumentData
+		if err := rows.Scan(&path, &rawData, &encoded.Ranges, &encoded.HoverResults, &encoded.Symbols); err != nil {
+			return nil, err
+		}
+
+		var document precise.DocumentData
+		if len(rawData) != 0 {
+			document, err = s.serializer.UnmarshalLegacyDocumentData(rawData)
+		} else {
+			document, err = s.serializer.UnmarshalDocumentData(encoded)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		documents[path] = document
+	}
+
+	return documents, nil
+}
+
+// firstLocation returns the location of the given non-empty slice that comes first in path and
+// position order.
+func firstLocation(locations []precise.LocationData) precise.LocationData {
+	first := locations[0]
+	for _, location := range locations[1:] {
+		if locationLess(location, first) {
+			first = location
+		}
+	}
+
+	return first
+}
+
+func locationLess(a, b precise.LocationData) bool {
+	if a.URI != b.URI {
+		return a.URI < b.URI
+	}
+	if a.StartLine != b.StartLine {
+		return a.StartLine < b.StartLine
+	}
+	return a.StartCharacter < b.StartCharacter
+}
+
+// hoverTextAt returns the hover text of the range of the given document that spans exactly the
+// given location, or the empty string if there is no such range.
+func hoverTextAt(document precise.DocumentData, location precise.LocationData) string {
+	for _, r := range document.Ranges {
+		if r.StartLine == location.StartLine &&
+			r.StartCharacter == location.StartCharacter &&
+			r.EndLine == location.EndLine &&
+			r.EndCharacter == location.EndCharacter {
+			return document.HoverResults[r.HoverResultID]
+		}
+	}
+
+	return ""
+}
+
+// signatureAt returns the detail of the document symbol whose name spans exactly the given location,
+// or the empty string if there is no such symbol.
+func signatureAt(symbols []precise.SymbolData, location precise.LocationData) string {
+	for _, symbol := range symbols {
+		if symbol.StartLine == location.StartLine &&
+			symbol.StartCharacter == location.StartCharacter &&
+			symbol.EndLine == location.EndLine &&
+			symbol.EndCharacter == location.EndCharacter {
+			return symbol.Detail
+		}
+
+		if detail := signatureAt(symbol.Children, location); detail != "" {
+			return detail
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,115 @@
+package lsifstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores"
+	codeintellsifstore "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestGetExportedSymbols(t *testing.T) {
+	logger := logtest.Scoped(t)
+	sqlDB := dbtest.NewDB(logger, t)
+	db := database.NewDB(logger, sqlDB)
+	store := New(db, &observation.TestContext)
+	writer := codeintellsifstore.NewStore(stores.NewCodeIntelDB(sqlDB), conf.DefaultClient(), &observation.TestContext)
+
+	documents := make(chan precise.KeyedDocumentData, 1)
+	documents <- precise.KeyedDocumentData{
+		Path: "parse.go",
+		Document: precise.DocumentData{
+			Ranges: map[precise.ID]precise.RangeData{
+				"r1": {StartLine: 4, StartCharacter: 5, EndLine: 4, EndCharacter: 10, HoverResultID: "h1"},
+				"r2": {StartLine: 12, StartCharacter: 5, EndLine: 12, EndCharacter: 11},
+			},
+			HoverResults: map[precise.ID]string{
+				"h1": "func Parse(s string) (Node, error)",
+			},
+			Symbols: []precise.SymbolData{
+				{
+					Name:      "Parser",
+					Kind:      protocol.Struct,
+					StartLine: 8, StartCharacter: 5, EndLine: 8, EndCharacter: 11,
+					Children: []precise.SymbolData{
+						{Name: "Parse", Kind: protocol.Method, Detail: "func(s string) (Node, error)", StartLine: 4, StartCharacter: 5, EndLine: 4, EndCharacter: 10},
+					},
+				},
+			},
+		},
+	}
+	close(documents)
+
+	definitions := make(chan precise.MonikerLocations, 3)
+	definitions <- precise.MonikerLocations{
+		Kind:       "export",
+		Scheme:     "gomod",
+		Identifier: "parser:Parse",
+		Locations: []precise.LocationData{
+			{URI: "parse_test.go", StartLine: 1, StartCharacter: 5, EndLine: 1, EndCharacter: 10},
+			{URI: "parse.go", StartLine: 4, StartCharacter: 5, EndLine: 4, EndCharacter: 10},
+		},
+	}
+	definitions <- precise.MonikerLocations{
+		Kind:       "export",
+		Scheme:     "gomod",
+		Identifier: "parser:Node",
+		Locations: []precise.LocationData{
+			{URI: "parse.go", StartLine: 12, StartCharacter: 5, EndLine: 12, EndCharacter: 11},
+		},
+	}
+	definitions <- precise.MonikerLocations{
+		Kind:       "export",
+		Scheme:     "gomod",
+		Identifier: "parser:Missing",
+		Locations: []precise.LocationData{
+			{URI: "missing.go", StartLine: 2, StartCharacter: 1, EndLine: 2, EndCharacter: 8},
+		},
+	}
+	close(definitions)
+
+	if _, err := writer.WriteDocuments(context.Background(), 42, documents); err != nil {
+		t.Fatalf("unexpected error writing documents: %s", err)
+	}
+	if _, err := writer.WriteDefinitions(context.Background(), 42, definitions); err != nil {
+		t.Fatalf("unexpected error writing definitions: %s", err)
+	}
+
+	symbols, err := store.GetExportedSymbols(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("unexpected error getting exported symbols: %s", err)
+	}
+
+	expectedSymbols := []shared.ExportedSymbol{
+		{Scheme: "gomod", Identifier: "parser:Missing", Path: "missing.go", Line: 2, Character: 1},
+		{Scheme: "gomod", Identifier: "parser:Node", Path: "parse.go", Line: 12, Character: 5},
+		{
+			Scheme:     "gomod",
+			Identifier: "parser:Parse",
+			Path:       "parse.go",
+			Line:       4,
+			Character:  5,
+			Signature:  "func(s string) (Node, error)",
+			HoverText:  "func Parse(s string) (Node, error)",
+		},
+	}
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected exported symbols (-want +got):\n%s", diff)
+	}
+
+	if symbols, err := store.GetExportedSymbols(context.Background(), 43); err != nil {
+		t.Fatalf("unexpected error getting exported symbols: %s", err)
+	} else if len(symbols) != 0 {
+		t.Errorf("unexpected exported symbols for missing upload: %v", symbols)
+	}
+}
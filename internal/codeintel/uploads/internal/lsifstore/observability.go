@@ -9,6 +9,7 @@ import (
 
 type operations struct {
 	deleteLsifDataByUploadIds *observation.Operation
+	getExportedSymbols        *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -29,5 +30,6 @@ func newOperations(observationContext *observation.Context) *operations {
 
 	return &operations{
 		deleteLsifDataByUploadIds: op("DeleteLsifDataByUploadIds"),
+		getExportedSymbols:        op("GetExportedSymbols"),
 	}
 }
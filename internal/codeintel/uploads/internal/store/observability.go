@@ -39,6 +39,7 @@ type operations struct {
 	hardDeleteUploadsByIDs         *observation.Operation
 
 	// Dumps
+	getDumpsByIDs                     *observation.Operation
 	findClosestDumps                  *observation.Operation
 	findClosestDumpsFromGraphFragment *observation.Operation
 
@@ -101,6 +102,7 @@ func newOperations(observationContext *observation.Context) *operations {
 		persistUploadsVisibleAtTip: op("persistUploadsVisibleAtTip"),
 
 		// Dumps
+		getDumpsByIDs:                     op("GetDumpsByIDs"),
 		findClosestDumps:                  op("FindClosestDumps"),
 		findClosestDumpsFromGraphFragment: op("FindClosestDumpsFromGraphFragment"),
 
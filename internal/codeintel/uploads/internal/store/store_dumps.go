@@ -13,6 +13,57 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
+// GetDumpsByIDs returns a set of dumps by identifiers. Uploads that have not completed processing
+// are not returned.
+func (s *store) GetDumpsByIDs(ctx context.Context, ids []int) (_ []shared.Dump, err error) {
+	ctx, trace, endObservation := s.operations.getDumpsByIDs.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numIDs", len(ids)),
+		log.String("ids", intsToString(ids)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var idx []*sqlf.Query
+	for _, id := range ids {
+		idx = append(idx, sqlf.Sprintf("%s", id))
+	}
+
+	dumps, err := scanDumps(s.db.Query(ctx, sqlf.Sprintf(getDumpsByIDsQuery, sqlf.Join(idx, ", "))))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numDumps", len(dumps)))
+
+	return dumps, nil
+}
+
+const getDumpsByIDsQuery = `
+-- source: internal/codeintel/uploads/internal/store/store_dumps.go:GetDumpsByIDs
+SELECT
+	u.id,
+	u.commit,
+	u.root,
+	EXISTS (` + visibleAtTipSubselectQuery + `) AS visible_at_tip,
+	u.uploaded_at,
+	u.state,
+	u.failure_message,
+	u.started_at,
+	u.finished_at,
+	u.process_after,
+	u.num_resets,
+	u.num_failures,
+	u.repository_id,
+	u.repository_name,
+	u.indexer,
+	u.indexer_version,
+	u.associated_index_id
+FROM lsif_dumps_with_repository_name u WHERE u.id IN (%s)
+ORDER BY u.id
+`
+
 // FindClosestDumps returns the set of dumps that can most accurately answer queries for the given repository, commit, path, and
 // optional indexer. If rootMustEnclosePath is true, then only dumps with a root which is a prefix of path are returned. Otherwise,
 // any dump with a root intersecting the given path is returned.
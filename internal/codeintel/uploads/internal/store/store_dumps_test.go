@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/keegancsmith/sqlf"
@@ -21,6 +22,75 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
+func TestGetDumpsByIDs(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(db, &observation.TestContext)
+
+	// Dumps do not exist initially
+	if dumps, err := store.GetDumpsByIDs(context.Background(), []int{1, 2}); err != nil {
+		t.Fatalf("unexpected error getting dump: %s", err)
+	} else if len(dumps) > 0 {
+		t.Fatal("unexpected record")
+	}
+
+	uploadedAt := time.Unix(1587396557, 0).UTC()
+	startedAt := uploadedAt.Add(time.Minute)
+	finishedAt := uploadedAt.Add(time.Minute * 2)
+	expectedAssociatedIndexID := 42
+	expected1 := shared.Dump{
+		ID:                1,
+		Commit:            makeCommit(1),
+		Root:              "sub/",
+		VisibleAtTip:      true,
+		UploadedAt:        uploadedAt,
+		State:             "completed",
+		FailureMessage:    nil,
+		StartedAt:         &startedAt,
+		FinishedAt:        &finishedAt,
+		RepositoryID:      50,
+		RepositoryName:    "n-50",
+		Indexer:           "lsif-go",
+		IndexerVersion:    "latest",
+		AssociatedIndexID: &expectedAssociatedIndexID,
+	}
+	expected2 := shared.Dump{
+		ID:                2,
+		Commit:            makeCommit(2),
+		Root:              "other/",
+		VisibleAtTip:      false,
+		UploadedAt:        uploadedAt,
+		State:             "completed",
+		FailureMessage:    nil,
+		StartedAt:         &startedAt,
+		FinishedAt:        &finishedAt,
+		RepositoryID:      50,
+		RepositoryName:    "n-50",
+		Indexer:           "scip-typescript",
+		IndexerVersion:    "1.2.3",
+		AssociatedIndexID: nil,
+	}
+
+	insertUploads(t, db,
+		dumpToUpload(expected1),
+		dumpToUpload(expected2),
+		shared.Upload{ID: 3, State: "queued"},
+	)
+	insertVisibleAtTip(t, db, 50, 1)
+
+	if dumps, err := store.GetDumpsByIDs(context.Background(), []int{1}); err != nil {
+		t.Fatalf("unexpected error getting dump: %s", err)
+	} else if diff := cmp.Diff([]shared.Dump{expected1}, dumps); diff != "" {
+		t.Errorf("unexpected dumps (-want +got):\n%s", diff)
+	}
+
+	if dumps, err := store.GetDumpsByIDs(context.Background(), []int{1, 2, 3}); err != nil {
+		t.Fatalf("unexpected error getting dump: %s", err)
+	} else if diff := cmp.Diff([]shared.Dump{expected1, expected2}, dumps); diff != "" {
+		t.Errorf("unexpected dumps (-want +got):\n%s", diff)
+	}
+}
+
 func TestFindClosestDumps(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
@@ -263,3 +333,24 @@ func testAllOf(t *testing.T, dumps []shared.Dump, expectedIDs []int) {
 		}
 	}
 }
+
+func dumpToUpload(expected shared.Dump) shared.Upload {
+	return shared.Upload{
+		ID:                expected.ID,
+		Commit:            expected.Commit,
+		Root:              expected.Root,
+		UploadedAt:        expected.UploadedAt,
+		State:             expected.State,
+		FailureMessage:    expected.FailureMessage,
+		StartedAt:         expected.StartedAt,
+		FinishedAt:        expected.FinishedAt,
+		ProcessAfter:      expected.ProcessAfter,
+		NumResets:         expected.NumResets,
+		NumFailures:       expected.NumFailures,
+		RepositoryID:      expected.RepositoryID,
+		RepositoryName:    expected.RepositoryName,
+		Indexer:           expected.Indexer,
+		IndexerVersion:    expected.IndexerVersion,
+		AssociatedIndexID: expected.AssociatedIndexID,
+	}
+}
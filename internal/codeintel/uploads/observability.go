@@ -41,7 +41,8 @@ type operations struct {
 	hardDeleteUploads              *observation.Operation
 
 	// Dumps
-	findClosestDumps *observation.Operation
+	findClosestDumps    *observation.Operation
+	diffExportedSymbols *observation.Operation
 
 	// Packages
 	updatePackages *observation.Operation
@@ -102,7 +103,8 @@ func newOperations(observationContext *observation.Context) *operations {
 		hardDeleteUploads:              op("HardDeleteUploads"),
 
 		// Dumps
-		findClosestDumps: op("FindClosestDumps"),
+		findClosestDumps:    op("FindClosestDumps"),
+		diffExportedSymbols: op("DiffExportedSymbols"),
 
 		// Packages
 		updatePackages: op("UpdatePackages"),
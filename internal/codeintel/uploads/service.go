@@ -60,6 +60,7 @@ type service interface {
 
 	// Dumps
 	FindClosestDumps(ctx context.Context, repositoryID int, commit, path string, rootMustEnclosePath bool, indexer string) (_ []shared.Dump, err error)
+	DiffExportedSymbols(ctx context.Context, baseUploadID, headUploadID int) (_ shared.ExportedSymbolsDiff, err error)
 
 	// Packages
 	UpdatePackages(ctx context.Context, dumpID int, packages []precise.Package) (err error)
@@ -377,6 +378,87 @@ func (s *Service) FindClosestDumps(ctx context.Context, repositoryID int, commit
 	return s.store.FindClosestDumps(ctx, repositoryID, commit, path, rootMustEnclosePath, indexer)
 }
 
+// DiffExportedSymbols compares the exported symbols of two completed uploads of the same repository
+// and root. A symbol of the head upload is added if the base upload does not export a symbol with the
+// same moniker, and changed if the signature or hover text of that symbol differ. A symbol of the base
+// upload is removed if the head upload does not export a symbol with the same moniker.
+func (s *Service) DiffExportedSymbols(ctx context.Context, baseUploadID, headUploadID int) (_ shared.ExportedSymbolsDiff, err error) {
+	ctx, _, endObservation := s.operations.diffExportedSymbols.With(ctx, &err, observation.Args{
+		LogFields: []log.Field{
+			log.Int("baseUploadID", baseUploadID),
+			log.Int("headUploadID", headUploadID),
+		},
+	})
+	defer endObservation(1, observation.Args{})
+
+	dumps, err := s.store.GetDumpsByIDs(ctx, []int{baseUploadID, headUploadID})
+	if err != nil {
+		return shared.ExportedSymbolsDiff{}, errors.Wrap(err, "store.GetDumpsByIDs")
+	}
+
+	dumpsByID := make(map[int]shared.Dump, len(dumps))
+	for _, dump := range dumps {
+		dumpsByID[dump.ID] = dump
+	}
+	base, ok := dumpsByID[baseUploadID]
+	if !ok {
+		return shared.ExportedSymbolsDiff{}, errors.Newf("upload %d does not exist or has not completed processing", baseUploadID)
+	}
+	head, ok := dumpsByID[headUploadID]
+	if !ok {
+		return shared.ExportedSymbolsDiff{}, errors.Newf("upload %d does not exist or has not completed processing", headUploadID)
+	}
+	if base.RepositoryID != head.RepositoryID || base.Root != head.Root {
+		return shared.ExportedSymbolsDiff{}, errors.Newf("uploads %d and %d do not index the same repository and root", baseUploadID, headUploadID)
+	}
+
+	baseSymbols, err := s.lsifstore.GetExportedSymbols(ctx, baseUploadID)
+	if err != nil {
+		return shared.ExportedSymbolsDiff{}, errors.Wrap(err, "lsifstore.GetExportedSymbols")
+	}
+	headSymbols, err := s.lsifstore.GetExportedSymbols(ctx, headUploadID)
+	if err != nil {
+		return shared.ExportedSymbolsDiff{}, errors.Wrap(err, "lsifstore.GetExportedSymbols")
+	}
+
+	return diffExportedSymbols(baseSymbols, headSymbols), nil
+}
+
+// diffExportedSymbols compares the given lists of exported symbols by moniker. Both lists must be
+// ordered by scheme and identifier, and the lists of the returned diff are ordered the same way.
+func diffExportedSymbols(baseSymbols, headSymbols []shared.ExportedSymbol) (diff shared.ExportedSymbolsDiff) {
+	i, j := 0, 0
+	for i < len(baseSymbols) && j < len(headSymbols) {
+		base, head := baseSymbols[i], headSymbols[j]
+
+		switch {
+		case monikerLess(base, head):
+			diff.Removed = append(diff.Removed, base)
+			i++
+		case monikerLess(head, base):
+			diff.Added = append(diff.Added, head)
+			j++
+		default:
+			if base.Signature != head.Signature || base.HoverText != head.HoverText {
+				diff.Changed = append(diff.Changed, shared.ChangedExportedSymbol{Base: base, Head: head})
+			}
+			i++
+			j++
+		}
+	}
+	diff.Removed = append(diff.Removed, baseSymbols[i:]...)
+	diff.Added = append(diff.Added, headSymbols[j:]...)
+
+	return diff
+}
+
+func monikerLess(a, b shared.ExportedSymbol) bool {
+	if a.Scheme != b.Scheme {
+		return a.Scheme < b.Scheme
+	}
+	return a.Identifier < b.Identifier
+}
+
 func (s *Service) HardDeleteExpiredUploads(ctx context.Context) (count int, err error) {
 	ctx, _, endObservation := s.operations.hardDeleteUploads.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
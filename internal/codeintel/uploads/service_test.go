@@ -0,0 +1,60 @@
+package uploads
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+)
+
+func TestDiffExportedSymbols(t *testing.T) {
+	symbol := func(identifier, signature, hoverText string) shared.ExportedSymbol {
+		return shared.ExportedSymbol{
+			Scheme:     "gomod",
+			Identifier: identifier,
+			Path:       "parse.go",
+			Signature:  signature,
+			HoverText:  hoverText,
+		}
+	}
+
+	baseSymbols := []shared.ExportedSymbol{
+		symbol("parser:Node", "struct{...}", "type Node struct"),
+		symbol("parser:Parse", "func(s string) Node", "Parse parses s."),
+		symbol("parser:Walk", "func(n Node)", "Walk visits n."),
+		symbol("parser:Zero", "const Zero", ""),
+	}
+	headSymbols := []shared.ExportedSymbol{
+		symbol("parser:Error", "struct{...}", "type Error struct"),
+		symbol("parser:Node", "struct{...}", "type Node struct"),
+		symbol("parser:Parse", "func(s string) (Node, error)", "Parse parses s."),
+		symbol("parser:Walk", "func(n Node)", "Walk visits n and its children."),
+	}
+
+	expected := shared.ExportedSymbolsDiff{
+		Added: []shared.ExportedSymbol{
+			symbol("parser:Error", "struct{...}", "type Error struct"),
+		},
+		Removed: []shared.ExportedSymbol{
+			symbol("parser:Zero", "const Zero", ""),
+		},
+		Changed: []shared.ChangedExportedSymbol{
+			{
+				Base: symbol("parser:Parse", "func(s string) Node", "Parse parses s."),
+				Head: symbol("parser:Parse", "func(s string) (Node, error)", "Parse parses s."),
+			},
+			{
+				Base: symbol("parser:Walk", "func(n Node)", "Walk visits n."),
+				Head: symbol("parser:Walk", "func(n Node)", "Walk visits n and its children."),
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, diffExportedSymbols(baseSymbols, headSymbols)); diff != "" {
+		t.Errorf("unexpected diff (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(shared.ExportedSymbolsDiff{}, diffExportedSymbols(baseSymbols, baseSymbols)); diff != "" {
+		t.Errorf("unexpected diff of identical uploads (-want +got):\n%s", diff)
+	}
+}
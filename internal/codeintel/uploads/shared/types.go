@@ -98,6 +98,33 @@ type PackageReference struct {
 	Package
 }
 
+// ExportedSymbol is a symbol defined by an upload that is visible to other packages, as identified
+// by the scheme and identifier of its export moniker.
+type ExportedSymbol struct {
+	Scheme     string
+	Identifier string
+	Path       string // relative to the root of the upload
+	Line       int    // 0-indexed
+	Character  int    // 0-indexed
+	Signature  string // detail of the document symbol defined at the same range, possibly empty
+	HoverText  string // possibly empty
+}
+
+// ChangedExportedSymbol pairs the base and head versions of an exported symbol whose signature or
+// hover text differ between two uploads.
+type ChangedExportedSymbol struct {
+	Base ExportedSymbol
+	Head ExportedSymbol
+}
+
+// ExportedSymbolsDiff describes the changes to the exported symbols of a repository root between a
+// base and a head upload. Each list is ordered by scheme and identifier.
+type ExportedSymbolsDiff struct {
+	Added   []ExportedSymbol
+	Removed []ExportedSymbol
+	Changed []ChangedExportedSymbol
+}
+
 type DependencyReferenceCountUpdateType int
 
 const (
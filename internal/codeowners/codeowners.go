@@ -0,0 +1,81 @@
+// Package codeowners parses CODEOWNERS files and resolves the owners of
+// paths in a repository.
+package codeowners
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// Paths are the locations of CODEOWNERS files in a repository, in the order in
+// which they are looked up. This is the union of the locations supported by
+// GitHub and GitLab.
+var Paths = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+}
+
+// Rule assigns owners to the paths matching a pattern.
+type Rule struct {
+	Pattern string
+	Owners  []string
+
+	pattern gitignore.Pattern
+}
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	Rules []*Rule
+}
+
+// Parse parses a CODEOWNERS file. Patterns follow the gitignore syntax. Blank
+// lines, comments and GitLab section headers (e.g., "[Documentation]") are
+// skipped.
+func Parse(r io.Reader) (*Ruleset, error) {
+	var rules []*Rule
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" || strings.HasPrefix(line, "#") || isSectionHeader(line) {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		rules = append(rules, &Rule{
+			Pattern: fields[0],
+			Owners:  fields[1:],
+			pattern: gitignore.ParsePattern(fields[0], nil),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &Ruleset{Rules: rules}, nil
+}
+
+// isSectionHeader returns true if line is a GitLab section header, which may
+// be optional ("^[Section]") and may specify default owners.
+func isSectionHeader(line string) bool {
+	return strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[")
+}
+
+// Match returns the owners of path. As in gitignore files, the last rule
+// matching path takes precedence. A matching rule without owners means that
+// path has no owners.
+func (s *Ruleset) Match(path string) []string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i := len(s.Rules) - 1; i >= 0; i-- {
+		if s.Rules[i].pattern.Match(parts, false) != gitignore.NoMatch {
+			return s.Rules[i].Owners
+		}
+	}
+	return nil
+}
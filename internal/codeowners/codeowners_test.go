@@ -0,0 +1,44 @@
+package codeowners
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRulesetMatch(t *testing.T) {
+	ruleset, err := Parse(strings.NewReader(`
+# Default owners of everything in the repository.
+*                 @sourcegraph/everyone
+
+[Documentation]
+/docs/            @sourcegraph/docs docs@example.com
+*.md              @sourcegraph/writers # inline comment
+
+/internal/search/ @sourcegraph/search
+/internal/search/vendored/
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "main.go", want: []string{"@sourcegraph/everyone"}},
+		{path: "docs/index.html", want: []string{"@sourcegraph/docs", "docs@example.com"}},
+		{path: "docs/index.md", want: []string{"@sourcegraph/writers"}},
+		{path: "cmd/docs/main.go", want: []string{"@sourcegraph/everyone"}},
+		{path: "internal/search/job/job.go", want: []string{"@sourcegraph/search"}},
+		{path: "internal/search/vendored/lib.go", want: []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, ruleset.Match(test.path)); diff != "" {
+				t.Fatalf("unexpected owners (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package codeowners
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+// Resolver resolves the owners of paths using the CODEOWNERS file of a
+// repository at a commit. Parsed CODEOWNERS files are cached for the lifetime
+// of the Resolver, so a Resolver should not outlive a single search.
+type Resolver struct {
+	gitserver gitserver.Client
+
+	mu       sync.Mutex
+	rulesets map[rulesetKey]*Ruleset
+}
+
+type rulesetKey struct {
+	repo   api.RepoName
+	commit api.CommitID
+}
+
+// NewResolver returns a Resolver that fetches CODEOWNERS files from gitserver.
+func NewResolver(client gitserver.Client) *Resolver {
+	return &Resolver{gitserver: client, rulesets: map[rulesetKey]*Ruleset{}}
+}
+
+// Owners returns the owners of path in repo at commit. It returns no owners if
+// the repository has no CODEOWNERS file.
+func (r *Resolver) Owners(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) ([]string, error) {
+	ruleset, err := r.ruleset(ctx, repo, commit)
+	if err != nil || ruleset == nil {
+		return nil, err
+	}
+	return ruleset.Match(path), nil
+}
+
+func (r *Resolver) ruleset(ctx context.Context, repo api.RepoName, commit api.CommitID) (*Ruleset, error) {
+	key := rulesetKey{repo: repo, commit: commit}
+
+	r.mu.Lock()
+	ruleset, ok := r.rulesets[key]
+	r.mu.Unlock()
+	if ok {
+		return ruleset, nil
+	}
+
+	ruleset, err := r.fetch(ctx, repo, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.rulesets[key] = ruleset
+	r.mu.Unlock()
+	return ruleset, nil
+}
+
+// fetch returns the ruleset of the first CODEOWNERS file found in repo at
+// commit, or nil if there is none.
+func (r *Resolver) fetch(ctx context.Context, repo api.RepoName, commit api.CommitID) (*Ruleset, error) {
+	for _, path := range Paths {
+		// CODEOWNERS files are read regardless of sub-repo permissions:
+		// they only determine ownership of results that have already
+		// passed permission checks.
+		content, err := r.gitserver.ReadFile(ctx, repo, commit, path, nil)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return Parse(bytes.NewReader(content))
+	}
+	return nil, nil
+}
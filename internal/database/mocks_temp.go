@@ -26661,6 +26661,9 @@ type MockRepoStore struct {
 	// DeleteFunc is an instance of a mock function object controlling the
 	// behavior of the method Delete.
 	DeleteFunc *RepoStoreDeleteFunc
+	// DeleteMetaTagFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteMetaTag.
+	DeleteMetaTagFunc *RepoStoreDeleteMetaTagFunc
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *RepoStoreDoneFunc
@@ -26695,12 +26698,21 @@ type MockRepoStore struct {
 	// ListMinimalReposFunc is an instance of a mock function object
 	// controlling the behavior of the method ListMinimalRepos.
 	ListMinimalReposFunc *RepoStoreListMinimalReposFunc
+	// ListTagsFunc is an instance of a mock function object controlling the
+	// behavior of the method ListTags.
+	ListTagsFunc *RepoStoreListTagsFunc
 	// MetadataFunc is an instance of a mock function object controlling the
 	// behavior of the method Metadata.
 	MetadataFunc *RepoStoreMetadataFunc
 	// QueryFunc is an instance of a mock function object controlling the
 	// behavior of the method Query.
 	QueryFunc *RepoStoreQueryFunc
+	// SetMetaTagFunc is an instance of a mock function object controlling
+	// the behavior of the method SetMetaTag.
+	SetMetaTagFunc *RepoStoreSetMetaTagFunc
+	// SetTopicsFunc is an instance of a mock function object controlling
+	// the behavior of the method SetTopics.
+	SetTopicsFunc *RepoStoreSetTopicsFunc
 	// StreamMinimalReposFunc is an instance of a mock function object
 	// controlling the behavior of the method StreamMinimalRepos.
 	StreamMinimalReposFunc *RepoStoreStreamMinimalReposFunc
@@ -26731,6 +26743,11 @@ func NewMockRepoStore() *MockRepoStore {
 				return
 			},
 		},
+		DeleteMetaTagFunc: &RepoStoreDeleteMetaTagFunc{
+			defaultHook: func(context.Context, api.RepoID, string) (r0 error) {
+				return
+			},
+		},
 		DoneFunc: &RepoStoreDoneFunc{
 			defaultHook: func(error) (r0 error) {
 				return
@@ -26786,6 +26803,11 @@ func NewMockRepoStore() *MockRepoStore {
 				return
 			},
 		},
+		ListTagsFunc: &RepoStoreListTagsFunc{
+			defaultHook: func(context.Context, api.RepoID) (r0 []*types.RepoTag, r1 error) {
+				return
+			},
+		},
 		MetadataFunc: &RepoStoreMetadataFunc{
 			defaultHook: func(context.Context, ...api.RepoID) (r0 []*types.SearchedRepo, r1 error) {
 				return
@@ -26796,6 +26818,16 @@ func NewMockRepoStore() *MockRepoStore {
 				return
 			},
 		},
+		SetMetaTagFunc: &RepoStoreSetMetaTagFunc{
+			defaultHook: func(context.Context, api.RepoID, string, string) (r0 error) {
+				return
+			},
+		},
+		SetTopicsFunc: &RepoStoreSetTopicsFunc{
+			defaultHook: func(context.Context, api.RepoID, []string) (r0 error) {
+				return
+			},
+		},
 		StreamMinimalReposFunc: &RepoStoreStreamMinimalReposFunc{
 			defaultHook: func(context.Context, ReposListOptions, func(*types.MinimalRepo)) (r0 error) {
 				return
@@ -26833,6 +26865,11 @@ func NewStrictMockRepoStore() *MockRepoStore {
 				panic("unexpected invocation of MockRepoStore.Delete")
 			},
 		},
+		DeleteMetaTagFunc: &RepoStoreDeleteMetaTagFunc{
+			defaultHook: func(context.Context, api.RepoID, string) error {
+				panic("unexpected invocation of MockRepoStore.DeleteMetaTag")
+			},
+		},
 		DoneFunc: &RepoStoreDoneFunc{
 			defaultHook: func(error) error {
 				panic("unexpected invocation of MockRepoStore.Done")
@@ -26888,6 +26925,11 @@ func NewStrictMockRepoStore() *MockRepoStore {
 				panic("unexpected invocation of MockRepoStore.ListMinimalRepos")
 			},
 		},
+		ListTagsFunc: &RepoStoreListTagsFunc{
+			defaultHook: func(context.Context, api.RepoID) ([]*types.RepoTag, error) {
+				panic("unexpected invocation of MockRepoStore.ListTags")
+			},
+		},
 		MetadataFunc: &RepoStoreMetadataFunc{
 			defaultHook: func(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error) {
 				panic("unexpected invocation of MockRepoStore.Metadata")
@@ -26898,6 +26940,16 @@ func NewStrictMockRepoStore() *MockRepoStore {
 				panic("unexpected invocation of MockRepoStore.Query")
 			},
 		},
+		SetMetaTagFunc: &RepoStoreSetMetaTagFunc{
+			defaultHook: func(context.Context, api.RepoID, string, string) error {
+				panic("unexpected invocation of MockRepoStore.SetMetaTag")
+			},
+		},
+		SetTopicsFunc: &RepoStoreSetTopicsFunc{
+			defaultHook: func(context.Context, api.RepoID, []string) error {
+				panic("unexpected invocation of MockRepoStore.SetTopics")
+			},
+		},
 		StreamMinimalReposFunc: &RepoStoreStreamMinimalReposFunc{
 			defaultHook: func(context.Context, ReposListOptions, func(*types.MinimalRepo)) error {
 				panic("unexpected invocation of MockRepoStore.StreamMinimalRepos")
@@ -26929,6 +26981,9 @@ func NewMockRepoStoreFrom(i RepoStore) *MockRepoStore {
 		DeleteFunc: &RepoStoreDeleteFunc{
 			defaultHook: i.Delete,
 		},
+		DeleteMetaTagFunc: &RepoStoreDeleteMetaTagFunc{
+			defaultHook: i.DeleteMetaTag,
+		},
 		DoneFunc: &RepoStoreDoneFunc{
 			defaultHook: i.Done,
 		},
@@ -26962,12 +27017,21 @@ func NewMockRepoStoreFrom(i RepoStore) *MockRepoStore {
 		ListMinimalReposFunc: &RepoStoreListMinimalReposFunc{
 			defaultHook: i.ListMinimalRepos,
 		},
+		ListTagsFunc: &RepoStoreListTagsFunc{
+			defaultHook: i.ListTags,
+		},
 		MetadataFunc: &RepoStoreMetadataFunc{
 			defaultHook: i.Metadata,
 		},
 		QueryFunc: &RepoStoreQueryFunc{
 			defaultHook: i.Query,
 		},
+		SetMetaTagFunc: &RepoStoreSetMetaTagFunc{
+			defaultHook: i.SetMetaTag,
+		},
+		SetTopicsFunc: &RepoStoreSetTopicsFunc{
+			defaultHook: i.SetTopics,
+		},
 		StreamMinimalReposFunc: &RepoStoreStreamMinimalReposFunc{
 			defaultHook: i.StreamMinimalRepos,
 		},
@@ -27309,6 +27373,114 @@ func (c RepoStoreDeleteFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// RepoStoreDeleteMetaTagFunc describes the behavior when the DeleteMetaTag
+// method of the parent MockRepoStore instance is invoked.
+type RepoStoreDeleteMetaTagFunc struct {
+	defaultHook func(context.Context, api.RepoID, string) error
+	hooks       []func(context.Context, api.RepoID, string) error
+	history     []RepoStoreDeleteMetaTagFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteMetaTag delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockRepoStore) DeleteMetaTag(v0 context.Context, v1 api.RepoID, v2 string) error {
+	r0 := m.DeleteMetaTagFunc.nextHook()(v0, v1, v2)
+	m.DeleteMetaTagFunc.appendCall(RepoStoreDeleteMetaTagFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteMetaTag method
+// of the parent MockRepoStore instance is invoked and the hook queue is
+// empty.
+func (f *RepoStoreDeleteMetaTagFunc) SetDefaultHook(hook func(context.Context, api.RepoID, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteMetaTag method of the parent MockRepoStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RepoStoreDeleteMetaTagFunc) PushHook(hook func(context.Context, api.RepoID, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreDeleteMetaTagFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreDeleteMetaTagFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID, string) error {
+		return r0
+	})
+}
+
+func (f *RepoStoreDeleteMetaTagFunc) nextHook() func(context.Context, api.RepoID, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreDeleteMetaTagFunc) appendCall(r0 RepoStoreDeleteMetaTagFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreDeleteMetaTagFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreDeleteMetaTagFunc) History() []RepoStoreDeleteMetaTagFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreDeleteMetaTagFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreDeleteMetaTagFuncCall is an object that describes an invocation
+// of method DeleteMetaTag on an instance of MockRepoStore.
+type RepoStoreDeleteMetaTagFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreDeleteMetaTagFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreDeleteMetaTagFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // RepoStoreDoneFunc describes the behavior when the Done method of the
 // parent MockRepoStore instance is invoked.
 type RepoStoreDoneFunc struct {
@@ -28494,6 +28666,113 @@ func (c RepoStoreListMinimalReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// RepoStoreListTagsFunc describes the behavior when the ListTags method of
+// the parent MockRepoStore instance is invoked.
+type RepoStoreListTagsFunc struct {
+	defaultHook func(context.Context, api.RepoID) ([]*types.RepoTag, error)
+	hooks       []func(context.Context, api.RepoID) ([]*types.RepoTag, error)
+	history     []RepoStoreListTagsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListTags delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) ListTags(v0 context.Context, v1 api.RepoID) ([]*types.RepoTag, error) {
+	r0, r1 := m.ListTagsFunc.nextHook()(v0, v1)
+	m.ListTagsFunc.appendCall(RepoStoreListTagsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListTags method of
+// the parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreListTagsFunc) SetDefaultHook(hook func(context.Context, api.RepoID) ([]*types.RepoTag, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListTags method of the parent MockRepoStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RepoStoreListTagsFunc) PushHook(hook func(context.Context, api.RepoID) ([]*types.RepoTag, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreListTagsFunc) SetDefaultReturn(r0 []*types.RepoTag, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) ([]*types.RepoTag, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreListTagsFunc) PushReturn(r0 []*types.RepoTag, r1 error) {
+	f.PushHook(func(context.Context, api.RepoID) ([]*types.RepoTag, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoStoreListTagsFunc) nextHook() func(context.Context, api.RepoID) ([]*types.RepoTag, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreListTagsFunc) appendCall(r0 RepoStoreListTagsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreListTagsFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreListTagsFunc) History() []RepoStoreListTagsFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreListTagsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreListTagsFuncCall is an object that describes an invocation of
+// method ListTags on an instance of MockRepoStore.
+type RepoStoreListTagsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.RepoTag
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreListTagsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreListTagsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // RepoStoreMetadataFunc describes the behavior when the Metadata method of
 // the parent MockRepoStore instance is invoked.
 type RepoStoreMetadataFunc struct {
@@ -28715,6 +28994,223 @@ func (c RepoStoreQueryFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// RepoStoreSetMetaTagFunc describes the behavior when the SetMetaTag method
+// of the parent MockRepoStore instance is invoked.
+type RepoStoreSetMetaTagFunc struct {
+	defaultHook func(context.Context, api.RepoID, string, string) error
+	hooks       []func(context.Context, api.RepoID, string, string) error
+	history     []RepoStoreSetMetaTagFuncCall
+	mutex       sync.Mutex
+}
+
+// SetMetaTag delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockRepoStore) SetMetaTag(v0 context.Context, v1 api.RepoID, v2 string, v3 string) error {
+	r0 := m.SetMetaTagFunc.nextHook()(v0, v1, v2, v3)
+	m.SetMetaTagFunc.appendCall(RepoStoreSetMetaTagFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetMetaTag method of
+// the parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreSetMetaTagFunc) SetDefaultHook(hook func(context.Context, api.RepoID, string, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetMetaTag method of the parent MockRepoStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RepoStoreSetMetaTagFunc) PushHook(hook func(context.Context, api.RepoID, string, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreSetMetaTagFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, string, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreSetMetaTagFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID, string, string) error {
+		return r0
+	})
+}
+
+func (f *RepoStoreSetMetaTagFunc) nextHook() func(context.Context, api.RepoID, string, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreSetMetaTagFunc) appendCall(r0 RepoStoreSetMetaTagFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreSetMetaTagFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreSetMetaTagFunc) History() []RepoStoreSetMetaTagFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreSetMetaTagFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreSetMetaTagFuncCall is an object that describes an invocation of
+// method SetMetaTag on an instance of MockRepoStore.
+type RepoStoreSetMetaTagFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreSetMetaTagFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreSetMetaTagFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// RepoStoreSetTopicsFunc describes the behavior when the SetTopics method
+// of the parent MockRepoStore instance is invoked.
+type RepoStoreSetTopicsFunc struct {
+	defaultHook func(context.Context, api.RepoID, []string) error
+	hooks       []func(context.Context, api.RepoID, []string) error
+	history     []RepoStoreSetTopicsFuncCall
+	mutex       sync.Mutex
+}
+
+// SetTopics delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) SetTopics(v0 context.Context, v1 api.RepoID, v2 []string) error {
+	r0 := m.SetTopicsFunc.nextHook()(v0, v1, v2)
+	m.SetTopicsFunc.appendCall(RepoStoreSetTopicsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetTopics method of
+// the parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreSetTopicsFunc) SetDefaultHook(hook func(context.Context, api.RepoID, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetTopics method of the parent MockRepoStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RepoStoreSetTopicsFunc) PushHook(hook func(context.Context, api.RepoID, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreSetTopicsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreSetTopicsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID, []string) error {
+		return r0
+	})
+}
+
+func (f *RepoStoreSetTopicsFunc) nextHook() func(context.Context, api.RepoID, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreSetTopicsFunc) appendCall(r0 RepoStoreSetTopicsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreSetTopicsFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreSetTopicsFunc) History() []RepoStoreSetTopicsFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreSetTopicsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreSetTopicsFuncCall is an object that describes an invocation of
+// method SetTopics on an instance of MockRepoStore.
+type RepoStoreSetTopicsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreSetTopicsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreSetTopicsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // RepoStoreStreamMinimalReposFunc describes the behavior when the
 // StreamMinimalRepos method of the parent MockRepoStore instance is
 // invoked.
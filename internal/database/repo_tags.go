@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// RepoTagFilter matches repositories by their tags. If Value is nil, any
+// repository with a tag of the given kind and key matches.
+type RepoTagFilter struct {
+	Kind    types.RepoTagKind
+	Key     string
+	Value   *string
+	Negated bool
+}
+
+// repoTagFilterConds returns the conditions that restrict the repo table to
+// the repositories matching filters.
+func repoTagFilterConds(filters []RepoTagFilter) []*sqlf.Query {
+	conds := make([]*sqlf.Query, 0, len(filters))
+	for _, f := range filters {
+		key := f.Key
+		if f.Kind == types.RepoTagKindTopic {
+			key = strings.ToLower(key)
+		}
+
+		tagConds := []*sqlf.Query{
+			sqlf.Sprintf("rt.repo_id = repo.id"),
+			sqlf.Sprintf("rt.kind = %s", f.Kind),
+			sqlf.Sprintf("rt.key = %s", key),
+		}
+		if f.Value != nil {
+			tagConds = append(tagConds, sqlf.Sprintf("rt.value = %s", *f.Value))
+		}
+
+		exists := sqlf.Sprintf("EXISTS (SELECT 1 FROM repo_tags rt WHERE %s)", sqlf.Join(tagConds, "AND"))
+		if f.Negated {
+			exists = sqlf.Sprintf("NOT %s", exists)
+		}
+		conds = append(conds, exists)
+	}
+	return conds
+}
+
+// ListTags returns the topics and meta tags of a repository, ordered by kind
+// and key.
+func (s *repoStore) ListTags(ctx context.Context, id api.RepoID) (_ []*types.RepoTag, err error) {
+	tr, ctx := trace.New(ctx, "repos.ListTags", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(listRepoTagsQuery, id))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var tags []*types.RepoTag
+	for rows.Next() {
+		var tag types.RepoTag
+		if err := rows.Scan(&tag.Kind, &tag.Key, &tag.Value); err != nil {
+			return nil, err
+		}
+		tags = append(tags, &tag)
+	}
+	return tags, nil
+}
+
+const listRepoTagsQuery = `
+-- source: internal/database/repo_tags.go:ListTags
+SELECT kind, key, value
+FROM repo_tags
+WHERE repo_id = %s
+ORDER BY kind, key
+`
+
+// SetMetaTag assigns value to the meta tag key of a repository, replacing
+// its previous value.
+func (s *repoStore) SetMetaTag(ctx context.Context, id api.RepoID, key, value string) (err error) {
+	tr, ctx := trace.New(ctx, "repos.SetMetaTag", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	return s.Exec(ctx, sqlf.Sprintf(setRepoMetaTagQuery, id, types.RepoTagKindMeta, key, value))
+}
+
+const setRepoMetaTagQuery = `
+-- source: internal/database/repo_tags.go:SetMetaTag
+INSERT INTO repo_tags (repo_id, kind, key, value)
+VALUES (%s, %s, %s, %s)
+ON CONFLICT (repo_id, kind, key) DO UPDATE SET value = excluded.value
+`
+
+// DeleteMetaTag removes the meta tag key from a repository.
+func (s *repoStore) DeleteMetaTag(ctx context.Context, id api.RepoID, key string) (err error) {
+	tr, ctx := trace.New(ctx, "repos.DeleteMetaTag", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	return s.Exec(ctx, sqlf.Sprintf(deleteRepoMetaTagQuery, id, types.RepoTagKindMeta, key))
+}
+
+const deleteRepoMetaTagQuery = `
+-- source: internal/database/repo_tags.go:DeleteMetaTag
+DELETE FROM repo_tags
+WHERE repo_id = %s AND kind = %s AND key = %s
+`
+
+// SetTopics replaces the code host topics of a repository. Topics are
+// case-insensitive and stored in lower case.
+func (s *repoStore) SetTopics(ctx context.Context, id api.RepoID, topics []string) (err error) {
+	tr, ctx := trace.New(ctx, "repos.SetTopics", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	lowered := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		lowered = append(lowered, strings.ToLower(topic))
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		setRepoTopicsQuery,
+		id, types.RepoTagKindTopic, pq.Array(lowered),
+		id, types.RepoTagKindTopic, pq.Array(lowered),
+	))
+}
+
+const setRepoTopicsQuery = `
+-- source: internal/database/repo_tags.go:SetTopics
+WITH deleted AS (
+	DELETE FROM repo_tags
+	WHERE repo_id = %s AND kind = %s AND NOT key = ANY(%s)
+)
+INSERT INTO repo_tags (repo_id, kind, key)
+SELECT %s, %s, topic FROM unnest(%s::text[]) AS topic
+ON CONFLICT DO NOTHING
+`
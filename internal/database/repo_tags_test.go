@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRepos_Tags(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := actor.WithInternalActor(context.Background())
+
+	pokedex := mustCreate(ctx, t, db, &types.Repo{Name: "pokedex"})[0]
+	digidex := mustCreate(ctx, t, db, &types.Repo{Name: "digidex"})[0]
+
+	if err := db.Repos().SetTopics(ctx, pokedex.ID, []string{"Go", "monsters"}); err != nil {
+		t.Fatal(err)
+	}
+	// Topics that are no longer set are removed.
+	if err := db.Repos().SetTopics(ctx, pokedex.ID, []string{"go", "pokemon"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Repos().SetTopics(ctx, digidex.ID, []string{"monsters"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Repos().SetMetaTag(ctx, pokedex.ID, "team", "oak"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Repos().SetMetaTag(ctx, pokedex.ID, "team", "search"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Repos().SetMetaTag(ctx, digidex.ID, "deprecated", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("ListTags", func(t *testing.T) {
+		tags, err := db.Repos().ListTags(ctx, pokedex.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*types.RepoTag{
+			{Kind: types.RepoTagKindMeta, Key: "team", Value: "search"},
+			{Kind: types.RepoTagKindTopic, Key: "go"},
+			{Kind: types.RepoTagKindTopic, Key: "pokemon"},
+		}
+		if diff := cmp.Diff(want, tags); diff != "" {
+			t.Fatalf("unexpected tags (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		value := func(s string) *string { return &s }
+
+		tests := []struct {
+			name    string
+			filters []RepoTagFilter
+			want    []api.RepoName
+		}{
+			{
+				name:    "topic",
+				filters: []RepoTagFilter{{Kind: types.RepoTagKindTopic, Key: "GO"}},
+				want:    []api.RepoName{"pokedex"},
+			},
+			{
+				name:    "removed topic",
+				filters: []RepoTagFilter{{Kind: types.RepoTagKindTopic, Key: "monsters"}},
+				want:    []api.RepoName{"digidex"},
+			},
+			{
+				name:    "negated topic",
+				filters: []RepoTagFilter{{Kind: types.RepoTagKindTopic, Key: "go", Negated: true}},
+				want:    []api.RepoName{"digidex"},
+			},
+			{
+				name:    "meta key",
+				filters: []RepoTagFilter{{Kind: types.RepoTagKindMeta, Key: "deprecated"}},
+				want:    []api.RepoName{"digidex"},
+			},
+			{
+				name:    "meta value",
+				filters: []RepoTagFilter{{Kind: types.RepoTagKindMeta, Key: "team", Value: value("search")}},
+				want:    []api.RepoName{"pokedex"},
+			},
+			{
+				name:    "overwritten meta value",
+				filters: []RepoTagFilter{{Kind: types.RepoTagKindMeta, Key: "team", Value: value("oak")}},
+			},
+		}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				repos, err := db.Repos().List(ctx, ReposListOptions{TagFilters: tc.filters})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(tc.want, sortedRepoNames(repos)); diff != "" {
+					t.Fatalf("unexpected repos (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("DeleteMetaTag", func(t *testing.T) {
+		if err := db.Repos().DeleteMetaTag(ctx, digidex.ID, "deprecated"); err != nil {
+			t.Fatal(err)
+		}
+		tags, err := db.Repos().ListTags(ctx, digidex.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*types.RepoTag{{Kind: types.RepoTagKindTopic, Key: "monsters"}}
+		if diff := cmp.Diff(want, tags); diff != "" {
+			t.Fatalf("unexpected tags (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -73,6 +73,7 @@ type RepoStore interface {
 	Count(context.Context, ReposListOptions) (int, error)
 	Create(context.Context, ...*types.Repo) error
 	Delete(context.Context, ...api.RepoID) error
+	DeleteMetaTag(context.Context, api.RepoID, string) error
 	Get(context.Context, api.RepoID) (*types.Repo, error)
 	GetByIDs(context.Context, ...api.RepoID) ([]*types.Repo, error)
 	GetByName(context.Context, api.RepoName) (*types.Repo, error)
@@ -82,7 +83,10 @@ type RepoStore interface {
 	List(context.Context, ReposListOptions) ([]*types.Repo, error)
 	ListIndexableRepos(context.Context, ListIndexableReposOptions) ([]types.MinimalRepo, error)
 	ListMinimalRepos(context.Context, ReposListOptions) ([]types.MinimalRepo, error)
+	ListTags(context.Context, api.RepoID) ([]*types.RepoTag, error)
 	Metadata(context.Context, ...api.RepoID) ([]*types.SearchedRepo, error)
+	SetMetaTag(context.Context, api.RepoID, string, string) error
+	SetTopics(context.Context, api.RepoID, []string) error
 	StreamMinimalRepos(context.Context, ReposListOptions, func(*types.MinimalRepo)) error
 }
 
@@ -543,6 +547,11 @@ type ReposListOptions struct {
 	// repositories returned in the list.
 	DescriptionPatterns []string
 
+	// TagFilters is a list of filters on the topics and meta tags of
+	// repositories, all of which must match all repositories returned in the
+	// list.
+	TagFilters []RepoTagFilter
+
 	// CaseSensitivePatterns determines if IncludePatterns and ExcludePattern are treated
 	// with case sensitivity or not.
 	CaseSensitivePatterns bool
@@ -886,6 +895,8 @@ func (s *repoStore) listSQL(ctx context.Context, tr *trace.Trace, opt ReposListO
 		where = append(where, descriptionConds...)
 	}
 
+	where = append(where, repoTagFilterConds(opt.TagFilters)...)
+
 	if len(opt.IDs) > 0 {
 		where = append(where, sqlf.Sprintf("id = ANY (%s)", pq.Array(opt.IDs)))
 	}
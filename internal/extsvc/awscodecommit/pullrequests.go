@@ -0,0 +1,456 @@
+package awscodecommit
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/codecommit"
+	codecommittypes "github.com/aws/aws-sdk-go-v2/service/codecommit/types"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// PullRequestStatus is the status of an AWS CodeCommit pull request.
+type PullRequestStatus string
+
+const (
+	PullRequestStatusOpen   PullRequestStatus = PullRequestStatus(codecommittypes.PullRequestStatusEnumOpen)
+	PullRequestStatusClosed PullRequestStatus = PullRequestStatus(codecommittypes.PullRequestStatusEnumClosed)
+)
+
+// PullRequest is an AWS CodeCommit pull request.
+//
+// AWS CodeCommit supports pull requests with multiple targets, but pull
+// requests created by Sourcegraph always have exactly one, so only the first
+// target is kept.
+type PullRequest struct {
+	ID               string            // the ID of the pull request
+	Region           string            // the AWS region of the repository of the pull request
+	Title            string            // the title of the pull request
+	Description      string            // the description of the pull request
+	Status           PullRequestStatus // the status of the pull request
+	AuthorARN        string            // the ARN of the user who created the pull request
+	RevisionID       string            // the ID of the current revision of the pull request
+	CreationDate     time.Time         // the date the pull request was created
+	LastActivityDate time.Time         // the date of the last activity on the pull request
+	Target           PullRequestTarget // the source and destination of the pull request
+
+	// Approvals are the approvals of the current revision of the pull request.
+	Approvals []*Approval
+
+	// Events are all events that happened on the pull request, oldest first.
+	Events []*PullRequestEvent
+}
+
+// PullRequestTarget is the source and destination of a pull request.
+type PullRequestTarget struct {
+	RepositoryName       string // the name of the repository of the pull request
+	SourceReference      string // the source branch, e.g. "refs/heads/my-branch"
+	SourceCommit         string // the commit at the tip of the source branch
+	DestinationReference string // the destination branch, e.g. "refs/heads/main"
+	DestinationCommit    string // the commit at the tip of the destination branch
+	MergeBase            string // the merge base of the source and destination
+	IsMerged             bool   // whether the pull request was merged
+	MergedBy             string // the ARN of the user who merged the pull request
+	MergeCommitID        string // the ID of the merge commit, if any
+}
+
+// URL returns the URL of the pull request in the AWS console.
+func (pr *PullRequest) URL() string {
+	return "https://" + pr.Region + ".console.aws.amazon.com/codesuite/codecommit/repositories/" +
+		url.PathEscape(pr.Target.RepositoryName) + "/pull-requests/" + url.PathEscape(pr.ID) +
+		"/details?region=" + url.QueryEscape(pr.Region)
+}
+
+// AuthorName returns the name of the IAM user or role that created the pull
+// request.
+func (pr *PullRequest) AuthorName() string {
+	return UserNameFromARN(pr.AuthorARN)
+}
+
+// UserNameFromARN returns the last segment of the resource of an IAM ARN,
+// which is the name of the user or role. For example, the name of
+// "arn:aws:iam::123456789012:user/Jane" is "Jane".
+func UserNameFromARN(arn string) string {
+	if i := strings.LastIndexAny(arn, ":/"); i >= 0 {
+		return arn[i+1:]
+	}
+	return arn
+}
+
+// Approval is the approval state of a user on a revision of a pull request.
+type Approval struct {
+	UserARN string // the ARN of the user
+	State   string // "APPROVE" or "REVOKE"
+}
+
+// Approval states of a pull request.
+const (
+	ApprovalStateApprove = string(codecommittypes.ApprovalStateApprove)
+	ApprovalStateRevoke  = string(codecommittypes.ApprovalStateRevoke)
+)
+
+// PullRequestEventType is the type of an event on a pull request.
+type PullRequestEventType string
+
+const (
+	PullRequestEventTypeCreated                = PullRequestEventType(codecommittypes.PullRequestEventTypePullRequestCreated)
+	PullRequestEventTypeStatusChanged          = PullRequestEventType(codecommittypes.PullRequestEventTypePullRequestStatusChanged)
+	PullRequestEventTypeSourceReferenceUpdated = PullRequestEventType(codecommittypes.PullRequestEventTypePullRequestSourceReferenceUpdated)
+	PullRequestEventTypeMergeStateChanged      = PullRequestEventType(codecommittypes.PullRequestEventTypePullRequestMergeStateChanged)
+	PullRequestEventTypeApprovalStateChanged   = PullRequestEventType(codecommittypes.PullRequestEventTypePullRequestApprovalStateChanged)
+)
+
+// PullRequestEvent is an event that happened on a pull request. Only the
+// metadata relevant to the type of the event is set.
+type PullRequestEvent struct {
+	PullRequestID string
+	Type          PullRequestEventType
+	ActorARN      string
+	Date          time.Time
+
+	// Status is set for PULL_REQUEST_STATUS_CHANGED events.
+	Status PullRequestStatus
+	// ApprovalState and RevisionID are set for
+	// PULL_REQUEST_APPROVAL_STATE_CHANGED events.
+	ApprovalState string
+	RevisionID    string
+	// IsMerged is set for PULL_REQUEST_MERGE_STATE_CHANGED events.
+	IsMerged bool
+	// SourceCommit is set for PULL_REQUEST_SOURCE_REFERENCE_UPDATED events.
+	SourceCommit string
+}
+
+// Key returns a unique key identifying this event on the pull request. AWS
+// CodeCommit doesn't assign IDs to events, so the key is derived from the
+// type, actor and date of the event.
+func (e *PullRequestEvent) Key() string {
+	return e.PullRequestID + ":" + string(e.Type) + ":" + e.ActorARN + ":" + e.Date.UTC().Format(time.RFC3339Nano)
+}
+
+// ErrPullRequestNotFound is returned when the requested AWS CodeCommit pull
+// request is not found.
+var ErrPullRequestNotFound = errors.New("AWS CodeCommit pull request not found")
+
+// IsPullRequestNotFound reports whether err is a AWS CodeCommit API error
+// caused by a pull request that doesn't exist.
+func IsPullRequestNotFound(err error) bool {
+	return errors.Is(err, ErrPullRequestNotFound) || errors.HasType(err, &codecommittypes.PullRequestDoesNotExistException{})
+}
+
+// IsNotMergeable reports whether err is a AWS CodeCommit API error caused by
+// a pull request that cannot be merged in its current state.
+func IsNotMergeable(err error) bool {
+	return errors.HasType(err, &codecommittypes.ManualMergeRequiredException{}) ||
+		errors.HasType(err, &codecommittypes.PullRequestApprovalRulesNotSatisfiedException{}) ||
+		errors.HasType(err, &codecommittypes.TipOfSourceReferenceIsDifferentException{}) ||
+		errors.HasType(err, &codecommittypes.PullRequestAlreadyClosedException{})
+}
+
+// GetPullRequest gets the pull request with the given ID, including the
+// approvals of its current revision and its events.
+func (c *Client) GetPullRequest(ctx context.Context, id string) (_ *PullRequest, err error) {
+	defer wrapErr(&err)
+
+	return c.getPullRequest(ctx, codecommit.NewFromConfig(c.aws), id)
+}
+
+// FindOpenPullRequest returns the open pull request from the given source
+// reference to the given destination reference in the given repository. If no
+// such pull request exists, ErrPullRequestNotFound is returned.
+func (c *Client) FindOpenPullRequest(ctx context.Context, repositoryName, sourceReference, destinationReference string) (_ *PullRequest, err error) {
+	defer wrapErr(&err)
+
+	svc := codecommit.NewFromConfig(c.aws)
+	input := codecommit.ListPullRequestsInput{
+		RepositoryName:    &repositoryName,
+		PullRequestStatus: codecommittypes.PullRequestStatusEnumOpen,
+	}
+	for {
+		result, err := svc.ListPullRequests(ctx, &input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range result.PullRequestIds {
+			id := id
+			pr, err := svc.GetPullRequest(ctx, &codecommit.GetPullRequestInput{PullRequestId: &id})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, t := range pr.PullRequest.PullRequestTargets {
+				if sameBranch(aws.ToString(t.SourceReference), sourceReference) && sameBranch(aws.ToString(t.DestinationReference), destinationReference) {
+					return c.getPullRequest(ctx, svc, id)
+				}
+			}
+		}
+
+		if result.NextToken == nil || *result.NextToken == "" {
+			return nil, ErrPullRequestNotFound
+		}
+		input.NextToken = result.NextToken
+	}
+}
+
+// CreatePullRequestInput is the input to CreatePullRequest.
+type CreatePullRequestInput struct {
+	RepositoryName       string
+	Title                string
+	Description          string
+	SourceReference      string
+	DestinationReference string
+}
+
+// CreatePullRequest creates a new pull request.
+func (c *Client) CreatePullRequest(ctx context.Context, input CreatePullRequestInput) (_ *PullRequest, err error) {
+	defer wrapErr(&err)
+
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.CreatePullRequest(ctx, &codecommit.CreatePullRequestInput{
+		Title:       &input.Title,
+		Description: &input.Description,
+		Targets: []codecommittypes.Target{{
+			RepositoryName:       &input.RepositoryName,
+			SourceReference:      &input.SourceReference,
+			DestinationReference: &input.DestinationReference,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pr := c.fromPullRequest(result.PullRequest)
+	if err := c.loadPullRequestDetails(ctx, svc, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// UpdatePullRequest updates the title and description of the given pull
+// request, if they differ from the given ones.
+func (c *Client) UpdatePullRequest(ctx context.Context, pr *PullRequest, title, description string) (_ *PullRequest, err error) {
+	defer wrapErr(&err)
+
+	svc := codecommit.NewFromConfig(c.aws)
+	if pr.Title != title {
+		if _, err := svc.UpdatePullRequestTitle(ctx, &codecommit.UpdatePullRequestTitleInput{
+			PullRequestId: &pr.ID,
+			Title:         &title,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if pr.Description != description {
+		if _, err := svc.UpdatePullRequestDescription(ctx, &codecommit.UpdatePullRequestDescriptionInput{
+			PullRequestId: &pr.ID,
+			Description:   &description,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.getPullRequest(ctx, svc, pr.ID)
+}
+
+// ClosePullRequest closes the given pull request. Closed pull requests can't
+// be reopened on AWS CodeCommit.
+func (c *Client) ClosePullRequest(ctx context.Context, id string) (_ *PullRequest, err error) {
+	defer wrapErr(&err)
+
+	svc := codecommit.NewFromConfig(c.aws)
+	if _, err := svc.UpdatePullRequestStatus(ctx, &codecommit.UpdatePullRequestStatusInput{
+		PullRequestId:     &id,
+		PullRequestStatus: codecommittypes.PullRequestStatusEnumClosed,
+	}); err != nil {
+		return nil, err
+	}
+
+	return c.getPullRequest(ctx, svc, id)
+}
+
+// MergePullRequest merges the given pull request, using a squash merge if
+// squash is true and a three-way merge otherwise. The merge fails if the
+// source branch has been updated since pr was loaded.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest, squash bool) (_ *PullRequest, err error) {
+	defer wrapErr(&err)
+
+	svc := codecommit.NewFromConfig(c.aws)
+	if squash {
+		_, err = svc.MergePullRequestBySquash(ctx, &codecommit.MergePullRequestBySquashInput{
+			PullRequestId:  &pr.ID,
+			RepositoryName: &pr.Target.RepositoryName,
+			SourceCommitId: &pr.Target.SourceCommit,
+		})
+	} else {
+		_, err = svc.MergePullRequestByThreeWay(ctx, &codecommit.MergePullRequestByThreeWayInput{
+			PullRequestId:  &pr.ID,
+			RepositoryName: &pr.Target.RepositoryName,
+			SourceCommitId: &pr.Target.SourceCommit,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getPullRequest(ctx, svc, pr.ID)
+}
+
+// CreatePullRequestComment posts a comment on the given pull request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest, content string) (err error) {
+	defer wrapErr(&err)
+
+	svc := codecommit.NewFromConfig(c.aws)
+	_, err = svc.PostCommentForPullRequest(ctx, &codecommit.PostCommentForPullRequestInput{
+		PullRequestId:  &pr.ID,
+		RepositoryName: &pr.Target.RepositoryName,
+		BeforeCommitId: &pr.Target.DestinationCommit,
+		AfterCommitId:  &pr.Target.SourceCommit,
+		Content:        &content,
+	})
+	return err
+}
+
+// ValidateCredentials checks that the credentials of the client can be used to
+// access the AWS CodeCommit API. There is no API to get the current user, so
+// this lists repositories, which fails if the credentials are invalid.
+func (c *Client) ValidateCredentials(ctx context.Context) (err error) {
+	defer wrapErr(&err)
+
+	svc := codecommit.NewFromConfig(c.aws)
+	_, err = svc.ListRepositories(ctx, &codecommit.ListRepositoriesInput{})
+	return err
+}
+
+func (c *Client) getPullRequest(ctx context.Context, svc *codecommit.Client, id string) (*PullRequest, error) {
+	result, err := svc.GetPullRequest(ctx, &codecommit.GetPullRequestInput{PullRequestId: &id})
+	if err != nil {
+		return nil, err
+	}
+
+	pr := c.fromPullRequest(result.PullRequest)
+	if err := c.loadPullRequestDetails(ctx, svc, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// loadPullRequestDetails loads the approvals of the current revision and the
+// events of the given pull request.
+func (c *Client) loadPullRequestDetails(ctx context.Context, svc *codecommit.Client, pr *PullRequest) error {
+	approvals, err := svc.GetPullRequestApprovalStates(ctx, &codecommit.GetPullRequestApprovalStatesInput{
+		PullRequestId: &pr.ID,
+		RevisionId:    &pr.RevisionID,
+	})
+	if err != nil {
+		return err
+	}
+	for _, a := range approvals.Approvals {
+		pr.Approvals = append(pr.Approvals, &Approval{
+			UserARN: aws.ToString(a.UserArn),
+			State:   string(a.ApprovalState),
+		})
+	}
+
+	var nextToken *string
+	for {
+		events, err := svc.DescribePullRequestEvents(ctx, &codecommit.DescribePullRequestEventsInput{
+			PullRequestId: &pr.ID,
+			NextToken:     nextToken,
+		})
+		if err != nil {
+			return err
+		}
+		for i := range events.PullRequestEvents {
+			pr.Events = append(pr.Events, fromPullRequestEvent(&events.PullRequestEvents[i]))
+		}
+
+		if events.NextToken == nil || *events.NextToken == "" {
+			break
+		}
+		nextToken = events.NextToken
+	}
+
+	// The API returns the most recent events first.
+	for i, j := 0, len(pr.Events)-1; i < j; i, j = i+1, j-1 {
+		pr.Events[i], pr.Events[j] = pr.Events[j], pr.Events[i]
+	}
+
+	return nil
+}
+
+func (c *Client) fromPullRequest(p *codecommittypes.PullRequest) *PullRequest {
+	pr := &PullRequest{
+		ID:               aws.ToString(p.PullRequestId),
+		Region:           c.aws.Region,
+		Title:            aws.ToString(p.Title),
+		Description:      aws.ToString(p.Description),
+		Status:           PullRequestStatus(p.PullRequestStatus),
+		AuthorARN:        aws.ToString(p.AuthorArn),
+		RevisionID:       aws.ToString(p.RevisionId),
+		CreationDate:     aws.ToTime(p.CreationDate),
+		LastActivityDate: aws.ToTime(p.LastActivityDate),
+	}
+
+	if len(p.PullRequestTargets) > 0 {
+		t := p.PullRequestTargets[0]
+		pr.Target = PullRequestTarget{
+			RepositoryName:       aws.ToString(t.RepositoryName),
+			SourceReference:      aws.ToString(t.SourceReference),
+			SourceCommit:         aws.ToString(t.SourceCommit),
+			DestinationReference: aws.ToString(t.DestinationReference),
+			DestinationCommit:    aws.ToString(t.DestinationCommit),
+			MergeBase:            aws.ToString(t.MergeBase),
+		}
+		if m := t.MergeMetadata; m != nil {
+			pr.Target.IsMerged = m.IsMerged
+			pr.Target.MergedBy = aws.ToString(m.MergedBy)
+			pr.Target.MergeCommitID = aws.ToString(m.MergeCommitId)
+		}
+	}
+
+	return pr
+}
+
+func fromPullRequestEvent(e *codecommittypes.PullRequestEvent) *PullRequestEvent {
+	event := &PullRequestEvent{
+		PullRequestID: aws.ToString(e.PullRequestId),
+		Type:          PullRequestEventType(e.PullRequestEventType),
+		ActorARN:      aws.ToString(e.ActorArn),
+		Date:          aws.ToTime(e.EventDate),
+	}
+
+	if m := e.PullRequestStatusChangedEventMetadata; m != nil {
+		event.Status = PullRequestStatus(m.PullRequestStatus)
+	}
+	if m := e.ApprovalStateChangedEventMetadata; m != nil {
+		event.ApprovalState = string(m.ApprovalStatus)
+		event.RevisionID = aws.ToString(m.RevisionId)
+	}
+	if m := e.PullRequestMergedStateChangedEventMetadata; m != nil && m.MergeMetadata != nil {
+		event.IsMerged = m.MergeMetadata.IsMerged
+	}
+	if m := e.PullRequestSourceReferenceUpdatedEventMetadata; m != nil {
+		event.SourceCommit = aws.ToString(m.AfterCommitId)
+	}
+
+	return event
+}
+
+// sameBranch reports whether the two references refer to the same branch. AWS
+// CodeCommit accepts both abbreviated and fully qualified branch names as pull
+// request targets.
+func sameBranch(a, b string) bool {
+	return strings.TrimPrefix(a, "refs/heads/") == strings.TrimPrefix(b, "refs/heads/")
+}
+
+// wrapErr wraps a non-nil error in a wrappedError, so that callers can
+// inspect it with the errcode package.
+func wrapErr(err *error) {
+	if *err != nil {
+		*err = &wrappedError{err: *err}
+	}
+}
@@ -230,8 +230,12 @@ func (w *wrappedError) Error() string {
 	return ""
 }
 
+func (w *wrappedError) Unwrap() error {
+	return w.err
+}
+
 func (w *wrappedError) NotFound() bool {
-	return IsNotFound(w.err)
+	return IsNotFound(w.err) || IsPullRequestNotFound(w.err)
 }
 
 func (w *wrappedError) Unauthorized() bool {
@@ -0,0 +1,361 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ChangeStatus is the status of a Gerrit change.
+type ChangeStatus string
+
+const (
+	ChangeStatusNew       ChangeStatus = "NEW"
+	ChangeStatusMerged    ChangeStatus = "MERGED"
+	ChangeStatusAbandoned ChangeStatus = "ABANDONED"
+)
+
+// ChangeIDTrailer is the git trailer Gerrit uses to associate commits with
+// changes.
+const ChangeIDTrailer = "Change-Id"
+
+// CodeReviewLabel is the name of the label Gerrit uses for code review votes.
+const CodeReviewLabel = "Code-Review"
+
+// VerifiedLabel is the name of the label CI systems conventionally use to
+// report build results on a change.
+const VerifiedLabel = "Verified"
+
+// Message tags set by Gerrit on the change messages it generates itself. See
+// https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#change-message-info.
+const (
+	MessageTagAbandon     = "autogenerated:gerrit:abandon"
+	MessageTagRestore     = "autogenerated:gerrit:restore"
+	MessageTagMerged      = "autogenerated:gerrit:merged"
+	MessageTagNewPatchSet = "autogenerated:gerrit:newPatchSet"
+)
+
+// Change is a Gerrit change, as returned by the ChangeInfo entity of the REST
+// API. Only the fields required by Sourcegraph are included.
+type Change struct {
+	ID              string               `json:"id"`
+	Project         string               `json:"project"`
+	Branch          string               `json:"branch"`
+	Topic           string               `json:"topic,omitempty"`
+	ChangeID        string               `json:"change_id"`
+	Subject         string               `json:"subject"`
+	Status          ChangeStatus         `json:"status"`
+	Created         Timestamp            `json:"created"`
+	Updated         Timestamp            `json:"updated"`
+	Submitted       *Timestamp           `json:"submitted,omitempty"`
+	Mergeable       bool                 `json:"mergeable,omitempty"`
+	WorkInProgress  bool                 `json:"work_in_progress,omitempty"`
+	Insertions      int                  `json:"insertions"`
+	Deletions       int                  `json:"deletions"`
+	Number          int                  `json:"_number"`
+	Owner           Account              `json:"owner"`
+	Labels          map[string]LabelInfo `json:"labels,omitempty"`
+	Messages        []*ChangeMessage     `json:"messages,omitempty"`
+	CurrentRevision string               `json:"current_revision,omitempty"`
+	Revisions       map[string]Revision  `json:"revisions,omitempty"`
+}
+
+// CurrentPatchSet returns the current revision of the change, if it was
+// returned by the API.
+func (c *Change) CurrentPatchSet() (Revision, bool) {
+	rev, ok := c.Revisions[c.CurrentRevision]
+	return rev, ok
+}
+
+// Description returns the commit message of the current patch set without its
+// subject line and without the Change-Id trailer.
+func (c *Change) Description() string {
+	rev, ok := c.CurrentPatchSet()
+	if !ok || rev.Commit == nil {
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimRight(rev.Commit.Message, "\n"), "\n")
+	if len(lines) > 0 {
+		lines = lines[1:]
+	}
+	if n := len(lines); n > 0 && strings.HasPrefix(lines[n-1], ChangeIDTrailer+": ") {
+		lines = lines[:n-1]
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// Revision is a patch set of a change.
+type Revision struct {
+	Kind     string      `json:"kind,omitempty"`
+	Number   int         `json:"_number"`
+	Created  Timestamp   `json:"created"`
+	Uploader Account     `json:"uploader"`
+	Ref      string      `json:"ref"`
+	Commit   *CommitInfo `json:"commit,omitempty"`
+}
+
+// CommitInfo is the commit of a patch set.
+type CommitInfo struct {
+	Parents []ParentCommit `json:"parents"`
+	Subject string         `json:"subject"`
+	Message string         `json:"message"`
+}
+
+// ParentCommit is the parent of the commit of a patch set.
+type ParentCommit struct {
+	Commit  string `json:"commit"`
+	Subject string `json:"subject"`
+}
+
+// LabelInfo contains the votes cast on a single label of a change.
+type LabelInfo struct {
+	Approved *Account       `json:"approved,omitempty"`
+	Rejected *Account       `json:"rejected,omitempty"`
+	All      []ApprovalInfo `json:"all,omitempty"`
+}
+
+// ApprovalInfo is a single vote on a label.
+type ApprovalInfo struct {
+	Account
+	Value int       `json:"value"`
+	Date  Timestamp `json:"date"`
+}
+
+// Reviewer is a vote on the Code-Review label of a change. Unlike
+// ApprovalInfo, it knows about the change it belongs to, which makes it usable
+// as the metadata of a changeset event.
+type Reviewer struct {
+	ApprovalInfo
+	ChangeID string `json:"change_id"`
+}
+
+// Key returns a unique key identifying the vote of this reviewer on the
+// change. Gerrit only keeps the latest vote of each reviewer, so the key
+// doesn't change when a reviewer updates their vote.
+func (r *Reviewer) Key() string {
+	return r.ChangeID + ":" + strconv.FormatInt(int64(r.ID), 10)
+}
+
+// ChangeMessage is a message on a change. Gerrit creates messages for
+// comments, as well as for changes to the change itself, like new patch sets.
+type ChangeMessage struct {
+	ID             string    `json:"id"`
+	Author         Account   `json:"author"`
+	Date           Timestamp `json:"date"`
+	Message        string    `json:"message"`
+	Tag            string    `json:"tag,omitempty"`
+	RevisionNumber int       `json:"_revision_number"`
+}
+
+// Key returns the ID of the message.
+func (m *ChangeMessage) Key() string { return m.ID }
+
+// timestampLayout is the format Gerrit uses to encode timestamps. They are
+// always in UTC.
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// Timestamp is a time.Time that can be decoded from and encoded to the
+// timestamp format used by Gerrit.
+type Timestamp struct {
+	time.Time
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.UTC().Format(timestampLayout))
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	parsed, err := time.ParseInLocation(timestampLayout, s, time.UTC)
+	if err != nil {
+		return errors.Wrapf(err, "parsing Gerrit timestamp %q", s)
+	}
+	t.Time = parsed
+	return nil
+}
+
+// changeOptions are the additional fields requested when loading a change.
+var changeOptions = []string{
+	"CURRENT_REVISION",
+	"CURRENT_COMMIT",
+	"DETAILED_LABELS",
+	"DETAILED_ACCOUNTS",
+	"MESSAGES",
+}
+
+// GetChange loads the change with the given ID. The ID can be anything Gerrit
+// accepts as a change identifier, such as the change number, the Change-Id,
+// or a "<project>~<branch>~<Change-Id>" triplet.
+func (c *Client) GetChange(ctx context.Context, changeID string) (*Change, error) {
+	u, err := changeURL(changeID)
+	if err != nil {
+		return nil, err
+	}
+
+	qs := make(url.Values)
+	for _, o := range changeOptions {
+		qs.Add("o", o)
+	}
+	u.RawQuery = qs.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if _, err := c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// AbandonChange abandons the given change.
+func (c *Client) AbandonChange(ctx context.Context, changeID string) (*Change, error) {
+	return c.changeAction(ctx, changeID, "abandon")
+}
+
+// RestoreChange restores the given abandoned change.
+func (c *Client) RestoreChange(ctx context.Context, changeID string) (*Change, error) {
+	return c.changeAction(ctx, changeID, "restore")
+}
+
+// SubmitChange submits the given change, merging it into its target branch.
+func (c *Client) SubmitChange(ctx context.Context, changeID string) (*Change, error) {
+	return c.changeAction(ctx, changeID, "submit")
+}
+
+// SetCommitMessage creates a new patch set of the given change, changing only
+// the commit message.
+func (c *Client) SetCommitMessage(ctx context.Context, changeID, message string) error {
+	req, err := newJSONRequest("PUT", changeID, "message", struct {
+		Message string `json:"message"`
+	}{Message: message})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// SetTopic sets the topic of the given change.
+func (c *Client) SetTopic(ctx context.Context, changeID, topic string) error {
+	req, err := newJSONRequest("PUT", changeID, "topic", struct {
+		Topic string `json:"topic"`
+	}{Topic: topic})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// ReviewInput is the input to SetReview.
+type ReviewInput struct {
+	Message string         `json:"message,omitempty"`
+	Labels  map[string]int `json:"labels,omitempty"`
+}
+
+// SetReview posts a review on the current patch set of the given change.
+func (c *Client) SetReview(ctx context.Context, changeID string, input ReviewInput) error {
+	req, err := newJSONRequest("POST", changeID, "revisions/current/review", input)
+	if err != nil {
+		return err
+	}
+
+	var result json.RawMessage
+	_, err = c.do(ctx, req, &result)
+	return err
+}
+
+// GetAuthenticatedAccount returns the account the client is authenticated as.
+func (c *Client) GetAuthenticatedAccount(ctx context.Context) (*Account, error) {
+	req, err := http.NewRequest("GET", "a/accounts/self", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if _, err := c.do(ctx, req, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (c *Client) changeAction(ctx context.Context, changeID, action string) (*Change, error) {
+	req, err := newJSONRequest("POST", changeID, action, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if _, err := c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// ChangeTriplet returns the "<project>~<branch>~<Change-Id>" identifier of a
+// change, which unlike the Change-Id alone is guaranteed to be unique.
+func ChangeTriplet(project, branch, changeID string) string {
+	return url.PathEscape(project) + "~" + url.PathEscape(branch) + "~" + changeID
+}
+
+// changeURL returns the relative URL of the REST endpoint for the given change.
+// Any parts of the change ID that require it, such as the project and branch
+// of a triplet, must already be URL encoded.
+func changeURL(changeID string, elem ...string) (*url.URL, error) {
+	return url.Parse(path.Join(append([]string{"a/changes", changeID}, elem...)...))
+}
+
+func newJSONRequest(method, changeID, endpoint string, input any) (*http.Request, error) {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling request body")
+	}
+
+	u, err := changeURL(changeID, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	return req, nil
+}
+
+// IsNotFound reports whether err is a Gerrit API error with a 404 status code.
+func IsNotFound(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.NotFound()
+}
+
+// IsConflict reports whether err is a Gerrit API error with a 409 status
+// code. Gerrit returns this status when an action, such as submitting a
+// change, is not possible in the current state of the change.
+func IsConflict(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.StatusCode == http.StatusConflict
+}
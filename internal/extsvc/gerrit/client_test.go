@@ -2,12 +2,14 @@ package gerrit
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/dnaeon/go-vcr/cassette"
 	"github.com/inconshreveable/log15"
@@ -38,6 +40,53 @@ func TestClient_ListProjects(t *testing.T) {
 	testutil.AssertGolden(t, "testdata/golden/ListProjects.json", *update, resp)
 }
 
+func TestClient_GetChange(t *testing.T) {
+	cli, save := NewTestClient(t, "GetChange", *update)
+	defer save()
+
+	ctx := context.Background()
+
+	t.Run("found", func(t *testing.T) {
+		change, err := cli.GetChange(ctx, "I5e2b2a6f6e6f7e3c0c6cb4b1ba0ce5dd5cdb0d4e")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := change.Description(), "This changes the README to mention the new release."; have != want {
+			t.Errorf("unexpected description: have %q, want %q", have, want)
+		}
+
+		testutil.AssertGolden(t, "testdata/golden/GetChange.json", *update, change)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := cli.GetChange(ctx, "I0000000000000000000000000000000000000000")
+		if !IsNotFound(err) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestTimestamp(t *testing.T) {
+	const raw = `"2022-07-20 14:02:11.123456789"`
+
+	var ts Timestamp
+	if err := json.Unmarshal([]byte(raw), &ts); err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2022, 7, 20, 14, 2, 11, 123456789, time.UTC); !ts.Equal(want) {
+		t.Errorf("unexpected time: have %s, want %s", ts, want)
+	}
+
+	data, err := json.Marshal(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != raw {
+		t.Errorf("unexpected encoding: have %s, want %s", data, raw)
+	}
+}
+
 func TestMain(m *testing.M) {
 	flag.Parse()
 	if !testing.Verbose() {
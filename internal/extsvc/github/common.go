@@ -1738,6 +1738,11 @@ type Repository struct {
 	StargazerCount int `json:",omitempty"`
 	ForkCount      int `json:",omitempty"`
 
+	// Topics are the topics of the repository. Only the REST API
+	// populates this, so it is nil for repositories fetched with the
+	// GraphQL API and empty for repositories without topics.
+	Topics []string `json:",omitempty"`
+
 	// This is available for GitHub Enterprise Cloud and GitHub Enterprise Server 3.3.0+ and is used
 	// to identify if a repository is public or private or internal.
 	// https://developer.github.com/changes/2019-12-03-internal-visibility-changes/#repository-visibility-fields
@@ -1765,6 +1770,7 @@ type restRepository struct {
 	Stars       int                       `json:"stargazers_count"`
 	Forks       int                       `json:"forks_count"`
 	Visibility  string                    `json:"visibility"`
+	Topics      []string                  `json:"topics"`
 }
 
 // getRepositoryFromAPI attempts to fetch a repository from the GitHub API without use of the redis cache.
@@ -1801,6 +1807,9 @@ func convertRestRepo(restRepo restRepository) *Repository {
 		StargazerCount:   restRepo.Stars,
 		ForkCount:        restRepo.Forks,
 	}
+	if restRepo.Topics != nil {
+		repo.Topics = restRepo.Topics
+	}
 
 	if conf.ExperimentalFeatures().EnableGithubInternalRepoVisibility {
 		repo.Visibility = Visibility(restRepo.Visibility)
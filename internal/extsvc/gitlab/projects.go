@@ -34,6 +34,8 @@ type Project struct {
 	Archived          bool           `json:"archived"`
 	StarCount         int            `json:"star_count"`
 	ForksCount        int            `json:"forks_count"`
+	Topics            []string       `json:"topics,omitempty"`
+	TagList           []string       `json:"tag_list,omitempty"` // Deprecated in favor of Topics since GitLab 14.0
 }
 
 type ProjectCommon struct {
@@ -184,6 +184,9 @@ type Client interface {
 	// BlameFile returns Git blame information about a file.
 	BlameFile(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, path string, opt *BlameOptions) ([]*Hunk, error)
 
+	// Commits returns all commits matching the options.
+	Commits(ctx context.Context, repo api.RepoName, opt CommitsOptions, checker authz.SubRepoPermissionChecker) ([]*gitdomain.Commit, error)
+
 	// GitCommand is deprecated. You should use one of the other methods provided
 	// here or add a new one if what you need doesn't exist.
 	GitCommand(repo api.RepoName, args ...string) GitCommand
@@ -211,6 +214,10 @@ type Client interface {
 
 	IsRepoCloned(context.Context, api.RepoName) (bool, error)
 
+	// LastModified returns the paths modified by the commits in the history of
+	// rev that match opt, each mapped to the most recent such commit.
+	LastModified(ctx context.Context, repo api.RepoName, rev string, opt LastModifiedOptions, checker authz.SubRepoPermissionChecker) (map[string]*gitdomain.Commit, error)
+
 	// ListCloned lists all cloned repositories
 	ListCloned(context.Context) ([]string, error)
 
@@ -299,6 +306,9 @@ type Client interface {
 
 	// ReadDir reads the contents of the named directory at commit.
 	ReadDir(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, path string, recurse bool) ([]fs.FileInfo, error)
+
+	// ReadFile returns the contents of the named file at commit.
+	ReadFile(ctx context.Context, repo api.RepoName, commit api.CommitID, name string, checker authz.SubRepoPermissionChecker) ([]byte, error)
 }
 
 func (c *ClientImplementor) Addrs() []string {
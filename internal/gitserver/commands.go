@@ -1628,6 +1628,149 @@ func hasAccessToCommit(ctx context.Context, commit *wrappedCommit, repoName api.
 	return false, nil
 }
 
+// LastModifiedOptions restricts the commits and paths considered by
+// LastModified.
+type LastModifiedOptions struct {
+	Author string // include only commits whose author matches this
+	After  string // include only commits after this date
+
+	// Paths, if set, restricts the result to these paths. The history is only
+	// walked until a commit has been found for each of them.
+	Paths []string
+}
+
+// LastModified returns the paths modified by the commits in the history of
+// rev that match opt, each mapped to the most recent such commit. Paths that
+// were deleted by that commit are omitted. Only the ID and author of the
+// commits are set.
+func (c *ClientImplementor) LastModified(ctx context.Context, repo api.RepoName, rev string, opt LastModifiedOptions, checker authz.SubRepoPermissionChecker) (map[string]*gitdomain.Commit, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "Git: LastModified")
+	span.SetTag("Rev", rev)
+	span.SetTag("Opt", opt)
+	defer span.Finish()
+
+	if err := checkSpecArgSafety(rev); err != nil {
+		return nil, err
+	}
+
+	args := []string{"log", "--no-renames", "--name-status", "-z", "--format=%x1e%H%x00%an%x00%ae%x00%at"}
+	if opt.Author != "" {
+		args = append(args, "--author="+opt.Author)
+	}
+	if opt.After != "" {
+		args = append(args, "--after="+opt.After)
+	}
+	args = append(args, rev, "--")
+	for _, path := range opt.Paths {
+		// Paths are matched literally, not as globs or with pathspec magic.
+		args = append(args, ":(literal)"+path)
+	}
+
+	rc, err := c.execReader(ctx, repo, args)
+	if err != nil {
+		return nil, err
+	}
+	commits, complete, err := parseLastModified(bufio.NewReader(rc), len(opt.Paths))
+	closeErr := rc.Close()
+	if err != nil {
+		return nil, err
+	}
+	// Closing the reader before the end of the output kills git, so the
+	// error is only meaningful if the whole output was read.
+	if complete && closeErr != nil {
+		return nil, closeErr
+	}
+
+	paths := make([]string, 0, len(commits))
+	for path := range commits {
+		paths = append(paths, path)
+	}
+	filtered, err := filterPaths(ctx, repo, checker, paths)
+	if err != nil {
+		return nil, err
+	}
+	if len(filtered) == len(paths) {
+		return commits, nil
+	}
+	result := make(map[string]*gitdomain.Commit, len(filtered))
+	for _, path := range filtered {
+		result[path] = commits[path]
+	}
+	return result, nil
+}
+
+// parseLastModified parses the output of the git log command run by
+// LastModified. If limit is positive, parsing stops once limit paths have been
+// seen. Paths whose most recent change deleted them are seen, but not
+// returned. The returned bool is true if the whole output was read.
+func parseLastModified(r *bufio.Reader, limit int) (map[string]*gitdomain.Commit, bool, error) {
+	// Deleted paths are mapped to nil until the end, so that older commits
+	// that modified them are skipped.
+	commits := map[string]*gitdomain.Commit{}
+	withoutDeleted := func() map[string]*gitdomain.Commit {
+		for path, commit := range commits {
+			if commit == nil {
+				delete(commits, path)
+			}
+		}
+		return commits
+	}
+
+	for limit <= 0 || len(commits) < limit {
+		record, err := r.ReadString('\x1e')
+		if err != nil && err != io.EOF {
+			return nil, false, err
+		}
+		record = strings.TrimSuffix(record, "\x1e")
+
+		// Each record is the commit header followed by the NUL terminated
+		// status and path of each file it modified. With -z, the header is
+		// terminated by a newline or NUL depending on the git version.
+		var fields []string
+		for _, field := range strings.Split(record, "\x00") {
+			if field = strings.Trim(field, "\n"); field != "" {
+				fields = append(fields, field)
+			}
+		}
+		if len(fields) >= 4 {
+			seconds, parseErr := strconv.ParseInt(fields[3], 10, 64)
+			if parseErr != nil {
+				return nil, false, errors.Wrapf(parseErr, "parsing author date of commit %s", fields[0])
+			}
+			commit := &gitdomain.Commit{
+				ID: api.CommitID(fields[0]),
+				Author: gitdomain.Signature{
+					Name:  fields[1],
+					Email: fields[2],
+					Date:  time.Unix(seconds, 0).UTC(),
+				},
+			}
+			changes := fields[4:]
+			if len(changes)%2 != 0 {
+				return nil, false, errors.Errorf("unexpected git log record %q", record)
+			}
+			for i := 0; i < len(changes); i += 2 {
+				status, path := changes[i], changes[i+1]
+				if _, ok := commits[path]; ok {
+					continue
+				}
+				if status == "D" {
+					commits[path] = nil
+				} else {
+					commits[path] = commit
+				}
+			}
+		} else if len(fields) > 0 {
+			return nil, false, errors.Errorf("unexpected git log record %q", record)
+		}
+
+		if err == io.EOF {
+			return withoutDeleted(), true, nil
+		}
+	}
+	return withoutDeleted(), false, nil
+}
+
 // CommitsUniqueToBranch returns a map from commits that exist on a particular
 // branch in the given repository to their committer date. This set of commits is
 // determined by listing `{branchName} ^HEAD`, which is interpreted as: all
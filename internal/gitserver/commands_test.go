@@ -1649,6 +1649,87 @@ func TestCommitExists(t *testing.T) {
 	testCommitExists("with sub-repo permissions filtering", gitCommandsWithFiles, commitIDWithAccess, commitIDWithoutAccess, getTestSubRepoPermsChecker(fileWithoutAccess))
 }
 
+func TestRepository_LastModified(t *testing.T) {
+	ClientMocks.LocalGitserver = true
+	defer ResetClientMocks()
+	// Other tests leave Mocks.ExecReader set.
+	ResetMocks()
+	ctx := actor.WithActor(context.Background(), &actor.Actor{
+		UID: 1,
+	})
+
+	repo := MakeGitRepository(t,
+		"echo a > f1 && echo a > f2 && echo a > f3",
+		"git add f1 f2 f3",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m a --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"echo b > f2",
+		"git add f2",
+		"GIT_COMMITTER_NAME=b GIT_COMMITTER_EMAIL=b@b.com GIT_COMMITTER_DATE=2007-01-02T15:04:05Z git commit -m b --author='b <b@b.com>' --date 2007-01-02T15:04:05Z",
+		"echo a > f3",
+		"echo a >> f3",
+		"git add f3",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2008-01-02T15:04:05Z git commit -m a2 --author='a <a@a.com>' --date 2008-01-02T15:04:05Z",
+		"echo a > f4 && echo a > 'f*'",
+		"git add f4 'f*'",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2009-01-02T15:04:05Z git commit -m a3 --author='a <a@a.com>' --date 2009-01-02T15:04:05Z",
+		"git rm f4",
+		"GIT_COMMITTER_NAME=b GIT_COMMITTER_EMAIL=b@b.com GIT_COMMITTER_DATE=2010-01-02T15:04:05Z git commit -m b2 --author='b <b@b.com>' --date 2010-01-02T15:04:05Z",
+	)
+
+	tests := []struct {
+		name string
+		opt  LastModifiedOptions
+		want map[string]string // path -> author date
+	}{
+		{
+			name: "all",
+			want: map[string]string{"f1": "2006-01-02T15:04:05Z", "f2": "2007-01-02T15:04:05Z", "f3": "2008-01-02T15:04:05Z", "f*": "2009-01-02T15:04:05Z"},
+		},
+		{
+			name: "author",
+			opt:  LastModifiedOptions{Author: "b@b.com"},
+			want: map[string]string{"f2": "2007-01-02T15:04:05Z"},
+		},
+		{
+			name: "after",
+			opt:  LastModifiedOptions{After: "2006-06-01"},
+			want: map[string]string{"f2": "2007-01-02T15:04:05Z", "f3": "2008-01-02T15:04:05Z", "f*": "2009-01-02T15:04:05Z"},
+		},
+		{
+			name: "paths",
+			opt:  LastModifiedOptions{Paths: []string{"f1", "f2"}},
+			want: map[string]string{"f1": "2006-01-02T15:04:05Z", "f2": "2007-01-02T15:04:05Z"},
+		},
+		{
+			name: "literal paths",
+			opt:  LastModifiedOptions{Paths: []string{"f*"}},
+			want: map[string]string{"f*": "2009-01-02T15:04:05Z"},
+		},
+		{
+			name: "deleted paths",
+			opt:  LastModifiedOptions{Paths: []string{"f1", "f4"}},
+			want: map[string]string{"f1": "2006-01-02T15:04:05Z"},
+		},
+	}
+
+	client := NewClient(database.NewMockDB())
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			commits, err := client.LastModified(ctx, repo, "HEAD", test.opt, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]string, len(commits))
+			for path, commit := range commits {
+				got[path] = commit.Author.Date.Format(time.RFC3339)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Fatalf("unexpected commits (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestRepository_Commits(t *testing.T) {
 	ClientMocks.LocalGitserver = true
 	defer ResetClientMocks()
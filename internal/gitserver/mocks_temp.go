@@ -44,6 +44,9 @@ type MockClient struct {
 	// BlameFileFunc is an instance of a mock function object controlling
 	// the behavior of the method BlameFile.
 	BlameFileFunc *ClientBlameFileFunc
+	// CommitsFunc is an instance of a mock function object controlling the
+	// behavior of the method Commits.
+	CommitsFunc *ClientCommitsFunc
 	// CreateCommitFromPatchFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateCommitFromPatch.
 	CreateCommitFromPatchFunc *ClientCreateCommitFromPatchFunc
@@ -68,6 +71,9 @@ type MockClient struct {
 	// IsRepoClonedFunc is an instance of a mock function object controlling
 	// the behavior of the method IsRepoCloned.
 	IsRepoClonedFunc *ClientIsRepoClonedFunc
+	// LastModifiedFunc is an instance of a mock function object controlling
+	// the behavior of the method LastModified.
+	LastModifiedFunc *ClientLastModifiedFunc
 	// ListBranchesFunc is an instance of a mock function object controlling
 	// the behavior of the method ListBranches.
 	ListBranchesFunc *ClientListBranchesFunc
@@ -92,6 +98,9 @@ type MockClient struct {
 	// RemoveFunc is an instance of a mock function object controlling the
 	// behavior of the method Remove.
 	RemoveFunc *ClientRemoveFunc
+	// ReadFileFunc is an instance of a mock function object controlling the
+	// behavior of the method ReadFile.
+	ReadFileFunc *ClientReadFileFunc
 	// RemoveFromFunc is an instance of a mock function object controlling
 	// the behavior of the method RemoveFrom.
 	RemoveFromFunc *ClientRemoveFromFunc
@@ -161,6 +170,11 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		CommitsFunc: &ClientCommitsFunc{
+			defaultHook: func(context.Context, api.RepoName, CommitsOptions, authz.SubRepoPermissionChecker) (r0 []*gitdomain.Commit, r1 error) {
+				return
+			},
+		},
 		CreateCommitFromPatchFunc: &ClientCreateCommitFromPatchFunc{
 			defaultHook: func(context.Context, protocol.CreateCommitFromPatchRequest) (r0 string, r1 error) {
 				return
@@ -201,6 +215,11 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		LastModifiedFunc: &ClientLastModifiedFunc{
+			defaultHook: func(context.Context, api.RepoName, string, LastModifiedOptions, authz.SubRepoPermissionChecker) (r0 map[string]*gitdomain.Commit, r1 error) {
+				return
+			},
+		},
 		ListBranchesFunc: &ClientListBranchesFunc{
 			defaultHook: func(context.Context, api.RepoName, BranchesOptions) (r0 []*gitdomain.Branch, r1 error) {
 				return
@@ -241,6 +260,11 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		ReadFileFunc: &ClientReadFileFunc{
+			defaultHook: func(context.Context, api.RepoName, api.CommitID, string, authz.SubRepoPermissionChecker) (r0 []byte, r1 error) {
+				return
+			},
+		},
 		RemoveFromFunc: &ClientRemoveFromFunc{
 			defaultHook: func(context.Context, api.RepoName, string) (r0 error) {
 				return
@@ -333,6 +357,11 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.BlameFile")
 			},
 		},
+		CommitsFunc: &ClientCommitsFunc{
+			defaultHook: func(context.Context, api.RepoName, CommitsOptions, authz.SubRepoPermissionChecker) ([]*gitdomain.Commit, error) {
+				panic("unexpected invocation of MockClient.Commits")
+			},
+		},
 		CreateCommitFromPatchFunc: &ClientCreateCommitFromPatchFunc{
 			defaultHook: func(context.Context, protocol.CreateCommitFromPatchRequest) (string, error) {
 				panic("unexpected invocation of MockClient.CreateCommitFromPatch")
@@ -373,6 +402,11 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.IsRepoCloned")
 			},
 		},
+		LastModifiedFunc: &ClientLastModifiedFunc{
+			defaultHook: func(context.Context, api.RepoName, string, LastModifiedOptions, authz.SubRepoPermissionChecker) (map[string]*gitdomain.Commit, error) {
+				panic("unexpected invocation of MockClient.LastModified")
+			},
+		},
 		ListBranchesFunc: &ClientListBranchesFunc{
 			defaultHook: func(context.Context, api.RepoName, BranchesOptions) ([]*gitdomain.Branch, error) {
 				panic("unexpected invocation of MockClient.ListBranches")
@@ -413,6 +447,11 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.Remove")
 			},
 		},
+		ReadFileFunc: &ClientReadFileFunc{
+			defaultHook: func(context.Context, api.RepoName, api.CommitID, string, authz.SubRepoPermissionChecker) ([]byte, error) {
+				panic("unexpected invocation of MockClient.ReadFile")
+			},
+		},
 		RemoveFromFunc: &ClientRemoveFromFunc{
 			defaultHook: func(context.Context, api.RepoName, string) error {
 				panic("unexpected invocation of MockClient.RemoveFrom")
@@ -493,6 +532,9 @@ func NewMockClientFrom(i Client) *MockClient {
 		BlameFileFunc: &ClientBlameFileFunc{
 			defaultHook: i.BlameFile,
 		},
+		CommitsFunc: &ClientCommitsFunc{
+			defaultHook: i.Commits,
+		},
 		CreateCommitFromPatchFunc: &ClientCreateCommitFromPatchFunc{
 			defaultHook: i.CreateCommitFromPatch,
 		},
@@ -517,6 +559,9 @@ func NewMockClientFrom(i Client) *MockClient {
 		IsRepoClonedFunc: &ClientIsRepoClonedFunc{
 			defaultHook: i.IsRepoCloned,
 		},
+		LastModifiedFunc: &ClientLastModifiedFunc{
+			defaultHook: i.LastModified,
+		},
 		ListBranchesFunc: &ClientListBranchesFunc{
 			defaultHook: i.ListBranches,
 		},
@@ -541,6 +586,9 @@ func NewMockClientFrom(i Client) *MockClient {
 		RemoveFunc: &ClientRemoveFunc{
 			defaultHook: i.Remove,
 		},
+		ReadFileFunc: &ClientReadFileFunc{
+			defaultHook: i.ReadFile,
+		},
 		RemoveFromFunc: &ClientRemoveFromFunc{
 			defaultHook: i.RemoveFrom,
 		},
@@ -1228,6 +1276,119 @@ func (c ClientBlameFileFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ClientCommitsFunc describes the behavior when the Commits method of the
+// parent MockClient instance is invoked.
+type ClientCommitsFunc struct {
+	defaultHook func(context.Context, api.RepoName, CommitsOptions, authz.SubRepoPermissionChecker) ([]*gitdomain.Commit, error)
+	hooks       []func(context.Context, api.RepoName, CommitsOptions, authz.SubRepoPermissionChecker) ([]*gitdomain.Commit, error)
+	history     []ClientCommitsFuncCall
+	mutex       sync.Mutex
+}
+
+// Commits delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockClient) Commits(v0 context.Context, v1 api.RepoName, v2 CommitsOptions, v3 authz.SubRepoPermissionChecker) ([]*gitdomain.Commit, error) {
+	r0, r1 := m.CommitsFunc.nextHook()(v0, v1, v2, v3)
+	m.CommitsFunc.appendCall(ClientCommitsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Commits method of
+// the parent MockClient instance is invoked and the hook queue is empty.
+func (f *ClientCommitsFunc) SetDefaultHook(hook func(context.Context, api.RepoName, CommitsOptions, authz.SubRepoPermissionChecker) ([]*gitdomain.Commit, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Commits method of the parent MockClient instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ClientCommitsFunc) PushHook(hook func(context.Context, api.RepoName, CommitsOptions, authz.SubRepoPermissionChecker) ([]*gitdomain.Commit, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientCommitsFunc) SetDefaultReturn(r0 []*gitdomain.Commit, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, CommitsOptions, authz.SubRepoPermissionChecker) ([]*gitdomain.Commit, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientCommitsFunc) PushReturn(r0 []*gitdomain.Commit, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, CommitsOptions, authz.SubRepoPermissionChecker) ([]*gitdomain.Commit, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientCommitsFunc) nextHook() func(context.Context, api.RepoName, CommitsOptions, authz.SubRepoPermissionChecker) ([]*gitdomain.Commit, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientCommitsFunc) appendCall(r0 ClientCommitsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientCommitsFuncCall objects describing
+// the invocations of this function.
+func (f *ClientCommitsFunc) History() []ClientCommitsFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientCommitsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientCommitsFuncCall is an object that describes an invocation of method
+// Commits on an instance of MockClient.
+type ClientCommitsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 CommitsOptions
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 authz.SubRepoPermissionChecker
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*gitdomain.Commit
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientCommitsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientCommitsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ClientCreateCommitFromPatchFunc describes the behavior when the
 // CreateCommitFromPatch method of the parent MockClient instance is
 // invoked.
@@ -2117,6 +2278,122 @@ func (c ClientIsRepoClonedFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ClientLastModifiedFunc describes the behavior when the LastModified
+// method of the parent MockClient instance is invoked.
+type ClientLastModifiedFunc struct {
+	defaultHook func(context.Context, api.RepoName, string, LastModifiedOptions, authz.SubRepoPermissionChecker) (map[string]*gitdomain.Commit, error)
+	hooks       []func(context.Context, api.RepoName, string, LastModifiedOptions, authz.SubRepoPermissionChecker) (map[string]*gitdomain.Commit, error)
+	history     []ClientLastModifiedFuncCall
+	mutex       sync.Mutex
+}
+
+// LastModified delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockClient) LastModified(v0 context.Context, v1 api.RepoName, v2 string, v3 LastModifiedOptions, v4 authz.SubRepoPermissionChecker) (map[string]*gitdomain.Commit, error) {
+	r0, r1 := m.LastModifiedFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.LastModifiedFunc.appendCall(ClientLastModifiedFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the LastModified method
+// of the parent MockClient instance is invoked and the hook queue is empty.
+func (f *ClientLastModifiedFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string, LastModifiedOptions, authz.SubRepoPermissionChecker) (map[string]*gitdomain.Commit, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// LastModified method of the parent MockClient instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ClientLastModifiedFunc) PushHook(hook func(context.Context, api.RepoName, string, LastModifiedOptions, authz.SubRepoPermissionChecker) (map[string]*gitdomain.Commit, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientLastModifiedFunc) SetDefaultReturn(r0 map[string]*gitdomain.Commit, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, string, LastModifiedOptions, authz.SubRepoPermissionChecker) (map[string]*gitdomain.Commit, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientLastModifiedFunc) PushReturn(r0 map[string]*gitdomain.Commit, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, string, LastModifiedOptions, authz.SubRepoPermissionChecker) (map[string]*gitdomain.Commit, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientLastModifiedFunc) nextHook() func(context.Context, api.RepoName, string, LastModifiedOptions, authz.SubRepoPermissionChecker) (map[string]*gitdomain.Commit, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientLastModifiedFunc) appendCall(r0 ClientLastModifiedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientLastModifiedFuncCall objects
+// describing the invocations of this function.
+func (f *ClientLastModifiedFunc) History() []ClientLastModifiedFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientLastModifiedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientLastModifiedFuncCall is an object that describes an invocation of
+// method LastModified on an instance of MockClient.
+type ClientLastModifiedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 LastModifiedOptions
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 authz.SubRepoPermissionChecker
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]*gitdomain.Commit
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientLastModifiedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientLastModifiedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ClientListBranchesFunc describes the behavior when the ListBranches
 // method of the parent MockClient instance is invoked.
 type ClientListBranchesFunc struct {
@@ -3007,6 +3284,122 @@ func (c ClientRemoveFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ClientReadFileFunc describes the behavior when the ReadFile method of the
+// parent MockClient instance is invoked.
+type ClientReadFileFunc struct {
+	defaultHook func(context.Context, api.RepoName, api.CommitID, string, authz.SubRepoPermissionChecker) ([]byte, error)
+	hooks       []func(context.Context, api.RepoName, api.CommitID, string, authz.SubRepoPermissionChecker) ([]byte, error)
+	history     []ClientReadFileFuncCall
+	mutex       sync.Mutex
+}
+
+// ReadFile delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockClient) ReadFile(v0 context.Context, v1 api.RepoName, v2 api.CommitID, v3 string, v4 authz.SubRepoPermissionChecker) ([]byte, error) {
+	r0, r1 := m.ReadFileFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.ReadFileFunc.appendCall(ClientReadFileFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ReadFile method of
+// the parent MockClient instance is invoked and the hook queue is empty.
+func (f *ClientReadFileFunc) SetDefaultHook(hook func(context.Context, api.RepoName, api.CommitID, string, authz.SubRepoPermissionChecker) ([]byte, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReadFile method of the parent MockClient instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ClientReadFileFunc) PushHook(hook func(context.Context, api.RepoName, api.CommitID, string, authz.SubRepoPermissionChecker) ([]byte, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientReadFileFunc) SetDefaultReturn(r0 []byte, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, api.CommitID, string, authz.SubRepoPermissionChecker) ([]byte, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientReadFileFunc) PushReturn(r0 []byte, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, api.CommitID, string, authz.SubRepoPermissionChecker) ([]byte, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientReadFileFunc) nextHook() func(context.Context, api.RepoName, api.CommitID, string, authz.SubRepoPermissionChecker) ([]byte, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientReadFileFunc) appendCall(r0 ClientReadFileFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientReadFileFuncCall objects describing
+// the invocations of this function.
+func (f *ClientReadFileFunc) History() []ClientReadFileFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientReadFileFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientReadFileFuncCall is an object that describes an invocation of
+// method ReadFile on an instance of MockClient.
+type ClientReadFileFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.CommitID
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 authz.SubRepoPermissionChecker
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []byte
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientReadFileFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientReadFileFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ClientRemoveFromFunc describes the behavior when the RemoveFrom method of
 // the parent MockClient instance is invoked.
 type ClientRemoveFromFunc struct {
@@ -300,6 +300,10 @@ type CreateCommitFromPatchRequest struct {
 	// Push specifies whether the target ref will be pushed to the code host: if
 	// nil, no push will be attempted, if non-nil, a push will be attempted.
 	Push *PushConfig
+	// PushRef is the ref the commit will be pushed to on the code host. If
+	// nil, TargetRef is used. Code hosts such as Gerrit require pushing to a
+	// magic ref that differs from the ref created locally.
+	PushRef *string
 	// GitApplyArgs are the arguments that will be passed to `git apply` along
 	// with `--cached`.
 	GitApplyArgs []string
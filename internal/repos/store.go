@@ -502,13 +502,20 @@ func (s *store) CreateExternalServiceRepo(ctx context.Context, svc *types.Extern
 		return err
 	}
 
-	return s.Exec(ctx, sqlf.Sprintf(upsertExternalServiceRepoQuery,
+	if err = s.Exec(ctx, sqlf.Sprintf(upsertExternalServiceRepoQuery,
 		svc.ID,
 		r.ID,
 		svc.NamespaceUserID,
 		svc.NamespaceOrgID,
 		src.CloneURL,
-	))
+	)); err != nil {
+		return err
+	}
+
+	if topics, ok := Topics(r); ok {
+		return s.RepoStore().SetTopics(ctx, r.ID, topics)
+	}
+	return nil
 }
 
 const createRepoQuery = `
@@ -673,13 +680,20 @@ func (s *store) UpdateExternalServiceRepo(ctx context.Context, svc *types.Extern
 		return err
 	}
 
-	return s.Exec(ctx, sqlf.Sprintf(upsertExternalServiceRepoQuery,
+	if err = s.Exec(ctx, sqlf.Sprintf(upsertExternalServiceRepoQuery,
 		svc.ID,
 		r.ID,
 		svc.NamespaceUserID,
 		svc.NamespaceOrgID,
 		src.CloneURL,
-	))
+	)); err != nil {
+		return err
+	}
+
+	if topics, ok := Topics(r); ok {
+		return s.RepoStore().SetTopics(ctx, r.ID, topics)
+	}
+	return nil
 }
 
 const updateRepoQuery = `
@@ -0,0 +1,36 @@
+package repos
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/pagure"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// Topics returns the topics the code host of repo assigned to it, as found in
+// its metadata. It returns false if the metadata holds no topics, because the
+// code host has none or the API the repository was fetched with does not
+// return them. The stored topics of the repository must then be kept.
+//
+// Bitbucket Server has no topics, so the key of the project a repository
+// belongs to is its topic.
+func Topics(repo *types.Repo) ([]string, bool) {
+	switch m := repo.Metadata.(type) {
+	case *github.Repository:
+		return m.Topics, m.Topics != nil
+	case *gitlab.Project:
+		if m.Topics != nil {
+			return m.Topics, true
+		}
+		return m.TagList, m.TagList != nil
+	case *pagure.Project:
+		return m.Tags, m.Tags != nil
+	case *bitbucketserver.Repo:
+		if m.Project == nil || m.Project.Key == "" {
+			return nil, false
+		}
+		return []string{m.Project.Key}, true
+	}
+	return nil, false
+}
@@ -64,6 +64,8 @@ func (q *ProposedQuery) QueryString() string {
 			return q.Query + " patternType:literal"
 		case query.SearchTypeStructural:
 			return q.Query + " patternType:structural"
+		case query.SearchTypeSyntax:
+			return q.Query + " patternType:syntax"
 		case query.SearchTypeLucky:
 			return q.Query
 		default:
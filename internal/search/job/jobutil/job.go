@@ -105,7 +105,7 @@ func NewBasicJob(inputs *run.SearchInputs, b query.Basic) (job.Job, error) {
 			}
 		}
 
-		if resultTypes.Has(result.TypeSymbol) {
+		if resultTypes.Has(result.TypeSymbol) && !b.SymbolHistory() {
 			// Create Global Symbol Search jobs.
 			if repoUniverseSearch {
 				job, err := builder.newZoektGlobalSearch(search.SymbolRequest)
@@ -161,10 +161,24 @@ func NewBasicJob(inputs *run.SearchInputs, b query.Basic) (job.Job, error) {
 
 	basicJob := NewParallelJob(children...)
 
+	{ // Apply owner filters
+		include, exclude := b.IncludeExcludeValues(query.FieldOwner)
+		if len(include) > 0 || len(exclude) > 0 {
+			basicJob = NewOwnerFilterJob(include, exclude, basicJob)
+		}
+	}
+
+	// Owners are selected after subrepo permissions are checked, so that
+	// we never resolve the owners of files the user cannot read.
+	var ownersSelector filter.SelectPath
 	{ // Apply selectors
 		if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
 			sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
-			basicJob = NewSelectJob(sp, basicJob)
+			if isOwnersSelect(sp) {
+				ownersSelector = sp
+			} else {
+				basicJob = NewSelectJob(sp, basicJob)
+			}
 		}
 	}
 
@@ -175,8 +189,26 @@ func NewBasicJob(inputs *run.SearchInputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	if ownersSelector != nil {
+		basicJob = NewSelectJob(ownersSelector, basicJob)
+	}
+
+	maxResults := b.ToParseTree().MaxResults(inputs.DefaultLimit())
+
+	{ // Apply sort
+		// Sorting buffers a bounded window of results, so that results keep
+		// streaming. Results are sorted exactly whenever the search returns
+		// at most as many results as the window holds.
+		if sortBy := b.ToParseTree().SortBy(); sortBy != nil {
+			windowSize := maxResults
+			if windowSize > maxSortWindowSize {
+				windowSize = maxSortWindowSize
+			}
+			basicJob = NewSortJob(*sortBy, windowSize, basicJob)
+		}
+	}
+
 	{ // Apply limit
-		maxResults := b.ToParseTree().MaxResults(inputs.DefaultLimit())
 		basicJob = NewLimitJob(maxResults, basicJob)
 	}
 
@@ -311,12 +343,20 @@ func NewFlatJob(searchInputs *run.SearchInputs, f query.Flat) (job.Job, error) {
 				symbolSearchJob := &searcher.SymbolSearchJob{
 					PatternInfo: patternInfo,
 					Limit:       maxResults,
+					History:     f.SymbolHistory(),
+				}
+
+				useIndex := f.Index()
+				if symbolSearchJob.History {
+					// Zoekt does not index the history of symbols, so all
+					// repos are searched with the symbols service.
+					useIndex = query.No
 				}
 
 				addJob(&repoPagerJob{
 					child:            &reposPartialJob{symbolSearchJob},
 					repoOpts:         repoOptions,
-					useIndex:         f.Index(),
+					useIndex:         useIndex,
 					containsRefGlobs: query.ContainsRefGlobs(f.ToBasic().ToParseTree()),
 				})
 			}
@@ -350,6 +390,24 @@ func NewFlatJob(searchInputs *run.SearchInputs, f query.Flat) (job.Job, error) {
 			})
 		}
 
+		if resultTypes.Has(result.TypeSyntax) {
+			// Syntax search parses files with tree-sitter, which only
+			// searcher can do. So all repos are searched unindexed.
+			searcherJob := &searcher.TextSearchJob{
+				PatternInfo:     patternInfo,
+				Indexed:         false,
+				UseFullDeadline: useFullDeadline,
+				Features:        features,
+			}
+
+			addJob(&repoPagerJob{
+				child:            &reposPartialJob{searcherJob},
+				repoOpts:         repoOptions,
+				useIndex:         query.No,
+				containsRefGlobs: query.ContainsRefGlobs(f.ToBasic().ToParseTree()),
+			})
+		}
+
 		if resultTypes.Has(result.TypeRepo) {
 			valid := func() bool {
 				fieldAllowlist := map[string]struct{}{
@@ -368,6 +426,7 @@ func NewFlatJob(searchInputs *run.SearchInputs, f query.Flat) (job.Job, error) {
 					query.FieldRepoHasCommitAfter: {},
 					query.FieldPatternType:        {},
 					query.FieldSelect:             {},
+					query.FieldSort:               {},
 				}
 
 				// Don't run a repo search if the search contains fields that aren't on the allowlist.
@@ -458,7 +517,7 @@ func computeFileMatchLimit(b query.Basic, p search.Protocol) int {
 		return *count
 	}
 
-	if b.IsStructural() {
+	if b.IsStructural() || b.IsSyntax() {
 		return limits.DefaultMaxSearchResults
 	}
 
@@ -500,7 +559,7 @@ func count(b query.Basic, p search.Protocol) int {
 		return *count
 	}
 
-	if b.IsStructural() {
+	if b.IsStructural() || b.IsSyntax() {
 		return limits.DefaultMaxSearchResults
 	}
 
@@ -548,6 +607,7 @@ func toTextPatternInfo(b query.Basic, resultTypes result.Types, p search.Protoco
 		// Values dependent on pattern atom.
 		IsRegExp:        isRegexp,
 		IsStructuralPat: b.IsStructural(),
+		IsSyntaxPat:     b.IsSyntax(),
 		IsCaseSensitive: b.IsCaseSensitive(),
 		FileMatchLimit:  int32(count),
 		Pattern:         b.PatternString(),
@@ -574,6 +634,8 @@ func computeResultTypes(types []string, b query.Basic, searchType query.SearchTy
 	var rts result.Types
 	if searchType == query.SearchTypeStructural && !b.IsEmptyPattern() {
 		rts = result.TypeStructural
+	} else if searchType == query.SearchTypeSyntax && !b.IsEmptyPattern() {
+		rts = result.TypeSyntax
 	} else {
 		if len(types) == 0 {
 			rts = result.TypeFile | result.TypePath | result.TypeRepo
@@ -628,6 +690,8 @@ func toRepoOptions(b query.Basic, userSettings *schema.Settings) search.RepoOpti
 		Dependencies:        b.Dependencies(),
 		Dependents:          b.Dependents(),
 		DescriptionPatterns: b.RepoHasDescription(),
+		HasTopics:           b.RepoHasTopics(),
+		HasMeta:             b.RepoHasMeta(),
 		SearchContextSpec:   searchContextSpec,
 		ForkSet:             b.Fork() != nil,
 		OnlyForks:           fork == query.Only,
@@ -730,7 +794,12 @@ func (b *jobBuilder) newZoektSearch(typ search.IndexedRequestType) (job.Job, err
 
 func jobMode(b query.Basic, resultTypes result.Types, st query.SearchType, onSourcegraphDotCom bool) (repoUniverseSearch, skipRepoSubsetSearch, runZoektOverRepos bool) {
 	isGlobalSearch := func() bool {
-		if st == query.SearchTypeStructural {
+		if st == query.SearchTypeStructural || st == query.SearchTypeSyntax {
+			return false
+		}
+
+		if b.SymbolHistory() {
+			// Symbol history is not indexed by Zoekt.
 			return false
 		}
 
@@ -0,0 +1,174 @@
+package jobutil
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// isOwnersSelect returns true if path is `select:file.owners`.
+func isOwnersSelect(path filter.SelectPath) bool {
+	return len(path) == 2 && path.Root() == filter.File && path[1] == "owners"
+}
+
+// normalizeOwner normalizes an owner handle for comparison, so that
+// `owner:sourcegraph/search` matches `@sourcegraph/search`.
+func normalizeOwner(handle string) string {
+	return strings.ToLower(strings.TrimPrefix(handle, "@"))
+}
+
+// NewOwnerFilterJob creates a job that filters the file matches of its child
+// by the owners of their paths, as declared by the CODEOWNERS file of their
+// repository. A file match is kept if it is owned by all of include and none
+// of exclude. Other matches are dropped, since they have no single path.
+func NewOwnerFilterJob(include, exclude []string, child job.Job) job.Job {
+	return &ownerFilterJob{include: include, exclude: exclude, child: child}
+}
+
+type ownerFilterJob struct {
+	include []string
+	exclude []string
+	child   job.Job
+}
+
+func (j *ownerFilterJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	resolver := codeowners.NewResolver(clients.Gitserver)
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		filtered := event.Results[:0]
+		for _, match := range event.Results {
+			fm, ok := match.(*result.FileMatch)
+			if !ok {
+				continue
+			}
+			owners, err := resolver.Owners(ctx, fm.Repo.Name, fm.CommitID, fm.Path)
+			if err != nil {
+				mu.Lock()
+				errs = errors.Append(errs, err)
+				mu.Unlock()
+				continue
+			}
+			if j.matches(owners) {
+				filtered = append(filtered, match)
+			}
+		}
+		event.Results = filtered
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+// matches returns true if owners contains all included and none of the
+// excluded owners.
+func (j *ownerFilterJob) matches(owners []string) bool {
+	set := make(map[string]struct{}, len(owners))
+	for _, owner := range owners {
+		set[normalizeOwner(owner)] = struct{}{}
+	}
+	for _, owner := range j.include {
+		if _, ok := set[normalizeOwner(owner)]; !ok {
+			return false
+		}
+	}
+	for _, owner := range j.exclude {
+		if _, ok := set[normalizeOwner(owner)]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (j *ownerFilterJob) Name() string {
+	return "OwnerFilterJob"
+}
+
+func (j *ownerFilterJob) Fields(v job.Verbosity) (res []log.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Strings("include", j.include),
+			trace.Strings("exclude", j.exclude),
+		)
+	}
+	return res
+}
+
+func (j *ownerFilterJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *ownerFilterJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+// newOwnersSelectingStream returns a child Stream of parent that replaces
+// file matches with the owners of their paths, deduplicating owners. Errors
+// resolving owners are passed to onError.
+func newOwnersSelectingStream(ctx context.Context, parent streaming.Sender, resolver *codeowners.Resolver, onError func(error)) streaming.Sender {
+	var mux sync.Mutex
+	dedup := result.NewDeduper()
+
+	return streaming.StreamFunc(func(e streaming.SearchEvent) {
+		// Owners are resolved before taking the lock, as reading CODEOWNERS
+		// files must not serialize the events of concurrent backends.
+		var candidates []*result.OwnerMatch
+		for _, match := range e.Results {
+			fm, ok := match.Select(filter.SelectPath{filter.File, "owners"}).(*result.FileMatch)
+			if !ok {
+				continue
+			}
+
+			owners, err := resolver.Owners(ctx, fm.Repo.Name, fm.CommitID, fm.Path)
+			if err != nil {
+				onError(err)
+				continue
+			}
+			for _, owner := range owners {
+				candidates = append(candidates, &result.OwnerMatch{Handle: owner, Repo: fm.Repo, CommitID: fm.CommitID})
+			}
+		}
+
+		mux.Lock()
+
+		var selected result.Matches
+		for _, current := range candidates {
+			if dedup.Seen(current) {
+				continue
+			}
+			dedup.Add(current)
+			selected = append(selected, current)
+		}
+		e.Results = selected
+
+		mux.Unlock()
+		parent.Send(e)
+	})
+}
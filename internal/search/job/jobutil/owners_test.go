@@ -0,0 +1,82 @@
+package jobutil
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func newCodeownersMockClient() *gitserver.MockClient {
+	client := gitserver.NewMockClient()
+	client.ReadFileFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, _ api.CommitID, name string, _ authz.SubRepoPermissionChecker) ([]byte, error) {
+		if repo == "pokedex" && name == ".github/CODEOWNERS" {
+			return []byte("* @professor-oak\n/fire/ @Charizard misty@example.com\n"), nil
+		}
+		return nil, os.ErrNotExist
+	})
+	return client
+}
+
+func TestOwnersSelectingStream(t *testing.T) {
+	pokedex := types.MinimalRepo{ID: 1, Name: "pokedex"}
+	digidex := types.MinimalRepo{ID: 2, Name: "digidex"}
+
+	agg := streaming.NewAggregatingStream()
+	stream := newOwnersSelectingStream(context.Background(), agg, codeowners.NewResolver(newCodeownersMockClient()), func(err error) {
+		t.Fatal(err)
+	})
+	stream.Send(streaming.SearchEvent{
+		Results: []result.Match{
+			&result.FileMatch{File: result.File{Repo: pokedex, CommitID: "c1", Path: "fire/charmandar"}},
+			&result.FileMatch{File: result.File{Repo: pokedex, CommitID: "c1", Path: "fire/vulpix"}},
+			&result.FileMatch{File: result.File{Repo: pokedex, CommitID: "c1", Path: "grass/bulbasaur"}},
+			&result.FileMatch{File: result.File{Repo: digidex, CommitID: "c2", Path: "agumon"}},
+			&result.RepoMatch{Name: pokedex.Name, ID: pokedex.ID},
+		},
+	})
+
+	want := []result.Match{
+		&result.OwnerMatch{Handle: "@Charizard", Repo: pokedex, CommitID: "c1"},
+		&result.OwnerMatch{Handle: "misty@example.com", Repo: pokedex, CommitID: "c1"},
+		&result.OwnerMatch{Handle: "@professor-oak", Repo: pokedex, CommitID: "c1"},
+	}
+	if diff := cmp.Diff(want, []result.Match(agg.Results)); diff != "" {
+		t.Fatalf("unexpected owners (-want +got):\n%s", diff)
+	}
+}
+
+func TestOwnerFilterJobMatches(t *testing.T) {
+	owners := []string{"@sourcegraph/Search", "alice@example.com"}
+
+	tests := []struct {
+		name             string
+		include, exclude []string
+		want             bool
+	}{
+		{name: "no filters", want: true},
+		{name: "include with @", include: []string{"@sourcegraph/search"}, want: true},
+		{name: "include without @", include: []string{"sourcegraph/search"}, want: true},
+		{name: "include all", include: []string{"sourcegraph/search", "alice@example.com"}, want: true},
+		{name: "include other", include: []string{"sourcegraph/search", "bob@example.com"}, want: false},
+		{name: "exclude", exclude: []string{"sourcegraph/search"}, want: false},
+		{name: "exclude other", exclude: []string{"bob@example.com"}, want: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			j := &ownerFilterJob{include: test.include, exclude: test.exclude}
+			if got := j.matches(owners); got != test.want {
+				t.Fatalf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -6,12 +6,14 @@ import (
 
 	"github.com/opentracing/opentracing-go/log"
 
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // NewSelectJob creates a job that transforms streamed results with
@@ -29,6 +31,24 @@ func (j *selectJob) Run(ctx context.Context, clients job.RuntimeClients, stream
 	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
 	defer func() { finish(alert, err) }()
 
+	if isOwnersSelect(j.path) {
+		var (
+			mu   sync.Mutex
+			errs error
+		)
+		resolver := codeowners.NewResolver(clients.Gitserver)
+		ownersStream := newOwnersSelectingStream(ctx, stream, resolver, func(err error) {
+			mu.Lock()
+			errs = errors.Append(errs, err)
+			mu.Unlock()
+		})
+		alert, err = j.child.Run(ctx, clients, ownersStream)
+		if err != nil {
+			errs = errors.Append(errs, err)
+		}
+		return alert, errs
+	}
+
 	selectingStream := newSelectingStream(stream, j.path)
 	return j.child.Run(ctx, clients, selectingStream)
 }
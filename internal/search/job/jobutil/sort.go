@@ -0,0 +1,357 @@
+package jobutil
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// maxSortWindowSize bounds the number of results buffered by a sort job,
+	// so that results keep streaming when a search has many results.
+	maxSortWindowSize = 100
+
+	// lastCommitConcurrency is the number of last commits of matches that
+	// are looked up concurrently when sorting by recency.
+	lastCommitConcurrency = 8
+)
+
+// NewSortJob creates a job that streams the results of its child in the
+// order given by sortBy. Results are buffered in a window of windowSize
+// results. Once the window is full, every new result causes the first result
+// of the window to be sent. Results are therefore sorted exactly if the child
+// returns at most windowSize results, and approximately otherwise.
+func NewSortJob(sortBy query.SortBy, windowSize int, child job.Job) job.Job {
+	if _, ok := child.(*NoopJob); ok {
+		return child
+	}
+	return &sortJob{sortBy: sortBy, windowSize: windowSize, child: child}
+}
+
+type sortJob struct {
+	sortBy     query.SortBy
+	windowSize int
+	child      job.Job
+}
+
+func (j *sortJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	s := newSortingStream(ctx, clients, stream, j.sortBy, j.windowSize)
+	alert, err = j.child.Run(ctx, clients, s)
+	s.flush()
+	if sortErr := s.err(); sortErr != nil {
+		err = errors.Append(err, sortErr)
+	}
+	return alert, err
+}
+
+func (j *sortJob) Name() string {
+	return "SortJob"
+}
+
+func (j *sortJob) Fields(v job.Verbosity) (res []log.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			log.String("sortBy", string(j.sortBy)),
+			log.Int("windowSize", j.windowSize),
+		)
+	}
+	return res
+}
+
+func (j *sortJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *sortJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+// sortKey holds the values a match is sorted by. Only the value for the sort
+// order of the stream is set.
+type sortKey struct {
+	date  time.Time
+	stars int
+}
+
+type sortItem struct {
+	match result.Match
+	key   sortKey
+}
+
+// sortWindow is a heap of matches, whose first element is the match that is
+// sent first.
+type sortWindow struct {
+	sortBy query.SortBy
+	items  []sortItem
+}
+
+func (w *sortWindow) Len() int      { return len(w.items) }
+func (w *sortWindow) Swap(i, j int) { w.items[i], w.items[j] = w.items[j], w.items[i] }
+func (w *sortWindow) Push(x any)    { w.items = append(w.items, x.(sortItem)) }
+
+func (w *sortWindow) Pop() any {
+	last := w.items[len(w.items)-1]
+	w.items = w.items[:len(w.items)-1]
+	return last
+}
+
+func (w *sortWindow) Less(i, j int) bool {
+	a, b := w.items[i], w.items[j]
+	switch w.sortBy {
+	case query.SortByRecency:
+		if !a.key.date.Equal(b.key.date) {
+			return a.key.date.After(b.key.date)
+		}
+	case query.SortByStars:
+		if a.key.stars != b.key.stars {
+			return a.key.stars > b.key.stars
+		}
+	case query.SortByPath:
+		if ak, bk := a.match.Key(), b.match.Key(); ak.Path != bk.Path {
+			return ak.Path < bk.Path
+		}
+	}
+	return a.match.Key().Less(b.match.Key())
+}
+
+type sortingStream struct {
+	ctx        context.Context
+	clients    job.RuntimeClients
+	parent     streaming.Sender
+	windowSize int
+
+	mu     sync.Mutex
+	window sortWindow
+	errs   error
+
+	// Sort keys are cached, since the matches of a repository or file
+	// are often sent in several events.
+	cacheMu sync.Mutex
+	stars   map[api.RepoID]int
+	dates   map[lastCommitKey]time.Time
+}
+
+type lastCommitKey struct {
+	repo api.RepoName
+	rev  string
+	path string
+}
+
+func newSortingStream(ctx context.Context, clients job.RuntimeClients, parent streaming.Sender, sortBy query.SortBy, windowSize int) *sortingStream {
+	return &sortingStream{
+		ctx:        ctx,
+		clients:    clients,
+		parent:     parent,
+		windowSize: windowSize,
+		window:     sortWindow{sortBy: sortBy},
+		stars:      map[api.RepoID]int{},
+		dates:      map[lastCommitKey]time.Time{},
+	}
+}
+
+func (s *sortingStream) Send(event streaming.SearchEvent) {
+	keys, err := s.sortKeys(event.Results)
+	if err != nil {
+		s.appendErr(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, match := range event.Results {
+		heap.Push(&s.window, sortItem{match: match, key: keys[i]})
+	}
+
+	var sorted result.Matches
+	for s.window.Len() > s.windowSize {
+		sorted = append(sorted, heap.Pop(&s.window).(sortItem).match)
+	}
+	event.Results = sorted
+	s.parent.Send(event)
+}
+
+// flush sends the remaining matches of the window in order.
+func (s *sortingStream) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.window.Len() == 0 {
+		return
+	}
+	sorted := make(result.Matches, 0, s.window.Len())
+	for s.window.Len() > 0 {
+		sorted = append(sorted, heap.Pop(&s.window).(sortItem).match)
+	}
+	s.parent.Send(streaming.SearchEvent{Results: sorted})
+}
+
+func (s *sortingStream) appendErr(err error) {
+	s.mu.Lock()
+	s.errs = errors.Append(s.errs, err)
+	s.mu.Unlock()
+}
+
+func (s *sortingStream) err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.errs
+}
+
+// sortKeys returns the sort keys of matches. Matches whose key cannot be
+// determined get the zero key, so that they are still sent.
+func (s *sortingStream) sortKeys(matches result.Matches) ([]sortKey, error) {
+	keys := make([]sortKey, len(matches))
+
+	switch s.window.sortBy {
+	case query.SortByRecency:
+		err := s.fetchDates(matches)
+		s.cacheMu.Lock()
+		for i, match := range matches {
+			if m, ok := match.(*result.CommitMatch); ok {
+				keys[i].date = m.Commit.Author.Date
+			} else if key, ok := lastCommitKeyOf(match); ok {
+				keys[i].date = s.dates[key]
+			}
+		}
+		s.cacheMu.Unlock()
+		return keys, err
+
+	case query.SortByStars:
+		if err := s.fetchStars(matches); err != nil {
+			return keys, err
+		}
+		s.cacheMu.Lock()
+		for i, match := range matches {
+			keys[i].stars = s.stars[match.RepoName().ID]
+		}
+		s.cacheMu.Unlock()
+	}
+
+	return keys, nil
+}
+
+// lastCommitKeyOf returns the key of the last commit that modified the file
+// of a file match or the revision of a repo match.
+func lastCommitKeyOf(match result.Match) (lastCommitKey, bool) {
+	var key lastCommitKey
+	switch m := match.(type) {
+	case *result.FileMatch:
+		key = lastCommitKey{repo: m.Repo.Name, rev: string(m.CommitID), path: m.Path}
+	case *result.RepoMatch:
+		key = lastCommitKey{repo: m.Name, rev: m.Rev}
+	default:
+		return key, false
+	}
+	if key.rev == "" {
+		key.rev = "HEAD"
+	}
+	return key, true
+}
+
+// fetchDates caches the author dates of the last commits of the file and repo
+// matches of matches. The commits are looked up concurrently.
+func (s *sortingStream) fetchDates(matches result.Matches) error {
+	var keys []lastCommitKey
+	s.cacheMu.Lock()
+	for _, match := range matches {
+		key, ok := lastCommitKeyOf(match)
+		if !ok {
+			continue
+		}
+		if _, ok := s.dates[key]; !ok {
+			s.dates[key] = time.Time{}
+			keys = append(keys, key)
+		}
+	}
+	s.cacheMu.Unlock()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs error
+		sem  = make(chan struct{}, lastCommitConcurrency)
+	)
+	for _, key := range keys {
+		key := key
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			commits, err := s.clients.Gitserver.Commits(s.ctx, key.repo, gitserver.CommitsOptions{
+				Range: key.rev,
+				Path:  key.path,
+				N:     1,
+			}, authz.DefaultSubRepoPermsChecker)
+			if err != nil {
+				if s.ctx.Err() == nil {
+					mu.Lock()
+					errs = errors.Append(errs, err)
+					mu.Unlock()
+				}
+				return
+			}
+			if len(commits) > 0 {
+				s.cacheMu.Lock()
+				s.dates[key] = commits[0].Author.Date
+				s.cacheMu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// fetchStars caches the star counts of the repositories of matches.
+func (s *sortingStream) fetchStars(matches result.Matches) error {
+	var ids []api.RepoID
+	s.cacheMu.Lock()
+	for _, match := range matches {
+		id := match.RepoName().ID
+		if _, ok := s.stars[id]; !ok {
+			s.stars[id] = 0
+			ids = append(ids, id)
+		}
+	}
+	s.cacheMu.Unlock()
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	repos, err := s.clients.DB.Repos().Metadata(s.ctx, ids...)
+	if err != nil {
+		return err
+	}
+
+	s.cacheMu.Lock()
+	for _, repo := range repos {
+		s.stars[repo.ID] = repo.Stars
+	}
+	s.cacheMu.Unlock()
+	return nil
+}
@@ -0,0 +1,122 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSortingStream(t *testing.T) {
+	pokedex := types.MinimalRepo{ID: 1, Name: "pokedex"}
+	digidex := types.MinimalRepo{ID: 2, Name: "digidex"}
+
+	lastModified := map[string]time.Time{
+		"charmander": time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		"bulbasaur":  time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+		"agumon":     time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.CommitsFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, opt gitserver.CommitsOptions, _ authz.SubRepoPermissionChecker) ([]*gitdomain.Commit, error) {
+		return []*gitdomain.Commit{{Author: gitdomain.Signature{Date: lastModified[opt.Path]}}}, nil
+	})
+
+	repos := database.NewMockRepoStore()
+	repos.MetadataFunc.SetDefaultReturn([]*types.SearchedRepo{
+		{ID: pokedex.ID, Name: pokedex.Name, Stars: 10},
+		{ID: digidex.ID, Name: digidex.Name, Stars: 20},
+	}, nil)
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	clients := job.RuntimeClients{DB: db, Gitserver: gitserverClient}
+
+	fileMatch := func(repo types.MinimalRepo, path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{Repo: repo, CommitID: "c", Path: path}}
+	}
+
+	test := func(sortBy query.SortBy, windowSize int) []string {
+		agg := streaming.NewAggregatingStream()
+		s := newSortingStream(context.Background(), clients, agg, sortBy, windowSize)
+		s.Send(streaming.SearchEvent{Results: result.Matches{
+			fileMatch(pokedex, "charmander"),
+			fileMatch(digidex, "agumon"),
+		}})
+		s.Send(streaming.SearchEvent{Results: result.Matches{
+			fileMatch(pokedex, "bulbasaur"),
+		}})
+		s.flush()
+		if err := s.err(); err != nil {
+			t.Fatal(err)
+		}
+
+		var paths []string
+		for _, match := range agg.Results {
+			paths = append(paths, match.(*result.FileMatch).Path)
+		}
+		return paths
+	}
+
+	tests := []struct {
+		sortBy     query.SortBy
+		windowSize int
+		want       []string
+	}{
+		{sortBy: query.SortByPath, windowSize: 10, want: []string{"agumon", "bulbasaur", "charmander"}},
+		{sortBy: query.SortByRecency, windowSize: 10, want: []string{"bulbasaur", "agumon", "charmander"}},
+		{sortBy: query.SortByStars, windowSize: 10, want: []string{"agumon", "bulbasaur", "charmander"}},
+
+		// With a window of one result, agumon is sent before bulbasaur
+		// has been seen.
+		{sortBy: query.SortByRecency, windowSize: 1, want: []string{"agumon", "bulbasaur", "charmander"}},
+	}
+	for _, tc := range tests {
+		t.Run(string(tc.sortBy), func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, test(tc.sortBy, tc.windowSize)); diff != "" {
+				t.Fatalf("unexpected order (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSortingStreamLastCommits(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "pokedex"}
+
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.CommitsFunc.SetDefaultReturn([]*gitdomain.Commit{{Author: gitdomain.Signature{Date: time.Now()}}}, nil)
+	clients := job.RuntimeClients{Gitserver: gitserverClient}
+
+	var matches result.Matches
+	for _, path := range []string{"a", "b", "c", "a", "b"} {
+		matches = append(matches, &result.FileMatch{File: result.File{Repo: repo, CommitID: "c", Path: path}})
+	}
+
+	agg := streaming.NewAggregatingStream()
+	s := newSortingStream(context.Background(), clients, agg, query.SortByRecency, 10)
+	s.Send(streaming.SearchEvent{Results: matches})
+	s.Send(streaming.SearchEvent{Results: matches[:1]})
+	s.flush()
+	if err := s.err(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The last commit of each file is looked up once.
+	if got, want := len(gitserverClient.CommitsFunc.History()), 3; got != want {
+		t.Fatalf("unexpected number of Commits calls. want=%d have=%d", want, got)
+	}
+	if got, want := len(agg.Results), 6; got != want {
+		t.Fatalf("unexpected number of results. want=%d have=%d", want, got)
+	}
+}
@@ -19,7 +19,17 @@ func StartSpan(ctx context.Context, stream streaming.Sender, job Job) (*trace.Tr
 
 	observingStream := newObservingStream(tr, stream)
 
+	// Profiling is opt-in per search, so the job is only profiled if the
+	// search is.
+	if parent := ProfileFromContext(ctx); parent != nil {
+		observingStream.profile = parent.startChild(job)
+		ctx = ContextWithProfile(ctx, observingStream.profile)
+	}
+
 	return tr, ctx, observingStream, func(alert *search.Alert, err error) {
+		if observingStream.profile != nil {
+			observingStream.profile.finish(alert, err)
+		}
 		tr.SetError(err)
 		if alert != nil {
 			tr.TagFields(log.String("alert", alert.Title))
@@ -37,6 +47,9 @@ type observingStream struct {
 	tr          *trace.Trace
 	parent      streaming.Sender
 	totalEvents atomic.Int64
+
+	// profile is the profile of the job, if the search is profiled.
+	profile *Profile
 }
 
 func (o *observingStream) Send(event streaming.SearchEvent) {
@@ -48,5 +61,8 @@ func (o *observingStream) Send(event streaming.SearchEvent) {
 			o.tr.LogFields(log.Event("first results"))
 		}
 	}
+	if o.profile != nil {
+		o.profile.observe(&event)
+	}
 	o.parent.Send(event)
 }
@@ -29,12 +29,18 @@ type node struct {
 	children []node
 }
 
-func (n node) params() map[string]interface{} {
+// FieldsMap returns the values of the fields of a job by their keys.
+func FieldsMap(fields []otlog.Field) map[string]interface{} {
 	m := make(map[string]interface{})
 	enc := jsonFieldEncoder{&m}
-	for _, field := range n.tags {
+	for _, field := range fields {
 		field.Marshal(enc)
 	}
+	return m
+}
+
+func (n node) params() map[string]interface{} {
+	m := FieldsMap(n.tags)
 	seenJobNames := map[string]int{}
 	for _, child := range n.children {
 		key := child.name
@@ -0,0 +1,89 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// Profile is the timing of a job that ran, and of the jobs it ran. Jobs are
+// profiled if the context they are run with has a profile, see
+// ContextWithProfile.
+//
+// The fields of a profile must only be read after the job and all of its
+// children finished running.
+type Profile struct {
+	Name   string
+	Fields []otlog.Field
+
+	Start    time.Time
+	Duration time.Duration
+
+	// ResultCount is the number of results the job sent.
+	ResultCount int
+
+	// Stats are the stats of the events the job sent.
+	Stats streaming.Stats
+
+	Alert *search.Alert
+	Err   error
+
+	mu       sync.Mutex
+	Children []*Profile
+}
+
+// NewProfile returns the root of a profile. The jobs run with a context
+// returned by ContextWithProfile are its children.
+func NewProfile() *Profile {
+	return &Profile{Start: time.Now()}
+}
+
+type profileKey struct{}
+
+// ContextWithProfile returns a context that profiles the jobs run with it as
+// children of p.
+func ContextWithProfile(ctx context.Context, p *Profile) context.Context {
+	return context.WithValue(ctx, profileKey{}, p)
+}
+
+// ProfileFromContext returns the profile of the job running with ctx, or nil if
+// jobs are not profiled.
+func ProfileFromContext(ctx context.Context) *Profile {
+	p, _ := ctx.Value(profileKey{}).(*Profile)
+	return p
+}
+
+// startChild starts the profile of a job run by the job of p.
+func (p *Profile) startChild(j Describer) *Profile {
+	child := &Profile{
+		Name:   j.Name(),
+		Fields: j.Fields(VerbosityBasic),
+		Start:  time.Now(),
+	}
+	p.mu.Lock()
+	p.Children = append(p.Children, child)
+	p.mu.Unlock()
+	return child
+}
+
+// observe records an event the job of p sent.
+func (p *Profile) observe(event *streaming.SearchEvent) {
+	p.mu.Lock()
+	p.ResultCount += len(event.Results)
+	p.Stats.Update(&event.Stats)
+	p.mu.Unlock()
+}
+
+// finish records the outcome of the job of p.
+func (p *Profile) finish(alert *search.Alert, err error) {
+	p.mu.Lock()
+	p.Duration = time.Since(p.Start)
+	p.Alert = alert
+	p.Err = err
+	p.mu.Unlock()
+}
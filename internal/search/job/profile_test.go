@@ -0,0 +1,71 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestProfile(t *testing.T) {
+	// Mock jobs do not start spans, so they are profiled as part of the
+	// job that runs them.
+	indexed := mockjob.NewMockJob()
+	indexed.NameFunc.SetDefaultReturn("IndexedJob")
+	indexed.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		var status search.RepoStatusMap
+		status.Update(2, search.RepoStatusTimedout)
+		s.Send(streaming.SearchEvent{
+			Results: result.Matches{&result.FileMatch{}, &result.FileMatch{}},
+			Stats: streaming.Stats{
+				Repos:  map[api.RepoID]struct{}{1: {}, 2: {}},
+				Status: status,
+			},
+		})
+		return nil, nil
+	})
+	unindexed := mockjob.NewMockJob()
+	unindexed.NameFunc.SetDefaultReturn("UnindexedJob")
+	unindexed.RunFunc.SetDefaultReturn(nil, errors.New("searcher unavailable"))
+
+	limitJob := jobutil.NewLimitJob(10, jobutil.NewParallelJob(indexed, unindexed))
+
+	t.Run("not profiled", func(t *testing.T) {
+		ctx := context.Background()
+		require.Nil(t, job.ProfileFromContext(ctx))
+		_, _ = limitJob.Run(ctx, job.RuntimeClients{}, streaming.NewAggregatingStream())
+	})
+
+	t.Run("profiled", func(t *testing.T) {
+		profile := job.NewProfile()
+		ctx := job.ContextWithProfile(context.Background(), profile)
+		_, err := limitJob.Run(ctx, job.RuntimeClients{}, streaming.NewAggregatingStream())
+		require.Error(t, err)
+
+		require.Len(t, profile.Children, 1)
+		limit := profile.Children[0]
+		require.Equal(t, "LimitJob", limit.Name)
+		require.Contains(t, limit.Fields, otlog.Int("limit", 10))
+		require.Equal(t, 2, limit.ResultCount)
+		require.Len(t, limit.Stats.Repos, 2)
+		require.True(t, limit.Stats.Status.Any(search.RepoStatusTimedout))
+		require.Error(t, limit.Err)
+
+		require.Len(t, limit.Children, 1)
+		parallel := limit.Children[0]
+		require.Equal(t, "ParallelJob", parallel.Name)
+		require.Equal(t, 2, parallel.ResultCount)
+		require.Empty(t, parallel.Children)
+		require.ErrorContains(t, parallel.Err, "searcher unavailable")
+	})
+}
@@ -0,0 +1,128 @@
+package predicate
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const (
+	// historyConcurrency is the number of repositories whose history is
+	// searched concurrently.
+	historyConcurrency = 8
+
+	// lastModifiedBatchSize is the maximum number of paths passed to a single
+	// git log command.
+	lastModifiedBatchSize = 500
+)
+
+// evaluateHistory evaluates a file predicate that matches files by their
+// history. matches are the repos found by the plan of the predicate. It
+// returns a file match for every file that satisfies pred in each repo.
+func evaluateHistory(ctx context.Context, client gitserver.Client, pred query.Predicate, matches result.Matches) (result.Matches, error) {
+	var (
+		mu    sync.Mutex
+		files result.Matches
+		sem   = make(chan struct{}, historyConcurrency)
+	)
+	g, ctx := errgroup.WithContext(ctx)
+
+	for _, match := range matches {
+		repoMatch, ok := match.(*result.RepoMatch)
+		if !ok {
+			continue
+		}
+
+		g.Go(func() error {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			rev := repoMatch.Rev
+			if rev == "" {
+				rev = "HEAD"
+			}
+
+			var (
+				paths map[string]*gitdomain.Commit
+				err   error
+			)
+			switch p := pred.(type) {
+			case *query.FileHasCommitAfterPredicate:
+				paths, err = client.LastModified(ctx, repoMatch.Name, rev, gitserver.LastModifiedOptions{After: p.TimeRef}, authz.DefaultSubRepoPermsChecker)
+			case *query.FileHasAuthorPredicate:
+				paths, err = lastModifiedByAuthor(ctx, client, repoMatch, rev, p.Author)
+			}
+			if err != nil {
+				return err
+			}
+
+			repoFiles := make(result.Matches, 0, len(paths))
+			for path := range paths {
+				fm := &result.FileMatch{File: result.File{
+					Repo: types.MinimalRepo{ID: repoMatch.ID, Name: repoMatch.Name},
+					Path: path,
+				}}
+				if repoMatch.Rev != "" {
+					rev := repoMatch.Rev
+					fm.InputRev = &rev
+				}
+				repoFiles = append(repoFiles, fm)
+			}
+
+			mu.Lock()
+			files = append(files, repoFiles...)
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// lastModifiedByAuthor returns the paths of a repo whose last commit is
+// authored by author. The candidates are the paths modified by author, whose
+// last commit is then looked up in bulk.
+func lastModifiedByAuthor(ctx context.Context, client gitserver.Client, repo *result.RepoMatch, rev, author string) (map[string]*gitdomain.Commit, error) {
+	byAuthor, err := client.LastModified(ctx, repo.Name, rev, gitserver.LastModifiedOptions{Author: author}, authz.DefaultSubRepoPermsChecker)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]string, 0, len(byAuthor))
+	for path := range byAuthor {
+		candidates = append(candidates, path)
+	}
+
+	paths := make(map[string]*gitdomain.Commit, len(byAuthor))
+	for len(candidates) > 0 {
+		batch := candidates
+		if len(batch) > lastModifiedBatchSize {
+			batch = batch[:lastModifiedBatchSize]
+		}
+		candidates = candidates[len(batch):]
+
+		last, err := client.LastModified(ctx, repo.Name, rev, gitserver.LastModifiedOptions{Paths: batch}, authz.DefaultSubRepoPermsChecker)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range batch {
+			// The last commit of the path is by author if it is the most
+			// recent commit of author that modified the path.
+			if commit, ok := last[path]; ok && commit.ID == byAuthor[path].ID {
+				paths[path] = commit
+			}
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,79 @@
+package predicate
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestEvaluateHistory(t *testing.T) {
+	// In the history of pokedex, misty last modified water/psyduck, and ash
+	// modified water/squirtle before misty did.
+	client := gitserver.NewMockClient()
+	client.LastModifiedFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, rev string, opt gitserver.LastModifiedOptions, _ authz.SubRepoPermissionChecker) (map[string]*gitdomain.Commit, error) {
+		if repo != "pokedex" || rev != "HEAD" {
+			return nil, nil
+		}
+		switch {
+		case opt.Author == "misty":
+			return map[string]*gitdomain.Commit{
+				"water/psyduck":  {ID: "c3"},
+				"water/squirtle": {ID: "c3"},
+			}, nil
+		case opt.After != "":
+			return map[string]*gitdomain.Commit{
+				"water/psyduck":  {ID: "c3"},
+				"water/squirtle": {ID: "c4"},
+			}, nil
+		}
+		commits := map[string]*gitdomain.Commit{}
+		for _, path := range opt.Paths {
+			switch path {
+			case "water/psyduck":
+				commits[path] = &gitdomain.Commit{ID: "c3"}
+			case "water/squirtle":
+				commits[path] = &gitdomain.Commit{ID: "c4"}
+			}
+		}
+		return commits, nil
+	})
+
+	test := func(pred query.Predicate) []string {
+		matches, err := evaluateHistory(context.Background(), client, pred, result.Matches{
+			&result.RepoMatch{Name: "pokedex", ID: 1},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var paths []string
+		for _, match := range matches {
+			fm := match.(*result.FileMatch)
+			paths = append(paths, string(fm.Repo.Name)+"/"+fm.Path)
+		}
+		sort.Strings(paths)
+		return paths
+	}
+
+	t.Run("has.author", func(t *testing.T) {
+		want := []string{"pokedex/water/psyduck"}
+		if diff := cmp.Diff(want, test(&query.FileHasAuthorPredicate{Author: "misty"})); diff != "" {
+			t.Fatalf("unexpected files (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("has.commit.after", func(t *testing.T) {
+		want := []string{"pokedex/water/psyduck", "pokedex/water/squirtle"}
+		if diff := cmp.Diff(want, test(&query.FileHasCommitAfterPredicate{TimeRef: "2 weeks ago"})); diff != "" {
+			t.Fatalf("unexpected files (-want +got):\n%s", diff)
+		}
+	})
+}
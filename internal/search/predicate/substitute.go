@@ -37,7 +37,7 @@ func Expand(ctx context.Context, clients job.RuntimeClients, inputs *run.SearchI
 	for _, q := range oldPlan {
 		q := q
 		g.Go(func() error {
-			predicatePlan, err := Substitute(q, func(plan query.Plan) (result.Matches, error) {
+			predicatePlan, err := Substitute(q, func(pred query.Predicate, plan query.Plan) (result.Matches, error) {
 				predicateJob, err := jobutil.NewPlanJob(inputs, plan)
 				if err != nil {
 					return nil, err
@@ -49,6 +49,12 @@ func Expand(ctx context.Context, clients job.RuntimeClients, inputs *run.SearchI
 					return nil, err
 				}
 
+				switch pred.(type) {
+				case *query.FileHasCommitAfterPredicate, *query.FileHasAuthorPredicate:
+					// The plan only finds the candidate repos, whose history
+					// determines the matching files.
+					return evaluateHistory(ctx, clients.Gitserver, pred, agg.Results)
+				}
 				return agg.Results, nil
 			})
 			if errors.Is(err, ErrNoResults) {
@@ -78,7 +84,8 @@ func Expand(ctx context.Context, clients job.RuntimeClients, inputs *run.SearchI
 }
 
 // Substitute replaces predicates that generate plans and substitutes them to create a new Plan.
-func Substitute(q query.Basic, evaluate func(query.Plan) (result.Matches, error)) (query.Plan, error) {
+// evaluate is called with each such predicate and its plan.
+func Substitute(q query.Basic, evaluate func(query.Predicate, query.Plan) (result.Matches, error)) (query.Plan, error) {
 	var topErr error
 	success := false
 	newQ := query.MapParameter(q.ToParseTree(), func(field, value string, neg bool, ann query.Annotation) query.Node {
@@ -108,7 +115,7 @@ func Substitute(q query.Basic, evaluate func(query.Plan) (result.Matches, error)
 		if plan == nil {
 			return orig
 		}
-		matches, err := evaluate(plan)
+		matches, err := evaluate(predicate, plan)
 		if err != nil {
 			topErr = err
 			return nil
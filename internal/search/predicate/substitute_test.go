@@ -102,7 +102,7 @@ func TestSubstitute(t *testing.T) {
 	test := func(input string) string {
 		q, _ := query.ParseLiteral(input)
 		b, _ := query.ToBasicQuery(q)
-		plan, _ := Substitute(b, func(p query.Plan) (result.Matches, error) {
+		plan, _ := Substitute(b, func(_ query.Predicate, _ query.Plan) (result.Matches, error) {
 			return []result.Match{&result.RepoMatch{Name: "contains-foo"}}, nil
 		})
 		return query.StringHuman(plan.ToQ())
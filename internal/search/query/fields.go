@@ -19,6 +19,7 @@ const (
 	FieldVisibility         = "visibility"
 	FieldRev                = "rev"
 	FieldContext            = "context"
+	FieldOwner              = "owner"
 
 	// For diff and commit search only:
 	FieldBefore    = "before"
@@ -27,12 +28,16 @@ const (
 	FieldCommitter = "committer"
 	FieldMessage   = "message"
 
+	// For symbol search only:
+	FieldSymbolHistory = "symbol.history"
+
 	// Temporary experimental fields:
 	FieldIndex     = "index"
 	FieldCount     = "count" // Searches that specify `count:` will fetch at least that number of results, or the full result set
 	FieldTimeout   = "timeout"
 	FieldCombyRule = "rule"
 	FieldSelect    = "select"
+	FieldSort      = "sort"
 )
 
 var allFields = map[string]struct{}{
@@ -40,6 +45,7 @@ var allFields = map[string]struct{}{
 	FieldRepo:               empty,
 	"r":                     empty,
 	FieldContext:            empty,
+	FieldOwner:              empty,
 	"g":                     empty,
 	FieldFile:               empty,
 	"f":                     empty,
@@ -71,6 +77,8 @@ var allFields = map[string]struct{}{
 	FieldRev:                empty,
 	"revision":              empty,
 	FieldSelect:             empty,
+	FieldSort:               empty,
+	FieldSymbolHistory:      empty,
 }
 
 var aliases = map[string]string{
@@ -575,7 +575,8 @@ loop:
 }
 
 // ScanField scans an optional '-' at the beginning of a string, and then scans
-// one or more alphabetic characters until it encounters a ':'. The prefix
+// one or more alphabetic characters, which may be separated by '.' as in
+// `symbol.history`, until it encounters a ':'. The prefix
 // string is checked against valid fields. If it is valid, the function returns
 // the value before the colon, whether it's negated, and its length. In all
 // other cases it returns zero values.
@@ -601,7 +602,7 @@ func ScanField(buf []byte) (string, bool, int) {
 	success := false
 	for len(buf) > 0 {
 		r = next()
-		if strings.ContainsRune(allowed, r) {
+		if strings.ContainsRune(allowed, r) || (r == '.' && len(result) > 0 && result[len(result)-1] != '-') {
 			result = append(result, r)
 			continue
 		}
@@ -1071,7 +1072,7 @@ func (p *parser) parseAnd() ([]Node, error) {
 	switch p.leafParser {
 	case SearchTypeRegex:
 		left, err = p.parseLeaves(Regexp)
-	case SearchTypeLiteral, SearchTypeStructural:
+	case SearchTypeLiteral, SearchTypeStructural, SearchTypeSyntax:
 		left, err = p.parseLeaves(Literal)
 	case SearchTypeStandard, SearchTypeLucky:
 		left, err = p.parseLeaves(Literal | Standard)
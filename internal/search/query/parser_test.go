@@ -274,6 +274,10 @@ func TestScanField(t *testing.T) {
 	autogold.Want("-repo", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test("-repo"))
 	autogold.Want("--repo:", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test("--repo:"))
 	autogold.Want(":foo", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test(":foo"))
+	autogold.Want("symbol.history:yes", `{"Field":"symbol.history","Negated":false,"Advance":15}`).Equal(t, test("symbol.history:yes"))
+	autogold.Want("-symbol.history:yes", `{"Field":"symbol.history","Negated":true,"Advance":16}`).Equal(t, test("-symbol.history:yes"))
+	autogold.Want("foo.bar:baz", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test("foo.bar:baz"))
+	autogold.Want("-.repo:", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test("-.repo:"))
 }
 
 func parseAndOrGrammar(in string) ([]Node, error) {
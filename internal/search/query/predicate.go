@@ -41,10 +41,14 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"dependents":            func() Predicate { return &RepoDependentsPredicate{} },
 		"revdeps":               func() Predicate { return &RepoDependentsPredicate{} },
 		"has.description":       func() Predicate { return &RepoHasDescriptionPredicate{} },
+		"has.topic":             func() Predicate { return &RepoHasTopicPredicate{} },
+		"has.meta":              func() Predicate { return &RepoHasMetaPredicate{} },
 	},
 	FieldFile: {
 		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
 		"contains":         func() Predicate { return &FileContainsContentPredicate{} },
+		"has.commit.after": func() Predicate { return &FileHasCommitAfterPredicate{} },
+		"has.author":       func() Predicate { return &FileHasAuthorPredicate{} },
 	},
 }
 
@@ -342,6 +346,51 @@ func (f *RepoHasDescriptionPredicate) Plan(parent Basic) (Plan, error) {
 	return nil, nil
 }
 
+/* repo:has.topic(...) */
+
+type RepoHasTopicPredicate struct {
+	Topic string
+}
+
+func (f *RepoHasTopicPredicate) ParseParams(params string) (err error) {
+	if len(params) == 0 {
+		return errors.New("empty repo:has.topic() predicate parameter")
+	}
+	f.Topic = params
+	return nil
+}
+
+func (f *RepoHasTopicPredicate) Field() string { return FieldRepo }
+func (f *RepoHasTopicPredicate) Name() string  { return "has.topic" }
+func (f *RepoHasTopicPredicate) Plan(parent Basic) (Plan, error) {
+	return nil, nil
+}
+
+/* repo:has.meta(key) or repo:has.meta(key:value) */
+
+type RepoHasMetaPredicate struct {
+	Key   string
+	Value *string
+}
+
+func (f *RepoHasMetaPredicate) ParseParams(params string) (err error) {
+	key, value, hasValue := strings.Cut(params, ":")
+	if len(key) == 0 {
+		return errors.Errorf("invalid repo:has.meta() argument %q: key must not be empty", params)
+	}
+	f.Key = key
+	if hasValue {
+		f.Value = &value
+	}
+	return nil
+}
+
+func (f *RepoHasMetaPredicate) Field() string { return FieldRepo }
+func (f *RepoHasMetaPredicate) Name() string  { return "has.meta" }
+func (f *RepoHasMetaPredicate) Plan(parent Basic) (Plan, error) {
+	return nil, nil
+}
+
 /* repo:contains.content(pattern) */
 
 type FileContainsContentPredicate struct {
@@ -379,6 +428,89 @@ func (f *FileContainsContentPredicate) Plan(parent Basic) (Plan, error) {
 	return BuildPlan(nodes), nil
 }
 
+/* file:has.commit.after(...) */
+
+// FileHasCommitAfterPredicate matches the files modified by a commit after
+// TimeRef. Its plan finds the repos with such commits. The files are then
+// looked up in the history of each repo.
+type FileHasCommitAfterPredicate struct {
+	TimeRef string
+}
+
+func (f *FileHasCommitAfterPredicate) ParseParams(params string) error {
+	if params == "" {
+		return errors.New("empty file:has.commit.after() predicate parameter")
+	}
+	f.TimeRef = params
+	return nil
+}
+
+func (f *FileHasCommitAfterPredicate) Field() string { return FieldFile }
+func (f *FileHasCommitAfterPredicate) Name() string  { return "has.commit.after" }
+func (f *FileHasCommitAfterPredicate) Plan(parent Basic) (Plan, error) {
+	nodes := []Node{
+		Parameter{
+			Field: FieldSelect,
+			Value: "repo",
+		},
+		Parameter{
+			Field: FieldCount,
+			Value: "99999",
+		},
+		Parameter{
+			Field:      FieldRepo,
+			Value:      "contains.commit.after(" + f.TimeRef + ")",
+			Annotation: Annotation{Labels: IsPredicate},
+		},
+	}
+
+	nodes = append(nodes, nonPredicateRepos(parent)...)
+	return BuildPlan(nodes), nil
+}
+
+/* file:has.author(...) */
+
+// FileHasAuthorPredicate matches the files whose last commit is authored by
+// Author. Its plan finds the repos with commits by Author. The files are then
+// looked up in the history of each repo.
+type FileHasAuthorPredicate struct {
+	Author string
+}
+
+func (f *FileHasAuthorPredicate) ParseParams(params string) error {
+	if params == "" {
+		return errors.New("empty file:has.author() predicate parameter")
+	}
+	f.Author = params
+	return nil
+}
+
+func (f *FileHasAuthorPredicate) Field() string { return FieldFile }
+func (f *FileHasAuthorPredicate) Name() string  { return "has.author" }
+func (f *FileHasAuthorPredicate) Plan(parent Basic) (Plan, error) {
+	nodes := []Node{
+		Parameter{
+			Field: FieldSelect,
+			Value: "repo",
+		},
+		Parameter{
+			Field: FieldCount,
+			Value: "99999",
+		},
+		Parameter{
+			Field: FieldType,
+			Value: "commit",
+		},
+		Parameter{
+			Field: FieldAuthor,
+			Value: f.Author,
+		},
+	}
+
+	nodes = append(nodes, nonPredicateRepos(parent)...)
+	return BuildPlan(nodes), nil
+}
+
 // nonPredicateRepos returns the repo nodes in a query that aren't predicates,
 // respecting parameters that determine repo results.
 func nonPredicateRepos(q Basic) []Node {
@@ -213,3 +213,87 @@ func TestRepoHasDescriptionPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestRepoHasMetaPredicate(t *testing.T) {
+	t.Run("ParseParams", func(t *testing.T) {
+		value := func(s string) *string { return &s }
+
+		type test struct {
+			name     string
+			params   string
+			expected *RepoHasMetaPredicate
+		}
+
+		valid := []test{
+			{`key`, `team`, &RepoHasMetaPredicate{Key: "team"}},
+			{`key and value`, `team:search`, &RepoHasMetaPredicate{Key: "team", Value: value("search")}},
+			{`empty value`, `team:`, &RepoHasMetaPredicate{Key: "team", Value: value("")}},
+			{`value with colon`, `owner:a:b`, &RepoHasMetaPredicate{Key: "owner", Value: value("a:b")}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RepoHasMetaPredicate{}
+				err := p.ParseParams(tc.params)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, nil},
+			{`empty key`, `:search`, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RepoHasMetaPredicate{}
+				err := p.ParseParams(tc.params)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
+
+func TestFileHistoryPredicatePlans(t *testing.T) {
+	parent, err := ParseLiteral("repo:pokedex file:has.author(misty) water")
+	if err != nil {
+		t.Fatal(err)
+	}
+	basic, err := ToBasicQuery(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		pred Predicate
+		want string
+	}{
+		{
+			pred: &FileHasAuthorPredicate{Author: "misty"},
+			want: "select:repo count:99999 type:commit author:misty repo:pokedex",
+		},
+		{
+			pred: &FileHasCommitAfterPredicate{TimeRef: "2 weeks ago"},
+			want: "select:repo count:99999 repo:contains.commit.after(2 weeks ago) repo:pokedex",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.pred.Name(), func(t *testing.T) {
+			plan, err := tc.pred.Plan(basic)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := StringHuman(plan.ToQ()); got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
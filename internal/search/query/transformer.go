@@ -824,6 +824,20 @@ func labelStructural(nodes []Node) []Node {
 	})
 }
 
+// labelSyntax converts Literal labels to Syntax labels. Like structural
+// queries, syntax queries are parsed the same as literal queries.
+func labelSyntax(nodes []Node) []Node {
+	return MapPattern(nodes, func(value string, negated bool, annotation Annotation) Node {
+		annotation.Labels.Unset(Literal)
+		annotation.Labels.Set(Syntax)
+		return Pattern{
+			Value:      value,
+			Negated:    negated,
+			Annotation: annotation,
+		}
+	})
+}
+
 // ellipsesForHoles substitutes ellipses ... for :[_] holes in structural search queries.
 func ellipsesForHoles(nodes []Node) []Node {
 	return MapPattern(nodes, func(value string, negated bool, annotation Annotation) Node {
@@ -35,6 +35,7 @@ const (
 	SearchTypeStructural
 	SearchTypeLucky
 	SearchTypeStandard
+	SearchTypeSyntax
 )
 
 func (s SearchType) String() string {
@@ -49,6 +50,8 @@ func (s SearchType) String() string {
 		return "structural"
 	case SearchTypeLucky:
 		return "lucky"
+	case SearchTypeSyntax:
+		return "syntax"
 	default:
 		return fmt.Sprintf("unknown{%d}", s)
 	}
@@ -131,6 +134,19 @@ func (q Q) yesNoOnlyValue(field string) *YesNoOnly {
 	return res
 }
 
+// SortBy returns the value of `sort:`, or nil if results are unsorted.
+func (q Q) SortBy() *SortBy {
+	var res *SortBy
+	VisitField(q, FieldSort, func(value string, _ bool, _ Annotation) {
+		sortBy := parseSortBy(value)
+		if sortBy == SortByInvalid {
+			panic(fmt.Sprintf("Invalid value %q for field %q", value, FieldSort))
+		}
+		res = &sortBy
+	})
+	return res
+}
+
 func (q Q) IsCaseSensitive() bool {
 	return q.BoolValue("case")
 }
@@ -289,6 +305,10 @@ func (b Basic) IsStructural() bool {
 	return b.HasPatternLabel(Structural)
 }
 
+func (b Basic) IsSyntax() bool {
+	return b.HasPatternLabel(Syntax)
+}
+
 // PatternString returns the simple string pattern of a basic query. It assumes
 // there is only on pattern atom.
 func (b Basic) PatternString() string {
@@ -401,6 +421,34 @@ func (p Parameters) RepoHasDescription() (descriptionPatterns []string) {
 	return descriptionPatterns
 }
 
+// RepoHasTopicFilter is a repo:has.topic() predicate of a query.
+type RepoHasTopicFilter struct {
+	Topic   string
+	Negated bool
+}
+
+func (p Parameters) RepoHasTopics() (filters []RepoHasTopicFilter) {
+	VisitTypedPredicate(toNodes(p), func(pred *RepoHasTopicPredicate, negated bool) {
+		filters = append(filters, RepoHasTopicFilter{Topic: pred.Topic, Negated: negated})
+	})
+	return filters
+}
+
+// RepoHasMetaFilter is a repo:has.meta() predicate of a query. A nil Value
+// matches any value of Key.
+type RepoHasMetaFilter struct {
+	Key     string
+	Value   *string
+	Negated bool
+}
+
+func (p Parameters) RepoHasMeta() (filters []RepoHasMetaFilter) {
+	VisitTypedPredicate(toNodes(p), func(pred *RepoHasMetaPredicate, negated bool) {
+		filters = append(filters, RepoHasMetaFilter{Key: pred.Key, Value: pred.Value, Negated: negated})
+	})
+	return filters
+}
+
 func (p Parameters) MaxResults(defaultLimit int) int {
 	if count := p.Count(); count != nil {
 		return *count
@@ -458,6 +506,12 @@ func (p Parameters) IsCaseSensitive() bool {
 	return p.boolValue(FieldCase)
 }
 
+// SymbolHistory returns whether the query asks for the history of symbols with
+// symbol.history:yes.
+func (p Parameters) SymbolHistory() bool {
+	return p.boolValue(FieldSymbolHistory)
+}
+
 func (p Parameters) yesNoOnlyValue(field string) *YesNoOnly {
 	var res *YesNoOnly
 	VisitField(toNodes(p), field, func(value string, _ bool, _ Annotation) {
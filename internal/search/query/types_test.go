@@ -27,3 +27,27 @@ func TestRepoHasDescription(t *testing.T) {
 
 	require.Equal(t, want, ps.RepoHasDescription())
 }
+
+func TestRepoHasMeta(t *testing.T) {
+	ps := Parameters{
+		Parameter{
+			Field:      FieldRepo,
+			Value:      "has.meta(team)",
+			Annotation: Annotation{Labels: IsPredicate},
+		},
+		Parameter{
+			Field:      FieldRepo,
+			Value:      "has.meta(team:search)",
+			Negated:    true,
+			Annotation: Annotation{Labels: IsPredicate},
+		},
+	}
+
+	search := "search"
+	want := []RepoHasMetaFilter{
+		{Key: "team"},
+		{Key: "team", Value: &search, Negated: true},
+	}
+
+	require.Equal(t, want, ps.RepoHasMeta())
+}
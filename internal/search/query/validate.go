@@ -216,6 +216,13 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		return err
 	}
 
+	isValidSort := func() error {
+		if parseSortBy(value) == SortByInvalid {
+			return errors.Errorf("invalid value %q for field %q. Valid values are: recency, path, stars", value, field)
+		}
+		return nil
+	}
+
 	isValidGitDate := func() error {
 		_, err := ParseGitDate(value, time.Now)
 		return err
@@ -235,7 +242,8 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		FieldDefault:
 		// Search patterns are not validated here, as it depends on the search type.
 	case
-		FieldCase:
+		FieldCase,
+		FieldSymbolHistory:
 		return satisfies(isSingular, isBoolean, isNotNegated)
 	case
 		FieldRepo:
@@ -246,6 +254,9 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldFile:
 		return satisfies(isValidRegexp)
+	case
+		FieldOwner:
+		// Owners are matched as-is against the handles in CODEOWNERS files.
 	case
 		FieldLang:
 		return satisfies(isLanguage)
@@ -292,6 +303,9 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldSelect:
 		return satisfies(isSingular, isNotNegated, isValidSelect)
+	case
+		FieldSort:
+		return satisfies(isSingular, isNotNegated, isValidSort)
 	default:
 		return isUnrecognizedField()
 	}
@@ -376,6 +390,24 @@ func validateTypeStructural(nodes []Node) error {
 	return nil
 }
 
+func validateTypeSyntax(nodes []Node) error {
+	seenSyntax := false
+	seenType := false
+	invalid := Exists(nodes, func(node Node) bool {
+		if p, ok := node.(Pattern); ok && p.Annotation.Labels.IsSet(Syntax) {
+			seenSyntax = true
+		}
+		if p, ok := node.(Parameter); ok && p.Field == FieldType {
+			seenType = true
+		}
+		return seenSyntax && seenType
+	})
+	if invalid {
+		return errors.New("this syntax search query specifies `type:` and is not supported. Syntax search queries only apply to searching file contents")
+	}
+	return nil
+}
+
 func validateRefGlobs(nodes []Node) error {
 	if !ContainsRefGlobs(nodes) {
 		return nil
@@ -422,6 +454,24 @@ func validateRepoHasFile(nodes []Node) error {
 	return nil
 }
 
+// validateSymbolHistory validates that symbol.history is only used in symbol
+// searches.
+func validateSymbolHistory(nodes []Node) error {
+	var seenSymbolHistory, seenTypeSymbol bool
+	VisitParameter(nodes, func(field, value string, _ bool, _ Annotation) {
+		if field == FieldSymbolHistory {
+			seenSymbolHistory, _ = parseBool(value)
+		}
+		if field == FieldType && strings.EqualFold(value, "symbol") {
+			seenTypeSymbol = true
+		}
+	})
+	if seenSymbolHistory && !seenTypeSymbol {
+		return errors.Errorf(`your query contains the field '%s', which requires type:symbol in the query`, FieldSymbolHistory)
+	}
+	return nil
+}
+
 // validatePureLiteralPattern checks that no pattern expression contains and/or
 // operators nested inside concat. It may happen that we interpret a query this
 // way due to ambiguity. If this happens, return an error message.
@@ -475,6 +525,9 @@ func validatePattern(nodes []Node) error {
 		if annotation.Labels.IsSet(Structural) && negated {
 			err = errors.New("the query contains a negated search pattern. Structural search does not support negated search patterns at the moment")
 		}
+		if annotation.Labels.IsSet(Syntax) && negated {
+			err = errors.New("the query contains a negated search pattern. Syntax search does not support negated search patterns")
+		}
 	})
 	return err
 }
@@ -495,7 +548,9 @@ func validate(nodes []Node) error {
 		validateRepoRevPair,
 		validateRepoHasFile,
 		validateCommitParameters,
+		validateSymbolHistory,
 		validateTypeStructural,
+		validateTypeSyntax,
 		validateRefGlobs,
 	)
 }
@@ -528,6 +583,29 @@ func parseYesNoOnly(s string) YesNoOnly {
 	}
 }
 
+// SortBy is the order in which results are streamed, as set by `sort:`.
+type SortBy string
+
+const (
+	SortByRecency SortBy = "recency"
+	SortByPath    SortBy = "path"
+	SortByStars   SortBy = "stars"
+	SortByInvalid SortBy = "invalid"
+)
+
+func parseSortBy(s string) SortBy {
+	switch strings.ToLower(s) {
+	case "recency":
+		return SortByRecency
+	case "path":
+		return SortByPath
+	case "stars":
+		return SortByStars
+	default:
+		return SortByInvalid
+	}
+}
+
 func ContainsRefGlobs(q Q) bool {
 	containsRefGlobs := false
 	if repoFilterValues, _ := q.Repositories(); len(repoFilterValues) > 0 {
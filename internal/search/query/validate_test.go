@@ -83,6 +83,18 @@ func TestValidation(t *testing.T) {
 			input: "repo:foo author:rob@saucegraph.com",
 			want:  `your query contains the field 'author', which requires type:commit or type:diff in the query`,
 		},
+		{
+			input: "symbol.history:yes deprecated",
+			want:  `your query contains the field 'symbol.history', which requires type:symbol in the query`,
+		},
+		{
+			input: "type:symbol symbol.history:maybe deprecated",
+			want:  `invalid boolean "maybe"`,
+		},
+		{
+			input: "type:symbol -symbol.history:yes deprecated",
+			want:  `field "symbol.history" does not support negation`,
+		},
 		{
 			input: "repohasfile:README type:symbol yolo",
 			want:  "repohasfile is not compatible for type:symbol. Subscribe to https://github.com/sourcegraph/sourcegraph/issues/4610 for updates",
@@ -99,6 +111,14 @@ func TestValidation(t *testing.T) {
 			input: "type:symbol select:symbol.timelime",
 			want:  `invalid field "timelime" on select path "symbol.timelime"`,
 		},
+		{
+			input: "sort:relevance",
+			want:  `invalid value "relevance" for field "sort". Valid values are: recency, path, stars`,
+		},
+		{
+			input: "-sort:path",
+			want:  `field "sort" does not support negation`,
+		},
 		{
 			input:      "nice try type:repo",
 			want:       "this structural search query specifies `type:` and is not supported. Structural search syntax only applies to searching file contents",
@@ -109,6 +129,16 @@ func TestValidation(t *testing.T) {
 			want:       "this structural search query specifies `type:` and is not supported. Structural search syntax only applies to searching file contents and is not currently supported for diff searches",
 			searchType: SearchTypeStructural,
 		},
+		{
+			input:      `-content:"(identifier) @id"`,
+			want:       "the query contains a negated search pattern. Syntax search does not support negated search patterns",
+			searchType: SearchTypeSyntax,
+		},
+		{
+			input:      "type:symbol (identifier) @id",
+			want:       "this syntax search query specifies `type:` and is not supported. Syntax search queries only apply to searching file contents",
+			searchType: SearchTypeSyntax,
+		},
 	}
 	for _, c := range cases {
 		t.Run("validate and/or query", func(t *testing.T) {
@@ -131,6 +131,7 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 		Names:                 dependencyNames,
 		ExcludePattern:        query.UnionRegExps(excludePatterns),
 		DescriptionPatterns:   op.DescriptionPatterns,
+		TagFilters:            tagFilters(op),
 		CaseSensitivePatterns: op.CaseSensitiveRepoFilters,
 		Cursors:               op.Cursors,
 		// List N+1 repos so we can see if there are repos omitted due to our repo limit.
@@ -702,6 +703,28 @@ func (r *Resolver) dependents(ctx context.Context, op *search.RepoOptions) (_ []
 	return depNames, depRevs, nil
 }
 
+// tagFilters converts the repo:has.topic() and repo:has.meta() predicates
+// of op to filters on the repo_tags table.
+func tagFilters(op search.RepoOptions) []database.RepoTagFilter {
+	filters := make([]database.RepoTagFilter, 0, len(op.HasTopics)+len(op.HasMeta))
+	for _, f := range op.HasTopics {
+		filters = append(filters, database.RepoTagFilter{
+			Kind:    types.RepoTagKindTopic,
+			Key:     f.Topic,
+			Negated: f.Negated,
+		})
+	}
+	for _, f := range op.HasMeta {
+		filters = append(filters, database.RepoTagFilter{
+			Kind:    types.RepoTagKindMeta,
+			Key:     f.Key,
+			Value:   f.Value,
+			Negated: f.Negated,
+		})
+	}
+	return filters
+}
+
 // ExactlyOneRepo returns whether exactly one repo: literal field is specified and
 // delineated by regex anchors ^ and $. This function helps determine whether we
 // should return results for a single repo regardless of whether it is a fork or
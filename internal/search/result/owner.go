@@ -0,0 +1,52 @@
+package result
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OwnerMatch is an owner of files in a repository, as declared by the
+// repository's CODEOWNERS file. Handle is a user or team (e.g.,
+// "@sourcegraph/search") or an email address.
+type OwnerMatch struct {
+	Handle   string
+	Repo     types.MinimalRepo
+	CommitID api.CommitID
+}
+
+func (o *OwnerMatch) RepoName() types.MinimalRepo {
+	return o.Repo
+}
+
+func (o *OwnerMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (o *OwnerMatch) ResultCount() int {
+	return 1
+}
+
+func (o *OwnerMatch) Select(path filter.SelectPath) Match {
+	switch path.Root() {
+	case filter.Repository:
+		return &RepoMatch{Name: o.Repo.Name, ID: o.Repo.ID}
+	case filter.File:
+		if len(path) == 2 && path[1] == "owners" {
+			return o
+		}
+	}
+	return nil
+}
+
+func (o *OwnerMatch) Key() Key {
+	return Key{
+		TypeRank: rankOwnerMatch,
+		Repo:     o.Repo.Name,
+		Commit:   o.CommitID,
+		Owner:    o.Handle,
+	}
+}
+
+func (o *OwnerMatch) searchResultMarker() {}
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/sourcegraph/go-lsp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
 )
 
 // Symbol is a code symbol.
@@ -26,6 +28,24 @@ type Symbol struct {
 	Signature  string
 
 	FileLimited bool
+
+	// History is set for symbols returned by symbol history searches.
+	History *SymbolHistory
+}
+
+// SymbolHistory is the history of a symbol along the first-parent history of
+// the searched commit.
+type SymbolHistory struct {
+	// Introduced is the commit that introduced the symbol.
+	Introduced api.CommitID
+
+	// Deleted is the commit that deleted the symbol, or empty if the symbol
+	// still exists at the searched commit.
+	Deleted api.CommitID
+
+	// LastSeen is the newest commit that contains the symbol, which the
+	// location of the symbol refers to.
+	LastSeen api.CommitID
 }
 
 // NewSymbolMatch returns a new SymbolMatch. Passing -1 as the character will make NewSymbolMatch infer
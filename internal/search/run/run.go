@@ -35,7 +35,7 @@ func (inputs SearchInputs) MaxResults() int {
 
 // DefaultLimit is the default limit to use if not specified in query.
 func (inputs SearchInputs) DefaultLimit() int {
-	if inputs.Protocol == search.Batch || inputs.PatternType == query.SearchTypeStructural {
+	if inputs.Protocol == search.Batch || inputs.PatternType == query.SearchTypeStructural || inputs.PatternType == query.SearchTypeSyntax {
 		return limits.DefaultMaxSearchResults
 	}
 	return limits.DefaultMaxSearchResultsStreaming
@@ -129,6 +129,8 @@ func detectSearchType(version string, patternType *string) (query.SearchType, er
 			searchType = query.SearchTypeRegex
 		case "structural":
 			searchType = query.SearchTypeStructural
+		case "syntax":
+			searchType = query.SearchTypeSyntax
 		case "lucky":
 			searchType = query.SearchTypeLucky
 		default:
@@ -167,6 +169,8 @@ func overrideSearchType(input string, searchType query.SearchType) query.SearchT
 			searchType = query.SearchTypeLiteral
 		case "structural":
 			searchType = query.SearchTypeStructural
+		case "syntax":
+			searchType = query.SearchTypeSyntax
 		case "lucky":
 			searchType = query.SearchTypeLucky
 		}
@@ -27,6 +27,10 @@ type SymbolSearchJob struct {
 	PatternInfo *search.TextPatternInfo
 	Repos       []*search.RepositoryRevisions // the set of repositories to search with searcher.
 	Limit       int
+
+	// History if true searches the history of symbols, including deleted
+	// symbols, instead of the symbols at the searched revision.
+	History bool
 }
 
 // Run calls the searcher service to search symbols.
@@ -51,7 +55,7 @@ func (s *SymbolSearchJob) Run(ctx context.Context, clients job.RuntimeClients, s
 		goroutine.Go(func() {
 			defer run.Release()
 
-			matches, err := searchInRepo(ctx, clients.DB, repoRevs, s.PatternInfo, s.Limit)
+			matches, err := searchInRepo(ctx, clients.DB, repoRevs, s.PatternInfo, s.Limit, s.History)
 			status, limitHit, err := search.HandleRepoSearchResult(repoRevs.Repo.ID, repoRevs.Revs, len(matches) > s.Limit, false, err)
 			stream.Send(streaming.SearchEvent{
 				Results: matches,
@@ -87,6 +91,7 @@ func (s *SymbolSearchJob) Fields(v job.Verbosity) (res []log.Field) {
 			trace.Scoped("patternInfo", s.PatternInfo.Fields()...),
 			log.Int("numRepos", len(s.Repos)),
 			log.Int("limit", s.Limit),
+			log.Bool("history", s.History),
 		)
 	}
 	return res
@@ -95,7 +100,7 @@ func (s *SymbolSearchJob) Fields(v job.Verbosity) (res []log.Field) {
 func (s *SymbolSearchJob) Children() []job.Describer       { return nil }
 func (s *SymbolSearchJob) MapChildren(job.MapFunc) job.Job { return s }
 
-func searchInRepo(ctx context.Context, db database.DB, repoRevs *search.RepositoryRevisions, patternInfo *search.TextPatternInfo, limit int) (res []result.Match, err error) {
+func searchInRepo(ctx context.Context, db database.DB, repoRevs *search.RepositoryRevisions, patternInfo *search.TextPatternInfo, limit int, history bool) (res []result.Match, err error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "Search symbols in repo")
 	defer func() {
 		if err != nil {
@@ -118,7 +123,7 @@ func searchInRepo(ctx context.Context, db database.DB, repoRevs *search.Reposito
 	}
 	span.SetTag("commit", string(commitID))
 
-	symbols, err := backend.Symbols.ListTags(ctx, search.SymbolsParameters{
+	symbols, err := backend.Symbols.Search(ctx, repoRevs.Repo.ID, search.SymbolsParameters{
 		Repo:            repoRevs.Repo.Name,
 		CommitID:        commitID,
 		Query:           patternInfo.Pattern,
@@ -127,7 +132,8 @@ func searchInRepo(ctx context.Context, db database.DB, repoRevs *search.Reposito
 		IncludePatterns: patternInfo.IncludePatterns,
 		ExcludePattern:  patternInfo.ExcludePattern,
 		// Ask for limit + 1 so we can detect whether there are more results than the limit.
-		First: limit + 1,
+		First:   limit + 1,
+		History: history,
 	})
 
 	// All symbols are from the same repo, so we can just partition them by path
@@ -136,21 +142,38 @@ func searchInRepo(ctx context.Context, db database.DB, repoRevs *search.Reposito
 }
 
 func symbolsToMatches(symbols []result.Symbol, repo types.MinimalRepo, commitID api.CommitID, inputRev string) result.Matches {
-	symbolsByPath := make(map[string][]result.Symbol)
+	// Symbols from history searches may no longer exist at the searched
+	// commit, so they are partitioned by the commit that they were last seen
+	// in as well.
+	type pathCommit struct {
+		path     string
+		commitID api.CommitID
+	}
+
+	symbolsByPath := make(map[pathCommit][]result.Symbol)
 	for _, symbol := range symbols {
-		cur := symbolsByPath[symbol.Path]
-		symbolsByPath[symbol.Path] = append(cur, symbol)
+		key := pathCommit{path: symbol.Path, commitID: commitID}
+		if symbol.History != nil && symbol.History.Deleted != "" {
+			key.commitID = symbol.History.LastSeen
+		}
+		cur := symbolsByPath[key]
+		symbolsByPath[key] = append(cur, symbol)
 	}
 
 	// Create file matches from partitioned symbols
 	matches := make(result.Matches, 0, len(symbolsByPath))
-	for path, symbols := range symbolsByPath {
+	for key, symbols := range symbolsByPath {
 		file := result.File{
-			Path:     path,
+			Path:     key.path,
 			Repo:     repo,
-			CommitID: commitID,
+			CommitID: key.commitID,
 			InputRev: &inputRev,
 		}
+		if key.commitID != commitID {
+			// Link deleted symbols to the commit that still contains them.
+			rev := string(key.commitID)
+			file.InputRev = &rev
+		}
 
 		symbolMatches := make([]*result.SymbolMatch, 0, len(symbols))
 		for _, symbol := range symbols {
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -48,3 +50,43 @@ func Test_symbolsToMatches(t *testing.T) {
 		t.Errorf("symbolsToMatches() returned diff (-got +want):\n%s", diff)
 	}
 }
+
+func Test_symbolsToMatchesHistory(t *testing.T) {
+	input := []result.Symbol{
+		{Path: "path1", Name: "live", History: &result.SymbolHistory{Introduced: "111111", LastSeen: "abcdef"}},
+		{Path: "path1", Name: "deleted", History: &result.SymbolHistory{Introduced: "111111", Deleted: "333333", LastSeen: "222222"}},
+	}
+
+	output := symbolsToMatches(input, types.MinimalRepo{Name: "somerepo"}, "abcdef", "main")
+
+	type fileType struct {
+		Path     string
+		CommitID api.CommitID
+		InputRev string
+		Symbols  []string
+	}
+
+	got := []fileType{}
+	for _, match := range output {
+		fileMatch := match.(*result.FileMatch)
+		symbols := []string{}
+		for _, symbol := range fileMatch.Symbols {
+			symbols = append(symbols, symbol.Symbol.Name)
+		}
+		got = append(got, fileType{
+			Path:     fileMatch.Path,
+			CommitID: fileMatch.CommitID,
+			InputRev: *fileMatch.InputRev,
+			Symbols:  symbols,
+		})
+	}
+
+	want := []fileType{
+		{Path: "path1", CommitID: "222222", InputRev: "222222", Symbols: []string{"deleted"}},
+		{Path: "path1", CommitID: "abcdef", InputRev: "main", Symbols: []string{"live"}},
+	}
+
+	if diff := cmp.Diff(got, want, cmpopts.SortSlices(func(a, b fileType) bool { return a.CommitID < b.CommitID })); diff != "" {
+		t.Errorf("symbolsToMatches() returned diff (-got +want):\n%s", diff)
+	}
+}
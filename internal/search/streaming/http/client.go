@@ -31,6 +31,7 @@ type FrontendStreamDecoder struct {
 	OnFilters  func([]*EventFilter)
 	OnAlert    func(*EventAlert)
 	OnError    func(*EventError)
+	OnProfile  func([]EventProfile)
 	OnUnknown  func(event, data []byte)
 }
 
@@ -90,6 +91,15 @@ func (rr FrontendStreamDecoder) ReadAll(r io.Reader) error {
 				return errors.Errorf("failed to decode error payload: %w", err)
 			}
 			rr.OnError(&d)
+		} else if bytes.Equal(event, []byte("profile")) {
+			if rr.OnProfile == nil {
+				continue
+			}
+			var d []EventProfile
+			if err := json.Unmarshal(data, &d); err != nil {
+				return errors.Errorf("failed to decode profile payload: %w", err)
+			}
+			rr.OnProfile(d)
 		} else if bytes.Equal(event, []byte("done")) {
 			// Always the last event
 			break
@@ -127,6 +137,8 @@ func (r *eventMatchUnmarshaller) UnmarshalJSON(b []byte) error {
 		r.EventMatch = &EventSymbolMatch{}
 	case CommitMatchType:
 		r.EventMatch = &EventCommitMatch{}
+	case OwnerMatchType:
+		r.EventMatch = &EventOwnerMatch{}
 	default:
 		return errors.Errorf("unknown MatchType %v", typeU.Type)
 	}
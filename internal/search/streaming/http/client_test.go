@@ -66,6 +66,20 @@ func TestFrontendClient(t *testing.T) {
 		Value: &EventError{
 			Message: "error",
 		},
+	}, {
+		Name: "profile",
+		Value: []EventProfile{{
+			Name:          "ParallelJob",
+			DurationMs:    10,
+			ReposSearched: 1,
+			Children: []EventProfile{{
+				Name:         "ZoektGlobalSearchJob",
+				Fields:       map[string]any{"fileMatchLimit": float64(500)},
+				DurationMs:   5,
+				ResultCount:  2,
+				RepoStatuses: map[string]int{"timedout": 1},
+			}},
+		}},
 	}}
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -108,6 +122,9 @@ func TestFrontendClient(t *testing.T) {
 		OnError: func(d *EventError) {
 			got = append(got, Event{Name: "error", Value: d})
 		},
+		OnProfile: func(d []EventProfile) {
+			got = append(got, Event{Name: "profile", Value: d})
+		},
 		OnUnknown: func(event, data []byte) {
 			t.Fatalf("got unexpected event: %s %s", event, data)
 		},
@@ -129,6 +129,11 @@ type Symbol struct {
 	Name          string `json:"name"`
 	ContainerName string `json:"containerName"`
 	Kind          string `json:"kind"`
+
+	// IntroducedCommit and DeletedCommit are only set by symbol history
+	// searches. DeletedCommit is empty for symbols that were not deleted.
+	IntroducedCommit string `json:"introducedCommit,omitempty"`
+	DeletedCommit    string `json:"deletedCommit,omitempty"`
 }
 
 // EventCommitMatch is the generic results interface from GQL. There is a lot
@@ -157,6 +162,20 @@ type EventCommitMatch struct {
 
 func (e *EventCommitMatch) eventMatch() {}
 
+// EventOwnerMatch is an owner of files in a repository, as returned by
+// `select:file.owners`.
+type EventOwnerMatch struct {
+	// Type is always OwnerMatchType. Included here for marshalling.
+	Type MatchType `json:"type"`
+
+	Handle       string `json:"handle"`
+	RepositoryID int32  `json:"repositoryID"`
+	Repository   string `json:"repository"`
+	Commit       string `json:"commit,omitempty"`
+}
+
+func (e *EventOwnerMatch) eventMatch() {}
+
 // EventFilter is a suggestion for a search filter. Currently has a 1-1
 // correspondance with the SearchFilter graphql type.
 type EventFilter struct {
@@ -188,6 +207,29 @@ type EventError struct {
 	Message string `json:"message"`
 }
 
+// EventProfile is the timing of a job of a search. It is only sent for
+// searches requested with debug=profile, as a list of the top-level jobs.
+type EventProfile struct {
+	Name       string         `json:"name"`
+	Fields     map[string]any `json:"fields,omitempty"`
+	DurationMs int64          `json:"durationMs"`
+
+	// ResultCount is the number of results the job sent.
+	ResultCount int `json:"resultCount"`
+
+	// ReposSearched is the number of repositories the job searched.
+	ReposSearched int  `json:"reposSearched"`
+	LimitHit      bool `json:"limitHit"`
+
+	// RepoStatuses is the number of repositories by the status of their
+	// search, e.g. timedout or cloning.
+	RepoStatuses map[string]int `json:"repoStatuses,omitempty"`
+
+	Alert    string         `json:"alert,omitempty"`
+	Error    string         `json:"error,omitempty"`
+	Children []EventProfile `json:"children,omitempty"`
+}
+
 type MatchType int
 
 const (
@@ -196,6 +238,7 @@ const (
 	SymbolMatchType
 	CommitMatchType
 	PathMatchType
+	OwnerMatchType
 )
 
 func (t MatchType) MarshalJSON() ([]byte, error) {
@@ -210,6 +253,8 @@ func (t MatchType) MarshalJSON() ([]byte, error) {
 		return []byte(`"commit"`), nil
 	case PathMatchType:
 		return []byte(`"path"`), nil
+	case OwnerMatchType:
+		return []byte(`"owner"`), nil
 	default:
 		return nil, errors.Errorf("unknown MatchType: %d", t)
 	}
@@ -226,6 +271,8 @@ func (t *MatchType) UnmarshalJSON(b []byte) error {
 		*t = CommitMatchType
 	} else if bytes.Equal(b, []byte(`"path"`)) {
 		*t = PathMatchType
+	} else if bytes.Equal(b, []byte(`"owner"`)) {
+		*t = OwnerMatchType
 	} else {
 		return errors.Errorf("unknown MatchType: %s", b)
 	}
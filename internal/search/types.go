@@ -68,6 +68,11 @@ type SymbolsParameters struct {
 
 	// Timeout in seconds.
 	Timeout int
+
+	// History if true returns the symbols that existed at any point of the
+	// first-parent history of CommitID, along with the commits that
+	// introduced and deleted them. Only Rockskip supports it.
+	History bool `json:"history,omitempty"`
 }
 
 type SymbolsResponse struct {
@@ -156,6 +161,7 @@ type TextPatternInfo struct {
 	IsNegated       bool
 	IsRegExp        bool
 	IsStructuralPat bool
+	IsSyntaxPat     bool
 	CombyRule       string
 	IsWordMatch     bool
 	IsCaseSensitive bool
@@ -196,6 +202,9 @@ func (p *TextPatternInfo) Fields() []otlog.Field {
 	if p.IsStructuralPat {
 		add(otlog.Bool("isStructural", p.IsStructuralPat))
 	}
+	if p.IsSyntaxPat {
+		add(otlog.Bool("isSyntax", p.IsSyntaxPat))
+	}
 	if p.CombyRule != "" {
 		add(otlog.String("combyRule", p.CombyRule))
 	}
@@ -248,6 +257,9 @@ func (p *TextPatternInfo) String() string {
 			args = append(args, "comby")
 		}
 	}
+	if p.IsSyntaxPat {
+		args = append(args, "syntax")
+	}
 	if p.IsWordMatch {
 		args = append(args, "word")
 	}
@@ -317,6 +329,8 @@ type RepoOptions struct {
 	Dependencies        []string
 	Dependents          []string
 	DescriptionPatterns []string
+	HasTopics           []query.RepoHasTopicFilter
+	HasMeta             []query.RepoHasMetaFilter
 
 	CaseSensitiveRepoFilters bool
 	SearchContextSpec        string
@@ -362,6 +376,12 @@ func (op *RepoOptions) Tags() []otlog.Field {
 	if len(op.DescriptionPatterns) > 0 {
 		add(trace.Strings("descriptionPatterns", op.DescriptionPatterns))
 	}
+	if len(op.HasTopics) > 0 {
+		add(trace.Printf("hasTopics", "%+v", op.HasTopics))
+	}
+	if len(op.HasMeta) > 0 {
+		add(trace.Printf("hasMeta", "%+v", op.HasMeta))
+	}
 	if op.CaseSensitiveRepoFilters {
 		add(otlog.Bool("caseSensitiveRepoFilters", true))
 	}
@@ -421,6 +441,23 @@ func (op *RepoOptions) String() string {
 	if len(op.DescriptionPatterns) > 0 {
 		fmt.Fprintf(&b, "DescriptionPatterns: %q\n", op.DescriptionPatterns)
 	}
+	if len(op.HasTopics) > 0 {
+		fmt.Fprintf(&b, "HasTopics: %+v\n", op.HasTopics)
+	}
+	if len(op.HasMeta) > 0 {
+		b.WriteString("HasMeta: [")
+		for i, f := range op.HasMeta {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			fmt.Fprintf(&b, "{Key:%s", f.Key)
+			if f.Value != nil {
+				fmt.Fprintf(&b, " Value:%s", *f.Value)
+			}
+			fmt.Fprintf(&b, " Negated:%t}", f.Negated)
+		}
+		b.WriteString("]\n")
+	}
 
 	fmt.Fprintf(&b, "CommitAfter: %s\n", op.CommitAfter)
 	fmt.Fprintf(&b, "Visibility: %s\n", string(op.Visibility))
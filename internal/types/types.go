@@ -76,6 +76,24 @@ type Repo struct {
 	Blocked *RepoBlock `json:",omitempty"`
 }
 
+// RepoTagKind is the kind of a RepoTag.
+type RepoTagKind string
+
+const (
+	// RepoTagKindTopic is the kind of topics synced from the code host.
+	RepoTagKindTopic RepoTagKind = "topic"
+	// RepoTagKindMeta is the kind of key/value tags assigned by site admins.
+	RepoTagKindMeta RepoTagKind = "meta"
+)
+
+// RepoTag is a code host topic or an admin-assigned key/value tag of a
+// repository. Topics have an empty Value.
+type RepoTag struct {
+	Kind  RepoTagKind
+	Key   string
+	Value string
+}
+
 // SearchedRepo is a collection of metadata about repos that is used to decorate search results
 type SearchedRepo struct {
 	// ID is the unique numeric ID for this repository.
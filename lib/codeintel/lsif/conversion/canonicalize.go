@@ -48,22 +48,25 @@ func canonicalizeDocuments(state *State) {
 	canonicalizeDocumentsInDefinitionReferences(state.DefinitionData, canonicalIDs)
 	canonicalizeDocumentsInDefinitionReferences(state.ReferenceData, canonicalIDs)
 	canonicalizeDocumentsInDefinitionReferences(state.ImplementationData, canonicalIDs)
+	canonicalizeDocumentsInDefinitionReferences(state.TypeDefinitionData, canonicalIDs)
 
 	for documentID, canonicalID := range canonicalIDs {
-		// Move ranges and diagnostics into the canonical document
+		// Move ranges, diagnostics, and document symbols into the canonical document
 		state.Contains.UnionIDSet(canonicalID, state.Contains.Get(documentID))
 		state.Diagnostics.UnionIDSet(canonicalID, state.Diagnostics.Get(documentID))
+		state.DocumentSymbols.UnionIDSet(canonicalID, state.DocumentSymbols.Get(documentID))
 
 		// Remove non-canonical documents
 		delete(state.DocumentData, documentID)
 		state.Contains.Delete(documentID)
 		state.Diagnostics.Delete(documentID)
+		state.DocumentSymbols.Delete(documentID)
 	}
 }
 
-// canonicalizeDocumentsInDefinitionReferences moves definition, reference, and implementation result
-// data from a document to its canonical document (if they differ) and removes all references to the
-// non-canonical document.
+// canonicalizeDocumentsInDefinitionReferences moves definition, reference, implementation, and type
+// definition result data from a document to its canonical document (if they differ) and removes all
+// references to the non-canonical document.
 func canonicalizeDocumentsInDefinitionReferences(definitionReferenceData map[int]*datastructures.DefaultIDSetMap, canonicalIDs map[int]int) {
 	for _, documentRanges := range definitionReferenceData {
 		// The length of documentRanges will always be less than or equal to
@@ -182,6 +185,9 @@ func mergeNextResultSetData(state *State, itemID int, item ResultSet, nextID int
 	if item.ImplementationResultID == 0 {
 		item = item.SetImplementationResultID(nextItem.ImplementationResultID)
 	}
+	if item.TypeDefinitionResultID == 0 {
+		item = item.SetTypeDefinitionResultID(nextItem.TypeDefinitionResultID)
+	}
 	if item.HoverResultID == 0 {
 		item = item.SetHoverResultID(nextItem.HoverResultID)
 	}
@@ -203,6 +209,9 @@ func mergeNextRangeData(state *State, itemID int, item Range, nextID int, nextIt
 	if item.ImplementationResultID == 0 {
 		item = item.SetImplementationResultID(nextItem.ImplementationResultID)
 	}
+	if item.TypeDefinitionResultID == 0 {
+		item = item.SetTypeDefinitionResultID(nextItem.TypeDefinitionResultID)
+	}
 	if item.HoverResultID == 0 {
 		item = item.SetHoverResultID(nextItem.HoverResultID)
 	}
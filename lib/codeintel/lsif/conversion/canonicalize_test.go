@@ -35,8 +35,9 @@ func TestCanonicalizeDocuments(t *testing.T) {
 			1003: newIDSet(3003),
 			1004: newIDSet(3004),
 		}),
-		Monikers:    datastructures.NewDefaultIDSetMap(),
-		Diagnostics: datastructures.NewDefaultIDSetMap(),
+		Monikers:        datastructures.NewDefaultIDSetMap(),
+		Diagnostics:     datastructures.NewDefaultIDSetMap(),
+		DocumentSymbols: newIDSetMap(map[int]*idSet{1004: newIDSet(4001)}),
 	}
 	canonicalizeDocuments(state)
 
@@ -59,8 +60,9 @@ func TestCanonicalizeDocuments(t *testing.T) {
 			1002: newIDSet(3002),
 			1003: newIDSet(3003),
 		}),
-		Monikers:    datastructures.NewDefaultIDSetMap(),
-		Diagnostics: datastructures.NewDefaultIDSetMap(),
+		Monikers:        datastructures.NewDefaultIDSetMap(),
+		Diagnostics:     datastructures.NewDefaultIDSetMap(),
+		DocumentSymbols: newIDSetMap(map[int]*idSet{1001: newIDSet(4001)}),
 	}
 
 	if diff := cmp.Diff(expectedState, state, datastructures.Comparers...); diff != "" {
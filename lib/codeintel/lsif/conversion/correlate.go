@@ -25,6 +25,15 @@ func Correlate(ctx context.Context, r io.Reader, root string, getChildren pathex
 		return nil, err
 	}
 
+	return CorrelateState(ctx, state, root, getChildren)
+}
+
+// CorrelateState canonicalizes and prunes the given correlation state for storage. This is
+// used by callers that populate a correlation state from something other than an LSIF dump.
+// Document paths of the given state must be relative to the given root.
+//
+// If getChildren == nil, no pruning of irrelevant data is performed.
+func CorrelateState(ctx context.Context, state *State, root string, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
 	// Remove duplicate elements, collapse linked elements
 	canonicalize(state)
 
@@ -157,7 +166,7 @@ type wrappedState struct {
 
 func newWrappedState(dumpRoot string) *wrappedState {
 	return &wrappedState{
-		State:               newState(),
+		State:               NewState(),
 		dumpRoot:            dumpRoot,
 		unsupportedVertices: datastructures.NewIDSet(),
 		rangeToDoc:          map[int]int{},
@@ -186,10 +195,12 @@ var vertexHandlers = map[string]vertexHandler{
 	"definitionResult":     correlateDefinitionResult,
 	"referenceResult":      correlateReferenceResult,
 	"implementationResult": correlateImplementationResult,
+	"typeDefinitionResult": correlateTypeDefinitionResult,
 	"hoverResult":          correlateHoverResult,
 	"moniker":              correlateMoniker,
 	"packageInformation":   correlatePackageInformation,
 	"diagnosticResult":     correlateDiagnosticResult,
+	"documentSymbolResult": correlateDocumentSymbolResult,
 }
 
 // correlateElement maps a single vertex element into the correlation state.
@@ -215,11 +226,13 @@ var edgeHandlers = map[string]func(state *wrappedState, id int, edge Edge) error
 	"textDocument/definition":     correlateTextDocumentDefinitionEdge,
 	"textDocument/references":     correlateTextDocumentReferencesEdge,
 	"textDocument/implementation": correlateTextDocumentImplementationEdge,
+	"textDocument/typeDefinition": correlateTextDocumentTypeDefinitionEdge,
 	"textDocument/hover":          correlateTextDocumentHoverEdge,
 	"moniker":                     correlateMonikerEdge,
 	"nextMoniker":                 correlateNextMonikerEdge,
 	"packageInformation":          correlatePackageInformationEdge,
 	"textDocument/diagnostic":     correlateDiagnosticEdge,
+	"textDocument/documentSymbol": correlateDocumentSymbolEdge,
 }
 
 // correlateElement maps a single edge element into the correlation state.
@@ -314,6 +327,11 @@ func correlateImplementationResult(state *wrappedState, element Element) error {
 	return nil
 }
 
+func correlateTypeDefinitionResult(state *wrappedState, element Element) error {
+	state.TypeDefinitionData[element.ID] = datastructures.NewDefaultIDSetMap()
+	return nil
+}
+
 func correlateHoverResult(state *wrappedState, element Element) error {
 	payload, ok := element.Payload.(string)
 	if !ok {
@@ -354,6 +372,16 @@ func correlateDiagnosticResult(state *wrappedState, element Element) error {
 	return nil
 }
 
+func correlateDocumentSymbolResult(state *wrappedState, element Element) error {
+	payload, ok := element.Payload.(DocumentSymbolResult)
+	if !ok {
+		return ErrUnexpectedPayload
+	}
+
+	state.DocumentSymbolResults[element.ID] = payload
+	return nil
+}
+
 func correlateContainsEdge(state *wrappedState, id int, edge Edge) error {
 	if _, ok := state.DocumentData[edge.OutV]; !ok {
 		// Do not track this relation for project vertices
@@ -444,6 +472,19 @@ func correlateItemEdge(state *wrappedState, id int, edge Edge) error {
 		return nil
 	}
 
+	if documentMap, ok := state.TypeDefinitionData[edge.OutV]; ok {
+		for _, inV := range edge.InVs {
+			if _, ok := state.RangeData[inV]; !ok {
+				return malformedDump(id, inV, "range")
+			}
+
+			// Link type definition data to the range defining the type
+			documentMap.AddID(edge.Document, inV)
+		}
+
+		return nil
+	}
+
 	if !state.unsupportedVertices.Contains(edge.OutV) {
 		return malformedDump(id, edge.OutV, "vertex")
 	}
@@ -496,6 +537,21 @@ func correlateTextDocumentImplementationEdge(state *wrappedState, id int, edge E
 	return nil
 }
 
+func correlateTextDocumentTypeDefinitionEdge(state *wrappedState, id int, edge Edge) error {
+	if _, ok := state.TypeDefinitionData[edge.InV]; !ok {
+		return malformedDump(id, edge.InV, "typeDefinitionResult")
+	}
+
+	if source, ok := state.RangeData[edge.OutV]; ok {
+		state.RangeData[edge.OutV] = source.SetTypeDefinitionResultID(edge.InV)
+	} else if source, ok := state.ResultSetData[edge.OutV]; ok {
+		state.ResultSetData[edge.OutV] = source.SetTypeDefinitionResultID(edge.InV)
+	} else {
+		return malformedDump(id, edge.OutV, "range", "resultSet")
+	}
+	return nil
+}
+
 func correlateTextDocumentHoverEdge(state *wrappedState, id int, edge Edge) error {
 	if _, ok := state.HoverData[edge.InV]; !ok {
 		return malformedDump(id, edge.InV, "hoverResult")
@@ -576,3 +632,16 @@ func correlateDiagnosticEdge(state *wrappedState, id int, edge Edge) error {
 	state.Diagnostics.AddID(edge.OutV, edge.InV)
 	return nil
 }
+
+func correlateDocumentSymbolEdge(state *wrappedState, id int, edge Edge) error {
+	if _, ok := state.DocumentData[edge.OutV]; !ok {
+		return malformedDump(id, edge.OutV, "document")
+	}
+
+	if _, ok := state.DocumentSymbolResults[edge.InV]; !ok {
+		return malformedDump(id, edge.InV, "documentSymbolResult")
+	}
+
+	state.DocumentSymbols.AddID(edge.OutV, edge.InV)
+	return nil
+}
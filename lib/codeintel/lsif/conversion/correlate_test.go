@@ -58,8 +58,9 @@ func TestCorrelate(t *testing.T) {
 						End:   protocol.Pos{Line: 5, Character: 6},
 					},
 				},
-				DefinitionResultID: 13,
-				HoverResultID:      17,
+				DefinitionResultID:     13,
+				TypeDefinitionResultID: 103,
+				HoverResultID:          17,
 			},
 			7: {
 				Range: reader.Range{
@@ -86,6 +87,15 @@ func TestCorrelate(t *testing.T) {
 						Start: protocol.Pos{Line: 6, Character: 7},
 						End:   protocol.Pos{Line: 8, Character: 9},
 					},
+					Tag: &protocol.RangeTag{
+						Type: "definition",
+						Text: "bar",
+						Kind: protocol.Function,
+						FullRange: &protocol.RangeData{
+							Start: protocol.Pos{Line: 6, Character: 0},
+							End:   protocol.Pos{Line: 9, Character: 1},
+						},
+					},
 				},
 			},
 		},
@@ -109,6 +119,9 @@ func TestCorrelate(t *testing.T) {
 		ImplementationData: map[int]*datastructures.DefaultIDSetMap{
 			100: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{2: datastructures.IDSetWith(5)}),
 		},
+		TypeDefinitionData: map[int]*datastructures.DefaultIDSetMap{
+			103: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{3: datastructures.IDSetWith(8)}),
+		},
 		HoverData: map[int]string{
 			16: "```go\ntext A\n```",
 			17: "```go\ntext B\n```",
@@ -165,6 +178,9 @@ func TestCorrelate(t *testing.T) {
 				},
 			},
 		},
+		DocumentSymbolResults: map[int]DocumentSymbolResult{
+			106: {{ID: 9}},
+		},
 		NextData: map[int]int{
 			9:  10,
 			10: 11,
@@ -187,6 +203,9 @@ func TestCorrelate(t *testing.T) {
 		Diagnostics: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
 			2: datastructures.IDSetWith(49),
 		}),
+		DocumentSymbols: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+			3: datastructures.IDSetWith(106),
+		}),
 	}
 
 	if diff := cmp.Diff(expectedState, state, datastructures.Comparers...); diff != "" {
@@ -216,10 +235,12 @@ func TestCorrelateMetaDataRoot(t *testing.T) {
 		DefinitionData:         map[int]*datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]*datastructures.DefaultIDSetMap{},
 		ImplementationData:     map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]*datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]Moniker{},
 		PackageInformationData: map[int]PackageInformation{},
 		DiagnosticResults:      map[int][]Diagnostic{},
+		DocumentSymbolResults:  map[int]DocumentSymbolResult{},
 		NextData:               map[int]int{},
 		ImportedMonikers:       datastructures.NewIDSet(),
 		ExportedMonikers:       datastructures.NewIDSet(),
@@ -229,6 +250,7 @@ func TestCorrelateMetaDataRoot(t *testing.T) {
 		Contains:               datastructures.NewDefaultIDSetMap(),
 		Monikers:               datastructures.NewDefaultIDSetMap(),
 		Diagnostics:            datastructures.NewDefaultIDSetMap(),
+		DocumentSymbols:        datastructures.NewDefaultIDSetMap(),
 	}
 
 	if diff := cmp.Diff(expectedState, state, datastructures.Comparers...); diff != "" {
@@ -258,10 +280,12 @@ func TestCorrelateMetaDataRootX(t *testing.T) {
 		DefinitionData:         map[int]*datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]*datastructures.DefaultIDSetMap{},
 		ImplementationData:     map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]*datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]Moniker{},
 		PackageInformationData: map[int]PackageInformation{},
 		DiagnosticResults:      map[int][]Diagnostic{},
+		DocumentSymbolResults:  map[int]DocumentSymbolResult{},
 		NextData:               map[int]int{},
 		ImportedMonikers:       datastructures.NewIDSet(),
 		ExportedMonikers:       datastructures.NewIDSet(),
@@ -271,6 +295,7 @@ func TestCorrelateMetaDataRootX(t *testing.T) {
 		Contains:               datastructures.NewDefaultIDSetMap(),
 		Monikers:               datastructures.NewDefaultIDSetMap(),
 		Diagnostics:            datastructures.NewDefaultIDSetMap(),
+		DocumentSymbols:        datastructures.NewDefaultIDSetMap(),
 	}
 
 	if diff := cmp.Diff(expectedState, state, datastructures.Comparers...); diff != "" {
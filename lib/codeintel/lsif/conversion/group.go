@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion/datastructures"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
 
@@ -21,7 +22,7 @@ const resultsPerResultChunk = 512
 
 // groupBundleData converts a raw (but canonicalized) correlation State into a GroupedBundleData.
 func groupBundleData(ctx context.Context, state *State) (*precise.GroupedBundleDataChans, error) {
-	numResults := len(state.DefinitionData) + len(state.ReferenceData) + len(state.ImplementationData)
+	numResults := len(state.DefinitionData) + len(state.ReferenceData) + len(state.ImplementationData) + len(state.TypeDefinitionData)
 	numResultChunks := int(math.Max(1, math.Floor(float64(numResults)/resultsPerResultChunk)))
 
 	meta := precise.MetaData{NumResultChunks: numResultChunks}
@@ -116,6 +117,7 @@ func serializeDocument(state *State, documentID int) precise.DocumentData {
 			DefinitionResultID:     toID(rangeData.DefinitionResultID),
 			ReferenceResultID:      toID(rangeData.ReferenceResultID),
 			ImplementationResultID: toID(rangeData.ImplementationResultID),
+			TypeDefinitionResultID: toID(rangeData.TypeDefinitionResultID),
 			HoverResultID:          toID(rangeData.HoverResultID),
 			MonikerIDs:             monikerIDs,
 		}
@@ -141,9 +143,52 @@ func serializeDocument(state *State, documentID int) precise.DocumentData {
 		}
 	})
 
+	state.DocumentSymbols.EachID(documentID, func(documentSymbolResultID int) {
+		document.Symbols = append(document.Symbols, serializeSymbols(state, state.DocumentSymbolResults[documentSymbolResultID])...)
+	})
+
 	return document
 }
 
+// serializeSymbols converts the given symbol trees into symbol data. The name, kind, and extent
+// of each symbol are read from the tag of its range. Symbols whose range is untagged are skipped
+// and their children take their place.
+func serializeSymbols(state *State, symbols []*protocol.RangeBasedDocumentSymbol) []precise.SymbolData {
+	var serialized []precise.SymbolData
+	for _, symbol := range symbols {
+		children := serializeSymbols(state, symbol.Children)
+
+		rangeData, ok := state.RangeData[int(symbol.ID)]
+		if !ok || rangeData.Tag == nil {
+			serialized = append(serialized, children...)
+			continue
+		}
+
+		fullRange := rangeData.RangeData
+		if rangeData.Tag.FullRange != nil {
+			fullRange = *rangeData.Tag.FullRange
+		}
+
+		serialized = append(serialized, precise.SymbolData{
+			Name:               rangeData.Tag.Text,
+			Kind:               rangeData.Tag.Kind,
+			Detail:             rangeData.Tag.Detail,
+			Tags:               rangeData.Tag.Tags,
+			StartLine:          rangeData.Start.Line,
+			StartCharacter:     rangeData.Start.Character,
+			EndLine:            rangeData.End.Line,
+			EndCharacter:       rangeData.End.Character,
+			FullStartLine:      fullRange.Start.Line,
+			FullStartCharacter: fullRange.Start.Character,
+			FullEndLine:        fullRange.End.Line,
+			FullEndCharacter:   fullRange.End.Character,
+			Children:           children,
+		})
+	}
+
+	return serialized
+}
+
 func serializeResultChunks(ctx context.Context, state *State, numResultChunks int) chan precise.IndexedResultChunkData {
 	type entry struct {
 		id     int
@@ -162,6 +207,10 @@ func serializeResultChunks(ctx context.Context, state *State, numResultChunks in
 		index := precise.HashKey(toID(id), numResultChunks)
 		chunkAssignments[index] = append(chunkAssignments[index], entry{id: id, ranges: ranges})
 	}
+	for id, ranges := range state.TypeDefinitionData {
+		index := precise.HashKey(toID(id), numResultChunks)
+		chunkAssignments[index] = append(chunkAssignments[index], entry{id: id, ranges: ranges})
+	}
 
 	ch := make(chan precise.IndexedResultChunkData)
 
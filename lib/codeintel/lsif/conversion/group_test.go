@@ -88,6 +88,16 @@ func TestGroupBundleData(t *testing.T) {
 						Start: protocol.Pos{Line: 7, Character: 8},
 						End:   protocol.Pos{Line: 9, Character: 0},
 					},
+					Tag: &protocol.RangeTag{
+						Type:   "definition",
+						Text:   "Baz",
+						Kind:   protocol.Class,
+						Detail: "class Baz",
+						FullRange: &protocol.RangeData{
+							Start: protocol.Pos{Line: 7, Character: 0},
+							End:   protocol.Pos{Line: 12, Character: 1},
+						},
+					},
 				},
 				DefinitionResultID: 3004,
 				ReferenceResultID:  0,
@@ -108,6 +118,12 @@ func TestGroupBundleData(t *testing.T) {
 						Start: protocol.Pos{Line: 9, Character: 0},
 						End:   protocol.Pos{Line: 1, Character: 2},
 					},
+					Tag: &protocol.RangeTag{
+						Type: "definition",
+						Text: "qux",
+						Kind: protocol.Method,
+						Tags: []protocol.SymbolTag{protocol.Deprecated},
+					},
 				},
 				DefinitionResultID: 3005,
 				ReferenceResultID:  0,
@@ -274,6 +290,17 @@ func TestGroupBundleData(t *testing.T) {
 				},
 			},
 		},
+		DocumentSymbolResults: map[int]DocumentSymbolResult{
+			6001: {
+				{
+					ID: 2007,
+					Children: []*protocol.RangeBasedDocumentSymbol{
+						// Untagged ranges are skipped
+						{ID: 2008, Children: []*protocol.RangeBasedDocumentSymbol{{ID: 2009}}},
+					},
+				},
+			},
+		},
 		ImportedMonikers:    datastructures.IDSetWith(4001, 4006),
 		ExportedMonikers:    datastructures.IDSetWith(4003, 4005),
 		ImplementedMonikers: datastructures.NewIDSet(),
@@ -290,6 +317,9 @@ func TestGroupBundleData(t *testing.T) {
 			1001: datastructures.IDSetWith(1001, 1002),
 			1002: datastructures.IDSetWith(1003),
 		}),
+		DocumentSymbols: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+			1003: datastructures.IDSetWith(6001),
+		}),
 	}
 
 	actualBundleData, err := groupBundleData(context.Background(), state)
@@ -535,6 +565,36 @@ func TestGroupBundleData(t *testing.T) {
 			Monikers:           map[precise.ID]precise.MonikerData{},
 			PackageInformation: map[precise.ID]precise.PackageInformationData{},
 			Diagnostics:        []precise.DiagnosticData{},
+			Symbols: []precise.SymbolData{
+				{
+					Name:               "Baz",
+					Kind:               protocol.Class,
+					Detail:             "class Baz",
+					StartLine:          7,
+					StartCharacter:     8,
+					EndLine:            9,
+					EndCharacter:       0,
+					FullStartLine:      7,
+					FullStartCharacter: 0,
+					FullEndLine:        12,
+					FullEndCharacter:   1,
+					Children: []precise.SymbolData{
+						{
+							Name:               "qux",
+							Kind:               protocol.Method,
+							Tags:               []protocol.SymbolTag{protocol.Deprecated},
+							StartLine:          9,
+							StartCharacter:     0,
+							EndLine:            1,
+							EndCharacter:       2,
+							FullStartLine:      9,
+							FullStartCharacter: 0,
+							FullEndLine:        1,
+							FullEndCharacter:   2,
+						},
+					},
+				},
+			},
 		},
 	}
 	if diff := cmp.Diff(expectedDocumentData, documents, datastructures.Comparers...); diff != "" {
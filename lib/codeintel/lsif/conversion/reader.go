@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol/reader"
 )
 
@@ -65,6 +66,9 @@ func translatePayload(payload any) any {
 		}
 
 		return diagnostics
+
+	case []*protocol.RangeBasedDocumentSymbol:
+		return DocumentSymbolResult(v)
 	}
 
 	return payload
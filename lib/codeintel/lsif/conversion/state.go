@@ -14,10 +14,12 @@ type State struct {
 	DefinitionData         map[int]*datastructures.DefaultIDSetMap // maps definitionResult ID -> document ID -> range ID
 	ReferenceData          map[int]*datastructures.DefaultIDSetMap // maps referenceResult ID -> document ID -> range ID
 	ImplementationData     map[int]*datastructures.DefaultIDSetMap // maps implementationResult ID -> document ID -> range ID
+	TypeDefinitionData     map[int]*datastructures.DefaultIDSetMap // maps typeDefinitionResult ID -> document ID -> range ID
 	HoverData              map[int]string                          // maps hoverResult ID -> hover string
 	MonikerData            map[int]Moniker                         // maps moniker ID -> Moniker (which has kind, scheme, identifier, and packageInformation ID)
 	PackageInformationData map[int]PackageInformation              // maps packageInformation ID -> PackageInformation (which has name and version)
 	DiagnosticResults      map[int][]Diagnostic                    // maps diagnosticResult ID -> []Diagnostic
+	DocumentSymbolResults  map[int]DocumentSymbolResult            // maps documentSymbolResult ID -> symbol trees
 	NextData               map[int]int                             // maps (range ID | resultSet ID) -> resultSet ID related via next edges
 	ImportedMonikers       *datastructures.IDSet                   // set of moniker IDs that have kind "import"
 	ExportedMonikers       *datastructures.IDSet                   // set of moniker IDs that have kind "export"
@@ -27,10 +29,11 @@ type State struct {
 	Monikers               *datastructures.DefaultIDSetMap         // maps (range ID | resultSet ID) -> moniker IDs
 	Contains               *datastructures.DefaultIDSetMap         // maps document ID -> range IDs that are contained in the document
 	Diagnostics            *datastructures.DefaultIDSetMap         // maps document ID -> diagnostic IDs
+	DocumentSymbols        *datastructures.DefaultIDSetMap         // maps document ID -> documentSymbolResult IDs
 }
 
-// newState create a new State with zero-valued map fields.
-func newState() *State {
+// NewState create a new State with zero-valued map fields.
+func NewState() *State {
 	return &State{
 		DocumentData:           map[int]string{},
 		RangeData:              map[int]Range{},
@@ -38,10 +41,12 @@ func newState() *State {
 		DefinitionData:         map[int]*datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]*datastructures.DefaultIDSetMap{},
 		ImplementationData:     map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]*datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]Moniker{},
 		PackageInformationData: map[int]PackageInformation{},
 		DiagnosticResults:      map[int][]Diagnostic{},
+		DocumentSymbolResults:  map[int]DocumentSymbolResult{},
 		NextData:               map[int]int{},
 		ImportedMonikers:       datastructures.NewIDSet(),
 		ExportedMonikers:       datastructures.NewIDSet(),
@@ -51,5 +56,6 @@ func newState() *State {
 		Monikers:               datastructures.NewDefaultIDSetMap(),
 		Contains:               datastructures.NewDefaultIDSetMap(),
 		Diagnostics:            datastructures.NewDefaultIDSetMap(),
+		DocumentSymbols:        datastructures.NewDefaultIDSetMap(),
 	}
 }
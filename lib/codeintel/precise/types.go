@@ -20,6 +20,7 @@ type DocumentData struct {
 	Monikers           map[ID]MonikerData
 	PackageInformation map[ID]PackageInformationData
 	Diagnostics        []DiagnosticData
+	Symbols            []SymbolData // possibly empty
 }
 
 // RangeData represents a range vertex within an index. It contains the same relevant
@@ -34,6 +35,7 @@ type RangeData struct {
 	DefinitionResultID     ID   // possibly empty
 	ReferenceResultID      ID   // possibly empty
 	ImplementationResultID ID   // possibly empty
+	TypeDefinitionResultID ID   // possibly empty
 	HoverResultID          ID   // possibly empty
 	MonikerIDs             []ID // possibly empty
 }
@@ -74,6 +76,26 @@ type DiagnosticData struct {
 	EndCharacter   int // 0-indexed, inclusive
 }
 
+// SymbolData represents a symbol declared within its containing document, along with the
+// symbols declared within it. The data here is gathered from the documentSymbolResult
+// attached to the document and the tags of the ranges that it refers to. The start and
+// end fields span the name of the symbol, and the full fields span its entire declaration.
+type SymbolData struct {
+	Name               string
+	Kind               protocol.SymbolKind
+	Detail             string               // possibly empty
+	Tags               []protocol.SymbolTag // possibly empty
+	StartLine          int                  // 0-indexed, inclusive
+	StartCharacter     int                  // 0-indexed, inclusive
+	EndLine            int                  // 0-indexed, inclusive
+	EndCharacter       int                  // 0-indexed, inclusive
+	FullStartLine      int                  // 0-indexed, inclusive
+	FullStartCharacter int                  // 0-indexed, inclusive
+	FullEndLine        int                  // 0-indexed, inclusive
+	FullEndCharacter   int                  // 0-indexed, inclusive
+	Children           []SymbolData         // possibly empty
+}
+
 // ResultChunkData represents a row of the resultChunk table. Each row is a subset
 // of definition and reference result data in the index. Results are inserted into
 // chunks based on the hash of their identifier, thus every chunk has a roughly
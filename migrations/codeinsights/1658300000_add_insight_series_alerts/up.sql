@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS insight_series_alerts
+(
+    id                SERIAL PRIMARY KEY,
+    insight_view_id   INT NOT NULL REFERENCES insight_view (id) ON DELETE CASCADE,
+    insight_series_id INT NOT NULL REFERENCES insight_series (id) ON DELETE CASCADE,
+    user_id           INT NOT NULL,
+    kind              TEXT NOT NULL,
+    threshold         DOUBLE PRECISION,
+    channel           TEXT NOT NULL,
+    webhook_url       TEXT,
+    firing            BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_notified_at  TIMESTAMP,
+    CONSTRAINT insight_series_alerts_kind_check CHECK (kind IN ('ABOVE', 'BELOW', 'INCREASE_PERCENT', 'NEW_CAPTURE_VALUE')),
+    CONSTRAINT insight_series_alerts_channel_check CHECK (channel IN ('EMAIL', 'WEBHOOK')),
+    CONSTRAINT insight_series_alerts_webhook_url_check CHECK (channel <> 'WEBHOOK' OR webhook_url IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_alerts_insight_series_id_idx ON insight_series_alerts (insight_series_id);
+
+COMMENT ON TABLE insight_series_alerts IS 'Alert rules that are evaluated against a series each time a new point is recorded.';
+COMMENT ON COLUMN insight_series_alerts.user_id IS 'User that created the alert. Series values are evaluated with the repository permissions of this user, and email notifications are sent to them.';
+COMMENT ON COLUMN insight_series_alerts.kind IS 'The condition of the alert: ABOVE, BELOW, INCREASE_PERCENT (week-over-week) or NEW_CAPTURE_VALUE.';
+COMMENT ON COLUMN insight_series_alerts.threshold IS 'The value compared against by the condition. Unused for NEW_CAPTURE_VALUE.';
+COMMENT ON COLUMN insight_series_alerts.channel IS 'How the alert notifies: EMAIL or WEBHOOK.';
+COMMENT ON COLUMN insight_series_alerts.firing IS 'Whether the condition held at the last evaluation. Threshold alerts only notify when this changes to true.';
+COMMENT ON COLUMN insight_series_alerts.last_notified_at IS 'Timestamp of the last notification sent for this alert.';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS lsif_data_symbols;
+
+ALTER TABLE lsif_data_documents DROP COLUMN IF EXISTS symbols;
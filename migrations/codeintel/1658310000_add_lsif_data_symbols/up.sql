@@ -0,0 +1,23 @@
+ALTER TABLE lsif_data_documents ADD COLUMN IF NOT EXISTS symbols bytea;
+
+COMMENT ON COLUMN lsif_data_documents.symbols IS 'A gob-encoded payload conforming to the Symbols field of the DocumentData type.';
+
+CREATE TABLE IF NOT EXISTS lsif_data_symbols (
+    dump_id         integer NOT NULL,
+    path            text NOT NULL,
+    name            text NOT NULL,
+    kind            integer NOT NULL,
+    parent          text,
+    start_line      integer NOT NULL,
+    start_character integer NOT NULL,
+    end_line        integer NOT NULL,
+    end_character   integer NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS lsif_data_symbols_dump_id_name ON lsif_data_symbols USING btree (dump_id, name);
+
+COMMENT ON TABLE lsif_data_symbols IS 'Stores the symbols declared within each text document of a dump, flattened for symbol search.';
+COMMENT ON COLUMN lsif_data_symbols.dump_id IS 'The identifier of the associated dump in the lsif_uploads table (state=completed).';
+COMMENT ON COLUMN lsif_data_symbols.path IS 'The path of the text document relative to the associated dump root.';
+COMMENT ON COLUMN lsif_data_symbols.kind IS 'The LSP SymbolKind of the symbol.';
+COMMENT ON COLUMN lsif_data_symbols.parent IS 'The name of the symbol that the symbol is declared within, if any.';
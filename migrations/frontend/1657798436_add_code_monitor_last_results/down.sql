@@ -0,0 +1,3 @@
+ALTER TABLE cm_trigger_jobs DROP COLUMN IF EXISTS file_results;
+
+DROP TABLE IF EXISTS cm_last_results;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS cm_last_results (
+    monitor_id bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    path text NOT NULL,
+    fingerprint text NOT NULL,
+    PRIMARY KEY (monitor_id, fingerprint)
+);
+
+COMMENT ON TABLE cm_last_results IS 'The file matches found by the last run of a content or symbol search code monitor';
+COMMENT ON COLUMN cm_last_results.fingerprint IS 'Identifies a file match and its matched content across runs of the code monitor';
+
+ALTER TABLE cm_trigger_jobs
+  ADD COLUMN IF NOT EXISTS file_results jsonb;
+
+COMMENT ON COLUMN cm_trigger_jobs.file_results IS 'The file matches that appeared or disappeared since the previous run of a content or symbol search code monitor';
@@ -0,0 +1,107 @@
+CREATE TABLE IF NOT EXISTS cm_teams_webhooks (
+    id bigserial PRIMARY KEY,
+    monitor bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    url text NOT NULL,
+    body_template text NOT NULL DEFAULT '',
+    content_type text NOT NULL DEFAULT '',
+    headers text NOT NULL DEFAULT '{}',
+    encryption_key_id text NOT NULL DEFAULT '',
+    enabled boolean NOT NULL,
+    include_results boolean NOT NULL DEFAULT false,
+    created_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    changed_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS cm_teams_webhooks_monitor ON cm_teams_webhooks USING btree (monitor);
+
+COMMENT ON TABLE cm_teams_webhooks IS 'Microsoft Teams webhook actions configured on code monitors';
+COMMENT ON COLUMN cm_teams_webhooks.monitor IS 'The code monitor that the action is defined on';
+COMMENT ON COLUMN cm_teams_webhooks.url IS 'The Microsoft Teams incoming webhook URL we send the code monitor event to';
+COMMENT ON COLUMN cm_teams_webhooks.body_template IS 'A Go text/template rendering the request body. The preset template is used when empty';
+COMMENT ON COLUMN cm_teams_webhooks.content_type IS 'The content type of the request body. The preset content type is used when empty';
+COMMENT ON COLUMN cm_teams_webhooks.headers IS 'Additional HTTP headers sent with the request, as a JSON object. Encrypted if encryption_key_id is set';
+COMMENT ON COLUMN cm_teams_webhooks.encryption_key_id IS 'The identifier of the key the secrets of the action are encrypted with. Empty if they are not encrypted';
+
+CREATE TABLE IF NOT EXISTS cm_mattermost_webhooks (
+    id bigserial PRIMARY KEY,
+    monitor bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    url text NOT NULL,
+    body_template text NOT NULL DEFAULT '',
+    content_type text NOT NULL DEFAULT '',
+    headers text NOT NULL DEFAULT '{}',
+    encryption_key_id text NOT NULL DEFAULT '',
+    enabled boolean NOT NULL,
+    include_results boolean NOT NULL DEFAULT false,
+    created_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    changed_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS cm_mattermost_webhooks_monitor ON cm_mattermost_webhooks USING btree (monitor);
+
+COMMENT ON TABLE cm_mattermost_webhooks IS 'Mattermost webhook actions configured on code monitors';
+COMMENT ON COLUMN cm_mattermost_webhooks.monitor IS 'The code monitor that the action is defined on';
+COMMENT ON COLUMN cm_mattermost_webhooks.url IS 'The Mattermost incoming webhook URL we send the code monitor event to';
+COMMENT ON COLUMN cm_mattermost_webhooks.body_template IS 'A Go text/template rendering the request body. The preset template is used when empty';
+COMMENT ON COLUMN cm_mattermost_webhooks.content_type IS 'The content type of the request body. The preset content type is used when empty';
+COMMENT ON COLUMN cm_mattermost_webhooks.headers IS 'Additional HTTP headers sent with the request, as a JSON object. Encrypted if encryption_key_id is set';
+COMMENT ON COLUMN cm_mattermost_webhooks.encryption_key_id IS 'The identifier of the key the secrets of the action are encrypted with. Empty if they are not encrypted';
+
+CREATE TABLE IF NOT EXISTS cm_pagerduty_webhooks (
+    id bigserial PRIMARY KEY,
+    monitor bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    url text NOT NULL,
+    routing_key text NOT NULL DEFAULT '',
+    body_template text NOT NULL DEFAULT '',
+    content_type text NOT NULL DEFAULT '',
+    headers text NOT NULL DEFAULT '{}',
+    encryption_key_id text NOT NULL DEFAULT '',
+    enabled boolean NOT NULL,
+    include_results boolean NOT NULL DEFAULT false,
+    created_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    changed_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS cm_pagerduty_webhooks_monitor ON cm_pagerduty_webhooks USING btree (monitor);
+
+COMMENT ON TABLE cm_pagerduty_webhooks IS 'PagerDuty Events v2 actions configured on code monitors';
+COMMENT ON COLUMN cm_pagerduty_webhooks.monitor IS 'The code monitor that the action is defined on';
+COMMENT ON COLUMN cm_pagerduty_webhooks.url IS 'The PagerDuty Events v2 endpoint we send the code monitor event to. Defaults to the public endpoint when empty';
+COMMENT ON COLUMN cm_pagerduty_webhooks.routing_key IS 'The routing key of the PagerDuty integration the events are sent to. Encrypted if encryption_key_id is set';
+COMMENT ON COLUMN cm_pagerduty_webhooks.body_template IS 'A Go text/template rendering the request body. The preset template is used when empty';
+COMMENT ON COLUMN cm_pagerduty_webhooks.content_type IS 'The content type of the request body. The preset content type is used when empty';
+COMMENT ON COLUMN cm_pagerduty_webhooks.headers IS 'Additional HTTP headers sent with the request, as a JSON object. Encrypted if encryption_key_id is set';
+COMMENT ON COLUMN cm_pagerduty_webhooks.encryption_key_id IS 'The identifier of the key the secrets of the action are encrypted with. Empty if they are not encrypted';
+
+ALTER TABLE cm_action_jobs
+    ADD COLUMN IF NOT EXISTS teams_webhook bigint REFERENCES cm_teams_webhooks(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS mattermost_webhook bigint REFERENCES cm_mattermost_webhooks(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS pagerduty_webhook bigint REFERENCES cm_pagerduty_webhooks(id) ON DELETE CASCADE;
+
+COMMENT ON COLUMN cm_action_jobs.teams_webhook IS 'The ID of the cm_teams_webhooks action to execute if this is a Microsoft Teams webhook job. Mutually exclusive with the other action types';
+COMMENT ON COLUMN cm_action_jobs.mattermost_webhook IS 'The ID of the cm_mattermost_webhooks action to execute if this is a Mattermost webhook job. Mutually exclusive with the other action types';
+COMMENT ON COLUMN cm_action_jobs.pagerduty_webhook IS 'The ID of the cm_pagerduty_webhooks action to execute if this is a PagerDuty job. Mutually exclusive with the other action types';
+
+ALTER TABLE cm_action_jobs DROP CONSTRAINT IF EXISTS cm_action_jobs_only_one_action_type;
+ALTER TABLE cm_action_jobs ADD CONSTRAINT cm_action_jobs_only_one_action_type CHECK (
+    (
+        CASE WHEN email IS NULL THEN 0 ELSE 1 END
+        +
+        CASE WHEN webhook IS NULL THEN 0 ELSE 1 END
+        +
+        CASE WHEN slack_webhook IS NULL THEN 0 ELSE 1 END
+        +
+        CASE WHEN teams_webhook IS NULL THEN 0 ELSE 1 END
+        +
+        CASE WHEN mattermost_webhook IS NULL THEN 0 ELSE 1 END
+        +
+        CASE WHEN pagerduty_webhook IS NULL THEN 0 ELSE 1 END
+    ) = 1
+);
+
+COMMENT ON CONSTRAINT cm_action_jobs_only_one_action_type ON cm_action_jobs IS 'Constrains that each queued code monitor action has exactly one action type';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS notebook_snapshot_jobs;
+DROP TABLE IF EXISTS notebook_snapshots;
+DROP TABLE IF EXISTS notebook_snapshot_schedules;
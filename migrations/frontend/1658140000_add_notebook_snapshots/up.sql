@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS notebook_snapshot_schedules (
+    id bigserial PRIMARY KEY,
+    notebook_id bigint NOT NULL UNIQUE REFERENCES notebooks(id) ON DELETE CASCADE DEFERRABLE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    snapshot_interval text NOT NULL,
+    next_run_at timestamp with time zone NOT NULL DEFAULT now(),
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS notebook_snapshot_schedules_next_run_at_idx ON notebook_snapshot_schedules USING btree (next_run_at);
+
+COMMENT ON TABLE notebook_snapshot_schedules IS 'Schedules on which the blocks of notebooks are executed and their results recorded';
+COMMENT ON COLUMN notebook_snapshot_schedules.user_id IS 'The user who scheduled the snapshots. Notebooks are executed as this user';
+COMMENT ON COLUMN notebook_snapshot_schedules.snapshot_interval IS 'How often snapshots are recorded, one of DAILY or WEEKLY';
+
+CREATE TABLE IF NOT EXISTS notebook_snapshots (
+    id bigserial PRIMARY KEY,
+    notebook_id bigint NOT NULL REFERENCES notebooks(id) ON DELETE CASCADE DEFERRABLE,
+    blocks jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE
+);
+
+CREATE INDEX IF NOT EXISTS notebook_snapshots_notebook_id_user_id_created_at_idx ON notebook_snapshots USING btree (notebook_id, user_id, created_at DESC);
+
+COMMENT ON TABLE notebook_snapshots IS 'The results of the executable blocks of a notebook at a point in time';
+COMMENT ON COLUMN notebook_snapshots.blocks IS 'The results of every executable block of the notebook';
+COMMENT ON COLUMN notebook_snapshots.user_id IS 'The user the notebook was executed as. Snapshots are only visible to this user';
+
+CREATE TABLE IF NOT EXISTS notebook_snapshot_jobs (
+    id serial PRIMARY KEY,
+    state text DEFAULT 'queued',
+    failure_message text,
+    queued_at timestamp with time zone DEFAULT now(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer NOT NULL DEFAULT 0,
+    num_failures integer NOT NULL DEFAULT 0,
+    last_heartbeat_at timestamp with time zone,
+    execution_logs json[],
+    worker_hostname text NOT NULL DEFAULT '',
+    -- additional columns
+    schedule_id bigint NOT NULL REFERENCES notebook_snapshot_schedules(id) ON DELETE CASCADE DEFERRABLE
+);
+
+CREATE INDEX IF NOT EXISTS notebook_snapshot_jobs_state_idx ON notebook_snapshot_jobs USING btree (state);
+CREATE INDEX IF NOT EXISTS notebook_snapshot_jobs_schedule_id_idx ON notebook_snapshot_jobs USING btree (schedule_id);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS repo_tags (
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    kind text NOT NULL,
+    key text NOT NULL,
+    value text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (repo_id, kind, key),
+    CONSTRAINT repo_tags_kind_check CHECK (kind IN ('topic', 'meta'))
+);
+
+CREATE INDEX IF NOT EXISTS repo_tags_kind_key_value_idx ON repo_tags USING btree (kind, key, value);
+
+COMMENT ON TABLE repo_tags IS 'Code host topics and admin-assigned key/value tags of repositories, used by the repo:has.topic() and repo:has.meta() search predicates';
+COMMENT ON COLUMN repo_tags.kind IS 'Either topic for topics synced from the code host, or meta for tags assigned by site admins';
+COMMENT ON COLUMN repo_tags.value IS 'The value of a meta tag. Always empty for topics';
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS search_export_jobs (
+    id serial PRIMARY KEY,
+    state text DEFAULT 'queued',
+    failure_message text,
+    queued_at timestamp with time zone DEFAULT now(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer NOT NULL DEFAULT 0,
+    num_failures integer NOT NULL DEFAULT 0,
+    last_heartbeat_at timestamp with time zone,
+    execution_logs json[],
+    worker_hostname text NOT NULL DEFAULT '',
+    -- additional columns
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    query text NOT NULL,
+    pattern_type text NOT NULL,
+    format text NOT NULL CHECK (format IN ('csv', 'jsonl')),
+    object_key text,
+    download_key bytea NOT NULL,
+    manifest jsonb NOT NULL DEFAULT '{}'::jsonb,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS search_export_jobs_state_idx ON search_export_jobs USING btree (state);
+CREATE INDEX IF NOT EXISTS search_export_jobs_user_id_idx ON search_export_jobs USING btree (user_id);
+
+COMMENT ON TABLE search_export_jobs IS 'Searches whose complete results are exported to a file in the upload store';
+COMMENT ON COLUMN search_export_jobs.user_id IS 'The user who requested the export. The search is run as this user';
+COMMENT ON COLUMN search_export_jobs.format IS 'The format of the exported file, one of csv or jsonl';
+COMMENT ON COLUMN search_export_jobs.object_key IS 'The key of the exported file in the upload store, set once the export completed';
+COMMENT ON COLUMN search_export_jobs.download_key IS 'The secret that download URLs of the exported file are signed with';
+COMMENT ON COLUMN search_export_jobs.manifest IS 'The progress of the export and the repositories whose results are incomplete';
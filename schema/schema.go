@@ -509,7 +509,8 @@ func (v *EncryptionKey) UnmarshalJSON(data []byte) error {
 type EncryptionKeys struct {
 	BatchChangesCredentialKey *EncryptionKey `json:"batchChangesCredentialKey,omitempty"`
 	// CacheSize description: number of values to keep in LRU cache
-	CacheSize int `json:"cacheSize,omitempty"`
+	CacheSize            int            `json:"cacheSize,omitempty"`
+	CodeMonitorActionKey *EncryptionKey `json:"codeMonitorActionKey,omitempty"`
 	// EnableCache description: enable LRU cache for decryption APIs
 	EnableCache            bool           `json:"enableCache,omitempty"`
 	ExternalServiceKey     *EncryptionKey `json:"externalServiceKey,omitempty"`